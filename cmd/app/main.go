@@ -5,15 +5,24 @@ import (
 
 	"github.com/Klef99/bhs-task/config"
 	"github.com/Klef99/bhs-task/internal/app"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
 )
 
 func main() {
 	// Configuration
 	cfg, err := config.NewConfig("./config/config.yml")
 	if err != nil {
-		log.Fatalf("Config error: %w", err)
+		log.Fatalf("Config error: %v", err)
 	}
 
+	// Metrics
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+
 	// Run
-	app.Run(cfg)
+	app.Run(cfg, reg)
 }
@@ -0,0 +1,304 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/Klef99/bhs-task/internal/entity"
+	"github.com/Klef99/bhs-task/internal/usecase"
+)
+
+// ctl - runs the commands on top of the use cases.
+type ctl struct {
+	user  usecase.User
+	asset usecase.Asset
+	admin usecase.Admin
+	in    io.Reader
+	out   io.Writer
+}
+
+func (c *ctl) run(ctx context.Context, args []string) error {
+	if len(args) == 1 && args[0] == "reconcile" {
+		return c.reconcile(ctx)
+	}
+	if len(args) < 2 {
+		return fmt.Errorf("%w: unknown command %q", errUsage, strings.Join(args, " "))
+	}
+	cmd, rest := args[0]+" "+args[1], args[2:]
+	switch cmd {
+	case "user create":
+		return c.userCreate(ctx, rest)
+	case "user reset-password":
+		return c.userResetPassword(ctx, rest)
+	case "user set-role":
+		return c.userSetRole(ctx, rest)
+	case "balance adjust":
+		return c.balanceAdjust(ctx, rest)
+	case "asset list":
+		return c.assetList(ctx, rest)
+	case "asset takedown":
+		return c.assetTakeDown(ctx, rest)
+	case "asset purge":
+		return c.assetPurge(ctx, rest)
+	case "access grant":
+		return c.accessGrant(ctx, rest)
+	case "access revoke":
+		return c.accessRevoke(ctx, rest)
+	}
+	return fmt.Errorf("%w: unknown command %q", errUsage, cmd)
+}
+
+func (c *ctl) userCreate(ctx context.Context, args []string) error {
+	fs := newFlagSet("user create")
+	password := fs.String("password", "", "password, read from stdin if empty")
+	admin := fs.Bool("admin", false, "grant the admin role")
+	pos, err := parse(fs, args, 1)
+	if err != nil {
+		return err
+	}
+	if *password == "" {
+		*password, err = c.readPassword()
+		if err != nil {
+			return err
+		}
+	}
+	_, err = c.user.Register(ctx, entity.Credentials{Username: pos[0], Password: *password})
+	if err != nil {
+		return err
+	}
+	if *admin {
+		err = c.admin.SetRole(ctx, pos[0], entity.RoleAdmin)
+		if err != nil {
+			return err
+		}
+	}
+	fmt.Fprintf(c.out, "user %s created\n", pos[0])
+	return nil
+}
+
+func (c *ctl) userResetPassword(ctx context.Context, args []string) error {
+	fs := newFlagSet("user reset-password")
+	password := fs.String("password", "", "new password, read from stdin if empty")
+	pos, err := parse(fs, args, 1)
+	if err != nil {
+		return err
+	}
+	if *password == "" {
+		*password, err = c.readPassword()
+		if err != nil {
+			return err
+		}
+	}
+	err = c.admin.ResetPassword(ctx, pos[0], *password)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(c.out, "password of %s reset\n", pos[0])
+	return nil
+}
+
+func (c *ctl) userSetRole(ctx context.Context, args []string) error {
+	pos, err := parse(newFlagSet("user set-role"), args, 2)
+	if err != nil {
+		return err
+	}
+	err = c.admin.SetRole(ctx, pos[0], entity.Role(pos[1]))
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(c.out, "%s is now %s\n", pos[0], pos[1])
+	return nil
+}
+
+func (c *ctl) balanceAdjust(ctx context.Context, args []string) error {
+	fs := newFlagSet("balance adjust")
+	amount := fs.Float64("amount", 0, "amount to add, negative to take away")
+	reason := fs.String("reason", "", "why the balance is adjusted")
+	actor := fs.String("actor", os.Getenv("USER"), "operator making the change")
+	pos, err := parse(fs, args, 1)
+	if err != nil {
+		return err
+	}
+	adj, err := c.admin.AdjustBalance(ctx, pos[0], *amount, *reason, *actor)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(c.out, "adjustment %d: %+g, balance of %s is %g\n", adj.Id, adj.Amount, pos[0], adj.Balance)
+	return nil
+}
+
+func (c *ctl) assetList(ctx context.Context, args []string) error {
+	fs := newFlagSet("asset list")
+	owner := fs.String("owner", "", "username of the owner")
+	status := fs.String("status", "", "draft, published, unlisted or archived")
+	deleted := fs.Bool("deleted", false, "include deleted assets")
+	_, err := parse(fs, args, 0)
+	if err != nil {
+		return err
+	}
+	assets, err := c.admin.ListAssets(ctx, entity.AssetFilter{Owner: *owner, Status: entity.AssetStatus(*status), IncludeDeleted: *deleted})
+	if err != nil {
+		return err
+	}
+	w := tabwriter.NewWriter(c.out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tNAME\tOWNER\tPRICE\tSTATUS\tMODE\tSOLD\tDELETED")
+	for _, a := range assets {
+		deletedAt := "-"
+		if a.DeletedAt != nil {
+			deletedAt = a.DeletedAt.Format("2006-01-02 15:04")
+		}
+		fmt.Fprintf(w, "%d\t%s\t%d\t%g\t%s\t%s\t%d\t%s\n", a.Id, a.Name, a.Owner_id, a.Price, a.Status, a.SaleMode, a.Sold, deletedAt)
+	}
+	return w.Flush()
+}
+
+func (c *ctl) assetTakeDown(ctx context.Context, args []string) error {
+	id, err := parseId(newFlagSet("asset takedown"), args)
+	if err != nil {
+		return err
+	}
+	ok, err := c.admin.TakeDownAsset(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("asset %d: %w or already deleted", id, entity.ErrAssetNotFound)
+	}
+	fmt.Fprintf(c.out, "asset %d taken down\n", id)
+	return nil
+}
+
+func (c *ctl) assetPurge(ctx context.Context, args []string) error {
+	id, err := parseId(newFlagSet("asset purge"), args)
+	if err != nil {
+		return err
+	}
+	ok, err := c.asset.PurgeAsset(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("asset %d: %w", id, entity.ErrAssetNotFound)
+	}
+	fmt.Fprintf(c.out, "asset %d purged\n", id)
+	return nil
+}
+
+func (c *ctl) accessGrant(ctx context.Context, args []string) error {
+	fs := newFlagSet("access grant")
+	days := fs.Int64("days", 0, "days of access, permanent if 0")
+	pos, err := parse(fs, args, 2)
+	if err != nil {
+		return err
+	}
+	assetId, err := strconv.ParseInt(pos[1], 10, 64)
+	if err != nil {
+		return fmt.Errorf("%w: invalid asset id %q", errUsage, pos[1])
+	}
+	var d *int64
+	if *days != 0 {
+		d = days
+	}
+	err = c.admin.GrantAccess(ctx, pos[0], assetId, d)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(c.out, "%s has access to asset %d\n", pos[0], assetId)
+	return nil
+}
+
+func (c *ctl) accessRevoke(ctx context.Context, args []string) error {
+	pos, err := parse(newFlagSet("access revoke"), args, 2)
+	if err != nil {
+		return err
+	}
+	assetId, err := strconv.ParseInt(pos[1], 10, 64)
+	if err != nil {
+		return fmt.Errorf("%w: invalid asset id %q", errUsage, pos[1])
+	}
+	ok, err := c.admin.RevokeAccess(ctx, pos[0], assetId)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("%s: %w", pos[0], entity.ErrAccessNotFound)
+	}
+	fmt.Fprintf(c.out, "access of %s to asset %d revoked\n", pos[0], assetId)
+	return nil
+}
+
+func (c *ctl) reconcile(ctx context.Context) error {
+	issues, err := c.admin.Reconcile(ctx)
+	if err != nil {
+		return err
+	}
+	if len(issues) == 0 {
+		fmt.Fprintln(c.out, "no issues found")
+		return nil
+	}
+	w := tabwriter.NewWriter(c.out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "CHECK\tSUBJECT\tDETAIL")
+	for _, i := range issues {
+		fmt.Fprintf(w, "%s\t%s\t%s\n", i.Check, i.Subject, i.Detail)
+	}
+	err = w.Flush()
+	if err != nil {
+		return err
+	}
+	return fmt.Errorf("%w: %d", errIssues, len(issues))
+}
+
+// readPassword - reads the first line of the input, so it can be piped instead of passed in the arguments.
+func (c *ctl) readPassword() (string, error) {
+	fmt.Fprint(os.Stderr, "Password: ")
+	line, err := bufio.NewReader(c.in).ReadString('\n')
+	if err != nil && err != io.EOF {
+		return "", fmt.Errorf("reading password: %w", err)
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+func newFlagSet(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	return fs
+}
+
+// parse - parses flags placed anywhere among the arguments and returns exactly n positional arguments.
+func parse(fs *flag.FlagSet, args []string, n int) ([]string, error) {
+	pos := make([]string, 0, n)
+	for {
+		err := fs.Parse(args)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s: %v", errUsage, fs.Name(), err)
+		}
+		if fs.NArg() == 0 {
+			break
+		}
+		pos = append(pos, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+	if len(pos) != n {
+		return nil, fmt.Errorf("%w: %s takes %d arguments, got %d", errUsage, fs.Name(), n, len(pos))
+	}
+	return pos, nil
+}
+
+func parseId(fs *flag.FlagSet, args []string) (int64, error) {
+	pos, err := parse(fs, args, 1)
+	if err != nil {
+		return 0, err
+	}
+	id, err := strconv.ParseInt(pos[0], 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: invalid asset id %q", errUsage, pos[0])
+	}
+	return id, nil
+}
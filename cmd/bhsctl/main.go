@@ -0,0 +1,99 @@
+// Command bhsctl runs administrative tasks against the application database:
+// managing users, balances, assets and access, and reconciliation checks.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/Klef99/bhs-task/config"
+	"github.com/Klef99/bhs-task/internal/usecase"
+	"github.com/Klef99/bhs-task/internal/usecase/repo"
+	"github.com/Klef99/bhs-task/pkg/hasher"
+	"github.com/Klef99/bhs-task/pkg/postgres"
+)
+
+const _usage = `Usage: bhsctl [-config path] <command> [flags] [args]
+
+Commands:
+  user create <username> [-password p] [-admin]   register a user, the password is read from stdin if not given
+  user reset-password <username> [-password p]    set a new password
+  user set-role <username> <user|admin>           change the role
+  balance adjust <username> -amount n -reason r   add n to the balance, negative n takes funds away
+                 [-actor name]
+  asset list [-owner username] [-status s] [-deleted]
+  asset takedown <id>                             remove the asset from the market, buyers keep access
+  asset purge <id>                                delete the asset and every buyer's access
+  access grant <username> <asset-id> [-days n]    give access, for good unless -days is set
+  access revoke <username> <asset-id>
+  reconcile                                       run consistency checks, exits with 1 if issues are found
+`
+
+// errUsage - the command line is malformed, usage is printed.
+var errUsage = errors.New("invalid usage")
+
+// errIssues - reconciliation found inconsistencies.
+var errIssues = errors.New("reconciliation issues found")
+
+func main() {
+	os.Exit(run())
+}
+
+// run - returns the exit code, so deferred calls are done before exiting.
+func run() int {
+	fs := flag.NewFlagSet("bhsctl", flag.ExitOnError)
+	cfgPath := fs.String("config", "./config/config.yml", "path to the config file")
+	fs.Usage = func() {
+		fmt.Fprint(os.Stderr, _usage)
+	}
+	fs.Parse(os.Args[1:])
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return 2
+	}
+
+	// Configuration
+	cfg, err := config.NewConfig(*cfgPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Config error: %v\n", err)
+		return 1
+	}
+
+	// Repository
+	pg, err := postgres.New(cfg.PG.URL, postgres.MaxPoolSize(cfg.PG.PoolMax))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "bhsctl - postgres.New: %v\n", err)
+		return 1
+	}
+	defer pg.Close()
+
+	// Use case
+	hs := hasher.NewHasher()
+	c := &ctl{
+		user:  usecase.NewUserUseCase(repo.NewUserRepository(pg, hs)),
+		asset: usecase.NewAssetUseCase(repo.NewAssetRepository(pg)),
+		admin: usecase.NewAdminUseCase(repo.NewAdminRepository(pg, hs)),
+		in:    os.Stdin,
+		out:   os.Stdout,
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	err = c.run(ctx, fs.Args())
+	switch {
+	case err == nil:
+		return 0
+	case errors.Is(err, errUsage):
+		fmt.Fprintf(os.Stderr, "%v\n\n", err)
+		fs.Usage()
+		return 2
+	default:
+		fmt.Fprintf(os.Stderr, "bhsctl: %v\n", err)
+		return 1
+	}
+}
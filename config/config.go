@@ -2,6 +2,7 @@ package config
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/ilyakaznacheev/cleanenv"
 )
@@ -9,11 +10,21 @@ import (
 type (
 	// Config -.
 	Config struct {
-		App  `yaml:"app"`
-		HTTP `yaml:"http"`
-		Log  `yaml:"logger"`
-		PG   `yaml:"postgres"`
-		Jwt  `yaml:"jwt"`
+		App       `yaml:"app"`
+		HTTP      `yaml:"http"`
+		GRPC      `yaml:"grpc"`
+		Log       `yaml:"logger"`
+		PG        `yaml:"postgres"`
+		Jwt       `yaml:"jwt"`
+		Auction   `yaml:"auction"`
+		Offer     `yaml:"offer"`
+		Access    `yaml:"access"`
+		Webhook   `yaml:"webhook"`
+		Outbox    `yaml:"outbox"`
+		Stream    `yaml:"stream"`
+		GraphQL   `yaml:"graphql"`
+		Tracing   `yaml:"tracing"`
+		RateLimit `yaml:"rate_limit"`
 	}
 
 	// App -.
@@ -24,8 +35,28 @@ type (
 
 	// HTTP -.
 	HTTP struct {
-		Port    string `env-required:"true" yaml:"port" env:"HTTP_PORT"`
-		Swagger bool   `yaml:"swagger" env-default:"false"`
+		Port            string        `env-required:"true" yaml:"port" env:"HTTP_PORT"`
+		Swagger         bool          `yaml:"swagger" env-default:"false"`
+		DrainDelay      time.Duration `yaml:"drain_delay" env:"HTTP_DRAIN_DELAY" env-default:"5s"`            // readiness fails this long before the server stops
+		ShutdownTimeout time.Duration `yaml:"shutdown_timeout" env:"HTTP_SHUTDOWN_TIMEOUT" env-default:"10s"` // for in-flight requests to complete
+		TLS             HTTPTLS       `yaml:"tls"`
+	}
+
+	// HTTPTLS - with TLS enabled the server doesn't accept plaintext connections.
+	HTTPTLS struct {
+		Enabled         bool     `yaml:"enabled" env:"HTTP_TLS_ENABLED" env-default:"false"`
+		CertFile        string   `yaml:"cert_file" env:"HTTP_TLS_CERT_FILE"` // reloaded when it changes, as is the key
+		KeyFile         string   `yaml:"key_file" env:"HTTP_TLS_KEY_FILE"`
+		MinVersion      string   `yaml:"min_version" env:"HTTP_TLS_MIN_VERSION" env-default:"1.2"`     // 1.2 or 1.3
+		CipherSuites    []string `yaml:"cipher_suites" env:"HTTP_TLS_CIPHER_SUITES" env-separator:","` // TLS 1.2 suites, Go's defaults if empty
+		ClientCAFile    string   `yaml:"client_ca_file" env:"HTTP_TLS_CLIENT_CA_FILE"`
+		ClientAuth      string   `yaml:"client_auth" env:"HTTP_TLS_CLIENT_AUTH" env-default:"none"`            // none, verify_if_given or require
+		ClientCertPaths []string `yaml:"client_cert_paths" env:"HTTP_TLS_CLIENT_CERT_PATHS" env-separator:","` // served only to clients with a verified certificate
+	}
+
+	// GRPC -.
+	GRPC struct {
+		Port string `env-required:"true" yaml:"port" env:"GRPC_PORT"`
 	}
 
 	// Log -.
@@ -45,6 +76,78 @@ type (
 		Nbf    int    `env-required:"true" yaml:"nbf"`
 		Exp    int    `env-required:"true" yaml:"exp"`
 	}
+
+	// Auction -.
+	Auction struct {
+		SettleInterval time.Duration `yaml:"settle_interval" env:"AUCTION_SETTLE_INTERVAL" env-default:"10s"`
+	}
+
+	// Offer -.
+	Offer struct {
+		TTL            time.Duration `yaml:"ttl"             env:"OFFER_TTL"             env-default:"72h"`
+		ExpireInterval time.Duration `yaml:"expire_interval" env:"OFFER_EXPIRE_INTERVAL" env-default:"1m"`
+	}
+
+	// Access -.
+	Access struct {
+		SweepInterval time.Duration `yaml:"sweep_interval" env:"ACCESS_SWEEP_INTERVAL" env-default:"1m"`
+	}
+
+	// Webhook -.
+	Webhook struct {
+		DeliverInterval time.Duration `yaml:"deliver_interval" env:"WEBHOOK_DELIVER_INTERVAL" env-default:"2s"`
+		Timeout         time.Duration `yaml:"timeout" env:"WEBHOOK_TIMEOUT" env-default:"10s"`
+		MaxAttempts     int           `yaml:"max_attempts" env:"WEBHOOK_MAX_ATTEMPTS" env-default:"8"`
+		Backoff         time.Duration `yaml:"backoff" env:"WEBHOOK_BACKOFF" env-default:"30s"`
+	}
+
+	// Outbox -.
+	Outbox struct {
+		RelayInterval time.Duration `yaml:"relay_interval" env:"OUTBOX_RELAY_INTERVAL" env-default:"1s"`
+		BatchSize     uint64        `yaml:"batch_size" env:"OUTBOX_BATCH_SIZE" env-default:"100"`
+		Publisher     string        `yaml:"publisher" env:"OUTBOX_PUBLISHER" env-default:"webhook"` // webhook, log or memory
+		Backoff       time.Duration `yaml:"backoff" env:"OUTBOX_BACKOFF" env-default:"5s"`
+	}
+
+	// Stream -.
+	Stream struct {
+		Buffer         int           `yaml:"buffer" env:"STREAM_BUFFER" env-default:"64"`
+		ReconnectDelay time.Duration `yaml:"reconnect_delay" env:"STREAM_RECONNECT_DELAY" env-default:"1s"`
+	}
+
+	// GraphQL -.
+	GraphQL struct {
+		MaxDepth      int `yaml:"max_depth" env:"GRAPHQL_MAX_DEPTH" env-default:"10"`
+		MaxComplexity int `yaml:"max_complexity" env:"GRAPHQL_MAX_COMPLEXITY" env-default:"1000"`
+	}
+
+	// Tracing -.
+	Tracing struct {
+		Exporter    string  `yaml:"exporter" env:"TRACING_EXPORTER" env-default:"none"` // none, stdout or otlp
+		Endpoint    string  `yaml:"endpoint" env:"TRACING_ENDPOINT" env-default:"localhost:4317"`
+		Insecure    bool    `yaml:"insecure" env:"TRACING_INSECURE" env-default:"false"`
+		SampleRatio float64 `yaml:"sample_ratio" env:"TRACING_SAMPLE_RATIO" env-default:"1"`
+	}
+
+	// RateLimit - a request is counted against the first policy it matches.
+	RateLimit struct {
+		Enabled        bool              `yaml:"enabled" env:"RATE_LIMIT_ENABLED" env-default:"true"`
+		Store          string            `yaml:"store" env:"RATE_LIMIT_STORE" env-default:"memory"` // memory or postgres
+		SweepInterval  time.Duration     `yaml:"sweep_interval" env:"RATE_LIMIT_SWEEP_INTERVAL" env-default:"1m"`
+		TrustedProxies []string          `yaml:"trusted_proxies" env:"RATE_LIMIT_TRUSTED_PROXIES" env-separator:","` // addresses or CIDR ranges
+		Policies       []RateLimitPolicy `yaml:"policies"`
+	}
+
+	// RateLimitPolicy - Limit requests per Period with bursts of up to Burst requests.
+	RateLimitPolicy struct {
+		Name    string        `yaml:"name"`
+		Paths   []string      `yaml:"paths"`   // a path ending with * matches by prefix
+		Methods []string      `yaml:"methods"` // all if empty
+		Key     string        `yaml:"key"`     // user or ip
+		Limit   int           `yaml:"limit"`
+		Period  time.Duration `yaml:"period"`
+		Burst   int           `yaml:"burst"`
+	}
 )
 
 // NewConfig returns app config.
@@ -15,6 +15,190 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/admin/asset/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Permanently removes an asset together with every buyer's access to it. Admin only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Purge Asset",
+                "operationId": "PurgeAsset",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Asset ID to be purged",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Asset purged successfully",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "403": {
+                        "description": "Admin role required",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "404": {
+                        "description": "Asset not found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/promo-codes": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Creates a discount code for any asset or seller, or for the whole market when neither is given. Admin only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Create Site Promo Code",
+                "operationId": "CreateSitePromoCode",
+                "parameters": [
+                    {
+                        "description": "Promo code details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.sitePromoCodeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Promo code created",
+                        "schema": {
+                            "$ref": "#/definitions/entity.PromoCode"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid promo code",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "403": {
+                        "description": "Admin role required",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "404": {
+                        "description": "Asset not found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "409": {
+                        "description": "Promo code already exists",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/webhooks": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Registers an endpoint receiving the events of every user. The secret is returned only in this response. Admin only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Create Global Webhook",
+                "operationId": "CreateGlobalWebhook",
+                "parameters": [
+                    {
+                        "description": "Endpoint and event types: asset.created, asset.purchased, deposit.completed",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.webhookRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Webhook created",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Webhook"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid url or event types",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "403": {
+                        "description": "Admin role required",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    }
+                }
+            }
+        },
         "/asset": {
             "get": {
                 "security": [
@@ -75,7 +259,7 @@ const docTemplate = `{
                 "operationId": "CreateAsset",
                 "parameters": [
                     {
-                        "description": "Asset details (name, description, price)",
+                        "description": "Asset details (name, description, price, initial status, sale mode, stock, access days)",
                         "name": "request",
                         "in": "body",
                         "required": true,
@@ -100,6 +284,41 @@ const docTemplate = `{
                 }
             }
         },
+        "/asset/history": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves the purchases the user paid for, including gifts sent, and the gifts they received, newest first.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Asset"
+                ],
+                "summary": "Get Purchase History",
+                "operationId": "PurchaseHistory",
+                "responses": {
+                    "200": {
+                        "description": "Purchase history of the user",
+                        "schema": {
+                            "$ref": "#/definitions/v1.purchaseHistoryResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    }
+                }
+            }
+        },
         "/asset/market": {
             "get": {
                 "security": [
@@ -107,7 +326,7 @@ const docTemplate = `{
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Retrieves all assets available for purchase in the system.",
+                "description": "Retrieves all assets available for purchase in the system. Limited-stock assets report the remaining editions, sold-out ones are not listed. Each asset carries its average rating and number of reviews.",
                 "consumes": [
                     "application/json"
                 ],
@@ -189,7 +408,7 @@ const docTemplate = `{
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Get an asset from the system based on the provided asset ID.",
+                "description": "Get an asset from the system based on the provided asset ID. Drafts are visible only to the owner, unlisted and archived assets only to the owner and buyers. Includes the average rating and number of reviews.",
                 "consumes": [
                     "application/json"
                 ],
@@ -237,7 +456,7 @@ const docTemplate = `{
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Removes an asset from the system based on the provided asset ID.",
+                "description": "Removes an asset from the market and the owner's listing based on the provided asset ID. Users who bought it keep their access.",
                 "consumes": [
                     "application/json"
                 ],
@@ -280,14 +499,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/asset/{id}/buy": {
+        "/asset/{id}/auction": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Allows the user to purchase an asset by its ID.",
+                "description": "Retrieves the current or the last auction of an asset with its highest bid.",
                 "consumes": [
                     "application/json"
                 ],
@@ -295,14 +514,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Asset"
+                    "Auction"
                 ],
-                "summary": "Buy Asset",
-                "operationId": "BuyAsset",
+                "summary": "Get Auction",
+                "operationId": "GetAuction",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Asset ID to retrieve",
+                        "description": "Asset ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -310,13 +529,13 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Asset purchased successfully",
+                        "description": "Auction of the asset",
                         "schema": {
-                            "$ref": "#/definitions/v1.response"
+                            "$ref": "#/definitions/entity.Auction"
                         }
                     },
                     "404": {
-                        "description": "Asset not found or purchase failed",
+                        "description": "Asset or auction not found",
                         "schema": {
                             "$ref": "#/definitions/v1.response"
                         }
@@ -328,16 +547,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/deposit": {
-            "get": {
+            },
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Retrieves the current balance for the authenticated user.",
+                "description": "Puts the user's published asset up for auction until the given end time. When it ends, the highest bid meeting the reserve price buys the asset.",
                 "consumes": [
                     "application/json"
                 ],
@@ -345,32 +562,70 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Deposit"
+                    "Auction"
+                ],
+                "summary": "Open Auction",
+                "operationId": "OpenAuction",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Asset ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Reserve price and end time (RFC 3339)",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.openAuctionRequest"
+                        }
+                    }
                 ],
-                "summary": "Get Current Deposit",
-                "operationId": "CheckDeposit",
                 "responses": {
                     "200": {
-                        "description": "Current balance retrieved successfully",
+                        "description": "Auction opened",
                         "schema": {
-                            "$ref": "#/definitions/v1.depositResponse"
+                            "$ref": "#/definitions/entity.Auction"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid reserve price or end time",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "404": {
+                        "description": "Asset not found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "409": {
+                        "description": "Asset is not published or already on auction",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
                         }
                     },
                     "500": {
-                        "description": "Internal server error or user not found",
+                        "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/v1.response"
                         }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/asset/{id}/bids": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Allows a user to make a deposit to their account and returns the updated balance.",
+                "description": "Retrieves the bids of the current or the last auction of an asset, highest first.",
                 "consumes": [
                     "application/json"
                 ],
@@ -378,40 +633,180 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Deposit"
+                    "Auction"
                 ],
-                "summary": "Make a Deposit",
-                "operationId": "MakeDeposit",
+                "summary": "List Bids",
+                "operationId": "ListBids",
                 "parameters": [
                     {
-                        "description": "Amount to be deposited",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/v1.depositRequest"
-                        }
+                        "type": "integer",
+                        "description": "Asset ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Deposit successful and updated balance",
+                        "description": "Bids of the auction",
                         "schema": {
-                            "$ref": "#/definitions/v1.depositResponse"
+                            "$ref": "#/definitions/v1.listOfBidsResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Asset or auction not found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
                         }
                     },
                     "500": {
-                        "description": "Internal server error or deposit failed",
+                        "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/v1.response"
                         }
                     }
                 }
-            }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Bids on the open auction of an asset. The amount is held from the user's balance and returned once they are outbid or the auction ends unsold.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auction"
+                ],
+                "summary": "Place Bid",
+                "operationId": "PlaceBid",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Asset ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Bid amount",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.placeBidRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Bid placed",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Bid"
+                        }
+                    },
+                    "400": {
+                        "description": "Amount must be positive",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "404": {
+                        "description": "No open auction for the asset",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "409": {
+                        "description": "Auction is closed, bid is too low or insufficient funds",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    }
+                }
+            }
         },
-        "/login": {
+        "/asset/{id}/buy": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Allows the user to purchase an asset by its ID. Buying a license grants access to the asset, buying a transfer-mode asset makes the user its owner and takes it off the market until relisted.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Asset"
+                ],
+                "summary": "Buy Asset",
+                "operationId": "BuyAsset",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Asset ID to retrieve",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Promo code to apply",
+                        "name": "promo_code",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Asset purchased successfully",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "404": {
+                        "description": "Asset or promo code not found, or purchase failed",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "409": {
+                        "description": "Asset is the user's own, not available for purchase, sold out, already purchased, insufficient funds, or the promo code is expired or does not apply",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    }
+                }
+            }
+        },
+        "/asset/{id}/gift": {
             "post": {
-                "description": "Authenticates the user by verifying credentials and returns a JWT token on success.",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Buys an asset for another user. The user is charged, the recipient gets the asset and the purchase shows up in the history of both.",
                 "consumes": [
                     "application/json"
                 ],
@@ -419,30 +814,55 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Authentication"
+                    "Asset"
                 ],
-                "summary": "User Login",
-                "operationId": "login",
+                "summary": "Gift Asset",
+                "operationId": "GiftAsset",
                 "parameters": [
                     {
-                        "description": "User credentials (e.g., username, password)",
+                        "type": "integer",
+                        "description": "Asset ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Recipient username and an optional message",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/entity.Credentials"
+                            "$ref": "#/definitions/v1.giftAssetRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Success message and JWT token",
+                        "description": "Asset gifted",
                         "schema": {
-                            "$ref": "#/definitions/v1.loginResponse"
+                            "$ref": "#/definitions/entity.Purchase"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid recipient or message is too long",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "404": {
+                        "description": "Asset or recipient not found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "409": {
+                        "description": "Asset is the user's own, not available, sold out, already owned by the recipient, or insufficient funds",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
                         }
                     },
                     "500": {
-                        "description": "Internal server error or invalid credentials",
+                        "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/v1.response"
                         }
@@ -450,9 +870,56 @@ const docTemplate = `{
                 }
             }
         },
-        "/register": {
+        "/asset/{id}/offers": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves the offers on an asset: all of them for the owner, the user's own negotiation otherwise.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Offer"
+                ],
+                "summary": "List Asset Offers",
+                "operationId": "ListAssetOffers",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Asset ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Offers on the asset",
+                        "schema": {
+                            "$ref": "#/definitions/v1.listOfOffersResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    }
+                }
+            },
             "post": {
-                "description": "Handles user registration by accepting credentials and registering a new user in the system.",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Proposes a price for a published asset to its owner. The offer expires if the owner does not respond in time.",
                 "consumes": [
                     "application/json"
                 ],
@@ -460,73 +927,2639 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Authentication"
+                    "Offer"
                 ],
-                "summary": "User Registration",
-                "operationId": "register",
+                "summary": "Make Offer",
+                "operationId": "MakeOffer",
                 "parameters": [
                     {
-                        "description": "User credentials (e.g., username, password)",
+                        "type": "integer",
+                        "description": "Asset ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Offered price",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/entity.Credentials"
+                            "$ref": "#/definitions/v1.offerPriceRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "User registered successfully",
+                        "description": "Offer made",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Offer"
+                        }
+                    },
+                    "400": {
+                        "description": "Price must be positive",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "404": {
+                        "description": "Asset not found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "409": {
+                        "description": "Asset is not available, already purchased or a pending offer exists",
                         "schema": {
                             "$ref": "#/definitions/v1.response"
                         }
                     },
                     "500": {
-                        "description": "Internal server error or user already exists",
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    }
+                }
+            }
+        },
+        "/asset/{id}/price": {
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Sets a new price of the user's asset. Users who have the asset on their wishlist are notified when the price goes down.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Asset"
+                ],
+                "summary": "Change Asset Price",
+                "operationId": "ChangeAssetPrice",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Asset ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New asset price",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.changeAssetPriceRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Asset price changed successfully",
                         "schema": {
                             "$ref": "#/definitions/v1.response"
                         }
+                    },
+                    "400": {
+                        "description": "Price can't be negative",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "404": {
+                        "description": "Asset not found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    }
+                }
+            }
+        },
+        "/asset/{id}/provenance": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves the chain of ownership transfers of an asset, oldest first.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Asset"
+                ],
+                "summary": "Get Asset Provenance",
+                "operationId": "AssetProvenance",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Asset ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Ownership transfers of the asset",
+                        "schema": {
+                            "$ref": "#/definitions/v1.provenanceResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Asset not found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    }
+                }
+            }
+        },
+        "/asset/{id}/renew": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Buys another access period of a rented asset at its current price. The period is added to the end of the current one, or starts now if it has lapsed.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Asset"
+                ],
+                "summary": "Renew Asset Access",
+                "operationId": "RenewAccess",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Asset ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Access renewed",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Purchase"
+                        }
+                    },
+                    "404": {
+                        "description": "Asset not found or the user has no access to it",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "409": {
+                        "description": "Access is permanent, asset is unavailable or insufficient funds",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    }
+                }
+            }
+        },
+        "/asset/{id}/reviews": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves the reviews of an asset with the seller's replies, newest first.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Review"
+                ],
+                "summary": "List Reviews",
+                "operationId": "ListReviews",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Asset ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Reviews of the asset",
+                        "schema": {
+                            "$ref": "#/definitions/v1.listOfReviewsResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Asset not found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Rates an asset the user bought, with an optional text. Each user can review an asset once; the rating counts towards the asset's average.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Review"
+                ],
+                "summary": "Post Review",
+                "operationId": "PostReview",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Asset ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Rating from 1 to 5 and an optional text",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.postReviewRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Review posted",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Review"
+                        }
+                    },
+                    "400": {
+                        "description": "Rating is out of range or the text is too long",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "403": {
+                        "description": "The user has not bought the asset",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "404": {
+                        "description": "Asset not found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "409": {
+                        "description": "The user already reviewed the asset",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    }
+                }
+            }
+        },
+        "/asset/{id}/status": {
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Moves the user's asset through its lifecycle: draft -\u003e published/unlisted/archived, published \u003c-\u003e unlisted, any -\u003e archived.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Asset"
+                ],
+                "summary": "Change Asset Status",
+                "operationId": "ChangeAssetStatus",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Asset ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New asset status",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.changeAssetStatusRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Asset status changed successfully",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid asset status",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "404": {
+                        "description": "Asset not found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "409": {
+                        "description": "Transition is not allowed",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    }
+                }
+            }
+        },
+        "/cart": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves the assets in the user's cart with their current prices, availability and total.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Cart"
+                ],
+                "summary": "Get Cart",
+                "operationId": "GetCart",
+                "responses": {
+                    "200": {
+                        "description": "Cart of the user",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Cart"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Puts a published asset into the user's cart. Adding an asset that is already in the cart has no effect.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Cart"
+                ],
+                "summary": "Add To Cart",
+                "operationId": "AddToCart",
+                "parameters": [
+                    {
+                        "description": "Asset to add",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.addToCartRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Asset added to cart",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid asset id",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "404": {
+                        "description": "Asset not found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "409": {
+                        "description": "Asset is not available or already purchased",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    }
+                }
+            }
+        },
+        "/cart/checkout": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Buys every asset in the cart in a single transaction and empties the cart. Either all assets are purchased or none.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Cart"
+                ],
+                "summary": "Checkout",
+                "operationId": "Checkout",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Promo code to apply",
+                        "name": "promo_code",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Receipt of the checkout",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Receipt"
+                        }
+                    },
+                    "404": {
+                        "description": "Promo code not found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "409": {
+                        "description": "Cart is empty, an asset is not available, sold out or already purchased, insufficient funds, or the promo code is expired or applies to no asset",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    }
+                }
+            }
+        },
+        "/cart/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Removes an asset from the user's cart.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Cart"
+                ],
+                "summary": "Remove From Cart",
+                "operationId": "RemoveFromCart",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Asset ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Asset removed from cart",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "404": {
+                        "description": "Asset is not in the cart",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    }
+                }
+            }
+        },
+        "/deposit": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves the current balance for the authenticated user.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Deposit"
+                ],
+                "summary": "Get Current Deposit",
+                "operationId": "CheckDeposit",
+                "responses": {
+                    "200": {
+                        "description": "Current balance retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/v1.depositResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error or user not found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Allows a user to make a deposit to their account and returns the updated balance.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Deposit"
+                ],
+                "summary": "Make a Deposit",
+                "operationId": "MakeDeposit",
+                "parameters": [
+                    {
+                        "description": "Amount to be deposited",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.depositRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Deposit successful and updated balance",
+                        "schema": {
+                            "$ref": "#/definitions/v1.depositResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error or deposit failed",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    }
+                }
+            }
+        },
+        "/login": {
+            "post": {
+                "description": "Authenticates the user by verifying credentials and returns a JWT token on success.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "User Login",
+                "operationId": "login",
+                "parameters": [
+                    {
+                        "description": "User credentials (e.g., username, password)",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.Credentials"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success message and JWT token",
+                        "schema": {
+                            "$ref": "#/definitions/v1.loginResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error or invalid credentials",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    }
+                }
+            }
+        },
+        "/notifications": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves the user's in-app notifications, newest first.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notification"
+                ],
+                "summary": "List Notifications",
+                "operationId": "ListNotifications",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Only unread notifications",
+                        "name": "unread",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Notifications of the user",
+                        "schema": {
+                            "$ref": "#/definitions/v1.listOfNotificationsResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    }
+                }
+            }
+        },
+        "/notifications/{id}/read": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Marks one of the user's notifications as read.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notification"
+                ],
+                "summary": "Mark Notification Read",
+                "operationId": "MarkNotificationRead",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Notification ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Notification marked as read",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "404": {
+                        "description": "Notification not found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    }
+                }
+            }
+        },
+        "/offers/inbox": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves the offers and counter-offers proposed to the user, newest first.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Offer"
+                ],
+                "summary": "Offers Inbox",
+                "operationId": "OffersInbox",
+                "responses": {
+                    "200": {
+                        "description": "Offers proposed to the user",
+                        "schema": {
+                            "$ref": "#/definitions/v1.listOfOffersResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    }
+                }
+            }
+        },
+        "/offers/outbox": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves the offers and counter-offers proposed by the user, newest first.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Offer"
+                ],
+                "summary": "Offers Outbox",
+                "operationId": "OffersOutbox",
+                "responses": {
+                    "200": {
+                        "description": "Offers proposed by the user",
+                        "schema": {
+                            "$ref": "#/definitions/v1.listOfOffersResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    }
+                }
+            }
+        },
+        "/offers/{id}/accept": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Accepts an offer proposed to the user. The buyer purchases the asset for the offered price.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Offer"
+                ],
+                "summary": "Accept Offer",
+                "operationId": "AcceptOffer",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Offer ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Offer accepted",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Offer"
+                        }
+                    },
+                    "404": {
+                        "description": "Offer not found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "409": {
+                        "description": "Offer is closed, asset is not available or insufficient funds",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    }
+                }
+            }
+        },
+        "/offers/{id}/counter": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Answers an offer proposed to the user with another price. The offer is closed and the counter-offer goes to the other party.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Offer"
+                ],
+                "summary": "Counter Offer",
+                "operationId": "CounterOffer",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Offer ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Counter price",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.offerPriceRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Counter-offer made",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Offer"
+                        }
+                    },
+                    "400": {
+                        "description": "Price must be positive",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "404": {
+                        "description": "Offer not found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "409": {
+                        "description": "Offer is no longer pending",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    }
+                }
+            }
+        },
+        "/offers/{id}/reject": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Rejects an offer proposed to the user.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Offer"
+                ],
+                "summary": "Reject Offer",
+                "operationId": "RejectOffer",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Offer ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Offer rejected",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Offer"
+                        }
+                    },
+                    "404": {
+                        "description": "Offer not found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "409": {
+                        "description": "Offer is no longer pending",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    }
+                }
+            }
+        },
+        "/promo-codes": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves the promo codes created by the user with their usage.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Promo"
+                ],
+                "summary": "List Promo Codes",
+                "operationId": "ListPromoCodes",
+                "responses": {
+                    "200": {
+                        "description": "Promo codes of the user",
+                        "schema": {
+                            "$ref": "#/definitions/v1.listOfPromoCodesResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Creates a discount code for all of the user's assets or one of them. Buyers apply it with the promo_code parameter of a purchase or checkout.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Promo"
+                ],
+                "summary": "Create Promo Code",
+                "operationId": "CreatePromoCode",
+                "parameters": [
+                    {
+                        "description": "Promo code details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.promoCodeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Promo code created",
+                        "schema": {
+                            "$ref": "#/definitions/entity.PromoCode"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid promo code",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "404": {
+                        "description": "Asset not found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "409": {
+                        "description": "Promo code already exists",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    }
+                }
+            }
+        },
+        "/register": {
+            "post": {
+                "description": "Handles user registration by accepting credentials and registering a new user in the system.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "User Registration",
+                "operationId": "register",
+                "parameters": [
+                    {
+                        "description": "User credentials (e.g., username, password)",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.Credentials"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "User registered successfully",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error or user already exists",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    }
+                }
+            }
+        },
+        "/reviews/{id}/reply": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "The owner of the reviewed asset answers a review. Each review can be answered once.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Review"
+                ],
+                "summary": "Reply To Review",
+                "operationId": "ReplyToReview",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Review ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Reply text",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.replyToReviewRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Review with the reply",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Review"
+                        }
+                    },
+                    "400": {
+                        "description": "Reply is empty or too long",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "404": {
+                        "description": "Review not found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "409": {
+                        "description": "Review already has a reply",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    }
+                }
+            }
+        },
+        "/seller/stats": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves the sales of the user's assets within a date range: units sold and revenue per asset, in total and per day, week or month. Revenue is what buyers paid after discounts. Buckets are in UTC, weeks start on Monday.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Seller"
+                ],
+                "summary": "Seller Stats",
+                "operationId": "SellerStats",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Start of the range, a date or an RFC 3339 timestamp, 30 days before its end by default",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End of the range, exclusive; a date includes the whole day. Now by default",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "day",
+                            "week",
+                            "month"
+                        ],
+                        "type": "string",
+                        "default": "day",
+                        "description": "Length of a point of the series",
+                        "name": "bucket",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Sales of the seller",
+                        "schema": {
+                            "$ref": "#/definitions/entity.SellerStats"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid range or bucket",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    }
+                }
+            }
+        },
+        "/stream/market": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Streams market changes as Server-Sent Events named after their type: asset.created, asset.updated, asset.deleted, asset.sold. A comment line is sent every 15 seconds as a heartbeat. A client that falls behind gets a \"lagged\" event and is disconnected. The token can be passed in the jwt query parameter.",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "Stream"
+                ],
+                "summary": "Market Feed (SSE)",
+                "operationId": "MarketEvents",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Token, when the Authorization header can't be set",
+                        "name": "jwt",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Stream of market events",
+                        "schema": {
+                            "$ref": "#/definitions/entity.MarketEvent"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    }
+                }
+            }
+        },
+        "/stream/market/ws": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Upgrades to a WebSocket streaming market changes as JSON messages, the same as the SSE feed. {\"type\":\"heartbeat\"} is sent every 15 seconds; a client that falls behind gets {\"type\":\"lagged\"} and is disconnected. Messages from the client are ignored. The token can be passed in the jwt query parameter.",
+                "tags": [
+                    "Stream"
+                ],
+                "summary": "Market Feed (WebSocket)",
+                "operationId": "MarketWebSocket",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Token, when the Authorization header can't be set",
+                        "name": "jwt",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "101": {
+                        "description": "Switching protocols",
+                        "schema": {
+                            "$ref": "#/definitions/entity.MarketEvent"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves the webhooks registered by the user, without their secrets.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhook"
+                ],
+                "summary": "List Webhooks",
+                "operationId": "ListWebhooks",
+                "responses": {
+                    "200": {
+                        "description": "Webhooks of the user",
+                        "schema": {
+                            "$ref": "#/definitions/v1.listOfWebhooksResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Registers an endpoint receiving the user's events. Deliveries are signed with HMAC-SHA256 of \"\u003cX-Webhook-Timestamp\u003e.\u003cbody\u003e\" in the X-Webhook-Signature header; the secret is returned only in this response.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhook"
+                ],
+                "summary": "Create Webhook",
+                "operationId": "CreateWebhook",
+                "parameters": [
+                    {
+                        "description": "Endpoint and event types: asset.created, asset.purchased, deposit.completed",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.webhookRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Webhook created",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Webhook"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid url or event types",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/deliveries/{id}/redeliver": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Queues a delivery of one of the user's webhooks to be sent again with a fresh set of attempts.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhook"
+                ],
+                "summary": "Redeliver Webhook Delivery",
+                "operationId": "RedeliverWebhookDelivery",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Delivery ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Delivery queued",
+                        "schema": {
+                            "$ref": "#/definitions/entity.WebhookDelivery"
+                        }
+                    },
+                    "404": {
+                        "description": "Delivery not found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Removes one of the user's webhooks together with its deliveries.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhook"
+                ],
+                "summary": "Delete Webhook",
+                "operationId": "DeleteWebhook",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Webhook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Webhook deleted",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "404": {
+                        "description": "Webhook not found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/{id}/deliveries": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves the deliveries of one of the user's webhooks with their status, attempts and last response, newest first.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhook"
+                ],
+                "summary": "List Webhook Deliveries",
+                "operationId": "ListWebhookDeliveries",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Webhook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Deliveries of the webhook",
+                        "schema": {
+                            "$ref": "#/definitions/v1.listOfDeliveriesResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Webhook not found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    }
+                }
+            }
+        },
+        "/wishlist": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves the assets the user saved for later with their current prices and whether they are listed on the market.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Wishlist"
+                ],
+                "summary": "Get Wishlist",
+                "operationId": "GetWishlist",
+                "responses": {
+                    "200": {
+                        "description": "Wishlist of the user",
+                        "schema": {
+                            "$ref": "#/definitions/v1.wishlistResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Saves a published asset of another user for later. The user is notified when its price goes down. Adding an asset that is already on the wishlist has no effect.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Wishlist"
+                ],
+                "summary": "Add To Wishlist",
+                "operationId": "AddToWishlist",
+                "parameters": [
+                    {
+                        "description": "Asset to add",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.addToWishlistRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Asset added to wishlist",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid asset id",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "404": {
+                        "description": "Asset not found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "409": {
+                        "description": "Asset is not published",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    }
+                }
+            }
+        },
+        "/wishlist/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Removes an asset from the user's wishlist.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Wishlist"
+                ],
+                "summary": "Remove From Wishlist",
+                "operationId": "RemoveFromWishlist",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Asset ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Asset removed from wishlist",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "404": {
+                        "description": "Asset is not on the wishlist",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.response"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "entity.Asset": {
+            "type": "object",
+            "properties": {
+                "access_days": {
+                    "description": "rental or subscription period, permanent access if not set",
+                    "type": "integer"
+                },
+                "access_expires_at": {
+                    "description": "AccessExpiresAt - end of the user's access, in purchased listings of rented assets.",
+                    "type": "string"
+                },
+                "deleted_at": {
+                    "description": "set once the owner has deleted the asset",
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "edition": {
+                    "description": "edition number owned by the user, in purchased listings",
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "owner_id": {
+                    "type": "integer"
+                },
+                "price": {
+                    "type": "number"
+                },
+                "rating": {
+                    "description": "average of the reviews, not set until the first one",
+                    "type": "number"
+                },
+                "rating_count": {
+                    "type": "integer"
+                },
+                "remaining": {
+                    "description": "editions left for sale, unlimited if not set",
+                    "type": "integer"
+                },
+                "sale_mode": {
+                    "$ref": "#/definitions/entity.SaleMode"
+                },
+                "sold": {
+                    "description": "editions sold so far",
+                    "type": "integer"
+                },
+                "status": {
+                    "$ref": "#/definitions/entity.AssetStatus"
+                },
+                "stock": {
+                    "description": "total editions, unlimited if not set",
+                    "type": "integer"
+                }
+            }
+        },
+        "entity.AssetSales": {
+            "type": "object",
+            "properties": {
+                "asset_id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "revenue": {
+                    "type": "number"
+                },
+                "units": {
+                    "type": "integer"
+                }
+            }
+        },
+        "entity.AssetStatus": {
+            "type": "string",
+            "enum": [
+                "draft",
+                "published",
+                "unlisted",
+                "archived"
+            ],
+            "x-enum-comments": {
+                "AssetStatusArchived": "withdrawn for good, still accessible to buyers",
+                "AssetStatusDraft": "visible only to the owner",
+                "AssetStatusPublished": "listed on the market and available for purchase",
+                "AssetStatusUnlisted": "hidden from the market, still accessible to buyers"
+            },
+            "x-enum-varnames": [
+                "AssetStatusDraft",
+                "AssetStatusPublished",
+                "AssetStatusUnlisted",
+                "AssetStatusArchived"
+            ]
+        },
+        "entity.Auction": {
+            "type": "object",
+            "properties": {
+                "asset_id": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "end_at": {
+                    "type": "string"
+                },
+                "highest_bid": {
+                    "type": "number"
+                },
+                "highest_bidder_id": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "reserve_price": {
+                    "type": "number"
+                },
+                "seller_id": {
+                    "type": "integer"
+                },
+                "settled_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/entity.AuctionStatus"
+                }
+            }
+        },
+        "entity.AuctionStatus": {
+            "type": "string",
+            "enum": [
+                "open",
+                "sold",
+                "unsold"
+            ],
+            "x-enum-comments": {
+                "AuctionStatusOpen": "accepting bids until EndAt",
+                "AuctionStatusSold": "settled, the highest bidder bought the asset",
+                "AuctionStatusUnsold": "settled without a sale, the reserve price was not met"
+            },
+            "x-enum-varnames": [
+                "AuctionStatusOpen",
+                "AuctionStatusSold",
+                "AuctionStatusUnsold"
+            ]
+        },
+        "entity.Bid": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "auction_id": {
+                    "type": "integer"
+                },
+                "bidder_id": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "status": {
+                    "$ref": "#/definitions/entity.BidStatus"
+                }
+            }
+        },
+        "entity.BidStatus": {
+            "type": "string",
+            "enum": [
+                "held",
+                "released",
+                "won"
+            ],
+            "x-enum-comments": {
+                "BidStatusHeld": "highest bid, the amount is held from the bidder's balance",
+                "BidStatusReleased": "outbid or auction unsold, the amount is returned",
+                "BidStatusWon": "the amount paid for the asset"
+            },
+            "x-enum-varnames": [
+                "BidStatusHeld",
+                "BidStatusReleased",
+                "BidStatusWon"
+            ]
+        },
+        "entity.Cart": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.CartItem"
+                    }
+                },
+                "total": {
+                    "type": "number"
+                }
+            }
+        },
+        "entity.CartItem": {
+            "type": "object",
+            "properties": {
+                "added_at": {
+                    "type": "string"
+                },
+                "asset_id": {
+                    "type": "integer"
+                },
+                "available": {
+                    "description": "can be bought right now",
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "number"
+                },
+                "status": {
+                    "$ref": "#/definitions/entity.AssetStatus"
+                }
+            }
+        },
+        "entity.Credentials": {
+            "type": "object",
+            "properties": {
+                "password": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.DeliveryStatus": {
+            "type": "string",
+            "enum": [
+                "pending",
+                "succeeded",
+                "failed"
+            ],
+            "x-enum-comments": {
+                "DeliveryStatusFailed": "every attempt failed, can be redelivered manually",
+                "DeliveryStatusPending": "waiting for the next attempt",
+                "DeliveryStatusSucceeded": "the endpoint answered with 2xx"
+            },
+            "x-enum-varnames": [
+                "DeliveryStatusPending",
+                "DeliveryStatusSucceeded",
+                "DeliveryStatusFailed"
+            ]
+        },
+        "entity.DiscountKind": {
+            "type": "string",
+            "enum": [
+                "percent",
+                "fixed"
+            ],
+            "x-enum-comments": {
+                "DiscountFixed": "Value off the price, never below zero",
+                "DiscountPercent": "Value percent off the price"
+            },
+            "x-enum-varnames": [
+                "DiscountPercent",
+                "DiscountFixed"
+            ]
+        },
+        "entity.EventType": {
+            "type": "string",
+            "enum": [
+                "asset.created",
+                "asset.purchased",
+                "deposit.completed"
+            ],
+            "x-enum-varnames": [
+                "EventAssetCreated",
+                "EventAssetPurchased",
+                "EventDepositCompleted"
+            ]
+        },
+        "entity.MarketEvent": {
+            "type": "object",
+            "properties": {
+                "asset_id": {
+                    "type": "integer"
+                },
+                "occurred_at": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "number"
+                },
+                "status": {
+                    "$ref": "#/definitions/entity.AssetStatus"
+                },
+                "type": {
+                    "$ref": "#/definitions/entity.MarketEventType"
+                }
+            }
+        },
+        "entity.MarketEventType": {
+            "type": "string",
+            "enum": [
+                "asset.created",
+                "asset.updated",
+                "asset.deleted",
+                "asset.sold"
+            ],
+            "x-enum-comments": {
+                "MarketAssetCreated": "a new asset is listed right away",
+                "MarketAssetUpdated": "status or price changed"
+            },
+            "x-enum-varnames": [
+                "MarketAssetCreated",
+                "MarketAssetUpdated",
+                "MarketAssetDeleted",
+                "MarketAssetSold"
+            ]
+        },
+        "entity.Notification": {
+            "type": "object",
+            "properties": {
+                "asset_id": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "kind": {
+                    "$ref": "#/definitions/entity.NotificationKind"
+                },
+                "new_price": {
+                    "type": "number"
+                },
+                "old_price": {
+                    "type": "number"
+                },
+                "read_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "entity.NotificationKind": {
+            "type": "string",
+            "enum": [
+                "price_drop"
+            ],
+            "x-enum-varnames": [
+                "NotificationKindPriceDrop"
+            ]
+        },
+        "entity.Offer": {
+            "type": "object",
+            "properties": {
+                "asset_id": {
+                    "type": "integer"
+                },
+                "buyer_id": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "parent_id": {
+                    "description": "the offer this one counters",
+                    "type": "integer"
+                },
+                "price": {
+                    "type": "number"
+                },
+                "proposed_by": {
+                    "type": "integer"
+                },
+                "purchase_id": {
+                    "type": "integer"
+                },
+                "responded_at": {
+                    "type": "string"
+                },
+                "seller_id": {
+                    "type": "integer"
+                },
+                "status": {
+                    "$ref": "#/definitions/entity.OfferStatus"
+                }
+            }
+        },
+        "entity.OfferStatus": {
+            "type": "string",
+            "enum": [
+                "pending",
+                "accepted",
+                "rejected",
+                "countered",
+                "expired"
+            ],
+            "x-enum-comments": {
+                "OfferStatusAccepted": "the buyer bought the asset for Price",
+                "OfferStatusCountered": "replaced by a counter-offer with another price",
+                "OfferStatusExpired": "not answered in time",
+                "OfferStatusPending": "waiting for the other party until ExpiresAt",
+                "OfferStatusRejected": "declined by the other party"
+            },
+            "x-enum-varnames": [
+                "OfferStatusPending",
+                "OfferStatusAccepted",
+                "OfferStatusRejected",
+                "OfferStatusCountered",
+                "OfferStatusExpired"
+            ]
+        },
+        "entity.PromoCode": {
+            "type": "object",
+            "properties": {
+                "asset_id": {
+                    "type": "integer"
+                },
+                "code": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "integer"
+                },
+                "expires_at": {
+                    "description": "never expires if not set",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "kind": {
+                    "$ref": "#/definitions/entity.DiscountKind"
+                },
+                "max_uses": {
+                    "description": "unlimited if not set",
+                    "type": "integer"
+                },
+                "seller_id": {
+                    "type": "integer"
+                },
+                "used": {
+                    "type": "integer"
+                },
+                "value": {
+                    "type": "number"
+                }
+            }
+        },
+        "entity.Purchase": {
+            "type": "object",
+            "properties": {
+                "access_expires_at": {
+                    "description": "AccessExpiresAt - end of the access bought, set for rented assets.",
+                    "type": "string"
+                },
+                "asset_id": {
+                    "description": "0 once the asset is purged",
+                    "type": "integer"
+                },
+                "buyer_id": {
+                    "type": "integer"
+                },
+                "discount": {
+                    "description": "taken off the listed price by a promo code",
+                    "type": "number"
+                },
+                "edition": {
+                    "description": "set for limited-stock assets",
+                    "type": "integer"
+                },
+                "gift_message": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "price": {
+                    "type": "number"
+                },
+                "purchased_at": {
+                    "type": "string"
+                },
+                "receipt_id": {
+                    "description": "set for purchases made by a cart checkout",
+                    "type": "integer"
+                },
+                "recipient_id": {
+                    "description": "RecipientId - set for gifts, the user who got the asset paid for by the buyer.",
+                    "type": "integer"
+                },
+                "sale_mode": {
+                    "$ref": "#/definitions/entity.SaleMode"
+                },
+                "seller_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "entity.Receipt": {
+            "type": "object",
+            "properties": {
+                "buyer_id": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "purchases": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.Purchase"
+                    }
+                },
+                "total": {
+                    "type": "number"
+                }
+            }
+        },
+        "entity.Review": {
+            "type": "object",
+            "properties": {
+                "asset_id": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "rating": {
+                    "type": "integer"
+                },
+                "replied_at": {
+                    "type": "string"
+                },
+                "reply": {
+                    "type": "string"
+                },
+                "text": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "entity.SaleMode": {
+            "type": "string",
+            "enum": [
+                "license",
+                "transfer"
+            ],
+            "x-enum-comments": {
+                "SaleModeLicense": "buyer gets access, the owner keeps the asset and can sell it again",
+                "SaleModeTransfer": "ownership passes to the buyer, one-of-a-kind goods"
+            },
+            "x-enum-varnames": [
+                "SaleModeLicense",
+                "SaleModeTransfer"
+            ]
+        },
+        "entity.SalesPoint": {
+            "type": "object",
+            "properties": {
+                "revenue": {
+                    "type": "number"
+                },
+                "start": {
+                    "type": "string"
+                },
+                "units": {
+                    "type": "integer"
+                }
+            }
+        },
+        "entity.SellerStats": {
+            "type": "object",
+            "properties": {
+                "assets": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.AssetSales"
+                    }
+                },
+                "bucket": {
+                    "$ref": "#/definitions/entity.StatsBucket"
+                },
+                "from": {
+                    "type": "string"
+                },
+                "series": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.SalesPoint"
+                    }
+                },
+                "to": {
+                    "type": "string"
+                },
+                "total_revenue": {
+                    "type": "number"
+                },
+                "total_units": {
+                    "type": "integer"
+                }
+            }
+        },
+        "entity.StatsBucket": {
+            "type": "string",
+            "enum": [
+                "day",
+                "week",
+                "month"
+            ],
+            "x-enum-comments": {
+                "StatsBucketMonth": "calendar months",
+                "StatsBucketWeek": "weeks start on Monday"
+            },
+            "x-enum-varnames": [
+                "StatsBucketDay",
+                "StatsBucketWeek",
+                "StatsBucketMonth"
+            ]
+        },
+        "entity.Webhook": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.EventType"
                     }
+                },
+                "global": {
+                    "description": "receives the events of every user, registered by admins",
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "secret": {
+                    "description": "Secret - key of the HMAC-SHA256 signature of deliveries, returned only when the webhook is created.",
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
                 }
             }
-        }
-    },
-    "definitions": {
-        "entity.Asset": {
+        },
+        "entity.WebhookDelivery": {
             "type": "object",
             "properties": {
-                "description": {
+                "attempts": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "delivered_at": {
                     "type": "string"
                 },
+                "event_type": {
+                    "$ref": "#/definitions/entity.EventType"
+                },
                 "id": {
                     "type": "integer"
                 },
-                "name": {
+                "last_error": {
                     "type": "string"
                 },
-                "owner_id": {
+                "last_status_code": {
                     "type": "integer"
                 },
-                "price": {
-                    "type": "number"
+                "next_attempt_at": {
+                    "type": "string"
+                },
+                "payload": {
+                    "type": "object"
+                },
+                "status": {
+                    "$ref": "#/definitions/entity.DeliveryStatus"
+                },
+                "webhook_id": {
+                    "type": "integer"
                 }
             }
         },
-        "entity.Credentials": {
+        "entity.WishlistItem": {
             "type": "object",
             "properties": {
-                "password": {
+                "added_at": {
                     "type": "string"
                 },
-                "username": {
+                "asset_id": {
+                    "type": "integer"
+                },
+                "available": {
+                    "description": "listed on the market right now",
+                    "type": "boolean"
+                },
+                "name": {
                     "type": "string"
+                },
+                "price": {
+                    "type": "number"
+                },
+                "status": {
+                    "$ref": "#/definitions/entity.AssetStatus"
+                }
+            }
+        },
+        "v1.addToCartRequest": {
+            "type": "object",
+            "properties": {
+                "asset_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "v1.addToWishlistRequest": {
+            "type": "object",
+            "properties": {
+                "asset_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "v1.changeAssetPriceRequest": {
+            "type": "object",
+            "properties": {
+                "price": {
+                    "type": "number"
+                }
+            }
+        },
+        "v1.changeAssetStatusRequest": {
+            "type": "object",
+            "properties": {
+                "status": {
+                    "enum": [
+                        "draft",
+                        "published",
+                        "unlisted",
+                        "archived"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/entity.AssetStatus"
+                        }
+                    ]
                 }
             }
         },
         "v1.createAssetRequest": {
             "type": "object",
             "properties": {
+                "access_days": {
+                    "description": "rental period of each purchase, permanent if omitted",
+                    "type": "integer"
+                },
                 "description": {
                     "type": "string"
                 },
@@ -535,6 +3568,35 @@ const docTemplate = `{
                 },
                 "price": {
                     "type": "number"
+                },
+                "sale_mode": {
+                    "description": "license if omitted",
+                    "enum": [
+                        "license",
+                        "transfer"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/entity.SaleMode"
+                        }
+                    ]
+                },
+                "status": {
+                    "description": "draft if omitted",
+                    "enum": [
+                        "draft",
+                        "published",
+                        "unlisted"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/entity.AssetStatus"
+                        }
+                    ]
+                },
+                "stock": {
+                    "description": "limited editions, unlimited if omitted",
+                    "type": "integer"
                 }
             }
         },
@@ -557,6 +3619,19 @@ const docTemplate = `{
                 }
             }
         },
+        "v1.giftAssetRequest": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string"
+                },
+                "recipient": {
+                    "description": "username",
+                    "type": "string",
+                    "example": "friend"
+                }
+            }
+        },
         "v1.listOfAssetResponse": {
             "type": "object",
             "properties": {
@@ -568,6 +3643,83 @@ const docTemplate = `{
                 }
             }
         },
+        "v1.listOfBidsResponse": {
+            "type": "object",
+            "properties": {
+                "bids": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.Bid"
+                    }
+                }
+            }
+        },
+        "v1.listOfDeliveriesResponse": {
+            "type": "object",
+            "properties": {
+                "deliveries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.WebhookDelivery"
+                    }
+                }
+            }
+        },
+        "v1.listOfNotificationsResponse": {
+            "type": "object",
+            "properties": {
+                "notifications": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.Notification"
+                    }
+                }
+            }
+        },
+        "v1.listOfOffersResponse": {
+            "type": "object",
+            "properties": {
+                "offers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.Offer"
+                    }
+                }
+            }
+        },
+        "v1.listOfPromoCodesResponse": {
+            "type": "object",
+            "properties": {
+                "promo_codes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.PromoCode"
+                    }
+                }
+            }
+        },
+        "v1.listOfReviewsResponse": {
+            "type": "object",
+            "properties": {
+                "reviews": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.Review"
+                    }
+                }
+            }
+        },
+        "v1.listOfWebhooksResponse": {
+            "type": "object",
+            "properties": {
+                "webhooks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.Webhook"
+                    }
+                }
+            }
+        },
         "v1.loginResponse": {
             "type": "object",
             "properties": {
@@ -579,6 +3731,113 @@ const docTemplate = `{
                 }
             }
         },
+        "v1.offerPriceRequest": {
+            "type": "object",
+            "properties": {
+                "price": {
+                    "type": "number"
+                }
+            }
+        },
+        "v1.openAuctionRequest": {
+            "type": "object",
+            "properties": {
+                "end_at": {
+                    "type": "string",
+                    "example": "2024-12-31T18:00:00Z"
+                },
+                "reserve_price": {
+                    "type": "number"
+                }
+            }
+        },
+        "v1.placeBidRequest": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number"
+                }
+            }
+        },
+        "v1.postReviewRequest": {
+            "type": "object",
+            "properties": {
+                "rating": {
+                    "type": "integer",
+                    "maximum": 5,
+                    "minimum": 1
+                },
+                "text": {
+                    "type": "string"
+                }
+            }
+        },
+        "v1.promoCodeRequest": {
+            "type": "object",
+            "properties": {
+                "asset_id": {
+                    "description": "all of the seller's assets if omitted",
+                    "type": "integer"
+                },
+                "code": {
+                    "type": "string",
+                    "example": "SUMMER10"
+                },
+                "expires_at": {
+                    "description": "never expires if omitted",
+                    "type": "string"
+                },
+                "kind": {
+                    "enum": [
+                        "percent",
+                        "fixed"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/entity.DiscountKind"
+                        }
+                    ]
+                },
+                "max_uses": {
+                    "description": "unlimited if omitted",
+                    "type": "integer"
+                },
+                "value": {
+                    "type": "number",
+                    "example": 10
+                }
+            }
+        },
+        "v1.provenanceResponse": {
+            "type": "object",
+            "properties": {
+                "transfers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.Purchase"
+                    }
+                }
+            }
+        },
+        "v1.purchaseHistoryResponse": {
+            "type": "object",
+            "properties": {
+                "purchases": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.Purchase"
+                    }
+                }
+            }
+        },
+        "v1.replyToReviewRequest": {
+            "type": "object",
+            "properties": {
+                "reply": {
+                    "type": "string"
+                }
+            }
+        },
         "v1.response": {
             "type": "object",
             "properties": {
@@ -587,6 +3846,75 @@ const docTemplate = `{
                     "example": "message"
                 }
             }
+        },
+        "v1.sitePromoCodeRequest": {
+            "type": "object",
+            "properties": {
+                "asset_id": {
+                    "description": "all of the seller's assets if omitted",
+                    "type": "integer"
+                },
+                "code": {
+                    "type": "string",
+                    "example": "SUMMER10"
+                },
+                "expires_at": {
+                    "description": "never expires if omitted",
+                    "type": "string"
+                },
+                "kind": {
+                    "enum": [
+                        "percent",
+                        "fixed"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/entity.DiscountKind"
+                        }
+                    ]
+                },
+                "max_uses": {
+                    "description": "unlimited if omitted",
+                    "type": "integer"
+                },
+                "seller_id": {
+                    "description": "with asset_id omitted, the code applies to every seller's assets",
+                    "type": "integer"
+                },
+                "value": {
+                    "type": "number",
+                    "example": 10
+                }
+            }
+        },
+        "v1.webhookRequest": {
+            "type": "object",
+            "properties": {
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.EventType"
+                    },
+                    "example": [
+                        "asset.purchased"
+                    ]
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://game.example.com/hooks/market"
+                }
+            }
+        },
+        "v1.wishlistResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.WishlistItem"
+                    }
+                }
+            }
         }
     },
     "securityDefinitions": {
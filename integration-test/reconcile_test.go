@@ -0,0 +1,61 @@
+package integration
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Klef99/bhs-task/internal/entity"
+	"github.com/Klef99/bhs-task/internal/usecase/repo"
+	"github.com/Klef99/bhs-task/pkg/hasher"
+	"github.com/Klef99/bhs-task/pkg/postgres"
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+)
+
+// TestReconcileRenewal - a renewed rental is recorded in purchases without changing assets.sold,
+// the sold check must not report it.
+func (i *SuiteStruct) TestReconcileRenewal(t provider.T) {
+	t.Title("Reconcile a renewed rental")
+	t.Tags("reconcile", "renewal")
+
+	ctx := context.Background()
+	pg, err := postgres.New(i.cfg.PG.URL, postgres.MaxPoolSize(2))
+	t.Require().NoError(err)
+	defer pg.Close()
+
+	hs := hasher.NewHasher()
+	users := repo.NewUserRepository(pg, hs)
+	assets := repo.NewAssetRepository(pg)
+	admin := repo.NewAdminRepository(pg, hs)
+
+	suffix := time.Now().UnixNano()
+	newUser := func(name string) entity.User {
+		crd := entity.Credentials{Username: fmt.Sprintf("%s-%d", name, suffix), Password: "test"}
+		_, err := users.CreateUser(ctx, crd)
+		t.Require().NoError(err)
+		id, err := users.LoginUser(ctx, crd)
+		t.Require().NoError(err)
+		return entity.User{Id: id, Username: crd.Username}
+	}
+	seller := newUser("seller")
+	buyer := newUser("buyer")
+	_, err = users.MakeDeposit(ctx, buyer, 100)
+	t.Require().NoError(err)
+
+	days := int64(30)
+	id, err := assets.Store(ctx, entity.Asset{
+		Name: "rental", Description: "renewed", Price: 10, Owner_id: seller.Id,
+		Status: entity.AssetStatusPublished, SaleMode: entity.SaleModeLicense, AccessDays: &days,
+	})
+	t.Require().NoError(err)
+	_, err = assets.BuyAsset(ctx, buyer, id, "")
+	t.Require().NoError(err)
+	_, err = assets.RenewAccess(ctx, buyer, id)
+	t.Require().NoError(err)
+
+	issues, err := admin.Reconcile(ctx)
+	t.Require().NoError(err)
+	for _, issue := range issues {
+		t.Require().NotEqual(fmt.Sprintf("asset %d", id), issue.Subject, "%s: %s", issue.Check, issue.Detail)
+	}
+}
@@ -1,25 +1,35 @@
 package app
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"os/signal"
+	"sync"
 	"syscall"
 	"time"
 
 	"github.com/Klef99/bhs-task/config"
-	v1 "github.com/Klef99/bhs-task/internal/controller/http/v1"
+	grpccontroller "github.com/Klef99/bhs-task/internal/controller/grpc"
+	"github.com/Klef99/bhs-task/internal/controller/http/probe"
 	"github.com/Klef99/bhs-task/internal/usecase"
+	"github.com/Klef99/bhs-task/internal/usecase/publisher"
 	"github.com/Klef99/bhs-task/internal/usecase/repo"
+	"github.com/Klef99/bhs-task/pkg/grpcserver"
 	"github.com/Klef99/bhs-task/pkg/hasher"
 	"github.com/Klef99/bhs-task/pkg/httpserver"
 	"github.com/Klef99/bhs-task/pkg/jwtgenerator"
 	"github.com/Klef99/bhs-task/pkg/logger"
 	"github.com/Klef99/bhs-task/pkg/postgres"
-	"github.com/go-chi/chi/v5"
+	"github.com/Klef99/bhs-task/pkg/tracing"
+	"github.com/Klef99/bhs-task/pkg/webhook"
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 )
 
-func Run(cfg *config.Config) {
+// Run - metrics are registered in reg and served by it on /metrics.
+func Run(cfg *config.Config, reg *prometheus.Registry) {
 	l := logger.New(cfg.Log.Level)
 
 	// Jwt generator
@@ -31,24 +41,213 @@ func Run(cfg *config.Config) {
 		l.Fatal(fmt.Errorf("app - Run - jwtgenerator.New: %w", err))
 	}
 
+	// Tracing
+	tr, err := tracing.New(
+		tracing.Exporter(cfg.Tracing.Exporter),
+		tracing.Endpoint(cfg.Tracing.Endpoint),
+		tracing.Insecure(cfg.Tracing.Insecure),
+		tracing.SampleRatio(cfg.Tracing.SampleRatio),
+		tracing.Service(cfg.App.Name, cfg.App.Version),
+	)
+	if err != nil {
+		l.Fatal(fmt.Errorf("app - Run - tracing.New: %w", err))
+	}
+
 	// Repository
-	pg, err := postgres.New(cfg.PG.URL, postgres.MaxPoolSize(cfg.PG.PoolMax))
+	pg, err := postgres.New(cfg.PG.URL, postgres.MaxPoolSize(cfg.PG.PoolMax), postgres.TracerProvider(tr.Provider), postgres.Registerer(reg))
 	if err != nil {
 		l.Fatal(fmt.Errorf("app - Run - postgres.New: %w", err))
 	}
 	defer pg.Close()
 
 	// Use case
-	UserUseCase := usecase.NewUserUseCase(
-		repo.NewUserRepository(pg, hasher.NewHasher()),
+	metrics := usecase.NewMetrics(reg)
+	WebhookUseCase := usecase.NewWebhookUseCase(
+		repo.NewWebhookRepository(pg),
+		webhook.NewClient(webhook.Timeout(cfg.Webhook.Timeout)),
+		cfg.Webhook.MaxAttempts,
+		cfg.Webhook.Backoff,
+	)
+	var eventPublisher usecase.EventPublisher
+	switch cfg.Outbox.Publisher {
+	case "webhook":
+		eventPublisher = WebhookUseCase
+	case "log":
+		eventPublisher = publisher.NewLogPublisher(l)
+	case "memory":
+		eventPublisher = publisher.NewMemoryPublisher()
+	default:
+		l.Fatal(fmt.Errorf("app - Run - unknown outbox publisher %q", cfg.Outbox.Publisher))
+	}
+	OutboxUseCase := usecase.NewOutboxUseCase(
+		repo.NewOutboxRepository(pg),
+		publisher.NewMetricsPublisher(eventPublisher, metrics),
+		l,
+		cfg.Outbox.BatchSize,
+		cfg.Outbox.Backoff,
+	)
+	UserUseCase := usecase.NewMeteredUserUseCase(
+		usecase.NewTracedUserUseCase(
+			usecase.NewUserUseCase(
+				repo.NewUserRepository(pg, hasher.NewHasher(hasher.TracerProvider(tr.Provider), hasher.Registerer(reg))),
+			),
+			tr.Provider,
+		),
+		metrics,
+	)
+	AssetUseCase := usecase.NewTracedAssetUseCase(
+		usecase.NewAssetUseCase(
+			repo.NewAssetRepository(pg),
+		),
+		tr.Provider,
+	)
+	AuctionUseCase := usecase.NewAuctionUseCase(
+		repo.NewAuctionRepository(pg),
+	)
+	OfferUseCase := usecase.NewOfferUseCase(
+		repo.NewOfferRepository(pg),
+		cfg.Offer.TTL,
+	)
+	CartUseCase := usecase.NewCartUseCase(
+		repo.NewCartRepository(pg),
+	)
+	PromoUseCase := usecase.NewPromoUseCase(
+		repo.NewPromoRepository(pg),
+	)
+	WishlistUseCase := usecase.NewWishlistUseCase(
+		repo.NewWishlistRepository(pg),
+	)
+	NotificationUseCase := usecase.NewNotificationUseCase(
+		repo.NewNotificationRepository(pg),
+	)
+	ReviewUseCase := usecase.NewReviewUseCase(
+		repo.NewReviewRepository(pg),
+	)
+	StatsUseCase := usecase.NewStatsUseCase(
+		repo.NewStatsRepository(pg),
 	)
-	AssetUseCase := usecase.NewAssetUseCase(
-		repo.NewAssetRepository(pg),
+	MarketFeedUseCase := usecase.NewMarketFeedUseCase(
+		repo.NewMarketFeedRepository(pg),
+		cfg.Stream.Buffer,
 	)
+	var rateLimitRepo usecase.RateLimitRepository
+	switch cfg.RateLimit.Store {
+	case "memory":
+		rateLimitRepo = repo.NewRateLimitMemoryRepository()
+	case "postgres":
+		rateLimitRepo = repo.NewRateLimitRepository(pg)
+	default:
+		l.Fatal(fmt.Errorf("app - Run - unknown rate limit store %q", cfg.RateLimit.Store))
+	}
+	RateLimitUseCase := usecase.NewRateLimitUseCase(rateLimitRepo)
+
+	// Background workers
+	workersCtx, stopWorkers := context.WithCancel(context.Background())
+	workers := &sync.WaitGroup{}
+	runPeriodically(workersCtx, workers, cfg.Auction.SettleInterval, func(ctx context.Context) {
+		settled, err := AuctionUseCase.SettleExpired(ctx)
+		if err != nil {
+			l.Error(fmt.Errorf("app - Run - AuctionUseCase.SettleExpired: %w", err))
+		}
+		if settled > 0 {
+			l.Info("app - Run - auctions settled: %d", settled)
+		}
+	})
+	runPeriodically(workersCtx, workers, cfg.Offer.ExpireInterval, func(ctx context.Context) {
+		expired, err := OfferUseCase.ExpireOffers(ctx)
+		if err != nil {
+			l.Error(fmt.Errorf("app - Run - OfferUseCase.ExpireOffers: %w", err))
+		}
+		if expired > 0 {
+			l.Info("app - Run - offers expired: %d", expired)
+		}
+	})
+	runPeriodically(workersCtx, workers, cfg.Access.SweepInterval, func(ctx context.Context) {
+		removed, err := AssetUseCase.SweepExpiredAccess(ctx)
+		if err != nil {
+			l.Error(fmt.Errorf("app - Run - AssetUseCase.SweepExpiredAccess: %w", err))
+		}
+		if removed > 0 {
+			l.Info("app - Run - access rows expired: %d", removed)
+		}
+	})
+	// The listener returns when its connection fails, it is started again after the delay.
+	runPeriodically(workersCtx, workers, cfg.Stream.ReconnectDelay, func(ctx context.Context) {
+		err := MarketFeedUseCase.Run(ctx)
+		if err != nil && ctx.Err() == nil {
+			l.Error(fmt.Errorf("app - Run - MarketFeedUseCase.Run: %w", err))
+		}
+	})
+	runPeriodically(workersCtx, workers, cfg.Outbox.RelayInterval, func(ctx context.Context) {
+		published, err := OutboxUseCase.Relay(ctx)
+		if err != nil {
+			l.Error(fmt.Errorf("app - Run - OutboxUseCase.Relay: %w", err))
+		}
+		if published > 0 {
+			l.Debug("app - Run - outbox events published: %d", published)
+		}
+	})
+	runPeriodically(workersCtx, workers, cfg.RateLimit.SweepInterval, func(ctx context.Context) {
+		removed, err := RateLimitUseCase.SweepFull(ctx)
+		if err != nil {
+			l.Error(fmt.Errorf("app - Run - RateLimitUseCase.SweepFull: %w", err))
+		}
+		if removed > 0 {
+			l.Debug("app - Run - rate limit buckets removed: %d", removed)
+		}
+	})
+	runPeriodically(workersCtx, workers, cfg.Webhook.DeliverInterval, func(ctx context.Context) {
+		sent, err := WebhookUseCase.DeliverDue(ctx)
+		if err != nil {
+			l.Error(fmt.Errorf("app - Run - WebhookUseCase.DeliverDue: %w", err))
+		}
+		if sent > 0 {
+			l.Info("app - Run - webhook deliveries attempted: %d", sent)
+		}
+	})
+
 	// HTTP Server
-	handler := chi.NewRouter()
-	v1.NewRouter(handler, l, UserUseCase, AssetUseCase, jtg, cfg.HTTP.Swagger)
-	httpServer := httpserver.New(handler, httpserver.Port(cfg.HTTP.Port))
+	readiness := probe.New(probe.Check("postgres", pg.Pool.Ping))
+	handler, err := newHTTPHandler(cfg, l, reg, tr, jtg, httpUseCases{
+		user:         UserUseCase,
+		asset:        AssetUseCase,
+		auction:      AuctionUseCase,
+		offer:        OfferUseCase,
+		cart:         CartUseCase,
+		promo:        PromoUseCase,
+		wishlist:     WishlistUseCase,
+		notification: NotificationUseCase,
+		review:       ReviewUseCase,
+		stats:        StatsUseCase,
+		webhook:      WebhookUseCase,
+		marketFeed:   MarketFeedUseCase,
+		rateLimit:    RateLimitUseCase,
+	}, readiness)
+	if err != nil {
+		l.Fatal(fmt.Errorf("app - Run - newHTTPHandler: %w", err))
+	}
+	httpOptions, err := httpServerOptions(cfg.HTTP)
+	if err != nil {
+		l.Fatal(fmt.Errorf("app - Run - httpServerOptions: %w", err))
+	}
+	httpServer := httpserver.New(handler, httpOptions...)
+
+	// gRPC Server
+	grpcOptions := []grpcserver.Option{
+		grpcserver.Port(cfg.GRPC.Port),
+		grpcserver.ServerOptions(grpccontroller.ServerOptions(jtg)...),
+	}
+	// With TLS enabled no listener accepts plaintext, gRPC is served with the same certificate and client verification.
+	tlsConfig, err := httpserver.TLSConfig(httpOptions...)
+	if err != nil {
+		l.Fatal(fmt.Errorf("app - Run - httpserver.TLSConfig: %w", err))
+	}
+	if tlsConfig != nil {
+		grpcOptions = append(grpcOptions, grpcserver.ServerOptions(grpc.Creds(credentials.NewTLS(tlsConfig))))
+	}
+	grpcServer := grpcserver.New(grpcOptions...)
+	grpccontroller.NewRouter(grpcServer.App, l, UserUseCase, AssetUseCase, jtg)
+	grpcServer.Start()
 
 	// Waiting signal
 	interrupt := make(chan os.Signal, 1)
@@ -57,8 +256,17 @@ func Run(cfg *config.Config) {
 	select {
 	case s := <-interrupt:
 		l.Info("app - Run - signal: " + s.String())
+		// Readiness fails first, so the load balancer stops routing requests here before the server stops accepting them.
+		// A second signal skips the wait.
+		readiness.Drain()
+		select {
+		case <-time.After(cfg.HTTP.DrainDelay):
+		case <-interrupt:
+		}
 	case err = <-httpServer.Notify():
 		l.Error(fmt.Errorf("app - Run - httpServer.Notify: %w", err))
+	case err = <-grpcServer.Notify():
+		l.Error(fmt.Errorf("app - Run - grpcServer.Notify: %w", err))
 	}
 
 	// Shutdown
@@ -66,4 +274,14 @@ func Run(cfg *config.Config) {
 	if err != nil {
 		l.Error(fmt.Errorf("app - Run - httpServer.Shutdown: %w", err))
 	}
+	err = grpcServer.Shutdown()
+	if err != nil {
+		l.Error(fmt.Errorf("app - Run - grpcServer.Shutdown: %w", err))
+	}
+	stopWorkers()
+	workers.Wait()
+	err = tr.Shutdown()
+	if err != nil {
+		l.Error(fmt.Errorf("app - Run - tr.Shutdown: %w", err))
+	}
 }
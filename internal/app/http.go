@@ -0,0 +1,107 @@
+package app
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/Klef99/bhs-task/config"
+	"github.com/Klef99/bhs-task/internal/controller/graphql"
+	"github.com/Klef99/bhs-task/internal/controller/http/middleware"
+	"github.com/Klef99/bhs-task/internal/controller/http/probe"
+	v1 "github.com/Klef99/bhs-task/internal/controller/http/v1"
+	"github.com/Klef99/bhs-task/internal/usecase"
+	"github.com/Klef99/bhs-task/pkg/httpserver"
+	"github.com/Klef99/bhs-task/pkg/jwtgenerator"
+	"github.com/Klef99/bhs-task/pkg/logger"
+	"github.com/Klef99/bhs-task/pkg/tracing"
+	"github.com/go-chi/chi/v5"
+	chimiddleware "github.com/go-chi/chi/v5/middleware"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// httpUseCases - use cases served over HTTP.
+type httpUseCases struct {
+	user         usecase.User
+	asset        usecase.Asset
+	auction      usecase.Auction
+	offer        usecase.Offer
+	cart         usecase.Cart
+	promo        usecase.Promo
+	wishlist     usecase.Wishlist
+	notification usecase.Notification
+	review       usecase.Review
+	stats        usecase.Stats
+	webhook      usecase.Webhook
+	marketFeed   usecase.MarketFeed
+	rateLimit    usecase.RateLimit
+}
+
+// newHTTPHandler - chi panics if a middleware is added after a route, so all of them are added first
+// and the routes mounted after.
+func newHTTPHandler(cfg *config.Config, l logger.Interface, reg *prometheus.Registry, tr *tracing.Tracing, jtg jwtgenerator.Interface, uc httpUseCases, readiness *probe.Probe) (http.Handler, error) {
+	trustedProxies, err := middleware.ParseTrustedProxies(cfg.RateLimit.TrustedProxies)
+	if err != nil {
+		return nil, fmt.Errorf("middleware.ParseTrustedProxies: %w", err)
+	}
+	handler := chi.NewRouter()
+	handler.Use(middleware.Tracing(tr.Provider, tr.Propagator))
+	handler.Use(middleware.RequestID)
+	handler.Use(middleware.ClientIP(trustedProxies))
+	handler.Use(middleware.AccessLog(l, jtg.GetJWTAuth()))
+	handler.Use(middleware.Metrics(reg))
+	handler.Use(chimiddleware.Recoverer)
+	// Connections without a client certificate are accepted, the routes for other services check there was one.
+	if cfg.HTTP.TLS.Enabled && cfg.HTTP.TLS.ClientAuth == "verify_if_given" {
+		handler.Use(middleware.RequireClientCert(cfg.HTTP.TLS.ClientCertPaths))
+	}
+	if cfg.RateLimit.Enabled {
+		rateLimit, err := middleware.RateLimit(uc.rateLimit, l, jtg.GetJWTAuth(), rateLimitRules(cfg.RateLimit))
+		if err != nil {
+			return nil, fmt.Errorf("middleware.RateLimit: %w", err)
+		}
+		handler.Use(rateLimit)
+	}
+
+	v1.NewRouter(handler, l, uc.user, uc.asset, uc.auction, uc.offer, uc.cart, uc.promo, uc.wishlist, uc.notification, uc.review, uc.stats, uc.webhook, uc.marketFeed, jtg, cfg.HTTP.Swagger)
+	err = graphql.NewRouter(handler, l, uc.user, uc.asset, jtg,
+		graphql.MaxDepth(cfg.GraphQL.MaxDepth),
+		graphql.MaxComplexity(cfg.GraphQL.MaxComplexity),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("graphql.NewRouter: %w", err)
+	}
+	handler.Handle("/metrics", promhttp.HandlerFor(reg, promhttp.HandlerOpts{Registry: reg}))
+	probe.NewRouter(handler, l, readiness)
+	return handler, nil
+}
+
+// httpServerOptions - with TLS enabled the server only accepts TLS connections.
+func httpServerOptions(cfg config.HTTP) ([]httpserver.Option, error) {
+	opts := []httpserver.Option{
+		httpserver.Port(cfg.Port),
+		httpserver.ShutdownTimeout(cfg.ShutdownTimeout),
+	}
+	if !cfg.TLS.Enabled {
+		return opts, nil
+	}
+
+	minVersion, err := httpserver.ParseTLSVersion(cfg.TLS.MinVersion)
+	if err != nil {
+		return nil, fmt.Errorf("httpserver.ParseTLSVersion: %w", err)
+	}
+	suites, err := httpserver.ParseCipherSuites(cfg.TLS.CipherSuites)
+	if err != nil {
+		return nil, fmt.Errorf("httpserver.ParseCipherSuites: %w", err)
+	}
+	opts = append(opts,
+		httpserver.TLS(cfg.TLS.CertFile, cfg.TLS.KeyFile),
+		httpserver.MinTLSVersion(minVersion),
+		httpserver.CipherSuites(suites),
+	)
+	clientAuth, err := httpserver.ParseClientAuth(cfg.TLS.ClientAuth)
+	if err != nil {
+		return nil, fmt.Errorf("httpserver.ParseClientAuth: %w", err)
+	}
+	return append(opts, httpserver.ClientCA(cfg.TLS.ClientCAFile, clientAuth)), nil
+}
@@ -0,0 +1,80 @@
+package app
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Klef99/bhs-task/config"
+	"github.com/Klef99/bhs-task/internal/controller/http/middleware"
+	"github.com/Klef99/bhs-task/internal/controller/http/probe"
+	"github.com/Klef99/bhs-task/internal/usecase"
+	"github.com/Klef99/bhs-task/internal/usecase/repo"
+	"github.com/Klef99/bhs-task/pkg/jwtgenerator"
+	"github.com/Klef99/bhs-task/pkg/logger"
+	"github.com/Klef99/bhs-task/pkg/tracing"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+)
+
+type httpHandlerTest struct {
+	name   string
+	method string
+	path   string
+	body   string
+	code   int
+	header string // set in the response
+}
+
+func TestHTTPHandler(t *testing.T) {
+	t.Parallel()
+
+	cfg := &config.Config{
+		GraphQL: config.GraphQL{MaxDepth: 10, MaxComplexity: 1000},
+		RateLimit: config.RateLimit{
+			Enabled: true,
+			Policies: []config.RateLimitPolicy{
+				{Name: "auth", Paths: []string{"/v1/register"}, Key: "ip", Limit: 1, Period: time.Minute, Burst: 1},
+			},
+		},
+	}
+	tr, err := tracing.New()
+	require.NoError(t, err)
+	jtg, err := jwtgenerator.New("secret")
+	require.NoError(t, err)
+	readiness := probe.New(probe.Check("postgres", func(context.Context) error { return nil }))
+
+	// Only the rate limiter is needed by the requests below, a route reaching a missing use case panics.
+	handler, err := newHTTPHandler(cfg, logger.New("error"), prometheus.NewRegistry(), tr, jtg, httpUseCases{
+		rateLimit: usecase.NewRateLimitUseCase(repo.NewRateLimitMemoryRepository()),
+	}, readiness)
+	require.NoError(t, err)
+
+	// The requests share the handler and run in order, the rate limit carries over between them.
+	tests := []httpHandlerTest{
+		{name: "liveness", method: http.MethodGet, path: "/livez", code: http.StatusOK, header: middleware.RequestIDHeader},
+		{name: "liveness alias", method: http.MethodGet, path: "/healthz", code: http.StatusOK},
+		{name: "readiness", method: http.MethodGet, path: "/readyz", code: http.StatusOK},
+		{name: "metrics", method: http.MethodGet, path: "/metrics", code: http.StatusOK},
+		{name: "panic is recovered", method: http.MethodPost, path: "/v1/register", body: `{"username":"u","password":"p"}`, code: http.StatusInternalServerError, header: "RateLimit-Remaining"},
+		{name: "rate limited", method: http.MethodPost, path: "/v1/register", body: `{"username":"u","password":"p"}`, code: http.StatusTooManyRequests, header: "Retry-After"},
+		{name: "graphql needs a token", method: http.MethodPost, path: "/graphql", body: `{"query":"{ me { id } }"}`, code: http.StatusUnauthorized},
+		{name: "unknown route", method: http.MethodGet, path: "/unknown", code: http.StatusNotFound},
+	}
+	for _, tc := range tests {
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, httptest.NewRequest(tc.method, tc.path, strings.NewReader(tc.body)))
+		require.Equal(t, tc.code, rr.Code, tc.name)
+		if tc.header != "" {
+			require.NotEmpty(t, rr.Header().Get(tc.header), tc.name)
+		}
+	}
+
+	readiness.Drain()
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	require.Equal(t, http.StatusServiceUnavailable, rr.Code)
+}
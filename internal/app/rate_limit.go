@@ -0,0 +1,25 @@
+package app
+
+import (
+	"github.com/Klef99/bhs-task/config"
+	"github.com/Klef99/bhs-task/internal/controller/http/middleware"
+	"github.com/Klef99/bhs-task/internal/entity"
+)
+
+// rateLimitRules - converts the configured limits per period to token bucket rules.
+func rateLimitRules(cfg config.RateLimit) []middleware.RateLimitRule {
+	rules := make([]middleware.RateLimitRule, 0, len(cfg.Policies))
+	for _, p := range cfg.Policies {
+		var rate float64
+		if p.Period > 0 {
+			rate = float64(p.Limit) / p.Period.Seconds()
+		}
+		rules = append(rules, middleware.RateLimitRule{
+			Policy:  entity.RateLimitPolicy{Name: p.Name, Rate: rate, Burst: p.Burst},
+			Paths:   p.Paths,
+			Methods: p.Methods,
+			By:      p.Key,
+		})
+	}
+	return rules
+}
@@ -0,0 +1,26 @@
+package app
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// runPeriodically - starts job in the background every interval until ctx is canceled.
+// wg is done once the last run has finished.
+func runPeriodically(ctx context.Context, wg *sync.WaitGroup, interval time.Duration, job func(context.Context)) {
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				job(ctx)
+			}
+		}
+	}()
+}
@@ -0,0 +1,27 @@
+// Package claims reads the user out of a verified JWT, shared by the HTTP, GraphQL and gRPC transports.
+package claims
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Klef99/bhs-task/internal/entity"
+	"github.com/go-chi/jwtauth/v5"
+)
+
+// User - returns the user the JWT verified into ctx was issued to.
+func User(ctx context.Context) (entity.User, error) {
+	_, claims, err := jwtauth.FromContext(ctx)
+	if err != nil {
+		return entity.User{}, fmt.Errorf("jwtauth.FromContext: %w", err)
+	}
+	id, ok := claims["id"].(float64)
+	if !ok {
+		return entity.User{}, fmt.Errorf("unexpected type of claim id: %T", claims["id"])
+	}
+	name, ok := claims["name"].(string)
+	if !ok {
+		return entity.User{}, fmt.Errorf("unexpected type of claim name: %T", claims["name"])
+	}
+	return entity.User{Username: name, Id: int64(id)}, nil
+}
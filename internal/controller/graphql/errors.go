@@ -0,0 +1,55 @@
+package graphql
+
+import (
+	"errors"
+
+	"github.com/Klef99/bhs-task/internal/entity"
+	"github.com/Klef99/bhs-task/pkg/logger"
+)
+
+// resolverError - returned to the client with the code in the extensions.
+type resolverError struct {
+	message string
+	code    string
+}
+
+func (e resolverError) Error() string {
+	return e.message
+}
+
+// Extensions -.
+func (e resolverError) Extensions() map[string]interface{} {
+	return map[string]interface{}{"code": e.code}
+}
+
+func badInput(message string) error {
+	return resolverError{message: message, code: "BAD_USER_INPUT"}
+}
+
+// _errorCodes - codes of the domain errors, the message of the error is returned to the client.
+var _errorCodes = []struct {
+	err  error
+	code string
+}{
+	{entity.ErrAssetNotFound, "NOT_FOUND"},
+	{entity.ErrPromoCodeNotFound, "NOT_FOUND"},
+	{entity.ErrAssetAlreadyPurchased, "CONFLICT"},
+	{entity.ErrAssetNotAvailable, "CONFLICT"},
+	{entity.ErrOwnAsset, "CONFLICT"},
+	{entity.ErrAssetSoldOut, "CONFLICT"},
+	{entity.ErrInsufficientFunds, "CONFLICT"},
+	{entity.ErrPromoCodeExpired, "CONFLICT"},
+	{entity.ErrPromoCodeNotApplicable, "CONFLICT"},
+}
+
+// resolveError - maps an error of a use case. Errors that are not domain ones are logged and hidden
+// behind msg, the wrapping of a use case error isn't meant for clients.
+func resolveError(l logger.Interface, err error, where string, msg string) error {
+	for _, e := range _errorCodes {
+		if errors.Is(err, e.err) {
+			return resolverError{message: e.err.Error(), code: e.code}
+		}
+	}
+	l.Error(err, where)
+	return resolverError{message: msg, code: "INTERNAL_SERVER_ERROR"}
+}
@@ -0,0 +1,54 @@
+package graphql
+
+import (
+	"strings"
+
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+// _listComplexity - lists aren't paginated, the selection of a list field is counted this many times.
+const _listComplexity = 10
+
+// checkLimits - rejects operations nested deeper than maxDepth or costing more than maxComplexity.
+// A field costs 1 plus the cost of its selection. Introspection fields are not counted, their size
+// is bounded by the schema. The document must be validated, fragment cycles are not detected here.
+func checkLimits(doc *ast.QueryDocument, maxDepth, maxComplexity int) gqlerror.List {
+	var errs gqlerror.List
+	for _, op := range doc.Operations {
+		depth, complexity := measure(op.SelectionSet)
+		if maxDepth > 0 && depth > maxDepth {
+			errs = append(errs, gqlerror.ErrorPosf(op.Position, "operation has depth %d that exceeds max depth %d", depth, maxDepth))
+		}
+		if maxComplexity > 0 && complexity > maxComplexity {
+			errs = append(errs, gqlerror.ErrorPosf(op.Position, "operation has complexity %d that exceeds max complexity %d", complexity, maxComplexity))
+		}
+	}
+	return errs
+}
+
+func measure(set ast.SelectionSet) (depth, complexity int) {
+	for _, sel := range set {
+		var d, c int
+		switch sel := sel.(type) {
+		case *ast.Field:
+			if strings.HasPrefix(sel.Name, "__") {
+				continue
+			}
+			d, c = measure(sel.SelectionSet)
+			if sel.Definition != nil && sel.Definition.Type.Elem != nil {
+				c *= _listComplexity
+			}
+			d, c = d+1, c+1
+		case *ast.InlineFragment:
+			d, c = measure(sel.SelectionSet)
+		case *ast.FragmentSpread:
+			if sel.Definition != nil {
+				d, c = measure(sel.Definition.SelectionSet)
+			}
+		}
+		depth = max(depth, d)
+		complexity += c
+	}
+	return depth, complexity
+}
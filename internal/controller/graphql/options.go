@@ -0,0 +1,18 @@
+package graphql
+
+// Option -.
+type Option func(*handler)
+
+// MaxDepth - 0 turns the limit off.
+func MaxDepth(depth int) Option {
+	return func(h *handler) {
+		h.maxDepth = depth
+	}
+}
+
+// MaxComplexity - 0 turns the limit off.
+func MaxComplexity(complexity int) Option {
+	return func(h *handler) {
+		h.maxComplexity = complexity
+	}
+}
@@ -0,0 +1,203 @@
+package graphql
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"github.com/Klef99/bhs-task/internal/entity"
+	"github.com/Klef99/bhs-task/internal/usecase"
+	"github.com/Klef99/bhs-task/pkg/logger"
+	graphql "github.com/graph-gophers/graphql-go"
+)
+
+// resolver - root of the schema, queries and mutations delegate to the use cases.
+type resolver struct {
+	u usecase.User
+	a usecase.Asset
+	l logger.Interface
+}
+
+func parseID(id graphql.ID) (int64, error) {
+	n, err := strconv.ParseInt(string(id), 10, 64)
+	if err != nil || n <= 0 {
+		return 0, badInput("invalid id")
+	}
+	return n, nil
+}
+
+func formatID(id int64) graphql.ID {
+	return graphql.ID(strconv.FormatInt(id, 10))
+}
+
+func (r *resolver) Me(ctx context.Context) *meResolver {
+	return &meResolver{r: r, user: scopeFrom(ctx).user}
+}
+
+func (r *resolver) Asset(ctx context.Context, args struct{ ID graphql.ID }) (*assetResolver, error) {
+	id, err := parseID(args.ID)
+	if err != nil {
+		return nil, err
+	}
+	ast, err := r.a.GetAssetById(ctx, scopeFrom(ctx).user, id)
+	if errors.Is(err, entity.ErrAssetNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, resolveError(r.l, err, "graphql - Asset - r.a.GetAssetById", "error getting asset")
+	}
+	return &assetResolver{r: r, asset: ast}, nil
+}
+
+func (r *resolver) Market(ctx context.Context) ([]*assetResolver, error) {
+	assets, err := r.a.GetAssetsToBuying(ctx, scopeFrom(ctx).user)
+	if err != nil {
+		return nil, resolveError(r.l, err, "graphql - Market - r.a.GetAssetsToBuying", "error getting assets")
+	}
+	return r.assets(assets), nil
+}
+
+func (r *resolver) Deposit(ctx context.Context, args struct{ Amount float64 }) (float64, error) {
+	if args.Amount <= 0 {
+		return 0, badInput("amount should be positive")
+	}
+	balance, err := r.u.MakeDeposit(ctx, scopeFrom(ctx).user, args.Amount)
+	if err != nil {
+		return 0, resolveError(r.l, err, "graphql - Deposit - r.u.MakeDeposit", "error depositing money")
+	}
+	return balance, nil
+}
+
+type createAssetInput struct {
+	Name        string
+	Description string
+	Price       float64
+	Status      *string
+	SaleMode    *string
+	Stock       *int32
+	AccessDays  *int32
+}
+
+func (r *resolver) CreateAsset(ctx context.Context, args struct{ Input createAssetInput }) (bool, error) {
+	in := args.Input
+	ast := entity.Asset{
+		Name:        in.Name,
+		Description: in.Description,
+		Price:       float32(in.Price),
+		Owner_id:    scopeFrom(ctx).user.Id,
+		Stock:       int64Ptr(in.Stock),
+		AccessDays:  int64Ptr(in.AccessDays),
+	}
+	if in.Status != nil {
+		ast.Status = entity.AssetStatus(*in.Status)
+	}
+	if in.SaleMode != nil {
+		ast.SaleMode = entity.SaleMode(*in.SaleMode)
+	}
+	ok, err := r.a.CreateAsset(ctx, ast)
+	if err != nil {
+		return false, resolveError(r.l, err, "graphql - CreateAsset - r.a.CreateAsset", "error creating asset")
+	}
+	return ok, nil
+}
+
+func (r *resolver) BuyAsset(ctx context.Context, args struct {
+	ID        graphql.ID
+	PromoCode *string
+}) (bool, error) {
+	id, err := parseID(args.ID)
+	if err != nil {
+		return false, err
+	}
+	promoCode := ""
+	if args.PromoCode != nil {
+		promoCode = *args.PromoCode
+	}
+	ok, err := r.a.BuyAsset(ctx, scopeFrom(ctx).user, id, promoCode)
+	if err != nil {
+		return false, resolveError(r.l, err, "graphql - BuyAsset - r.a.BuyAsset", "error buying asset")
+	}
+	return ok, nil
+}
+
+func (r *resolver) assets(assets []entity.Asset) []*assetResolver {
+	res := make([]*assetResolver, 0, len(assets))
+	for _, a := range assets {
+		res = append(res, &assetResolver{r: r, asset: a})
+	}
+	return res
+}
+
+func (r *resolver) purchases(purchases []entity.Purchase) []*purchaseResolver {
+	res := make([]*purchaseResolver, 0, len(purchases))
+	for _, p := range purchases {
+		res = append(res, &purchaseResolver{r: r, purchase: p})
+	}
+	return res
+}
+
+// loadUser - the user is loaded together with the others requested by sibling fields.
+func (r *resolver) loadUser(ctx context.Context, id int64) (*userResolver, error) {
+	user, err := scopeFrom(ctx).users.Load(ctx, id)()
+	if err != nil {
+		return nil, resolveError(r.l, err, "graphql - loadUser", "error getting user")
+	}
+	return &userResolver{user: user}, nil
+}
+
+type meResolver struct {
+	r    *resolver
+	user entity.User
+}
+
+func (m *meResolver) ID() graphql.ID {
+	return formatID(m.user.Id)
+}
+
+func (m *meResolver) Username() string {
+	return m.user.Username
+}
+
+func (m *meResolver) Balance(ctx context.Context) (float64, error) {
+	balance, err := m.r.u.CheckDeposit(ctx, m.user)
+	if err != nil {
+		return 0, resolveError(m.r.l, err, "graphql - Me.Balance - u.CheckDeposit", "error getting balance")
+	}
+	return balance, nil
+}
+
+func (m *meResolver) Assets(ctx context.Context) ([]*assetResolver, error) {
+	assets, err := m.r.a.UserAssetsList(ctx, m.user)
+	if err != nil {
+		return nil, resolveError(m.r.l, err, "graphql - Me.Assets - a.UserAssetsList", "error getting assets")
+	}
+	return m.r.assets(assets), nil
+}
+
+func (m *meResolver) PurchasedAssets(ctx context.Context) ([]*assetResolver, error) {
+	assets, err := m.r.a.GetPurchasedAssets(ctx, m.user)
+	if err != nil {
+		return nil, resolveError(m.r.l, err, "graphql - Me.PurchasedAssets - a.GetPurchasedAssets", "error getting assets")
+	}
+	return m.r.assets(assets), nil
+}
+
+func (m *meResolver) Purchases(ctx context.Context) ([]*purchaseResolver, error) {
+	purchases, err := m.r.a.GetPurchaseHistory(ctx, m.user)
+	if err != nil {
+		return nil, resolveError(m.r.l, err, "graphql - Me.Purchases - a.GetPurchaseHistory", "error getting purchases")
+	}
+	return m.r.purchases(purchases), nil
+}
+
+type userResolver struct {
+	user entity.User
+}
+
+func (u *userResolver) ID() graphql.ID {
+	return formatID(u.user.Id)
+}
+
+func (u *userResolver) Username() string {
+	return u.user.Username
+}
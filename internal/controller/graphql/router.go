@@ -0,0 +1,109 @@
+// Package graphql implements the /graphql endpoint on top of the user and asset use cases.
+package graphql
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/Klef99/bhs-task/internal/controller/claims"
+	"github.com/Klef99/bhs-task/internal/usecase"
+	"github.com/Klef99/bhs-task/pkg/jwtgenerator"
+	"github.com/Klef99/bhs-task/pkg/logger"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/jwtauth/v5"
+	graphql "github.com/graph-gophers/graphql-go"
+	"github.com/vektah/gqlparser/v2"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+const (
+	_defaultMaxDepth      = 10
+	_defaultMaxComplexity = 1000
+	_maxBodySize          = 1 << 20
+	// _maxParallelism - fields resolved at once, items waiting for a slot miss the batch of a loader.
+	_maxParallelism = 50
+)
+
+//go:embed schema.graphql
+var _schema string
+
+type handler struct {
+	u             usecase.User
+	a             usecase.Asset
+	l             logger.Interface
+	schema        *graphql.Schema
+	validation    *ast.Schema // the same schema, for measuring queries before they run
+	maxDepth      int
+	maxComplexity int
+}
+
+// NewRouter - mounts /graphql, every operation needs a token.
+func NewRouter(router chi.Router, l logger.Interface, u usecase.User, a usecase.Asset, jtg jwtgenerator.Interface, opts ...Option) error {
+	h := &handler{u: u, a: a, l: l, maxDepth: _defaultMaxDepth, maxComplexity: _defaultMaxComplexity}
+	for _, opt := range opts {
+		opt(h)
+	}
+	var err error
+	h.schema, err = graphql.ParseSchema(_schema, &resolver{u: u, a: a, l: l}, graphql.MaxParallelism(_maxParallelism))
+	if err != nil {
+		return fmt.Errorf("graphql - NewRouter - graphql.ParseSchema: %w", err)
+	}
+	h.validation, err = gqlparser.LoadSchema(&ast.Source{Name: "schema.graphql", Input: _schema})
+	if err != nil {
+		return fmt.Errorf("graphql - NewRouter - gqlparser.LoadSchema: %w", err)
+	}
+
+	tokenAuth := jtg.GetJWTAuth()
+	r := chi.NewRouter()
+	r.Use(jwtauth.Verifier(tokenAuth))
+	r.Use(jwtauth.Authenticator(tokenAuth))
+	r.Post("/", h.ServeHTTP)
+	router.Mount("/graphql", r)
+	return nil
+}
+
+type request struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+type errorsResponse struct {
+	Errors gqlerror.List `json:"errors"`
+}
+
+func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	req := request{}
+	err := json.NewDecoder(http.MaxBytesReader(w, r.Body, _maxBodySize)).Decode(&req)
+	if err != nil {
+		h.l.WithContext(r.Context()).Error(err, "graphql - ServeHTTP - decoder.Decode")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(errorsResponse{gqlerror.List{gqlerror.Errorf("error decoding request body")}})
+		return
+	}
+	doc, errs := gqlparser.LoadQuery(h.validation, req.Query)
+	if len(errs) == 0 {
+		errs = checkLimits(doc, h.maxDepth, h.maxComplexity)
+	}
+	if len(errs) != 0 {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(errorsResponse{errs})
+		return
+	}
+	usr, err := claims.User(r.Context())
+	if err != nil {
+		h.l.WithContext(r.Context()).Error(err, "graphql - ServeHTTP - claims.User")
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(errorsResponse{gqlerror.List{gqlerror.Errorf("error getting token claims")}})
+		return
+	}
+
+	ctx := withScope(r.Context(), newScope(h.u, h.a, usr))
+	res := h.schema.Exec(ctx, req.Query, req.OperationName, req.Variables)
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(res)
+}
@@ -0,0 +1,73 @@
+package graphql
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Klef99/bhs-task/internal/entity"
+	"github.com/Klef99/bhs-task/internal/usecase"
+	"github.com/graph-gophers/dataloader/v7"
+)
+
+// _loaderWait - how long a loader collects keys before querying them at once. Fields of list items are
+// resolved concurrently, so a list of assets loads its owners with one query instead of one per asset.
+const _loaderWait = 2 * time.Millisecond
+
+var errUserNotFound = errors.New("user not found")
+
+type scopeKey struct{}
+
+// scope - state of a single request: the user the token was issued to and the batch loaders,
+// their cache lives as long as the request.
+type scope struct {
+	user   entity.User
+	users  *dataloader.Loader[int64, entity.User]
+	assets *dataloader.Loader[int64, entity.Asset]
+}
+
+func newScope(u usecase.User, a usecase.Asset, user entity.User) *scope {
+	return &scope{
+		user: user,
+		users: dataloader.NewBatchedLoader(func(ctx context.Context, ids []int64) []*dataloader.Result[entity.User] {
+			users, err := u.GetUsersByIds(ctx, ids)
+			return results(ids, users, err, func(u entity.User) int64 { return u.Id }, errUserNotFound)
+		}, dataloader.WithWait[int64, entity.User](_loaderWait)),
+		assets: dataloader.NewBatchedLoader(func(ctx context.Context, ids []int64) []*dataloader.Result[entity.Asset] {
+			assets, err := a.GetAssetsByIds(ctx, user, ids)
+			return results(ids, assets, err, func(a entity.Asset) int64 { return a.Id }, entity.ErrAssetNotFound)
+		}, dataloader.WithWait[int64, entity.Asset](_loaderWait)),
+	}
+}
+
+// results - orders values by ids as the loader expects, ids without a value get notFound.
+func results[V any](ids []int64, values []V, err error, id func(V) int64, notFound error) []*dataloader.Result[V] {
+	res := make([]*dataloader.Result[V], len(ids))
+	if err != nil {
+		for i := range res {
+			res[i] = &dataloader.Result[V]{Error: err}
+		}
+		return res
+	}
+	byId := make(map[int64]V, len(values))
+	for _, v := range values {
+		byId[id(v)] = v
+	}
+	for i, key := range ids {
+		v, ok := byId[key]
+		if !ok {
+			res[i] = &dataloader.Result[V]{Error: notFound}
+			continue
+		}
+		res[i] = &dataloader.Result[V]{Data: v}
+	}
+	return res
+}
+
+func withScope(ctx context.Context, s *scope) context.Context {
+	return context.WithValue(ctx, scopeKey{}, s)
+}
+
+func scopeFrom(ctx context.Context) *scope {
+	return ctx.Value(scopeKey{}).(*scope)
+}
@@ -0,0 +1,166 @@
+package graphql
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Klef99/bhs-task/internal/entity"
+	graphql "github.com/graph-gophers/graphql-go"
+)
+
+func int32Ptr(v *int64) *int32 {
+	if v == nil {
+		return nil
+	}
+	n := int32(*v)
+	return &n
+}
+
+func int64Ptr(v *int32) *int64 {
+	if v == nil {
+		return nil
+	}
+	n := int64(*v)
+	return &n
+}
+
+func timePtr(t *time.Time) *graphql.Time {
+	if t == nil {
+		return nil
+	}
+	return &graphql.Time{Time: *t}
+}
+
+type assetResolver struct {
+	r     *resolver
+	asset entity.Asset
+}
+
+func (a *assetResolver) ID() graphql.ID {
+	return formatID(a.asset.Id)
+}
+
+func (a *assetResolver) Name() string {
+	return a.asset.Name
+}
+
+func (a *assetResolver) Description() string {
+	return a.asset.Description
+}
+
+func (a *assetResolver) Price() float64 {
+	return float64(a.asset.Price)
+}
+
+func (a *assetResolver) Owner(ctx context.Context) (*userResolver, error) {
+	return a.r.loadUser(ctx, a.asset.Owner_id)
+}
+
+func (a *assetResolver) Status() string {
+	return string(a.asset.Status)
+}
+
+func (a *assetResolver) SaleMode() string {
+	return string(a.asset.SaleMode)
+}
+
+func (a *assetResolver) Stock() *int32 {
+	return int32Ptr(a.asset.Stock)
+}
+
+func (a *assetResolver) Sold() int32 {
+	return int32(a.asset.Sold)
+}
+
+func (a *assetResolver) Remaining() *int32 {
+	return int32Ptr(a.asset.Remaining)
+}
+
+func (a *assetResolver) Edition() *int32 {
+	return int32Ptr(a.asset.Edition)
+}
+
+func (a *assetResolver) DeletedAt() *graphql.Time {
+	return timePtr(a.asset.DeletedAt)
+}
+
+func (a *assetResolver) AccessDays() *int32 {
+	return int32Ptr(a.asset.AccessDays)
+}
+
+func (a *assetResolver) AccessExpiresAt() *graphql.Time {
+	return timePtr(a.asset.AccessExpiresAt)
+}
+
+func (a *assetResolver) Rating() *float64 {
+	return a.asset.Rating
+}
+
+func (a *assetResolver) RatingCount() int32 {
+	return int32(a.asset.RatingCount)
+}
+
+type purchaseResolver struct {
+	r        *resolver
+	purchase entity.Purchase
+}
+
+func (p *purchaseResolver) ID() graphql.ID {
+	return formatID(p.purchase.Id)
+}
+
+// Asset - loaded together with the assets of the other purchases in the list.
+func (p *purchaseResolver) Asset(ctx context.Context) (*assetResolver, error) {
+	ast, err := scopeFrom(ctx).assets.Load(ctx, p.purchase.AssetId)()
+	if errors.Is(err, entity.ErrAssetNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, resolveError(p.r.l, err, "graphql - Purchase.Asset - assets.Load", "error getting asset")
+	}
+	return &assetResolver{r: p.r, asset: ast}, nil
+}
+
+func (p *purchaseResolver) Buyer(ctx context.Context) (*userResolver, error) {
+	return p.r.loadUser(ctx, p.purchase.BuyerId)
+}
+
+func (p *purchaseResolver) Seller(ctx context.Context) (*userResolver, error) {
+	return p.r.loadUser(ctx, p.purchase.SellerId)
+}
+
+func (p *purchaseResolver) Price() float64 {
+	return p.purchase.Price
+}
+
+func (p *purchaseResolver) SaleMode() string {
+	return string(p.purchase.SaleMode)
+}
+
+func (p *purchaseResolver) Edition() *int32 {
+	return int32Ptr(p.purchase.Edition)
+}
+
+func (p *purchaseResolver) Discount() float64 {
+	return p.purchase.Discount
+}
+
+func (p *purchaseResolver) Recipient(ctx context.Context) (*userResolver, error) {
+	if p.purchase.RecipientId == nil {
+		return nil, nil
+	}
+	return p.r.loadUser(ctx, *p.purchase.RecipientId)
+}
+
+func (p *purchaseResolver) GiftMessage() string {
+	return p.purchase.GiftMessage
+}
+
+func (p *purchaseResolver) AccessExpiresAt() *graphql.Time {
+	return timePtr(p.purchase.AccessExpiresAt)
+}
+
+func (p *purchaseResolver) PurchasedAt() graphql.Time {
+	return graphql.Time{Time: p.purchase.PurchasedAt}
+}
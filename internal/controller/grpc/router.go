@@ -0,0 +1,38 @@
+// Package grpc implements the gRPC transport, each service is in its own file of v1.
+package grpc
+
+import (
+	v1 "github.com/Klef99/bhs-task/internal/controller/grpc/v1"
+	"github.com/Klef99/bhs-task/internal/usecase"
+	"github.com/Klef99/bhs-task/pkg/jwtgenerator"
+	"github.com/Klef99/bhs-task/pkg/logger"
+	pb "github.com/Klef99/bhs-task/pkg/pb/v1"
+	pbgrpc "google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+)
+
+// ServerOptions - interceptors the server has to be created with for the routes to be authenticated.
+func ServerOptions(jwt jwtgenerator.Interface) []pbgrpc.ServerOption {
+	return []pbgrpc.ServerOption{
+		pbgrpc.ChainUnaryInterceptor(v1.UnaryAuthInterceptor(jwt)),
+		pbgrpc.ChainStreamInterceptor(v1.StreamAuthInterceptor(jwt)),
+	}
+}
+
+// NewRouter -.
+// Services:
+// bhs.v1.UserService, bhs.v1.AssetService
+// grpc.health.v1.Health, reflection.
+func NewRouter(app *pbgrpc.Server, l logger.Interface, t usecase.User, a usecase.Asset, jwt jwtgenerator.Interface) {
+	v1.NewUserRoutes(app, t, l, jwt)
+	v1.NewAssetRoutes(app, a, t, l)
+
+	healthServer := health.NewServer()
+	healthServer.SetServingStatus(pb.UserService_ServiceDesc.ServiceName, healthpb.HealthCheckResponse_SERVING)
+	healthServer.SetServingStatus(pb.AssetService_ServiceDesc.ServiceName, healthpb.HealthCheckResponse_SERVING)
+	healthpb.RegisterHealthServer(app, healthServer)
+
+	reflection.Register(app)
+}
@@ -0,0 +1,317 @@
+package v1
+
+import (
+	"context"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/Klef99/bhs-task/internal/controller/claims"
+	"github.com/Klef99/bhs-task/internal/entity"
+	"github.com/Klef99/bhs-task/internal/usecase"
+	"github.com/Klef99/bhs-task/pkg/logger"
+	pb "github.com/Klef99/bhs-task/pkg/pb/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type assetRoutes struct {
+	pb.UnimplementedAssetServiceServer
+
+	t usecase.Asset
+	u usecase.User
+	l logger.Interface
+}
+
+func NewAssetRoutes(app *grpc.Server, t usecase.Asset, u usecase.User, l logger.Interface) {
+	pb.RegisterAssetServiceServer(app, &assetRoutes{t: t, u: u, l: l})
+}
+
+func timestamp(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
+
+func assetMessage(a entity.Asset) *pb.Asset {
+	return &pb.Asset{
+		Id:              a.Id,
+		Name:            a.Name,
+		Description:     a.Description,
+		Price:           a.Price,
+		OwnerId:         a.Owner_id,
+		Status:          string(a.Status),
+		SaleMode:        string(a.SaleMode),
+		Stock:           a.Stock,
+		Sold:            a.Sold,
+		Remaining:       a.Remaining,
+		Edition:         a.Edition,
+		DeletedAt:       timestamp(a.DeletedAt),
+		AccessDays:      a.AccessDays,
+		AccessExpiresAt: timestamp(a.AccessExpiresAt),
+		Rating:          a.Rating,
+		RatingCount:     a.RatingCount,
+	}
+}
+
+func assetsResponse(assets []entity.Asset) *pb.ListAssetsResponse {
+	res := &pb.ListAssetsResponse{Assets: make([]*pb.Asset, 0, len(assets))}
+	for _, a := range assets {
+		res.Assets = append(res.Assets, assetMessage(a))
+	}
+	return res
+}
+
+func purchaseMessage(p entity.Purchase) *pb.Purchase {
+	return &pb.Purchase{
+		Id:              p.Id,
+		AssetId:         p.AssetId,
+		BuyerId:         p.BuyerId,
+		SellerId:        p.SellerId,
+		Price:           p.Price,
+		SaleMode:        string(p.SaleMode),
+		Edition:         p.Edition,
+		ReceiptId:       p.ReceiptId,
+		Discount:        p.Discount,
+		RecipientId:     p.RecipientId,
+		GiftMessage:     p.GiftMessage,
+		AccessExpiresAt: timestamp(p.AccessExpiresAt),
+		PurchasedAt:     timestamppb.New(p.PurchasedAt),
+	}
+}
+
+func purchasesResponse(purchases []entity.Purchase) *pb.ListPurchasesResponse {
+	res := &pb.ListPurchasesResponse{Purchases: make([]*pb.Purchase, 0, len(purchases))}
+	for _, p := range purchases {
+		res.Purchases = append(res.Purchases, purchaseMessage(p))
+	}
+	return res
+}
+
+func (rt *assetRoutes) CreateAsset(ctx context.Context, req *pb.CreateAssetRequest) (*pb.CreateAssetResponse, error) {
+	ast := entity.Asset{
+		Name:        req.GetName(),
+		Description: req.GetDescription(),
+		Price:       req.GetPrice(),
+		Status:      entity.AssetStatus(req.GetStatus()),
+		SaleMode:    entity.SaleMode(req.GetSaleMode()),
+		Stock:       req.Stock,
+		AccessDays:  req.AccessDays,
+	}
+	if ast.Status != "" && !ast.Status.Valid() {
+		return nil, status.Error(codes.InvalidArgument, "invalid asset status")
+	}
+	if ast.SaleMode != "" && !ast.SaleMode.Valid() {
+		return nil, status.Error(codes.InvalidArgument, "invalid sale mode")
+	}
+	usr, err := claims.User(ctx)
+	if err != nil {
+		return nil, claimsError(rt.l, err, "grpc - v1 - CreateAsset - claims.User")
+	}
+	ast.Owner_id = usr.Id
+	_, err = rt.t.CreateAsset(ctx, ast)
+	if err != nil {
+		return nil, errorStatus(rt.l, err, "grpc - v1 - CreateAsset - rt.t.CreateAsset", "error creating asset")
+	}
+	return &pb.CreateAssetResponse{}, nil
+}
+
+func (rt *assetRoutes) DeleteAsset(ctx context.Context, req *pb.DeleteAssetRequest) (*pb.DeleteAssetResponse, error) {
+	usr, err := claims.User(ctx)
+	if err != nil {
+		return nil, claimsError(rt.l, err, "grpc - v1 - DeleteAsset - claims.User")
+	}
+	ok, err := rt.t.DeleteAsset(ctx, usr, req.GetId())
+	if err != nil {
+		return nil, errorStatus(rt.l, err, "grpc - v1 - DeleteAsset - rt.t.DeleteAsset", "error deleting asset")
+	}
+	if !ok {
+		return nil, status.Error(codes.NotFound, entity.ErrAssetNotFound.Error())
+	}
+	return &pb.DeleteAssetResponse{}, nil
+}
+
+func (rt *assetRoutes) BuyAsset(ctx context.Context, req *pb.BuyAssetRequest) (*pb.BuyAssetResponse, error) {
+	usr, err := claims.User(ctx)
+	if err != nil {
+		return nil, claimsError(rt.l, err, "grpc - v1 - BuyAsset - claims.User")
+	}
+	ok, err := rt.t.BuyAsset(ctx, usr, req.GetId(), req.GetPromoCode())
+	if err != nil {
+		return nil, errorStatus(rt.l, err, "grpc - v1 - BuyAsset - rt.t.BuyAsset", "error buying asset")
+	}
+	if !ok {
+		return nil, status.Error(codes.NotFound, entity.ErrAssetNotFound.Error())
+	}
+	return &pb.BuyAssetResponse{}, nil
+}
+
+func (rt *assetRoutes) ListUserAssets(ctx context.Context, _ *pb.ListUserAssetsRequest) (*pb.ListAssetsResponse, error) {
+	usr, err := claims.User(ctx)
+	if err != nil {
+		return nil, claimsError(rt.l, err, "grpc - v1 - ListUserAssets - claims.User")
+	}
+	assets, err := rt.t.UserAssetsList(ctx, usr)
+	if err != nil {
+		return nil, errorStatus(rt.l, err, "grpc - v1 - ListUserAssets - rt.t.UserAssetsList", "error getting assets")
+	}
+	return assetsResponse(assets), nil
+}
+
+func (rt *assetRoutes) GetAsset(ctx context.Context, req *pb.GetAssetRequest) (*pb.Asset, error) {
+	usr, err := claims.User(ctx)
+	if err != nil {
+		return nil, claimsError(rt.l, err, "grpc - v1 - GetAsset - claims.User")
+	}
+	asset, err := rt.t.GetAssetById(ctx, usr, req.GetId())
+	if err != nil {
+		return nil, errorStatus(rt.l, err, "grpc - v1 - GetAsset - rt.t.GetAssetById", "error getting asset")
+	}
+	if asset.Id <= 0 {
+		return nil, status.Error(codes.NotFound, entity.ErrAssetNotFound.Error())
+	}
+	return assetMessage(asset), nil
+}
+
+func (rt *assetRoutes) ListMarketAssets(ctx context.Context, _ *pb.ListMarketAssetsRequest) (*pb.ListAssetsResponse, error) {
+	usr, err := claims.User(ctx)
+	if err != nil {
+		return nil, claimsError(rt.l, err, "grpc - v1 - ListMarketAssets - claims.User")
+	}
+	assets, err := rt.t.GetAssetsToBuying(ctx, usr)
+	if err != nil {
+		return nil, errorStatus(rt.l, err, "grpc - v1 - ListMarketAssets - rt.t.GetAssetsToBuying", "error getting assets")
+	}
+	return assetsResponse(assets), nil
+}
+
+func (rt *assetRoutes) ListPurchasedAssets(ctx context.Context, _ *pb.ListPurchasedAssetsRequest) (*pb.ListAssetsResponse, error) {
+	usr, err := claims.User(ctx)
+	if err != nil {
+		return nil, claimsError(rt.l, err, "grpc - v1 - ListPurchasedAssets - claims.User")
+	}
+	assets, err := rt.t.GetPurchasedAssets(ctx, usr)
+	if err != nil {
+		return nil, errorStatus(rt.l, err, "grpc - v1 - ListPurchasedAssets - rt.t.GetPurchasedAssets", "error getting assets")
+	}
+	return assetsResponse(assets), nil
+}
+
+func (rt *assetRoutes) ChangeAssetStatus(ctx context.Context, req *pb.ChangeAssetStatusRequest) (*pb.ChangeAssetStatusResponse, error) {
+	st := entity.AssetStatus(req.GetStatus())
+	if !st.Valid() {
+		return nil, status.Error(codes.InvalidArgument, "invalid asset status")
+	}
+	usr, err := claims.User(ctx)
+	if err != nil {
+		return nil, claimsError(rt.l, err, "grpc - v1 - ChangeAssetStatus - claims.User")
+	}
+	ok, err := rt.t.ChangeAssetStatus(ctx, usr, req.GetId(), st)
+	if err != nil {
+		return nil, errorStatus(rt.l, err, "grpc - v1 - ChangeAssetStatus - rt.t.ChangeAssetStatus", "error changing asset status")
+	}
+	if !ok {
+		return nil, status.Error(codes.NotFound, entity.ErrAssetNotFound.Error())
+	}
+	return &pb.ChangeAssetStatusResponse{}, nil
+}
+
+func (rt *assetRoutes) ChangeAssetPrice(ctx context.Context, req *pb.ChangeAssetPriceRequest) (*pb.ChangeAssetPriceResponse, error) {
+	if req.GetPrice() < 0 {
+		return nil, status.Error(codes.InvalidArgument, "price can't be negative")
+	}
+	usr, err := claims.User(ctx)
+	if err != nil {
+		return nil, claimsError(rt.l, err, "grpc - v1 - ChangeAssetPrice - claims.User")
+	}
+	ok, err := rt.t.ChangeAssetPrice(ctx, usr, req.GetId(), req.GetPrice())
+	if err != nil {
+		return nil, errorStatus(rt.l, err, "grpc - v1 - ChangeAssetPrice - rt.t.ChangeAssetPrice", "error changing asset price")
+	}
+	if !ok {
+		return nil, status.Error(codes.NotFound, entity.ErrAssetNotFound.Error())
+	}
+	return &pb.ChangeAssetPriceResponse{}, nil
+}
+
+// PurgeAsset - the role is read from the database on every call, as adminOnly does for HTTP.
+func (rt *assetRoutes) PurgeAsset(ctx context.Context, req *pb.PurgeAssetRequest) (*pb.PurgeAssetResponse, error) {
+	usr, err := claims.User(ctx)
+	if err != nil {
+		return nil, claimsError(rt.l, err, "grpc - v1 - PurgeAsset - claims.User")
+	}
+	admin, err := rt.u.IsAdmin(ctx, usr)
+	if err != nil {
+		rt.l.WithContext(ctx).Error(err, "grpc - v1 - PurgeAsset - rt.u.IsAdmin")
+		return nil, status.Error(codes.Internal, "error checking user role")
+	}
+	if !admin {
+		return nil, status.Error(codes.PermissionDenied, "admin role required")
+	}
+	ok, err := rt.t.PurgeAsset(ctx, req.GetId())
+	if err != nil {
+		return nil, errorStatus(rt.l, err, "grpc - v1 - PurgeAsset - rt.t.PurgeAsset", "error purging asset")
+	}
+	if !ok {
+		return nil, status.Error(codes.NotFound, entity.ErrAssetNotFound.Error())
+	}
+	return &pb.PurgeAssetResponse{}, nil
+}
+
+func (rt *assetRoutes) GetProvenance(ctx context.Context, req *pb.GetProvenanceRequest) (*pb.ListPurchasesResponse, error) {
+	usr, err := claims.User(ctx)
+	if err != nil {
+		return nil, claimsError(rt.l, err, "grpc - v1 - GetProvenance - claims.User")
+	}
+	transfers, err := rt.t.GetProvenance(ctx, usr, req.GetId())
+	if err != nil {
+		return nil, errorStatus(rt.l, err, "grpc - v1 - GetProvenance - rt.t.GetProvenance", "error getting provenance")
+	}
+	return purchasesResponse(transfers), nil
+}
+
+func (rt *assetRoutes) RenewAccess(ctx context.Context, req *pb.RenewAccessRequest) (*pb.Purchase, error) {
+	usr, err := claims.User(ctx)
+	if err != nil {
+		return nil, claimsError(rt.l, err, "grpc - v1 - RenewAccess - claims.User")
+	}
+	p, err := rt.t.RenewAccess(ctx, usr, req.GetId())
+	if err != nil {
+		return nil, errorStatus(rt.l, err, "grpc - v1 - RenewAccess - rt.t.RenewAccess", "error renewing access")
+	}
+	return purchaseMessage(p), nil
+}
+
+func (rt *assetRoutes) GiftAsset(ctx context.Context, req *pb.GiftAssetRequest) (*pb.Purchase, error) {
+	usr, err := claims.User(ctx)
+	if err != nil {
+		return nil, claimsError(rt.l, err, "grpc - v1 - GiftAsset - claims.User")
+	}
+	recipient := strings.TrimSpace(req.GetRecipient())
+	if recipient == "" || recipient == usr.Username {
+		return nil, status.Error(codes.InvalidArgument, "recipient should be another user")
+	}
+	if utf8.RuneCountInString(strings.TrimSpace(req.GetMessage())) > 500 {
+		return nil, status.Error(codes.InvalidArgument, "message should be at most 500 characters")
+	}
+	p, err := rt.t.GiftAsset(ctx, usr, req.GetId(), recipient, req.GetMessage())
+	if err != nil {
+		return nil, errorStatus(rt.l, err, "grpc - v1 - GiftAsset - rt.t.GiftAsset", "error gifting asset")
+	}
+	return purchaseMessage(p), nil
+}
+
+func (rt *assetRoutes) GetPurchaseHistory(ctx context.Context, _ *pb.GetPurchaseHistoryRequest) (*pb.ListPurchasesResponse, error) {
+	usr, err := claims.User(ctx)
+	if err != nil {
+		return nil, claimsError(rt.l, err, "grpc - v1 - GetPurchaseHistory - claims.User")
+	}
+	purchases, err := rt.t.GetPurchaseHistory(ctx, usr)
+	if err != nil {
+		return nil, errorStatus(rt.l, err, "grpc - v1 - GetPurchaseHistory - rt.t.GetPurchaseHistory", "error getting purchase history")
+	}
+	return purchasesResponse(purchases), nil
+}
@@ -0,0 +1,85 @@
+package v1
+
+import (
+	"context"
+	"strings"
+
+	"github.com/Klef99/bhs-task/pkg/jwtgenerator"
+	pb "github.com/Klef99/bhs-task/pkg/pb/v1"
+	"github.com/go-chi/jwtauth/v5"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// _servicePrefix - methods of our services need a token, health checks and reflection don't.
+const _servicePrefix = "/bhs.v1."
+
+// _publicMethods - methods of our services callable without a token.
+var _publicMethods = map[string]bool{
+	pb.UserService_Register_FullMethodName: true,
+	pb.UserService_Login_FullMethodName:    true,
+}
+
+func authRequired(method string) bool {
+	return strings.HasPrefix(method, _servicePrefix) && !_publicMethods[method]
+}
+
+// UnaryAuthInterceptor - verifies the JWT sent in the "authorization: Bearer <token>" metadata and puts it
+// in the context the same way jwtauth.Verifier does for HTTP requests.
+func UnaryAuthInterceptor(jtg jwtgenerator.Interface) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if !authRequired(info.FullMethod) {
+			return handler(ctx, req)
+		}
+		ctx, err := authenticate(ctx, jtg.GetJWTAuth())
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamAuthInterceptor - UnaryAuthInterceptor for streaming methods.
+func StreamAuthInterceptor(jtg jwtgenerator.Interface) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if !authRequired(info.FullMethod) {
+			return handler(srv, ss)
+		}
+		ctx, err := authenticate(ss.Context(), jtg.GetJWTAuth())
+		if err != nil {
+			return err
+		}
+		return handler(srv, &authenticatedStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+type authenticatedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authenticatedStream) Context() context.Context {
+	return s.ctx
+}
+
+func authenticate(ctx context.Context, ja *jwtauth.JWTAuth) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get("authorization")
+	if len(values) == 0 {
+		return nil, status.Error(codes.Unauthenticated, "authorization token is required")
+	}
+	token, found := strings.CutPrefix(values[0], "Bearer ")
+	if !found {
+		token, found = strings.CutPrefix(values[0], "bearer ")
+	}
+	if !found || token == "" {
+		return nil, status.Error(codes.Unauthenticated, "authorization should be a bearer token")
+	}
+	t, err := jwtauth.VerifyToken(ja, token)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, jwtauth.ErrorReason(err).Error())
+	}
+	return jwtauth.NewContext(ctx, t, nil), nil
+}
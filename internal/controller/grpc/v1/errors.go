@@ -0,0 +1,49 @@
+package v1
+
+import (
+	"errors"
+
+	"github.com/Klef99/bhs-task/internal/entity"
+	"github.com/Klef99/bhs-task/pkg/logger"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// _errorCodes - status codes of the domain errors, the message of the error is returned to the client.
+var _errorCodes = []struct {
+	err  error
+	code codes.Code
+}{
+	{entity.ErrAssetNotFound, codes.NotFound},
+	{entity.ErrAccessNotFound, codes.NotFound},
+	{entity.ErrRecipientNotFound, codes.NotFound},
+	{entity.ErrPromoCodeNotFound, codes.NotFound},
+	{entity.ErrAssetAlreadyPurchased, codes.AlreadyExists},
+	{entity.ErrRecipientOwnsAsset, codes.AlreadyExists},
+	{entity.ErrAssetNotAvailable, codes.FailedPrecondition},
+	{entity.ErrOwnAsset, codes.FailedPrecondition},
+	{entity.ErrAssetSoldOut, codes.FailedPrecondition},
+	{entity.ErrInsufficientFunds, codes.FailedPrecondition},
+	{entity.ErrAssetNotRenewable, codes.FailedPrecondition},
+	{entity.ErrInvalidStatusTransition, codes.FailedPrecondition},
+	{entity.ErrPromoCodeExpired, codes.FailedPrecondition},
+	{entity.ErrPromoCodeNotApplicable, codes.FailedPrecondition},
+}
+
+// errorStatus - maps an error of a use case to a status. Errors that are not domain ones are logged
+// and hidden behind msg, like the HTTP handlers do with a 500.
+func errorStatus(l logger.Interface, err error, where string, msg string) error {
+	for _, e := range _errorCodes {
+		if errors.Is(err, e.err) {
+			return status.Error(e.code, e.err.Error())
+		}
+	}
+	l.Error(err, where)
+	return status.Error(codes.Internal, msg)
+}
+
+// claimsError - the token passed the interceptor, but its claims can't be read.
+func claimsError(l logger.Interface, err error, where string) error {
+	l.Error(err, where)
+	return status.Error(codes.Internal, "error getting token claims")
+}
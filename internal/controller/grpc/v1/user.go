@@ -0,0 +1,110 @@
+package v1
+
+import (
+	"context"
+
+	"github.com/Klef99/bhs-task/internal/controller/claims"
+	"github.com/Klef99/bhs-task/internal/entity"
+	"github.com/Klef99/bhs-task/internal/usecase"
+	"github.com/Klef99/bhs-task/pkg/jwtgenerator"
+	"github.com/Klef99/bhs-task/pkg/logger"
+	pb "github.com/Klef99/bhs-task/pkg/pb/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type userRoutes struct {
+	pb.UnimplementedUserServiceServer
+
+	t   usecase.User
+	l   logger.Interface
+	jtg jwtgenerator.Interface
+}
+
+func NewUserRoutes(app *grpc.Server, t usecase.User, l logger.Interface, jtg jwtgenerator.Interface) {
+	pb.RegisterUserServiceServer(app, &userRoutes{t: t, l: l, jtg: jtg})
+}
+
+func credentials(c *pb.Credentials) entity.Credentials {
+	return entity.Credentials{Username: c.GetUsername(), Password: c.GetPassword()}
+}
+
+func (rt *userRoutes) Register(ctx context.Context, req *pb.RegisterRequest) (*pb.RegisterResponse, error) {
+	crd := credentials(req.GetCredentials())
+	if crd.Username == "" || crd.Password == "" {
+		return nil, status.Error(codes.InvalidArgument, "username and password are required")
+	}
+	ok, err := rt.t.Register(ctx, crd)
+	if err != nil {
+		rt.l.WithContext(ctx).Error(err, "grpc - v1 - Register - rt.t.Register")
+		return nil, status.Error(codes.Internal, "error registering user")
+	}
+	if !ok {
+		return nil, status.Error(codes.AlreadyExists, "user already exists")
+	}
+	return &pb.RegisterResponse{}, nil
+}
+
+func (rt *userRoutes) Login(ctx context.Context, req *pb.LoginRequest) (*pb.LoginResponse, error) {
+	crd := credentials(req.GetCredentials())
+	if crd.Username == "" || crd.Password == "" {
+		return nil, status.Error(codes.InvalidArgument, "username and password are required")
+	}
+	user, err := rt.t.Login(ctx, crd)
+	if err != nil {
+		rt.l.WithContext(ctx).Error(err, "grpc - v1 - Login - rt.t.Login")
+		return nil, status.Error(codes.Internal, "error login user")
+	}
+	if user.Id == 0 {
+		return nil, status.Error(codes.Unauthenticated, "invalid username or password")
+	}
+	token, err := rt.jtg.GenerateToken(user.Username, user.Id)
+	if err != nil {
+		rt.l.WithContext(ctx).Error(err, "grpc - v1 - Login - rt.jtg.GenerateToken")
+		return nil, status.Error(codes.Internal, "error generating token")
+	}
+	return &pb.LoginResponse{User: &pb.User{Id: user.Id, Username: user.Username}, Token: token}, nil
+}
+
+func (rt *userRoutes) MakeDeposit(ctx context.Context, req *pb.MakeDepositRequest) (*pb.MakeDepositResponse, error) {
+	if req.GetAmount() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "amount should be positive")
+	}
+	usr, err := claims.User(ctx)
+	if err != nil {
+		return nil, claimsError(rt.l, err, "grpc - v1 - MakeDeposit - claims.User")
+	}
+	balance, err := rt.t.MakeDeposit(ctx, usr, req.GetAmount())
+	if err != nil {
+		rt.l.WithContext(ctx).Error(err, "grpc - v1 - MakeDeposit - rt.t.MakeDeposit")
+		return nil, status.Error(codes.Internal, "error depositing money")
+	}
+	return &pb.MakeDepositResponse{Balance: balance}, nil
+}
+
+func (rt *userRoutes) CheckDeposit(ctx context.Context, _ *pb.CheckDepositRequest) (*pb.CheckDepositResponse, error) {
+	usr, err := claims.User(ctx)
+	if err != nil {
+		return nil, claimsError(rt.l, err, "grpc - v1 - CheckDeposit - claims.User")
+	}
+	balance, err := rt.t.CheckDeposit(ctx, usr)
+	if err != nil {
+		rt.l.WithContext(ctx).Error(err, "grpc - v1 - CheckDeposit - rt.t.CheckDeposit")
+		return nil, status.Error(codes.Internal, "error getting balance")
+	}
+	return &pb.CheckDepositResponse{Balance: balance}, nil
+}
+
+func (rt *userRoutes) IsAdmin(ctx context.Context, _ *pb.IsAdminRequest) (*pb.IsAdminResponse, error) {
+	usr, err := claims.User(ctx)
+	if err != nil {
+		return nil, claimsError(rt.l, err, "grpc - v1 - IsAdmin - claims.User")
+	}
+	ok, err := rt.t.IsAdmin(ctx, usr)
+	if err != nil {
+		rt.l.WithContext(ctx).Error(err, "grpc - v1 - IsAdmin - rt.t.IsAdmin")
+		return nil, status.Error(codes.Internal, "error checking user role")
+	}
+	return &pb.IsAdminResponse{IsAdmin: ok}, nil
+}
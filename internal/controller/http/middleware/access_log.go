@@ -0,0 +1,49 @@
+package middleware
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/Klef99/bhs-task/pkg/logger"
+	"github.com/go-chi/chi/v5"
+	chimiddleware "github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/jwtauth/v5"
+)
+
+// AccessLog - scopes a logger with the request id and, if the request carries a valid token, the user id
+// to the request context, so messages logged while handling it are correlated, then writes an access log entry
+// once the request is handled. RequestID must run before it.
+func AccessLog(l logger.Interface, tokenAuth *jwtauth.JWTAuth) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			fields := logger.Fields{"request_id": RequestIDFromContext(r.Context())}
+			if id, ok := userIDFromRequest(tokenAuth, r); ok {
+				fields["user_id"] = id
+			}
+			ctx := logger.NewContext(r.Context(), l.WithFields(fields))
+
+			ww := chimiddleware.NewWrapResponseWriter(w, r.ProtoMajor)
+			next.ServeHTTP(ww, r.WithContext(ctx))
+
+			status := ww.Status()
+			if status == 0 {
+				status = http.StatusOK
+			}
+			entry := logger.Fields{
+				"method":      r.Method,
+				"path":        r.URL.Path,
+				"status":      status,
+				"latency_ms":  float64(time.Since(start).Microseconds()) / 1000,
+				"bytes":       ww.BytesWritten(),
+				"remote_addr": r.RemoteAddr,
+				"client_ip":   ClientIPFromContext(r.Context()),
+				"user_agent":  r.UserAgent(),
+			}
+			if rctx := chi.RouteContext(r.Context()); rctx != nil && rctx.RoutePattern() != "" {
+				entry["route"] = rctx.RoutePattern()
+			}
+			l.WithContext(ctx).WithFields(entry).Info("http - request")
+		})
+	}
+}
@@ -0,0 +1,21 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/go-chi/jwtauth/v5"
+)
+
+// userIDFromRequest - id of the user the request's token was issued to, if it carries a valid one.
+// Middlewares run before the routes verify the token, so they verify it on their own.
+func userIDFromRequest(tokenAuth *jwtauth.JWTAuth, r *http.Request) (int64, bool) {
+	token, err := jwtauth.VerifyRequest(tokenAuth, r, jwtauth.TokenFromHeader, jwtauth.TokenFromQuery)
+	if err != nil {
+		return 0, false
+	}
+	id, ok := token.PrivateClaims()["id"].(float64)
+	if !ok {
+		return 0, false
+	}
+	return int64(id), true
+}
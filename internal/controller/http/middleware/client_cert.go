@@ -0,0 +1,41 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+type clientCertResponse struct {
+	Status string `json:"status"`
+}
+
+// RequireClientCert - requests to the paths are only served over connections that presented a verified client
+// certificate, for routes called by other services while other clients connect without one.
+// A path ending with * matches by prefix, others match exactly.
+func RequireClientCert(paths []string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if matchPath(paths, r.URL.Path) && (r.TLS == nil || len(r.TLS.VerifiedChains) == 0) {
+				w.WriteHeader(http.StatusForbidden)
+				json.NewEncoder(w).Encode(clientCertResponse{"client certificate required"})
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// matchPath - whether path is one of paths, those ending with * match by prefix.
+func matchPath(paths []string, path string) bool {
+	for _, p := range paths {
+		if prefix, ok := strings.CutSuffix(p, "*"); ok {
+			if strings.HasPrefix(path, prefix) {
+				return true
+			}
+		} else if path == p {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,115 @@
+package middleware
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Klef99/bhs-task/internal/entity"
+	"github.com/Klef99/bhs-task/internal/usecase"
+	"github.com/Klef99/bhs-task/pkg/logger"
+	"github.com/go-chi/jwtauth/v5"
+)
+
+// Keys requests are counted by.
+const (
+	RateLimitByUser = "user" // requests without a valid token are counted by client IP
+	RateLimitByIP   = "ip"
+)
+
+// RateLimitRule - applies the policy to requests to one of the paths and, if any are set, with one of the methods.
+// A path ending with * matches by prefix, others match exactly.
+type RateLimitRule struct {
+	Policy  entity.RateLimitPolicy
+	Paths   []string
+	Methods []string
+	By      string
+}
+
+func (rule RateLimitRule) matches(r *http.Request) bool {
+	if len(rule.Methods) > 0 && !containsFold(rule.Methods, r.Method) {
+		return false
+	}
+	return matchPath(rule.Paths, r.URL.Path)
+}
+
+type rateLimitResponse struct {
+	Status string `json:"status"`
+}
+
+// RateLimit - counts requests against the first rule they match, requests matching none aren't limited.
+// The state of the limit is reported in the RateLimit-* headers, requests over it get 429 with Retry-After.
+// If the limiter fails the request is let through, an outage of its store doesn't take the API down.
+// ClientIP must run before it.
+func RateLimit(uc usecase.RateLimit, l logger.Interface, tokenAuth *jwtauth.JWTAuth, rules []RateLimitRule) (func(http.Handler) http.Handler, error) {
+	for _, rule := range rules {
+		if !rule.Policy.Valid() {
+			return nil, fmt.Errorf("rate limit rule %q: %w", rule.Policy.Name, entity.ErrInvalidRateLimitPolicy)
+		}
+		if rule.By != RateLimitByUser && rule.By != RateLimitByIP {
+			return nil, fmt.Errorf("rate limit rule %q: unknown key %q", rule.Policy.Name, rule.By)
+		}
+		if len(rule.Paths) == 0 {
+			return nil, fmt.Errorf("rate limit rule %q: no paths", rule.Policy.Name)
+		}
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var rule *RateLimitRule
+			for i := range rules {
+				if rules[i].matches(r) {
+					rule = &rules[i]
+					break
+				}
+			}
+			if rule == nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			key := "ip:" + ClientIPFromContext(r.Context())
+			if rule.By == RateLimitByUser {
+				if id, ok := userIDFromRequest(tokenAuth, r); ok {
+					key = "user:" + strconv.FormatInt(id, 10)
+				}
+			}
+			res, err := uc.Allow(r.Context(), rule.Policy, key)
+			if err != nil {
+				l.WithContext(r.Context()).Error(err, "http - middleware - rate limit")
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			window := float64(rule.Policy.Burst) / rule.Policy.Rate
+			w.Header().Set("RateLimit-Policy", fmt.Sprintf("%d;w=%d", rule.Policy.Burst, int64(math.Ceil(window))))
+			w.Header().Set("RateLimit-Limit", strconv.Itoa(res.Limit))
+			w.Header().Set("RateLimit-Remaining", strconv.Itoa(res.Remaining))
+			w.Header().Set("RateLimit-Reset", strconv.FormatInt(ceilSeconds(res.Reset), 10))
+			if !res.Allowed {
+				w.Header().Set("Retry-After", strconv.FormatInt(ceilSeconds(res.RetryAfter), 10))
+				w.WriteHeader(http.StatusTooManyRequests)
+				json.NewEncoder(w).Encode(rateLimitResponse{"too many requests"})
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}, nil
+}
+
+func ceilSeconds(d time.Duration) int64 {
+	return int64(math.Ceil(d.Seconds()))
+}
+
+func containsFold(list []string, s string) bool {
+	for _, v := range list {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,23 @@
+package probe
+
+import (
+	"context"
+	"time"
+)
+
+// Option -.
+type Option func(*Probe)
+
+// Check - adds a dependency the instance needs to serve requests, it is ready while check returns nil.
+func Check(name string, check func(ctx context.Context) error) Option {
+	return func(p *Probe) {
+		p.checks = append(p.checks, namedCheck{name: name, check: check})
+	}
+}
+
+// Timeout - time the checks of a readiness request have to complete.
+func Timeout(timeout time.Duration) Option {
+	return func(p *Probe) {
+		p.timeout = timeout
+	}
+}
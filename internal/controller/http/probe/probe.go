@@ -0,0 +1,113 @@
+// Package probe implements the liveness and readiness endpoints polled by the orchestrator.
+package probe
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/Klef99/bhs-task/pkg/logger"
+	"github.com/go-chi/chi/v5"
+)
+
+const _defaultTimeout = 2 * time.Second
+
+const (
+	statusOK       = "ok"
+	statusFailed   = "failed"
+	statusReady    = "ready"
+	statusNotReady = "not ready"
+	statusDraining = "draining"
+)
+
+type namedCheck struct {
+	name  string
+	check func(ctx context.Context) error
+}
+
+// Probe - readiness depends on the checks passing and the instance not shutting down.
+type Probe struct {
+	checks   []namedCheck
+	timeout  time.Duration
+	draining atomic.Bool
+}
+
+// New -.
+func New(opts ...Option) *Probe {
+	p := &Probe{timeout: _defaultTimeout}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+// Drain - reports the instance as not ready from now on, so no new requests are routed to it while it shuts down.
+func (p *Probe) Drain() {
+	p.draining.Store(true)
+}
+
+type checkResponse struct {
+	Status string `json:"status"`
+}
+
+type readinessResponse struct {
+	Status string                   `json:"status"`
+	Checks map[string]checkResponse `json:"checks,omitempty"`
+}
+
+// NewRouter - mounts /livez, /readyz and /healthz, kept as an alias of /livez for existing probes.
+func NewRouter(router chi.Router, l logger.Interface, p *Probe) {
+	live := func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, checkResponse{statusOK})
+	}
+	router.Get("/livez", live)
+	router.Get("/healthz", live)
+	router.Get("/readyz", func(w http.ResponseWriter, r *http.Request) {
+		if p.draining.Load() {
+			writeJSON(w, http.StatusServiceUnavailable, readinessResponse{Status: statusDraining})
+			return
+		}
+		ctx, cancel := context.WithTimeout(r.Context(), p.timeout)
+		defer cancel()
+
+		// Errors are logged rather than returned, they may describe the infrastructure.
+		errs := p.run(ctx)
+		res := readinessResponse{Status: statusReady, Checks: make(map[string]checkResponse, len(p.checks))}
+		code := http.StatusOK
+		for i, c := range p.checks {
+			if errs[i] != nil {
+				l.WithContext(r.Context()).Error(errs[i], "http - probe - readyz - "+c.name)
+				res.Checks[c.name] = checkResponse{statusFailed}
+				res.Status = statusNotReady
+				code = http.StatusServiceUnavailable
+				continue
+			}
+			res.Checks[c.name] = checkResponse{statusOK}
+		}
+		writeJSON(w, code, res)
+	})
+}
+
+// run - runs the checks at once, errors are in the order of the checks.
+func (p *Probe) run(ctx context.Context) []error {
+	errs := make([]error, len(p.checks))
+	wg := sync.WaitGroup{}
+	for i, c := range p.checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = c.check(ctx)
+		}()
+	}
+	wg.Wait()
+	return errs
+}
+
+func writeJSON(w http.ResponseWriter, code int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(v)
+}
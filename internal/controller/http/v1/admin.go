@@ -0,0 +1,191 @@
+package v1
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/Klef99/bhs-task/internal/controller/claims"
+	"github.com/Klef99/bhs-task/internal/usecase"
+	"github.com/Klef99/bhs-task/pkg/jwtgenerator"
+	"github.com/Klef99/bhs-task/pkg/logger"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/jwtauth/v5"
+)
+
+type adminRoutes struct {
+	u   usecase.User
+	a   usecase.Asset
+	p   usecase.Promo
+	wh  usecase.Webhook
+	l   logger.Interface
+	jtg jwtgenerator.Interface
+}
+
+func NewAdminRoutes(handler chi.Router, u usecase.User, a usecase.Asset, p usecase.Promo, wh usecase.Webhook, l logger.Interface, jtg jwtgenerator.Interface) {
+	rt := &adminRoutes{u: u, a: a, p: p, wh: wh, l: l, jtg: jtg}
+	tokenAuth := rt.jtg.GetJWTAuth()
+	router := chi.NewRouter()
+	router.Use(jwtauth.Verifier(tokenAuth))
+	router.Use(jwtauth.Authenticator(tokenAuth))
+	router.Use(adminOnly(u, l))
+	router.Group(func(r chi.Router) {
+		r.Delete("/asset/{id}", rt.PurgeAsset)
+		r.Post("/promo-codes", rt.CreateSitePromoCode)
+		r.Post("/webhooks", rt.CreateGlobalWebhook)
+	})
+	handler.Mount("/admin", router)
+}
+
+// adminOnly - lets through only users with the admin role. The role is read from the database
+// on every request, so revoking it takes effect without waiting for the token to expire.
+func adminOnly(u usecase.User, l logger.Interface) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			usr, err := claims.User(r.Context())
+			if err != nil {
+				l.Error(err, "http - v1 - adminOnly - claims.User")
+				errorResponse(w, http.StatusInternalServerError, "error getting token claims")
+				return
+			}
+			ok, err := u.IsAdmin(r.Context(), usr)
+			if err != nil {
+				l.Error(err, "http - v1 - adminOnly - u.IsAdmin")
+				errorResponse(w, http.StatusInternalServerError, "error checking user role")
+				return
+			}
+			if !ok {
+				errorResponse(w, http.StatusForbidden, "admin role required")
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// @Summary     Purge Asset
+// @Description Permanently removes an asset together with every buyer's access to it. Admin only.
+// @ID          PurgeAsset
+// @Security    ApiKeyAuth
+// @Tags        Admin
+// @Accept      json
+// @Produce     json
+// @Success     200 {object} response "Asset purged successfully"
+// @Failure     403 {object} response "Admin role required"
+// @Failure     404 {object} response "Asset not found"
+// @Failure     500 {object} response "Internal server error"
+// @Router      /admin/asset/{id} [delete]
+// @Param       id path int true "Asset ID to be purged"
+func (rt *adminRoutes) PurgeAsset(w http.ResponseWriter, r *http.Request) {
+	idAsset, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		rt.l.WithContext(r.Context()).Error(err, "http - v1 - PurgeAsset")
+		errorResponse(w, http.StatusInternalServerError, "error decoding request parameters")
+		return
+	}
+	status, err := rt.a.PurgeAsset(r.Context(), idAsset)
+	if err != nil {
+		rt.l.WithContext(r.Context()).Error(err, "http - v1 - PurgeAsset - rt.a.PurgeAsset")
+		errorResponse(w, http.StatusInternalServerError, "error purging asset")
+		return
+	}
+	if status {
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(response{"Asset purged successfully"})
+	} else {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(response{"Asset not found"})
+	}
+}
+
+type sitePromoCodeRequest struct {
+	promoCodeRequest
+	SellerId *int64 `json:"seller_id,omitempty"` // with asset_id omitted, the code applies to every seller's assets
+}
+
+// @Summary     Create Site Promo Code
+// @Description Creates a discount code for any asset or seller, or for the whole market when neither is given. Admin only.
+// @ID          CreateSitePromoCode
+// @Security    ApiKeyAuth
+// @Tags        Admin
+// @Accept      json
+// @Produce     json
+// @Success     200 {object} entity.PromoCode "Promo code created"
+// @Failure     400 {object} response "Invalid promo code"
+// @Failure     403 {object} response "Admin role required"
+// @Failure     404 {object} response "Asset not found"
+// @Failure     409 {object} response "Promo code already exists"
+// @Failure     500 {object} response "Internal server error"
+// @Router      /admin/promo-codes [post]
+// @Param       request body sitePromoCodeRequest true "Promo code details"
+func (rt *adminRoutes) CreateSitePromoCode(w http.ResponseWriter, r *http.Request) {
+	req := sitePromoCodeRequest{}
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		rt.l.WithContext(r.Context()).Error(err, "http - v1 - CreateSitePromoCode - decoder.Decode")
+		errorResponse(w, http.StatusInternalServerError, "error decoding request body")
+		return
+	}
+	promo := req.toEntity()
+	promo.SellerId = req.SellerId
+	err = promo.Validate()
+	if err != nil {
+		errorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	usr, err := claims.User(r.Context())
+	if err != nil {
+		rt.l.WithContext(r.Context()).Error(err, "http - v1 - CreateSitePromoCode - claims.User")
+		errorResponse(w, http.StatusInternalServerError, "error getting token claims")
+		return
+	}
+	created, err := rt.p.CreateSitePromoCode(r.Context(), usr, promo)
+	if err != nil {
+		writePromoCodeError(w, rt.l, err, "http - v1 - CreateSitePromoCode - rt.p.CreateSitePromoCode")
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(created)
+}
+
+// @Summary     Create Global Webhook
+// @Description Registers an endpoint receiving the events of every user. The secret is returned only in this response. Admin only.
+// @ID          CreateGlobalWebhook
+// @Security    ApiKeyAuth
+// @Tags        Admin
+// @Accept      json
+// @Produce     json
+// @Success     200 {object} entity.Webhook "Webhook created"
+// @Failure     400 {object} response "Invalid url or event types"
+// @Failure     403 {object} response "Admin role required"
+// @Failure     500 {object} response "Internal server error"
+// @Router      /admin/webhooks [post]
+// @Param       request body webhookRequest true "Endpoint and event types: asset.created, asset.purchased, deposit.completed"
+func (rt *adminRoutes) CreateGlobalWebhook(w http.ResponseWriter, r *http.Request) {
+	req := webhookRequest{}
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		rt.l.WithContext(r.Context()).Error(err, "http - v1 - CreateGlobalWebhook - decoder.Decode")
+		errorResponse(w, http.StatusInternalServerError, "error decoding request body")
+		return
+	}
+	wh := req.toEntity()
+	if err = wh.Validate(); err != nil {
+		errorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	usr, err := claims.User(r.Context())
+	if err != nil {
+		rt.l.WithContext(r.Context()).Error(err, "http - v1 - CreateGlobalWebhook - claims.User")
+		errorResponse(w, http.StatusInternalServerError, "error getting token claims")
+		return
+	}
+	created, err := rt.wh.CreateGlobalWebhook(r.Context(), usr, wh)
+	if err != nil {
+		rt.l.WithContext(r.Context()).Error(err, "http - v1 - CreateGlobalWebhook - rt.wh.CreateGlobalWebhook")
+		errorResponse(w, http.StatusInternalServerError, "error creating webhook")
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(created)
+}
@@ -2,10 +2,13 @@ package v1
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 	"strconv"
 	"strings"
+	"unicode/utf8"
 
+	"github.com/Klef99/bhs-task/internal/controller/claims"
 	"github.com/Klef99/bhs-task/internal/entity"
 	"github.com/Klef99/bhs-task/internal/usecase"
 	"github.com/Klef99/bhs-task/pkg/jwtgenerator"
@@ -34,14 +37,24 @@ func NewAssetRoutes(handler chi.Router, t usecase.Asset, l logger.Interface, jtg
 		r.Get("/market", rt.AssetsToBuying)
 		r.Get("/{id}/buy", rt.BuyAsset)
 		r.Get("/purchased", rt.GetPurchasedAsset)
+		r.Patch("/{id}/status", rt.ChangeAssetStatus)
+		r.Patch("/{id}/price", rt.ChangeAssetPrice)
+		r.Get("/{id}/provenance", rt.GetProvenance)
+		r.Post("/{id}/renew", rt.RenewAccess)
+		r.Post("/{id}/gift", rt.GiftAsset)
+		r.Get("/history", rt.GetPurchaseHistory)
 	})
 	handler.Mount("/asset", router)
 }
 
 type createAssetRequest struct {
-	Name        string  `json:"name"`
-	Description string  `json:"description"`
-	Price       float32 `json:"price"`
+	Name        string             `json:"name"`
+	Description string             `json:"description"`
+	Price       float32            `json:"price"`
+	Status      entity.AssetStatus `json:"status" enums:"draft,published,unlisted"` // draft if omitted
+	SaleMode    entity.SaleMode    `json:"sale_mode" enums:"license,transfer"`      // license if omitted
+	Stock       *int64             `json:"stock,omitempty"`                         // limited editions, unlimited if omitted
+	AccessDays  *int64             `json:"access_days,omitempty"`                   // rental period of each purchase, permanent if omitted
 }
 
 // @Summary     Create Asset
@@ -54,13 +67,13 @@ type createAssetRequest struct {
 // @Success     200 {object} response "Asset added successfully"
 // @Failure     500 {object} response "Internal server error or asset creation failed"
 // @Router      /asset [post]
-// @Param       request body createAssetRequest true "Asset details (name, description, price)"
+// @Param       request body createAssetRequest true "Asset details (name, description, price, initial status, sale mode, stock, access days)"
 func (rt *assetRoutes) CreateAsset(w http.ResponseWriter, r *http.Request) {
 	car := createAssetRequest{}
 	decoder := json.NewDecoder(r.Body)
 	err := decoder.Decode(&car)
 	if err != nil {
-		rt.l.Error(err, "http - v1 - CreateAsset")
+		rt.l.WithContext(r.Context()).Error(err, "http - v1 - CreateAsset")
 		errorResponse(w, http.StatusInternalServerError, "error decoding request body")
 		return
 	}
@@ -68,23 +81,27 @@ func (rt *assetRoutes) CreateAsset(w http.ResponseWriter, r *http.Request) {
 		Name:        car.Name,
 		Description: car.Description,
 		Price:       car.Price,
+		Status:      car.Status,
+		SaleMode:    car.SaleMode,
+		Stock:       car.Stock,
+		AccessDays:  car.AccessDays,
 	}
 	_, claims, err := jwtauth.FromContext(r.Context())
 	if err != nil {
-		rt.l.Error(err, "http - v1 - CreateAsset - jwtauth.FromContext")
+		rt.l.WithContext(r.Context()).Error(err, "http - v1 - CreateAsset - jwtauth.FromContext")
 		errorResponse(w, http.StatusInternalServerError, "error getting token claims")
 		return
 	}
 	f, ok := claims["id"].(float64)
 	if !ok {
-		rt.l.Error(err, claims["id"], "http - v1 - CreateAsset - .(int64)")
+		rt.l.WithContext(r.Context()).Error(err, claims["id"], "http - v1 - CreateAsset - .(int64)")
 		errorResponse(w, http.StatusInternalServerError, "error getting token claims")
 		return
 	}
 	ast.Owner_id = int64(f)
 	status, err := rt.t.CreateAsset(r.Context(), ast)
 	if err != nil {
-		rt.l.Error(err, "http - v1 - CreateAsset")
+		rt.l.WithContext(r.Context()).Error(err, "http - v1 - CreateAsset")
 		errorResponse(w, http.StatusInternalServerError, "error creating asset")
 		return
 	}
@@ -95,7 +112,7 @@ func (rt *assetRoutes) CreateAsset(w http.ResponseWriter, r *http.Request) {
 }
 
 // @Summary     Delete Asset
-// @Description Removes an asset from the system based on the provided asset ID.
+// @Description Removes an asset from the market and the owner's listing based on the provided asset ID. Users who bought it keep their access.
 // @ID          DeleteAsset
 // @Security    ApiKeyAuth
 // @Tags        Asset
@@ -110,33 +127,33 @@ func (rt *assetRoutes) DeleteAsset(w http.ResponseWriter, r *http.Request) {
 	idParam := chi.URLParam(r, "id")
 	idAsset, err := strconv.ParseInt(idParam, 10, 64)
 	if err != nil {
-		rt.l.Error(err, "http - v1 - DeleteAsset")
+		rt.l.WithContext(r.Context()).Error(err, "http - v1 - DeleteAsset")
 		errorResponse(w, http.StatusInternalServerError, "error decoding request parameters")
 		return
 	}
 
 	_, claims, err := jwtauth.FromContext(r.Context())
 	if err != nil {
-		rt.l.Error(err, "http - v1 - DeleteAsset - jwtauth.FromContext")
+		rt.l.WithContext(r.Context()).Error(err, "http - v1 - DeleteAsset - jwtauth.FromContext")
 		errorResponse(w, http.StatusInternalServerError, "error getting token claims")
 		return
 	}
 	id, ok := claims["id"].(float64)
 	if !ok {
-		rt.l.Error(err, "http - v1 - DeleteAsset - .(float64)")
+		rt.l.WithContext(r.Context()).Error(err, "http - v1 - DeleteAsset - .(float64)")
 		errorResponse(w, http.StatusInternalServerError, "error getting token claims")
 		return
 	}
 	name, ok := claims["name"].(string)
 	if !ok {
-		rt.l.Error(err, "http - v1 - DeleteAsset - .(string)")
+		rt.l.WithContext(r.Context()).Error(err, "http - v1 - DeleteAsset - .(string)")
 		errorResponse(w, http.StatusInternalServerError, "error getting token claims")
 		return
 	}
 	usr := entity.User{Username: name, Id: int64(id)}
 	status, err := rt.t.DeleteAsset(r.Context(), usr, int64(idAsset))
 	if err != nil {
-		rt.l.Error(err, "http - v1 - DeleteAsset - rt.t.DeleteAsset")
+		rt.l.WithContext(r.Context()).Error(err, "http - v1 - DeleteAsset - rt.t.DeleteAsset")
 		errorResponse(w, http.StatusInternalServerError, "error deleting asset")
 		return
 	}
@@ -167,26 +184,26 @@ type listOfAssetResponse struct {
 func (rt *assetRoutes) UserAssetsList(w http.ResponseWriter, r *http.Request) {
 	_, claims, err := jwtauth.FromContext(r.Context())
 	if err != nil {
-		rt.l.Error(err, "http - v1 - AssetsList - jwtauth.FromContext")
+		rt.l.WithContext(r.Context()).Error(err, "http - v1 - AssetsList - jwtauth.FromContext")
 		errorResponse(w, http.StatusInternalServerError, "error getting token claims")
 		return
 	}
 	id, ok := claims["id"].(float64)
 	if !ok {
-		rt.l.Error(err, "http - v1 - AssetsList - .(float64)")
+		rt.l.WithContext(r.Context()).Error(err, "http - v1 - AssetsList - .(float64)")
 		errorResponse(w, http.StatusInternalServerError, "error getting token claims")
 		return
 	}
 	name, ok := claims["name"].(string)
 	if !ok {
-		rt.l.Error(err, "http - v1 - AssetsList - .(string)")
+		rt.l.WithContext(r.Context()).Error(err, "http - v1 - AssetsList - .(string)")
 		errorResponse(w, http.StatusInternalServerError, "error getting token claims")
 		return
 	}
 	usr := entity.User{Username: name, Id: int64(id)}
 	assets, err := rt.t.UserAssetsList(r.Context(), usr)
 	if err != nil {
-		rt.l.Error(err, "http - v1 - AssetsList - rt.t.AssetsList")
+		rt.l.WithContext(r.Context()).Error(err, "http - v1 - AssetsList - rt.t.AssetsList")
 		errorResponse(w, http.StatusInternalServerError, "error getting asset")
 		return
 	}
@@ -200,7 +217,7 @@ func (rt *assetRoutes) UserAssetsList(w http.ResponseWriter, r *http.Request) {
 }
 
 // @Summary     Get List of Assets for Buying
-// @Description Retrieves all assets available for purchase in the system.
+// @Description Retrieves all assets available for purchase in the system. Limited-stock assets report the remaining editions, sold-out ones are not listed. Each asset carries its average rating and number of reviews.
 // @ID          BuyingList
 // @Security    ApiKeyAuth
 // @Tags        Asset
@@ -213,26 +230,26 @@ func (rt *assetRoutes) UserAssetsList(w http.ResponseWriter, r *http.Request) {
 func (rt *assetRoutes) AssetsToBuying(w http.ResponseWriter, r *http.Request) {
 	_, claims, err := jwtauth.FromContext(r.Context())
 	if err != nil {
-		rt.l.Error(err, "http - v1 - AssetsList - jwtauth.FromContext")
+		rt.l.WithContext(r.Context()).Error(err, "http - v1 - AssetsList - jwtauth.FromContext")
 		errorResponse(w, http.StatusInternalServerError, "error getting token claims")
 		return
 	}
 	id, ok := claims["id"].(float64)
 	if !ok {
-		rt.l.Error(err, "http - v1 - AssetsList - .(float64)")
+		rt.l.WithContext(r.Context()).Error(err, "http - v1 - AssetsList - .(float64)")
 		errorResponse(w, http.StatusInternalServerError, "error getting token claims")
 		return
 	}
 	name, ok := claims["name"].(string)
 	if !ok {
-		rt.l.Error(err, "http - v1 - AssetsList - .(string)")
+		rt.l.WithContext(r.Context()).Error(err, "http - v1 - AssetsList - .(string)")
 		errorResponse(w, http.StatusInternalServerError, "error getting token claims")
 		return
 	}
 	usr := entity.User{Username: name, Id: int64(id)}
 	assets, err := rt.t.GetAssetsToBuying(r.Context(), usr)
 	if err != nil {
-		rt.l.Error(err, "http - v1 - AssetsList - rt.t.GetAllAssets")
+		rt.l.WithContext(r.Context()).Error(err, "http - v1 - AssetsList - rt.t.GetAllAssets")
 		errorResponse(w, http.StatusInternalServerError, "error getting asset")
 		return
 	}
@@ -246,52 +263,81 @@ func (rt *assetRoutes) AssetsToBuying(w http.ResponseWriter, r *http.Request) {
 }
 
 // @Summary     Buy Asset
-// @Description Allows the user to purchase an asset by its ID.
+// @Description Allows the user to purchase an asset by its ID. Buying a license grants access to the asset, buying a transfer-mode asset makes the user its owner and takes it off the market until relisted.
 // @ID          BuyAsset
 // @Security    ApiKeyAuth
 // @Tags        Asset
 // @Accept      json
 // @Produce     json
 // @Success     200 {object} response "Asset purchased successfully"
-// @Failure     404 {object} response "Asset not found or purchase failed"
+// @Failure     404 {object} response "Asset or promo code not found, or purchase failed"
+// @Failure     409 {object} response "Asset is the user's own, not available for purchase, sold out, already purchased, insufficient funds, or the promo code is expired or does not apply"
 // @Failure     500 {object} response "Internal server error"
 // @Router      /asset/{id}/buy [get]
 // @Param       id path int true "Asset ID to retrieve"
+// @Param       promo_code query string false "Promo code to apply"
 func (rt *assetRoutes) BuyAsset(w http.ResponseWriter, r *http.Request) {
 	idParam := chi.URLParam(r, "id")
 	idAsset, err := strconv.ParseInt(idParam, 10, 64)
 	if err != nil {
-		rt.l.Error(err, "http - v1 - GetAssetById")
+		rt.l.WithContext(r.Context()).Error(err, "http - v1 - GetAssetById")
 		errorResponse(w, http.StatusInternalServerError, "error decoding request parameters")
 		return
 	}
 	_, claims, err := jwtauth.FromContext(r.Context())
 	if err != nil {
-		rt.l.Error(err, "http - v1 - BuyAsset - jwtauth.FromContext")
+		rt.l.WithContext(r.Context()).Error(err, "http - v1 - BuyAsset - jwtauth.FromContext")
 		errorResponse(w, http.StatusInternalServerError, "error getting token claims")
 		return
 	}
 	id, ok := claims["id"].(float64)
 	if !ok {
-		rt.l.Error(err, "http - v1 - BuyAsset - .(float64)")
+		rt.l.WithContext(r.Context()).Error(err, "http - v1 - BuyAsset - .(float64)")
 		errorResponse(w, http.StatusInternalServerError, "error getting token claims")
 		return
 	}
 	name, ok := claims["name"].(string)
 	if !ok {
-		rt.l.Error(err, "http - v1 - BuyAsset - .(string)")
+		rt.l.WithContext(r.Context()).Error(err, "http - v1 - BuyAsset - .(string)")
 		errorResponse(w, http.StatusInternalServerError, "error getting token claims")
 		return
 	}
 	usr := entity.User{Username: name, Id: int64(id)}
-	status, err := rt.t.BuyAsset(r.Context(), usr, idAsset)
+	status, err := rt.t.BuyAsset(r.Context(), usr, idAsset, r.URL.Query().Get("promo_code"))
 	if err != nil {
-		if strings.Contains(err.Error(), "row.Scan: no rows in result set") { // I don't know if this can be considered a bad practice?
-			w.WriteHeader(http.StatusNotFound)
-			json.NewEncoder(w).Encode(response{"Asset not found"})
+		if errors.Is(err, entity.ErrAssetNotFound) {
+			errorResponse(w, http.StatusNotFound, "Asset not found")
 			return
 		}
-		rt.l.Error(err, "http - v1 - BuyAsset - rt.t.BuyAsset")
+		if errors.Is(err, entity.ErrAssetNotAvailable) {
+			errorResponse(w, http.StatusConflict, "Asset is not available for purchase")
+			return
+		}
+		if errors.Is(err, entity.ErrOwnAsset) {
+			errorResponse(w, http.StatusConflict, "You can't buy your own asset")
+			return
+		}
+		if errors.Is(err, entity.ErrAssetAlreadyPurchased) {
+			errorResponse(w, http.StatusConflict, "Asset already purchased")
+			return
+		}
+		if errors.Is(err, entity.ErrAssetSoldOut) {
+			errorResponse(w, http.StatusConflict, "Asset is sold out")
+			return
+		}
+		if errors.Is(err, entity.ErrInsufficientFunds) {
+			errorResponse(w, http.StatusConflict, "Insufficient funds")
+			return
+		}
+		if errors.Is(err, entity.ErrPromoCodeNotFound) {
+			errorResponse(w, http.StatusNotFound, "Promo code not found")
+			return
+		}
+		if errors.Is(err, entity.ErrPromoCodeExpired) || errors.Is(err, entity.ErrPromoCodeNotApplicable) {
+			errorResponse(w, http.StatusConflict, "Promo code is expired or does not apply to the asset")
+			return
+		}
+		rt.l.WithContext(r.Context()).Error(err, "http - v1 - BuyAsset - rt.t.BuyAsset")
 		errorResponse(w, http.StatusInternalServerError, "error buying asset")
 		return
 	}
@@ -318,26 +364,26 @@ func (rt *assetRoutes) BuyAsset(w http.ResponseWriter, r *http.Request) {
 func (rt *assetRoutes) GetPurchasedAsset(w http.ResponseWriter, r *http.Request) {
 	_, claims, err := jwtauth.FromContext(r.Context())
 	if err != nil {
-		rt.l.Error(err, "http - v1 - GetPurchasedAsset - jwtauth.FromContext")
+		rt.l.WithContext(r.Context()).Error(err, "http - v1 - GetPurchasedAsset - jwtauth.FromContext")
 		errorResponse(w, http.StatusInternalServerError, "error getting token claims")
 		return
 	}
 	id, ok := claims["id"].(float64)
 	if !ok {
-		rt.l.Error(err, "http - v1 - GetPurchasedAsset - .(float64)")
+		rt.l.WithContext(r.Context()).Error(err, "http - v1 - GetPurchasedAsset - .(float64)")
 		errorResponse(w, http.StatusInternalServerError, "error getting token claims")
 		return
 	}
 	name, ok := claims["name"].(string)
 	if !ok {
-		rt.l.Error(err, "http - v1 - GetPurchasedAsset - .(string)")
+		rt.l.WithContext(r.Context()).Error(err, "http - v1 - GetPurchasedAsset - .(string)")
 		errorResponse(w, http.StatusInternalServerError, "error getting token claims")
 		return
 	}
 	usr := entity.User{Username: name, Id: int64(id)}
 	assets, err := rt.t.GetPurchasedAssets(r.Context(), usr)
 	if err != nil {
-		rt.l.Error(err, "http - v1 - GetPurchasedAsset - rt.t.GetAllAvaliableAsset")
+		rt.l.WithContext(r.Context()).Error(err, "http - v1 - GetPurchasedAsset - rt.t.GetAllAvaliableAsset")
 		errorResponse(w, http.StatusInternalServerError, "error getting asset")
 		return
 	}
@@ -351,7 +397,7 @@ func (rt *assetRoutes) GetPurchasedAsset(w http.ResponseWriter, r *http.Request)
 }
 
 // @Summary     Get Asset
-// @Description Get an asset from the system based on the provided asset ID.
+// @Description Get an asset from the system based on the provided asset ID. Drafts are visible only to the owner, unlisted and archived assets only to the owner and buyers. Includes the average rating and number of reviews.
 // @ID          GetAsset
 // @Security    ApiKeyAuth
 // @Tags        Asset
@@ -366,11 +412,17 @@ func (rt *assetRoutes) GetAssetById(w http.ResponseWriter, r *http.Request) {
 	idParam := chi.URLParam(r, "id")
 	idAsset, err := strconv.ParseInt(idParam, 10, 64)
 	if err != nil {
-		rt.l.Error(err, "http - v1 - GetAssetById")
+		rt.l.WithContext(r.Context()).Error(err, "http - v1 - GetAssetById")
 		errorResponse(w, http.StatusInternalServerError, "error decoding request parameters")
 		return
 	}
-	asset, err := rt.t.GetAssetById(r.Context(), int64(idAsset))
+	usr, err := claims.User(r.Context())
+	if err != nil {
+		rt.l.WithContext(r.Context()).Error(err, "http - v1 - GetAssetById - claims.User")
+		errorResponse(w, http.StatusInternalServerError, "error getting token claims")
+		return
+	}
+	asset, err := rt.t.GetAssetById(r.Context(), usr, int64(idAsset))
 	if asset.Id <= 0 {
 		w.WriteHeader(http.StatusNotFound)
 		json.NewEncoder(w).Encode(response{"Asset not found"})
@@ -378,8 +430,325 @@ func (rt *assetRoutes) GetAssetById(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
 		json.NewEncoder(w).Encode(asset)
 	} else {
-		rt.l.Error(err, "http - v1 - GetAssetById - rt.t.GetAssetById")
+		rt.l.WithContext(r.Context()).Error(err, "http - v1 - GetAssetById - rt.t.GetAssetById")
 		errorResponse(w, http.StatusInternalServerError, "error getting asset")
 		return
 	}
 }
+
+type changeAssetStatusRequest struct {
+	Status entity.AssetStatus `json:"status" enums:"draft,published,unlisted,archived"`
+}
+
+// @Summary     Change Asset Status
+// @Description Moves the user's asset through its lifecycle: draft -> published/unlisted/archived, published <-> unlisted, any -> archived.
+// @ID          ChangeAssetStatus
+// @Security    ApiKeyAuth
+// @Tags        Asset
+// @Accept      json
+// @Produce     json
+// @Success     200 {object} response "Asset status changed successfully"
+// @Failure     400 {object} response "Invalid asset status"
+// @Failure     404 {object} response "Asset not found"
+// @Failure     409 {object} response "Transition is not allowed"
+// @Failure     500 {object} response "Internal server error"
+// @Router      /asset/{id}/status [patch]
+// @Param       id path int true "Asset ID"
+// @Param       request body changeAssetStatusRequest true "New asset status"
+func (rt *assetRoutes) ChangeAssetStatus(w http.ResponseWriter, r *http.Request) {
+	idAsset, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		rt.l.WithContext(r.Context()).Error(err, "http - v1 - ChangeAssetStatus")
+		errorResponse(w, http.StatusInternalServerError, "error decoding request parameters")
+		return
+	}
+	req := changeAssetStatusRequest{}
+	err = json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		rt.l.WithContext(r.Context()).Error(err, "http - v1 - ChangeAssetStatus - decoder.Decode")
+		errorResponse(w, http.StatusInternalServerError, "error decoding request body")
+		return
+	}
+	if !req.Status.Valid() {
+		errorResponse(w, http.StatusBadRequest, "invalid asset status")
+		return
+	}
+	usr, err := claims.User(r.Context())
+	if err != nil {
+		rt.l.WithContext(r.Context()).Error(err, "http - v1 - ChangeAssetStatus - claims.User")
+		errorResponse(w, http.StatusInternalServerError, "error getting token claims")
+		return
+	}
+	status, err := rt.t.ChangeAssetStatus(r.Context(), usr, idAsset, req.Status)
+	if err != nil {
+		if errors.Is(err, entity.ErrInvalidStatusTransition) {
+			errorResponse(w, http.StatusConflict, "transition is not allowed")
+			return
+		}
+		rt.l.WithContext(r.Context()).Error(err, "http - v1 - ChangeAssetStatus - rt.t.ChangeAssetStatus")
+		errorResponse(w, http.StatusInternalServerError, "error changing asset status")
+		return
+	}
+	if status {
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(response{"Asset status changed successfully"})
+	} else {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(response{"Asset not found"})
+	}
+}
+
+type changeAssetPriceRequest struct {
+	Price float64 `json:"price"`
+}
+
+// @Summary     Change Asset Price
+// @Description Sets a new price of the user's asset. Users who have the asset on their wishlist are notified when the price goes down.
+// @ID          ChangeAssetPrice
+// @Security    ApiKeyAuth
+// @Tags        Asset
+// @Accept      json
+// @Produce     json
+// @Success     200 {object} response "Asset price changed successfully"
+// @Failure     400 {object} response "Price can't be negative"
+// @Failure     404 {object} response "Asset not found"
+// @Failure     500 {object} response "Internal server error"
+// @Router      /asset/{id}/price [patch]
+// @Param       id path int true "Asset ID"
+// @Param       request body changeAssetPriceRequest true "New asset price"
+func (rt *assetRoutes) ChangeAssetPrice(w http.ResponseWriter, r *http.Request) {
+	idAsset, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		rt.l.WithContext(r.Context()).Error(err, "http - v1 - ChangeAssetPrice")
+		errorResponse(w, http.StatusInternalServerError, "error decoding request parameters")
+		return
+	}
+	req := changeAssetPriceRequest{}
+	err = json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		rt.l.WithContext(r.Context()).Error(err, "http - v1 - ChangeAssetPrice - decoder.Decode")
+		errorResponse(w, http.StatusInternalServerError, "error decoding request body")
+		return
+	}
+	if req.Price < 0 {
+		errorResponse(w, http.StatusBadRequest, "price can't be negative")
+		return
+	}
+	usr, err := claims.User(r.Context())
+	if err != nil {
+		rt.l.WithContext(r.Context()).Error(err, "http - v1 - ChangeAssetPrice - claims.User")
+		errorResponse(w, http.StatusInternalServerError, "error getting token claims")
+		return
+	}
+	status, err := rt.t.ChangeAssetPrice(r.Context(), usr, idAsset, req.Price)
+	if err != nil {
+		rt.l.WithContext(r.Context()).Error(err, "http - v1 - ChangeAssetPrice - rt.t.ChangeAssetPrice")
+		errorResponse(w, http.StatusInternalServerError, "error changing asset price")
+		return
+	}
+	if !status {
+		errorResponse(w, http.StatusNotFound, "Asset not found")
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response{"Asset price changed successfully"})
+}
+
+type provenanceResponse struct {
+	Transfers []entity.Purchase `json:"transfers"`
+}
+
+// @Summary     Get Asset Provenance
+// @Description Retrieves the chain of ownership transfers of an asset, oldest first.
+// @ID          AssetProvenance
+// @Security    ApiKeyAuth
+// @Tags        Asset
+// @Accept      json
+// @Produce     json
+// @Success     200 {object} provenanceResponse "Ownership transfers of the asset"
+// @Failure     404 {object} response "Asset not found"
+// @Failure     500 {object} response "Internal server error"
+// @Router      /asset/{id}/provenance [get]
+// @Param       id path int true "Asset ID"
+func (rt *assetRoutes) GetProvenance(w http.ResponseWriter, r *http.Request) {
+	idAsset, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		rt.l.WithContext(r.Context()).Error(err, "http - v1 - GetProvenance")
+		errorResponse(w, http.StatusInternalServerError, "error decoding request parameters")
+		return
+	}
+	usr, err := claims.User(r.Context())
+	if err != nil {
+		rt.l.WithContext(r.Context()).Error(err, "http - v1 - GetProvenance - claims.User")
+		errorResponse(w, http.StatusInternalServerError, "error getting token claims")
+		return
+	}
+	transfers, err := rt.t.GetProvenance(r.Context(), usr, idAsset)
+	if err != nil {
+		if errors.Is(err, entity.ErrAssetNotFound) {
+			errorResponse(w, http.StatusNotFound, "Asset not found")
+			return
+		}
+		rt.l.WithContext(r.Context()).Error(err, "http - v1 - GetProvenance - rt.t.GetProvenance")
+		errorResponse(w, http.StatusInternalServerError, "error getting provenance")
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(provenanceResponse{transfers})
+}
+
+// @Summary     Renew Asset Access
+// @Description Buys another access period of a rented asset at its current price. The period is added to the end of the current one, or starts now if it has lapsed.
+// @ID          RenewAccess
+// @Security    ApiKeyAuth
+// @Tags        Asset
+// @Accept      json
+// @Produce     json
+// @Success     200 {object} entity.Purchase "Access renewed"
+// @Failure     404 {object} response "Asset not found or the user has no access to it"
+// @Failure     409 {object} response "Access is permanent, asset is unavailable or insufficient funds"
+// @Failure     500 {object} response "Internal server error"
+// @Router      /asset/{id}/renew [post]
+// @Param       id path int true "Asset ID"
+func (rt *assetRoutes) RenewAccess(w http.ResponseWriter, r *http.Request) {
+	idAsset, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		rt.l.WithContext(r.Context()).Error(err, "http - v1 - RenewAccess")
+		errorResponse(w, http.StatusInternalServerError, "error decoding request parameters")
+		return
+	}
+	usr, err := claims.User(r.Context())
+	if err != nil {
+		rt.l.WithContext(r.Context()).Error(err, "http - v1 - RenewAccess - claims.User")
+		errorResponse(w, http.StatusInternalServerError, "error getting token claims")
+		return
+	}
+	p, err := rt.t.RenewAccess(r.Context(), usr, idAsset)
+	if err != nil {
+		switch {
+		case errors.Is(err, entity.ErrAssetNotFound):
+			errorResponse(w, http.StatusNotFound, "Asset not found")
+		case errors.Is(err, entity.ErrAccessNotFound):
+			errorResponse(w, http.StatusNotFound, "You have no access to the asset")
+		case errors.Is(err, entity.ErrAssetNotRenewable):
+			errorResponse(w, http.StatusConflict, "Access to the asset is permanent")
+		case errors.Is(err, entity.ErrAssetNotAvailable):
+			errorResponse(w, http.StatusConflict, "Asset is not available")
+		case errors.Is(err, entity.ErrInsufficientFunds):
+			errorResponse(w, http.StatusConflict, "Insufficient funds")
+		default:
+			rt.l.WithContext(r.Context()).Error(err, "http - v1 - RenewAccess - rt.t.RenewAccess")
+			errorResponse(w, http.StatusInternalServerError, "error renewing access")
+		}
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(p)
+}
+
+type giftAssetRequest struct {
+	Recipient string `json:"recipient" example:"friend"` // username
+	Message   string `json:"message,omitempty"`
+}
+
+// @Summary     Gift Asset
+// @Description Buys an asset for another user. The user is charged, the recipient gets the asset and the purchase shows up in the history of both.
+// @ID          GiftAsset
+// @Security    ApiKeyAuth
+// @Tags        Asset
+// @Accept      json
+// @Produce     json
+// @Success     200 {object} entity.Purchase "Asset gifted"
+// @Failure     400 {object} response "Invalid recipient or message is too long"
+// @Failure     404 {object} response "Asset or recipient not found"
+// @Failure     409 {object} response "Asset is the user's own, not available, sold out, already owned by the recipient, or insufficient funds"
+// @Failure     500 {object} response "Internal server error"
+// @Router      /asset/{id}/gift [post]
+// @Param       id path int true "Asset ID"
+// @Param       request body giftAssetRequest true "Recipient username and an optional message"
+func (rt *assetRoutes) GiftAsset(w http.ResponseWriter, r *http.Request) {
+	idAsset, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		rt.l.WithContext(r.Context()).Error(err, "http - v1 - GiftAsset")
+		errorResponse(w, http.StatusInternalServerError, "error decoding request parameters")
+		return
+	}
+	req := giftAssetRequest{}
+	err = json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		rt.l.WithContext(r.Context()).Error(err, "http - v1 - GiftAsset - decoder.Decode")
+		errorResponse(w, http.StatusInternalServerError, "error decoding request body")
+		return
+	}
+	usr, err := claims.User(r.Context())
+	if err != nil {
+		rt.l.WithContext(r.Context()).Error(err, "http - v1 - GiftAsset - claims.User")
+		errorResponse(w, http.StatusInternalServerError, "error getting token claims")
+		return
+	}
+	req.Recipient = strings.TrimSpace(req.Recipient)
+	if req.Recipient == "" || req.Recipient == usr.Username {
+		errorResponse(w, http.StatusBadRequest, "recipient should be another user")
+		return
+	}
+	if utf8.RuneCountInString(strings.TrimSpace(req.Message)) > 500 {
+		errorResponse(w, http.StatusBadRequest, "message should be at most 500 characters")
+		return
+	}
+	p, err := rt.t.GiftAsset(r.Context(), usr, idAsset, req.Recipient, req.Message)
+	if err != nil {
+		switch {
+		case errors.Is(err, entity.ErrAssetNotFound):
+			errorResponse(w, http.StatusNotFound, "Asset not found")
+		case errors.Is(err, entity.ErrRecipientNotFound):
+			errorResponse(w, http.StatusNotFound, "Recipient not found")
+		case errors.Is(err, entity.ErrRecipientOwnsAsset):
+			errorResponse(w, http.StatusConflict, "Recipient already owns the asset")
+		case errors.Is(err, entity.ErrAssetNotAvailable):
+			errorResponse(w, http.StatusConflict, "Asset is not available for purchase")
+		case errors.Is(err, entity.ErrOwnAsset):
+			errorResponse(w, http.StatusConflict, "You can't gift your own asset")
+		case errors.Is(err, entity.ErrAssetSoldOut):
+			errorResponse(w, http.StatusConflict, "Asset is sold out")
+		case errors.Is(err, entity.ErrInsufficientFunds):
+			errorResponse(w, http.StatusConflict, "Insufficient funds")
+		default:
+			rt.l.WithContext(r.Context()).Error(err, "http - v1 - GiftAsset - rt.t.GiftAsset")
+			errorResponse(w, http.StatusInternalServerError, "error gifting asset")
+		}
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(p)
+}
+
+type purchaseHistoryResponse struct {
+	Purchases []entity.Purchase `json:"purchases"`
+}
+
+// @Summary     Get Purchase History
+// @Description Retrieves the purchases the user paid for, including gifts sent, and the gifts they received, newest first.
+// @ID          PurchaseHistory
+// @Security    ApiKeyAuth
+// @Tags        Asset
+// @Accept      json
+// @Produce     json
+// @Success     200 {object} purchaseHistoryResponse "Purchase history of the user"
+// @Failure     500 {object} response "Internal server error"
+// @Router      /asset/history [get]
+func (rt *assetRoutes) GetPurchaseHistory(w http.ResponseWriter, r *http.Request) {
+	usr, err := claims.User(r.Context())
+	if err != nil {
+		rt.l.WithContext(r.Context()).Error(err, "http - v1 - GetPurchaseHistory - claims.User")
+		errorResponse(w, http.StatusInternalServerError, "error getting token claims")
+		return
+	}
+	history, err := rt.t.GetPurchaseHistory(r.Context(), usr)
+	if err != nil {
+		rt.l.WithContext(r.Context()).Error(err, "http - v1 - GetPurchaseHistory - rt.t.GetPurchaseHistory")
+		errorResponse(w, http.StatusInternalServerError, "error getting purchase history")
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(purchaseHistoryResponse{history})
+}
@@ -0,0 +1,253 @@
+package v1
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Klef99/bhs-task/internal/controller/claims"
+	"github.com/Klef99/bhs-task/internal/entity"
+	"github.com/Klef99/bhs-task/internal/usecase"
+	"github.com/Klef99/bhs-task/pkg/jwtgenerator"
+	"github.com/Klef99/bhs-task/pkg/logger"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/jwtauth/v5"
+)
+
+type auctionRoutes struct {
+	a   usecase.Auction
+	l   logger.Interface
+	jtg jwtgenerator.Interface
+}
+
+func NewAuctionRoutes(handler chi.Router, a usecase.Auction, l logger.Interface, jtg jwtgenerator.Interface) {
+	rt := &auctionRoutes{a: a, l: l, jtg: jtg}
+	tokenAuth := rt.jtg.GetJWTAuth()
+	handler.Group(func(r chi.Router) {
+		r.Use(jwtauth.Verifier(tokenAuth))
+		r.Use(jwtauth.Authenticator(tokenAuth))
+		r.Post("/asset/{id}/auction", rt.OpenAuction)
+		r.Get("/asset/{id}/auction", rt.GetAuction)
+		r.Post("/asset/{id}/bids", rt.PlaceBid)
+		r.Get("/asset/{id}/bids", rt.GetBids)
+	})
+}
+
+type openAuctionRequest struct {
+	ReservePrice float64   `json:"reserve_price"`
+	EndAt        time.Time `json:"end_at" example:"2024-12-31T18:00:00Z"`
+}
+
+// @Summary     Open Auction
+// @Description Puts the user's published asset up for auction until the given end time. When it ends, the highest bid meeting the reserve price buys the asset.
+// @ID          OpenAuction
+// @Security    ApiKeyAuth
+// @Tags        Auction
+// @Accept      json
+// @Produce     json
+// @Success     200 {object} entity.Auction "Auction opened"
+// @Failure     400 {object} response "Invalid reserve price or end time"
+// @Failure     404 {object} response "Asset not found"
+// @Failure     409 {object} response "Asset is not published or already on auction"
+// @Failure     500 {object} response "Internal server error"
+// @Router      /asset/{id}/auction [post]
+// @Param       id path int true "Asset ID"
+// @Param       request body openAuctionRequest true "Reserve price and end time (RFC 3339)"
+func (rt *auctionRoutes) OpenAuction(w http.ResponseWriter, r *http.Request) {
+	idAsset, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		rt.l.WithContext(r.Context()).Error(err, "http - v1 - OpenAuction")
+		errorResponse(w, http.StatusInternalServerError, "error decoding request parameters")
+		return
+	}
+	req := openAuctionRequest{}
+	err = json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		rt.l.WithContext(r.Context()).Error(err, "http - v1 - OpenAuction - decoder.Decode")
+		errorResponse(w, http.StatusInternalServerError, "error decoding request body")
+		return
+	}
+	if req.ReservePrice < 0 || !req.EndAt.After(time.Now()) {
+		errorResponse(w, http.StatusBadRequest, "reserve price can't be negative and end time must be in the future")
+		return
+	}
+	usr, err := claims.User(r.Context())
+	if err != nil {
+		rt.l.WithContext(r.Context()).Error(err, "http - v1 - OpenAuction - claims.User")
+		errorResponse(w, http.StatusInternalServerError, "error getting token claims")
+		return
+	}
+	auction, err := rt.a.OpenAuction(r.Context(), usr, idAsset, req.ReservePrice, req.EndAt)
+	if err != nil {
+		switch {
+		case errors.Is(err, entity.ErrAssetNotFound):
+			errorResponse(w, http.StatusNotFound, "Asset not found")
+		case errors.Is(err, entity.ErrAssetNotAvailable):
+			errorResponse(w, http.StatusConflict, "Only published assets can be auctioned")
+		case errors.Is(err, entity.ErrAuctionExists):
+			errorResponse(w, http.StatusConflict, "Asset is already on auction")
+		default:
+			rt.l.WithContext(r.Context()).Error(err, "http - v1 - OpenAuction - rt.a.OpenAuction")
+			errorResponse(w, http.StatusInternalServerError, "error opening auction")
+		}
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(auction)
+}
+
+// @Summary     Get Auction
+// @Description Retrieves the current or the last auction of an asset with its highest bid.
+// @ID          GetAuction
+// @Security    ApiKeyAuth
+// @Tags        Auction
+// @Accept      json
+// @Produce     json
+// @Success     200 {object} entity.Auction "Auction of the asset"
+// @Failure     404 {object} response "Asset or auction not found"
+// @Failure     500 {object} response "Internal server error"
+// @Router      /asset/{id}/auction [get]
+// @Param       id path int true "Asset ID"
+func (rt *auctionRoutes) GetAuction(w http.ResponseWriter, r *http.Request) {
+	idAsset, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		rt.l.WithContext(r.Context()).Error(err, "http - v1 - GetAuction")
+		errorResponse(w, http.StatusInternalServerError, "error decoding request parameters")
+		return
+	}
+	usr, err := claims.User(r.Context())
+	if err != nil {
+		rt.l.WithContext(r.Context()).Error(err, "http - v1 - GetAuction - claims.User")
+		errorResponse(w, http.StatusInternalServerError, "error getting token claims")
+		return
+	}
+	auction, err := rt.a.GetAuction(r.Context(), usr, idAsset)
+	if err != nil {
+		if errors.Is(err, entity.ErrAssetNotFound) {
+			errorResponse(w, http.StatusNotFound, "Asset not found")
+			return
+		}
+		if errors.Is(err, entity.ErrAuctionNotFound) {
+			errorResponse(w, http.StatusNotFound, "Auction not found")
+			return
+		}
+		rt.l.WithContext(r.Context()).Error(err, "http - v1 - GetAuction - rt.a.GetAuction")
+		errorResponse(w, http.StatusInternalServerError, "error getting auction")
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(auction)
+}
+
+type placeBidRequest struct {
+	Amount float64 `json:"amount"`
+}
+
+// @Summary     Place Bid
+// @Description Bids on the open auction of an asset. The amount is held from the user's balance and returned once they are outbid or the auction ends unsold.
+// @ID          PlaceBid
+// @Security    ApiKeyAuth
+// @Tags        Auction
+// @Accept      json
+// @Produce     json
+// @Success     200 {object} entity.Bid "Bid placed"
+// @Failure     400 {object} response "Amount must be positive"
+// @Failure     404 {object} response "No open auction for the asset"
+// @Failure     409 {object} response "Auction is closed, bid is too low or insufficient funds"
+// @Failure     500 {object} response "Internal server error"
+// @Router      /asset/{id}/bids [post]
+// @Param       id path int true "Asset ID"
+// @Param       request body placeBidRequest true "Bid amount"
+func (rt *auctionRoutes) PlaceBid(w http.ResponseWriter, r *http.Request) {
+	idAsset, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		rt.l.WithContext(r.Context()).Error(err, "http - v1 - PlaceBid")
+		errorResponse(w, http.StatusInternalServerError, "error decoding request parameters")
+		return
+	}
+	req := placeBidRequest{}
+	err = json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		rt.l.WithContext(r.Context()).Error(err, "http - v1 - PlaceBid - decoder.Decode")
+		errorResponse(w, http.StatusInternalServerError, "error decoding request body")
+		return
+	}
+	if req.Amount <= 0 {
+		errorResponse(w, http.StatusBadRequest, "amount should be positive")
+		return
+	}
+	usr, err := claims.User(r.Context())
+	if err != nil {
+		rt.l.WithContext(r.Context()).Error(err, "http - v1 - PlaceBid - claims.User")
+		errorResponse(w, http.StatusInternalServerError, "error getting token claims")
+		return
+	}
+	bid, err := rt.a.PlaceBid(r.Context(), usr, idAsset, req.Amount)
+	if err != nil {
+		switch {
+		case errors.Is(err, entity.ErrAuctionNotFound):
+			errorResponse(w, http.StatusNotFound, "No open auction for the asset")
+		case errors.Is(err, entity.ErrAuctionClosed):
+			errorResponse(w, http.StatusConflict, "Auction is closed")
+		case errors.Is(err, entity.ErrBidTooLow):
+			errorResponse(w, http.StatusConflict, "Bid must be higher than the current highest bid")
+		case errors.Is(err, entity.ErrInsufficientFunds):
+			errorResponse(w, http.StatusConflict, "Insufficient funds")
+		default:
+			rt.l.WithContext(r.Context()).Error(err, "http - v1 - PlaceBid - rt.a.PlaceBid")
+			errorResponse(w, http.StatusInternalServerError, "error placing bid")
+		}
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(bid)
+}
+
+type listOfBidsResponse struct {
+	Bids []entity.Bid `json:"bids"`
+}
+
+// @Summary     List Bids
+// @Description Retrieves the bids of the current or the last auction of an asset, highest first.
+// @ID          ListBids
+// @Security    ApiKeyAuth
+// @Tags        Auction
+// @Accept      json
+// @Produce     json
+// @Success     200 {object} listOfBidsResponse "Bids of the auction"
+// @Failure     404 {object} response "Asset or auction not found"
+// @Failure     500 {object} response "Internal server error"
+// @Router      /asset/{id}/bids [get]
+// @Param       id path int true "Asset ID"
+func (rt *auctionRoutes) GetBids(w http.ResponseWriter, r *http.Request) {
+	idAsset, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		rt.l.WithContext(r.Context()).Error(err, "http - v1 - GetBids")
+		errorResponse(w, http.StatusInternalServerError, "error decoding request parameters")
+		return
+	}
+	usr, err := claims.User(r.Context())
+	if err != nil {
+		rt.l.WithContext(r.Context()).Error(err, "http - v1 - GetBids - claims.User")
+		errorResponse(w, http.StatusInternalServerError, "error getting token claims")
+		return
+	}
+	bids, err := rt.a.GetBids(r.Context(), usr, idAsset)
+	if err != nil {
+		if errors.Is(err, entity.ErrAssetNotFound) {
+			errorResponse(w, http.StatusNotFound, "Asset not found")
+			return
+		}
+		if errors.Is(err, entity.ErrAuctionNotFound) {
+			errorResponse(w, http.StatusNotFound, "Auction not found")
+			return
+		}
+		rt.l.WithContext(r.Context()).Error(err, "http - v1 - GetBids - rt.a.GetBids")
+		errorResponse(w, http.StatusInternalServerError, "error getting bids")
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(listOfBidsResponse{bids})
+}
@@ -0,0 +1,203 @@
+package v1
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/Klef99/bhs-task/internal/controller/claims"
+	"github.com/Klef99/bhs-task/internal/entity"
+	"github.com/Klef99/bhs-task/internal/usecase"
+	"github.com/Klef99/bhs-task/pkg/jwtgenerator"
+	"github.com/Klef99/bhs-task/pkg/logger"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/jwtauth/v5"
+)
+
+type cartRoutes struct {
+	c   usecase.Cart
+	l   logger.Interface
+	jtg jwtgenerator.Interface
+}
+
+func NewCartRoutes(handler chi.Router, c usecase.Cart, l logger.Interface, jtg jwtgenerator.Interface) {
+	rt := &cartRoutes{c: c, l: l, jtg: jtg}
+	tokenAuth := rt.jtg.GetJWTAuth()
+	router := chi.NewRouter()
+	router.Use(jwtauth.Verifier(tokenAuth))
+	router.Use(jwtauth.Authenticator(tokenAuth))
+	router.Group(func(r chi.Router) {
+		r.Get("/", rt.GetCart)
+		r.Post("/", rt.AddToCart)
+		r.Delete("/{id}", rt.RemoveFromCart)
+		r.Post("/checkout", rt.Checkout)
+	})
+	handler.Mount("/cart", router)
+}
+
+type addToCartRequest struct {
+	AssetId int64 `json:"asset_id"`
+}
+
+// @Summary     Get Cart
+// @Description Retrieves the assets in the user's cart with their current prices, availability and total.
+// @ID          GetCart
+// @Security    ApiKeyAuth
+// @Tags        Cart
+// @Accept      json
+// @Produce     json
+// @Success     200 {object} entity.Cart "Cart of the user"
+// @Failure     500 {object} response "Internal server error"
+// @Router      /cart [get]
+func (rt *cartRoutes) GetCart(w http.ResponseWriter, r *http.Request) {
+	usr, err := claims.User(r.Context())
+	if err != nil {
+		rt.l.WithContext(r.Context()).Error(err, "http - v1 - GetCart - claims.User")
+		errorResponse(w, http.StatusInternalServerError, "error getting token claims")
+		return
+	}
+	cart, err := rt.c.GetCart(r.Context(), usr)
+	if err != nil {
+		rt.l.WithContext(r.Context()).Error(err, "http - v1 - GetCart - rt.c.GetCart")
+		errorResponse(w, http.StatusInternalServerError, "error getting cart")
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(cart)
+}
+
+// @Summary     Add To Cart
+// @Description Puts a published asset into the user's cart. Adding an asset that is already in the cart has no effect.
+// @ID          AddToCart
+// @Security    ApiKeyAuth
+// @Tags        Cart
+// @Accept      json
+// @Produce     json
+// @Success     200 {object} response "Asset added to cart"
+// @Failure     400 {object} response "Invalid asset id"
+// @Failure     404 {object} response "Asset not found"
+// @Failure     409 {object} response "Asset is not available or already purchased"
+// @Failure     500 {object} response "Internal server error"
+// @Router      /cart [post]
+// @Param       request body addToCartRequest true "Asset to add"
+func (rt *cartRoutes) AddToCart(w http.ResponseWriter, r *http.Request) {
+	req := addToCartRequest{}
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		rt.l.WithContext(r.Context()).Error(err, "http - v1 - AddToCart - decoder.Decode")
+		errorResponse(w, http.StatusInternalServerError, "error decoding request body")
+		return
+	}
+	if req.AssetId <= 0 {
+		errorResponse(w, http.StatusBadRequest, "asset id should be positive")
+		return
+	}
+	usr, err := claims.User(r.Context())
+	if err != nil {
+		rt.l.WithContext(r.Context()).Error(err, "http - v1 - AddToCart - claims.User")
+		errorResponse(w, http.StatusInternalServerError, "error getting token claims")
+		return
+	}
+	_, err = rt.c.AddToCart(r.Context(), usr, req.AssetId)
+	if err != nil {
+		switch {
+		case errors.Is(err, entity.ErrAssetNotFound):
+			errorResponse(w, http.StatusNotFound, "Asset not found")
+		case errors.Is(err, entity.ErrAssetNotAvailable):
+			errorResponse(w, http.StatusConflict, "Asset is not available for purchase")
+		case errors.Is(err, entity.ErrAssetAlreadyPurchased):
+			errorResponse(w, http.StatusConflict, "Asset already purchased")
+		default:
+			rt.l.WithContext(r.Context()).Error(err, "http - v1 - AddToCart - rt.c.AddToCart")
+			errorResponse(w, http.StatusInternalServerError, "error adding asset to cart")
+		}
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response{"Asset added to cart"})
+}
+
+// @Summary     Remove From Cart
+// @Description Removes an asset from the user's cart.
+// @ID          RemoveFromCart
+// @Security    ApiKeyAuth
+// @Tags        Cart
+// @Accept      json
+// @Produce     json
+// @Success     200 {object} response "Asset removed from cart"
+// @Failure     404 {object} response "Asset is not in the cart"
+// @Failure     500 {object} response "Internal server error"
+// @Router      /cart/{id} [delete]
+// @Param       id path int true "Asset ID"
+func (rt *cartRoutes) RemoveFromCart(w http.ResponseWriter, r *http.Request) {
+	idAsset, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		rt.l.WithContext(r.Context()).Error(err, "http - v1 - RemoveFromCart")
+		errorResponse(w, http.StatusInternalServerError, "error decoding request parameters")
+		return
+	}
+	usr, err := claims.User(r.Context())
+	if err != nil {
+		rt.l.WithContext(r.Context()).Error(err, "http - v1 - RemoveFromCart - claims.User")
+		errorResponse(w, http.StatusInternalServerError, "error getting token claims")
+		return
+	}
+	status, err := rt.c.RemoveFromCart(r.Context(), usr, idAsset)
+	if err != nil {
+		rt.l.WithContext(r.Context()).Error(err, "http - v1 - RemoveFromCart - rt.c.RemoveFromCart")
+		errorResponse(w, http.StatusInternalServerError, "error removing asset from cart")
+		return
+	}
+	if !status {
+		errorResponse(w, http.StatusNotFound, "Asset is not in the cart")
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response{"Asset removed from cart"})
+}
+
+// @Summary     Checkout
+// @Description Buys every asset in the cart in a single transaction and empties the cart. Either all assets are purchased or none.
+// @ID          Checkout
+// @Security    ApiKeyAuth
+// @Tags        Cart
+// @Accept      json
+// @Produce     json
+// @Success     200 {object} entity.Receipt "Receipt of the checkout"
+// @Failure     404 {object} response "Promo code not found"
+// @Failure     409 {object} response "Cart is empty, an asset is not available, sold out or already purchased, insufficient funds, or the promo code is expired or applies to no asset"
+// @Failure     500 {object} response "Internal server error"
+// @Router      /cart/checkout [post]
+// @Param       promo_code query string false "Promo code to apply"
+func (rt *cartRoutes) Checkout(w http.ResponseWriter, r *http.Request) {
+	usr, err := claims.User(r.Context())
+	if err != nil {
+		rt.l.WithContext(r.Context()).Error(err, "http - v1 - Checkout - claims.User")
+		errorResponse(w, http.StatusInternalServerError, "error getting token claims")
+		return
+	}
+	receipt, err := rt.c.Checkout(r.Context(), usr, r.URL.Query().Get("promo_code"))
+	if err != nil {
+		switch {
+		case errors.Is(err, entity.ErrCartEmpty):
+			errorResponse(w, http.StatusConflict, "Cart is empty")
+		case errors.Is(err, entity.ErrAssetNotAvailable), errors.Is(err, entity.ErrAssetSoldOut):
+			errorResponse(w, http.StatusConflict, "An asset in the cart is not available for purchase")
+		case errors.Is(err, entity.ErrAssetAlreadyPurchased):
+			errorResponse(w, http.StatusConflict, "An asset in the cart is already purchased")
+		case errors.Is(err, entity.ErrInsufficientFunds):
+			errorResponse(w, http.StatusConflict, "Insufficient funds")
+		case errors.Is(err, entity.ErrPromoCodeNotFound):
+			errorResponse(w, http.StatusNotFound, "Promo code not found")
+		case errors.Is(err, entity.ErrPromoCodeExpired), errors.Is(err, entity.ErrPromoCodeNotApplicable):
+			errorResponse(w, http.StatusConflict, "Promo code is expired or does not apply to the cart")
+		default:
+			rt.l.WithContext(r.Context()).Error(err, "http - v1 - Checkout - rt.c.Checkout")
+			errorResponse(w, http.StatusInternalServerError, "error checking out")
+		}
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(receipt)
+}
@@ -0,0 +1,106 @@
+package v1
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/Klef99/bhs-task/internal/controller/claims"
+	"github.com/Klef99/bhs-task/internal/entity"
+	"github.com/Klef99/bhs-task/internal/usecase"
+	"github.com/Klef99/bhs-task/pkg/jwtgenerator"
+	"github.com/Klef99/bhs-task/pkg/logger"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/jwtauth/v5"
+)
+
+type notificationRoutes struct {
+	n   usecase.Notification
+	l   logger.Interface
+	jtg jwtgenerator.Interface
+}
+
+func NewNotificationRoutes(handler chi.Router, n usecase.Notification, l logger.Interface, jtg jwtgenerator.Interface) {
+	rt := &notificationRoutes{n: n, l: l, jtg: jtg}
+	tokenAuth := rt.jtg.GetJWTAuth()
+	router := chi.NewRouter()
+	router.Use(jwtauth.Verifier(tokenAuth))
+	router.Use(jwtauth.Authenticator(tokenAuth))
+	router.Group(func(r chi.Router) {
+		r.Get("/", rt.GetNotifications)
+		r.Post("/{id}/read", rt.MarkRead)
+	})
+	handler.Mount("/notifications", router)
+}
+
+type listOfNotificationsResponse struct {
+	Notifications []entity.Notification `json:"notifications"`
+}
+
+// @Summary     List Notifications
+// @Description Retrieves the user's in-app notifications, newest first.
+// @ID          ListNotifications
+// @Security    ApiKeyAuth
+// @Tags        Notification
+// @Accept      json
+// @Produce     json
+// @Success     200 {object} listOfNotificationsResponse "Notifications of the user"
+// @Failure     500 {object} response "Internal server error"
+// @Router      /notifications [get]
+// @Param       unread query bool false "Only unread notifications"
+func (rt *notificationRoutes) GetNotifications(w http.ResponseWriter, r *http.Request) {
+	usr, err := claims.User(r.Context())
+	if err != nil {
+		rt.l.WithContext(r.Context()).Error(err, "http - v1 - GetNotifications - claims.User")
+		errorResponse(w, http.StatusInternalServerError, "error getting token claims")
+		return
+	}
+	unreadOnly, _ := strconv.ParseBool(r.URL.Query().Get("unread"))
+	notifications, err := rt.n.GetNotifications(r.Context(), usr, unreadOnly)
+	if err != nil {
+		rt.l.WithContext(r.Context()).Error(err, "http - v1 - GetNotifications - rt.n.GetNotifications")
+		errorResponse(w, http.StatusInternalServerError, "error getting notifications")
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(listOfNotificationsResponse{notifications})
+}
+
+// @Summary     Mark Notification Read
+// @Description Marks one of the user's notifications as read.
+// @ID          MarkNotificationRead
+// @Security    ApiKeyAuth
+// @Tags        Notification
+// @Accept      json
+// @Produce     json
+// @Success     200 {object} response "Notification marked as read"
+// @Failure     404 {object} response "Notification not found"
+// @Failure     500 {object} response "Internal server error"
+// @Router      /notifications/{id}/read [post]
+// @Param       id path int true "Notification ID"
+func (rt *notificationRoutes) MarkRead(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		rt.l.WithContext(r.Context()).Error(err, "http - v1 - MarkRead")
+		errorResponse(w, http.StatusInternalServerError, "error decoding request parameters")
+		return
+	}
+	usr, err := claims.User(r.Context())
+	if err != nil {
+		rt.l.WithContext(r.Context()).Error(err, "http - v1 - MarkRead - claims.User")
+		errorResponse(w, http.StatusInternalServerError, "error getting token claims")
+		return
+	}
+	status, err := rt.n.MarkRead(r.Context(), usr, id)
+	if err != nil {
+		rt.l.WithContext(r.Context()).Error(err, "http - v1 - MarkRead - rt.n.MarkRead")
+		errorResponse(w, http.StatusInternalServerError, "error marking notification as read")
+		return
+	}
+	if !status {
+		errorResponse(w, http.StatusNotFound, "Notification not found")
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response{"Notification marked as read"})
+}
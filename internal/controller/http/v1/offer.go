@@ -0,0 +1,342 @@
+package v1
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/Klef99/bhs-task/internal/controller/claims"
+	"github.com/Klef99/bhs-task/internal/entity"
+	"github.com/Klef99/bhs-task/internal/usecase"
+	"github.com/Klef99/bhs-task/pkg/jwtgenerator"
+	"github.com/Klef99/bhs-task/pkg/logger"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/jwtauth/v5"
+)
+
+type offerRoutes struct {
+	o   usecase.Offer
+	l   logger.Interface
+	jtg jwtgenerator.Interface
+}
+
+func NewOfferRoutes(handler chi.Router, o usecase.Offer, l logger.Interface, jtg jwtgenerator.Interface) {
+	rt := &offerRoutes{o: o, l: l, jtg: jtg}
+	tokenAuth := rt.jtg.GetJWTAuth()
+	handler.Group(func(r chi.Router) {
+		r.Use(jwtauth.Verifier(tokenAuth))
+		r.Use(jwtauth.Authenticator(tokenAuth))
+		r.Post("/asset/{id}/offers", rt.MakeOffer)
+		r.Get("/asset/{id}/offers", rt.GetAssetOffers)
+		r.Get("/offers/inbox", rt.GetInbox)
+		r.Get("/offers/outbox", rt.GetOutbox)
+		r.Post("/offers/{id}/accept", rt.AcceptOffer)
+		r.Post("/offers/{id}/reject", rt.RejectOffer)
+		r.Post("/offers/{id}/counter", rt.CounterOffer)
+	})
+}
+
+type offerPriceRequest struct {
+	Price float64 `json:"price"`
+}
+
+type listOfOffersResponse struct {
+	Offers []entity.Offer `json:"offers"`
+}
+
+// @Summary     Make Offer
+// @Description Proposes a price for a published asset to its owner. The offer expires if the owner does not respond in time.
+// @ID          MakeOffer
+// @Security    ApiKeyAuth
+// @Tags        Offer
+// @Accept      json
+// @Produce     json
+// @Success     200 {object} entity.Offer "Offer made"
+// @Failure     400 {object} response "Price must be positive"
+// @Failure     404 {object} response "Asset not found"
+// @Failure     409 {object} response "Asset is not available, already purchased or a pending offer exists"
+// @Failure     500 {object} response "Internal server error"
+// @Router      /asset/{id}/offers [post]
+// @Param       id path int true "Asset ID"
+// @Param       request body offerPriceRequest true "Offered price"
+func (rt *offerRoutes) MakeOffer(w http.ResponseWriter, r *http.Request) {
+	idAsset, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		rt.l.WithContext(r.Context()).Error(err, "http - v1 - MakeOffer")
+		errorResponse(w, http.StatusInternalServerError, "error decoding request parameters")
+		return
+	}
+	req := offerPriceRequest{}
+	err = json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		rt.l.WithContext(r.Context()).Error(err, "http - v1 - MakeOffer - decoder.Decode")
+		errorResponse(w, http.StatusInternalServerError, "error decoding request body")
+		return
+	}
+	if req.Price <= 0 {
+		errorResponse(w, http.StatusBadRequest, "price should be positive")
+		return
+	}
+	usr, err := claims.User(r.Context())
+	if err != nil {
+		rt.l.WithContext(r.Context()).Error(err, "http - v1 - MakeOffer - claims.User")
+		errorResponse(w, http.StatusInternalServerError, "error getting token claims")
+		return
+	}
+	offer, err := rt.o.MakeOffer(r.Context(), usr, idAsset, req.Price)
+	if err != nil {
+		switch {
+		case errors.Is(err, entity.ErrAssetNotFound):
+			errorResponse(w, http.StatusNotFound, "Asset not found")
+		case errors.Is(err, entity.ErrAssetNotAvailable):
+			errorResponse(w, http.StatusConflict, "Asset is not available for purchase")
+		case errors.Is(err, entity.ErrAssetAlreadyPurchased):
+			errorResponse(w, http.StatusConflict, "Asset already purchased")
+		case errors.Is(err, entity.ErrOfferExists):
+			errorResponse(w, http.StatusConflict, "You already have a pending offer for the asset")
+		default:
+			rt.l.WithContext(r.Context()).Error(err, "http - v1 - MakeOffer - rt.o.MakeOffer")
+			errorResponse(w, http.StatusInternalServerError, "error making offer")
+		}
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(offer)
+}
+
+// @Summary     List Asset Offers
+// @Description Retrieves the offers on an asset: all of them for the owner, the user's own negotiation otherwise.
+// @ID          ListAssetOffers
+// @Security    ApiKeyAuth
+// @Tags        Offer
+// @Accept      json
+// @Produce     json
+// @Success     200 {object} listOfOffersResponse "Offers on the asset"
+// @Failure     500 {object} response "Internal server error"
+// @Router      /asset/{id}/offers [get]
+// @Param       id path int true "Asset ID"
+func (rt *offerRoutes) GetAssetOffers(w http.ResponseWriter, r *http.Request) {
+	idAsset, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		rt.l.WithContext(r.Context()).Error(err, "http - v1 - GetAssetOffers")
+		errorResponse(w, http.StatusInternalServerError, "error decoding request parameters")
+		return
+	}
+	usr, err := claims.User(r.Context())
+	if err != nil {
+		rt.l.WithContext(r.Context()).Error(err, "http - v1 - GetAssetOffers - claims.User")
+		errorResponse(w, http.StatusInternalServerError, "error getting token claims")
+		return
+	}
+	offers, err := rt.o.GetAssetOffers(r.Context(), usr, idAsset)
+	if err != nil {
+		rt.l.WithContext(r.Context()).Error(err, "http - v1 - GetAssetOffers - rt.o.GetAssetOffers")
+		errorResponse(w, http.StatusInternalServerError, "error getting offers")
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(listOfOffersResponse{offers})
+}
+
+// @Summary     Offers Inbox
+// @Description Retrieves the offers and counter-offers proposed to the user, newest first.
+// @ID          OffersInbox
+// @Security    ApiKeyAuth
+// @Tags        Offer
+// @Accept      json
+// @Produce     json
+// @Success     200 {object} listOfOffersResponse "Offers proposed to the user"
+// @Failure     500 {object} response "Internal server error"
+// @Router      /offers/inbox [get]
+func (rt *offerRoutes) GetInbox(w http.ResponseWriter, r *http.Request) {
+	usr, err := claims.User(r.Context())
+	if err != nil {
+		rt.l.WithContext(r.Context()).Error(err, "http - v1 - GetInbox - claims.User")
+		errorResponse(w, http.StatusInternalServerError, "error getting token claims")
+		return
+	}
+	offers, err := rt.o.GetInbox(r.Context(), usr)
+	if err != nil {
+		rt.l.WithContext(r.Context()).Error(err, "http - v1 - GetInbox - rt.o.GetInbox")
+		errorResponse(w, http.StatusInternalServerError, "error getting offers")
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(listOfOffersResponse{offers})
+}
+
+// @Summary     Offers Outbox
+// @Description Retrieves the offers and counter-offers proposed by the user, newest first.
+// @ID          OffersOutbox
+// @Security    ApiKeyAuth
+// @Tags        Offer
+// @Accept      json
+// @Produce     json
+// @Success     200 {object} listOfOffersResponse "Offers proposed by the user"
+// @Failure     500 {object} response "Internal server error"
+// @Router      /offers/outbox [get]
+func (rt *offerRoutes) GetOutbox(w http.ResponseWriter, r *http.Request) {
+	usr, err := claims.User(r.Context())
+	if err != nil {
+		rt.l.WithContext(r.Context()).Error(err, "http - v1 - GetOutbox - claims.User")
+		errorResponse(w, http.StatusInternalServerError, "error getting token claims")
+		return
+	}
+	offers, err := rt.o.GetOutbox(r.Context(), usr)
+	if err != nil {
+		rt.l.WithContext(r.Context()).Error(err, "http - v1 - GetOutbox - rt.o.GetOutbox")
+		errorResponse(w, http.StatusInternalServerError, "error getting offers")
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(listOfOffersResponse{offers})
+}
+
+// @Summary     Accept Offer
+// @Description Accepts an offer proposed to the user. The buyer purchases the asset for the offered price.
+// @ID          AcceptOffer
+// @Security    ApiKeyAuth
+// @Tags        Offer
+// @Accept      json
+// @Produce     json
+// @Success     200 {object} entity.Offer "Offer accepted"
+// @Failure     404 {object} response "Offer not found"
+// @Failure     409 {object} response "Offer is closed, asset is not available or insufficient funds"
+// @Failure     500 {object} response "Internal server error"
+// @Router      /offers/{id}/accept [post]
+// @Param       id path int true "Offer ID"
+func (rt *offerRoutes) AcceptOffer(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		rt.l.WithContext(r.Context()).Error(err, "http - v1 - AcceptOffer")
+		errorResponse(w, http.StatusInternalServerError, "error decoding request parameters")
+		return
+	}
+	usr, err := claims.User(r.Context())
+	if err != nil {
+		rt.l.WithContext(r.Context()).Error(err, "http - v1 - AcceptOffer - claims.User")
+		errorResponse(w, http.StatusInternalServerError, "error getting token claims")
+		return
+	}
+	offer, err := rt.o.AcceptOffer(r.Context(), usr, id)
+	if err != nil {
+		switch {
+		case errors.Is(err, entity.ErrOfferNotFound):
+			errorResponse(w, http.StatusNotFound, "Offer not found")
+		case errors.Is(err, entity.ErrOfferClosed):
+			errorResponse(w, http.StatusConflict, "Offer is no longer pending")
+		case errors.Is(err, entity.ErrAssetNotAvailable), errors.Is(err, entity.ErrAssetSoldOut):
+			errorResponse(w, http.StatusConflict, "Asset is not available for purchase")
+		case errors.Is(err, entity.ErrAssetAlreadyPurchased):
+			errorResponse(w, http.StatusConflict, "Asset already purchased")
+		case errors.Is(err, entity.ErrInsufficientFunds):
+			errorResponse(w, http.StatusConflict, "Insufficient funds")
+		default:
+			rt.l.WithContext(r.Context()).Error(err, "http - v1 - AcceptOffer - rt.o.AcceptOffer")
+			errorResponse(w, http.StatusInternalServerError, "error accepting offer")
+		}
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(offer)
+}
+
+// @Summary     Reject Offer
+// @Description Rejects an offer proposed to the user.
+// @ID          RejectOffer
+// @Security    ApiKeyAuth
+// @Tags        Offer
+// @Accept      json
+// @Produce     json
+// @Success     200 {object} entity.Offer "Offer rejected"
+// @Failure     404 {object} response "Offer not found"
+// @Failure     409 {object} response "Offer is no longer pending"
+// @Failure     500 {object} response "Internal server error"
+// @Router      /offers/{id}/reject [post]
+// @Param       id path int true "Offer ID"
+func (rt *offerRoutes) RejectOffer(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		rt.l.WithContext(r.Context()).Error(err, "http - v1 - RejectOffer")
+		errorResponse(w, http.StatusInternalServerError, "error decoding request parameters")
+		return
+	}
+	usr, err := claims.User(r.Context())
+	if err != nil {
+		rt.l.WithContext(r.Context()).Error(err, "http - v1 - RejectOffer - claims.User")
+		errorResponse(w, http.StatusInternalServerError, "error getting token claims")
+		return
+	}
+	offer, err := rt.o.RejectOffer(r.Context(), usr, id)
+	if err != nil {
+		switch {
+		case errors.Is(err, entity.ErrOfferNotFound):
+			errorResponse(w, http.StatusNotFound, "Offer not found")
+		case errors.Is(err, entity.ErrOfferClosed):
+			errorResponse(w, http.StatusConflict, "Offer is no longer pending")
+		default:
+			rt.l.WithContext(r.Context()).Error(err, "http - v1 - RejectOffer - rt.o.RejectOffer")
+			errorResponse(w, http.StatusInternalServerError, "error rejecting offer")
+		}
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(offer)
+}
+
+// @Summary     Counter Offer
+// @Description Answers an offer proposed to the user with another price. The offer is closed and the counter-offer goes to the other party.
+// @ID          CounterOffer
+// @Security    ApiKeyAuth
+// @Tags        Offer
+// @Accept      json
+// @Produce     json
+// @Success     200 {object} entity.Offer "Counter-offer made"
+// @Failure     400 {object} response "Price must be positive"
+// @Failure     404 {object} response "Offer not found"
+// @Failure     409 {object} response "Offer is no longer pending"
+// @Failure     500 {object} response "Internal server error"
+// @Router      /offers/{id}/counter [post]
+// @Param       id path int true "Offer ID"
+// @Param       request body offerPriceRequest true "Counter price"
+func (rt *offerRoutes) CounterOffer(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		rt.l.WithContext(r.Context()).Error(err, "http - v1 - CounterOffer")
+		errorResponse(w, http.StatusInternalServerError, "error decoding request parameters")
+		return
+	}
+	req := offerPriceRequest{}
+	err = json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		rt.l.WithContext(r.Context()).Error(err, "http - v1 - CounterOffer - decoder.Decode")
+		errorResponse(w, http.StatusInternalServerError, "error decoding request body")
+		return
+	}
+	if req.Price <= 0 {
+		errorResponse(w, http.StatusBadRequest, "price should be positive")
+		return
+	}
+	usr, err := claims.User(r.Context())
+	if err != nil {
+		rt.l.WithContext(r.Context()).Error(err, "http - v1 - CounterOffer - claims.User")
+		errorResponse(w, http.StatusInternalServerError, "error getting token claims")
+		return
+	}
+	offer, err := rt.o.CounterOffer(r.Context(), usr, id, req.Price)
+	if err != nil {
+		switch {
+		case errors.Is(err, entity.ErrOfferNotFound):
+			errorResponse(w, http.StatusNotFound, "Offer not found")
+		case errors.Is(err, entity.ErrOfferClosed):
+			errorResponse(w, http.StatusConflict, "Offer is no longer pending")
+		default:
+			rt.l.WithContext(r.Context()).Error(err, "http - v1 - CounterOffer - rt.o.CounterOffer")
+			errorResponse(w, http.StatusInternalServerError, "error countering offer")
+		}
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(offer)
+}
@@ -0,0 +1,141 @@
+package v1
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/Klef99/bhs-task/internal/controller/claims"
+	"github.com/Klef99/bhs-task/internal/entity"
+	"github.com/Klef99/bhs-task/internal/usecase"
+	"github.com/Klef99/bhs-task/pkg/jwtgenerator"
+	"github.com/Klef99/bhs-task/pkg/logger"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/jwtauth/v5"
+)
+
+type promoRoutes struct {
+	p   usecase.Promo
+	l   logger.Interface
+	jtg jwtgenerator.Interface
+}
+
+func NewPromoRoutes(handler chi.Router, p usecase.Promo, l logger.Interface, jtg jwtgenerator.Interface) {
+	rt := &promoRoutes{p: p, l: l, jtg: jtg}
+	tokenAuth := rt.jtg.GetJWTAuth()
+	router := chi.NewRouter()
+	router.Use(jwtauth.Verifier(tokenAuth))
+	router.Use(jwtauth.Authenticator(tokenAuth))
+	router.Group(func(r chi.Router) {
+		r.Post("/", rt.CreatePromoCode)
+		r.Get("/", rt.GetPromoCodes)
+	})
+	handler.Mount("/promo-codes", router)
+}
+
+type promoCodeRequest struct {
+	Code      string              `json:"code" example:"SUMMER10"`
+	Kind      entity.DiscountKind `json:"kind" enums:"percent,fixed"`
+	Value     float64             `json:"value" example:"10"`
+	AssetId   *int64              `json:"asset_id,omitempty"`   // all of the seller's assets if omitted
+	MaxUses   *int64              `json:"max_uses,omitempty"`   // unlimited if omitted
+	ExpiresAt *time.Time          `json:"expires_at,omitempty"` // never expires if omitted
+}
+
+func (req promoCodeRequest) toEntity() entity.PromoCode {
+	return entity.PromoCode{
+		Code:      entity.NormalizePromoCode(req.Code),
+		Kind:      req.Kind,
+		Value:     req.Value,
+		AssetId:   req.AssetId,
+		MaxUses:   req.MaxUses,
+		ExpiresAt: req.ExpiresAt,
+	}
+}
+
+type listOfPromoCodesResponse struct {
+	PromoCodes []entity.PromoCode `json:"promo_codes"`
+}
+
+// @Summary     Create Promo Code
+// @Description Creates a discount code for all of the user's assets or one of them. Buyers apply it with the promo_code parameter of a purchase or checkout.
+// @ID          CreatePromoCode
+// @Security    ApiKeyAuth
+// @Tags        Promo
+// @Accept      json
+// @Produce     json
+// @Success     200 {object} entity.PromoCode "Promo code created"
+// @Failure     400 {object} response "Invalid promo code"
+// @Failure     404 {object} response "Asset not found"
+// @Failure     409 {object} response "Promo code already exists"
+// @Failure     500 {object} response "Internal server error"
+// @Router      /promo-codes [post]
+// @Param       request body promoCodeRequest true "Promo code details"
+func (rt *promoRoutes) CreatePromoCode(w http.ResponseWriter, r *http.Request) {
+	req := promoCodeRequest{}
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		rt.l.WithContext(r.Context()).Error(err, "http - v1 - CreatePromoCode - decoder.Decode")
+		errorResponse(w, http.StatusInternalServerError, "error decoding request body")
+		return
+	}
+	promo := req.toEntity()
+	err = promo.Validate()
+	if err != nil {
+		errorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	usr, err := claims.User(r.Context())
+	if err != nil {
+		rt.l.WithContext(r.Context()).Error(err, "http - v1 - CreatePromoCode - claims.User")
+		errorResponse(w, http.StatusInternalServerError, "error getting token claims")
+		return
+	}
+	promo, err = rt.p.CreatePromoCode(r.Context(), usr, promo)
+	if err != nil {
+		writePromoCodeError(w, rt.l, err, "http - v1 - CreatePromoCode - rt.p.CreatePromoCode")
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(promo)
+}
+
+// @Summary     List Promo Codes
+// @Description Retrieves the promo codes created by the user with their usage.
+// @ID          ListPromoCodes
+// @Security    ApiKeyAuth
+// @Tags        Promo
+// @Accept      json
+// @Produce     json
+// @Success     200 {object} listOfPromoCodesResponse "Promo codes of the user"
+// @Failure     500 {object} response "Internal server error"
+// @Router      /promo-codes [get]
+func (rt *promoRoutes) GetPromoCodes(w http.ResponseWriter, r *http.Request) {
+	usr, err := claims.User(r.Context())
+	if err != nil {
+		rt.l.WithContext(r.Context()).Error(err, "http - v1 - GetPromoCodes - claims.User")
+		errorResponse(w, http.StatusInternalServerError, "error getting token claims")
+		return
+	}
+	codes, err := rt.p.GetPromoCodes(r.Context(), usr)
+	if err != nil {
+		rt.l.WithContext(r.Context()).Error(err, "http - v1 - GetPromoCodes - rt.p.GetPromoCodes")
+		errorResponse(w, http.StatusInternalServerError, "error getting promo codes")
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(listOfPromoCodesResponse{codes})
+}
+
+func writePromoCodeError(w http.ResponseWriter, l logger.Interface, err error, where string) {
+	switch {
+	case errors.Is(err, entity.ErrAssetNotFound):
+		errorResponse(w, http.StatusNotFound, "Asset not found")
+	case errors.Is(err, entity.ErrPromoCodeExists):
+		errorResponse(w, http.StatusConflict, "Promo code already exists")
+	default:
+		l.Error(err, where)
+		errorResponse(w, http.StatusInternalServerError, "error creating promo code")
+	}
+}
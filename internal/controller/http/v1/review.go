@@ -0,0 +1,205 @@
+package v1
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/Klef99/bhs-task/internal/controller/claims"
+	"github.com/Klef99/bhs-task/internal/entity"
+	"github.com/Klef99/bhs-task/internal/usecase"
+	"github.com/Klef99/bhs-task/pkg/jwtgenerator"
+	"github.com/Klef99/bhs-task/pkg/logger"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/jwtauth/v5"
+)
+
+type reviewRoutes struct {
+	rv  usecase.Review
+	l   logger.Interface
+	jtg jwtgenerator.Interface
+}
+
+func NewReviewRoutes(handler chi.Router, rv usecase.Review, l logger.Interface, jtg jwtgenerator.Interface) {
+	rt := &reviewRoutes{rv: rv, l: l, jtg: jtg}
+	tokenAuth := rt.jtg.GetJWTAuth()
+	handler.Group(func(r chi.Router) {
+		r.Use(jwtauth.Verifier(tokenAuth))
+		r.Use(jwtauth.Authenticator(tokenAuth))
+		r.Post("/asset/{id}/reviews", rt.PostReview)
+		r.Get("/asset/{id}/reviews", rt.GetReviews)
+		r.Post("/reviews/{id}/reply", rt.ReplyToReview)
+	})
+}
+
+type postReviewRequest struct {
+	Rating int    `json:"rating" minimum:"1" maximum:"5"`
+	Text   string `json:"text,omitempty"`
+}
+
+// @Summary     Post Review
+// @Description Rates an asset the user bought, with an optional text. Each user can review an asset once; the rating counts towards the asset's average.
+// @ID          PostReview
+// @Security    ApiKeyAuth
+// @Tags        Review
+// @Accept      json
+// @Produce     json
+// @Success     200 {object} entity.Review "Review posted"
+// @Failure     400 {object} response "Rating is out of range or the text is too long"
+// @Failure     403 {object} response "The user has not bought the asset"
+// @Failure     404 {object} response "Asset not found"
+// @Failure     409 {object} response "The user already reviewed the asset"
+// @Failure     500 {object} response "Internal server error"
+// @Router      /asset/{id}/reviews [post]
+// @Param       id path int true "Asset ID"
+// @Param       request body postReviewRequest true "Rating from 1 to 5 and an optional text"
+func (rt *reviewRoutes) PostReview(w http.ResponseWriter, r *http.Request) {
+	idAsset, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		rt.l.WithContext(r.Context()).Error(err, "http - v1 - PostReview")
+		errorResponse(w, http.StatusInternalServerError, "error decoding request parameters")
+		return
+	}
+	req := postReviewRequest{}
+	err = json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		rt.l.WithContext(r.Context()).Error(err, "http - v1 - PostReview - decoder.Decode")
+		errorResponse(w, http.StatusInternalServerError, "error decoding request body")
+		return
+	}
+	review := entity.Review{AssetId: idAsset, Rating: req.Rating, Text: strings.TrimSpace(req.Text)}
+	if err = review.Validate(); err != nil {
+		errorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	usr, err := claims.User(r.Context())
+	if err != nil {
+		rt.l.WithContext(r.Context()).Error(err, "http - v1 - PostReview - claims.User")
+		errorResponse(w, http.StatusInternalServerError, "error getting token claims")
+		return
+	}
+	created, err := rt.rv.PostReview(r.Context(), usr, review)
+	if err != nil {
+		switch {
+		case errors.Is(err, entity.ErrAssetNotFound):
+			errorResponse(w, http.StatusNotFound, "Asset not found")
+		case errors.Is(err, entity.ErrAccessNotFound):
+			errorResponse(w, http.StatusForbidden, "Only buyers of the asset can review it")
+		case errors.Is(err, entity.ErrReviewExists):
+			errorResponse(w, http.StatusConflict, "You already reviewed the asset")
+		default:
+			rt.l.WithContext(r.Context()).Error(err, "http - v1 - PostReview - rt.rv.PostReview")
+			errorResponse(w, http.StatusInternalServerError, "error posting review")
+		}
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(created)
+}
+
+type listOfReviewsResponse struct {
+	Reviews []entity.Review `json:"reviews"`
+}
+
+// @Summary     List Reviews
+// @Description Retrieves the reviews of an asset with the seller's replies, newest first.
+// @ID          ListReviews
+// @Security    ApiKeyAuth
+// @Tags        Review
+// @Accept      json
+// @Produce     json
+// @Success     200 {object} listOfReviewsResponse "Reviews of the asset"
+// @Failure     404 {object} response "Asset not found"
+// @Failure     500 {object} response "Internal server error"
+// @Router      /asset/{id}/reviews [get]
+// @Param       id path int true "Asset ID"
+func (rt *reviewRoutes) GetReviews(w http.ResponseWriter, r *http.Request) {
+	idAsset, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		rt.l.WithContext(r.Context()).Error(err, "http - v1 - GetReviews")
+		errorResponse(w, http.StatusInternalServerError, "error decoding request parameters")
+		return
+	}
+	usr, err := claims.User(r.Context())
+	if err != nil {
+		rt.l.WithContext(r.Context()).Error(err, "http - v1 - GetReviews - claims.User")
+		errorResponse(w, http.StatusInternalServerError, "error getting token claims")
+		return
+	}
+	reviews, err := rt.rv.GetReviews(r.Context(), usr, idAsset)
+	if err != nil {
+		if errors.Is(err, entity.ErrAssetNotFound) {
+			errorResponse(w, http.StatusNotFound, "Asset not found")
+			return
+		}
+		rt.l.WithContext(r.Context()).Error(err, "http - v1 - GetReviews - rt.rv.GetReviews")
+		errorResponse(w, http.StatusInternalServerError, "error getting reviews")
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(listOfReviewsResponse{reviews})
+}
+
+type replyToReviewRequest struct {
+	Reply string `json:"reply"`
+}
+
+// @Summary     Reply To Review
+// @Description The owner of the reviewed asset answers a review. Each review can be answered once.
+// @ID          ReplyToReview
+// @Security    ApiKeyAuth
+// @Tags        Review
+// @Accept      json
+// @Produce     json
+// @Success     200 {object} entity.Review "Review with the reply"
+// @Failure     400 {object} response "Reply is empty or too long"
+// @Failure     404 {object} response "Review not found"
+// @Failure     409 {object} response "Review already has a reply"
+// @Failure     500 {object} response "Internal server error"
+// @Router      /reviews/{id}/reply [post]
+// @Param       id path int true "Review ID"
+// @Param       request body replyToReviewRequest true "Reply text"
+func (rt *reviewRoutes) ReplyToReview(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		rt.l.WithContext(r.Context()).Error(err, "http - v1 - ReplyToReview")
+		errorResponse(w, http.StatusInternalServerError, "error decoding request parameters")
+		return
+	}
+	req := replyToReviewRequest{}
+	err = json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		rt.l.WithContext(r.Context()).Error(err, "http - v1 - ReplyToReview - decoder.Decode")
+		errorResponse(w, http.StatusInternalServerError, "error decoding request body")
+		return
+	}
+	req.Reply = strings.TrimSpace(req.Reply)
+	if req.Reply == "" || utf8.RuneCountInString(req.Reply) > entity.MaxReviewLength {
+		errorResponse(w, http.StatusBadRequest, "reply should not be empty or too long")
+		return
+	}
+	usr, err := claims.User(r.Context())
+	if err != nil {
+		rt.l.WithContext(r.Context()).Error(err, "http - v1 - ReplyToReview - claims.User")
+		errorResponse(w, http.StatusInternalServerError, "error getting token claims")
+		return
+	}
+	review, err := rt.rv.ReplyToReview(r.Context(), usr, id, req.Reply)
+	if err != nil {
+		switch {
+		case errors.Is(err, entity.ErrReviewNotFound):
+			errorResponse(w, http.StatusNotFound, "Review not found")
+		case errors.Is(err, entity.ErrReviewReplied):
+			errorResponse(w, http.StatusConflict, "Review already has a reply")
+		default:
+			rt.l.WithContext(r.Context()).Error(err, "http - v1 - ReplyToReview - rt.rv.ReplyToReview")
+			errorResponse(w, http.StatusInternalServerError, "error replying to review")
+		}
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(review)
+}
@@ -1,13 +1,10 @@
 package v1
 
 import (
-	"net/http"
-
 	_ "github.com/Klef99/bhs-task/docs"
 	"github.com/Klef99/bhs-task/internal/usecase"
 	"github.com/Klef99/bhs-task/pkg/jwtgenerator"
 	"github.com/Klef99/bhs-task/pkg/logger"
-	"github.com/go-chi/chi/middleware"
 	"github.com/go-chi/chi/v5"
 	httpSwagger "github.com/swaggo/http-swagger"
 )
@@ -24,14 +21,7 @@ import (
 // @in header
 // @name Authorization
 // @description Type "Bearer" followed by a space and JWT token.
-func NewRouter(handler chi.Router, l logger.Interface, t usecase.User, a usecase.Asset, jwt jwtgenerator.Interface, enableSwagger bool) {
-	// Options
-	handler.Use(middleware.Logger)
-	handler.Use(middleware.Recoverer)
-
-	// K8s probe
-	handler.Get("/healthz", func(resp http.ResponseWriter, req *http.Request) { resp.WriteHeader(http.StatusOK) })
-
+func NewRouter(handler chi.Router, l logger.Interface, t usecase.User, a usecase.Asset, au usecase.Auction, o usecase.Offer, c usecase.Cart, p usecase.Promo, w usecase.Wishlist, n usecase.Notification, rv usecase.Review, st usecase.Stats, wh usecase.Webhook, mf usecase.MarketFeed, jwt jwtgenerator.Interface, enableSwagger bool) {
 	// Swagger
 	if enableSwagger {
 		handler.Get("/swagger/*", httpSwagger.WrapHandler)
@@ -40,5 +30,16 @@ func NewRouter(handler chi.Router, l logger.Interface, t usecase.User, a usecase
 	r := chi.NewRouter()
 	NewUserRoutes(r, t, l, jwt)
 	NewAssetRoutes(r, a, l, jwt)
+	NewAuctionRoutes(r, au, l, jwt)
+	NewOfferRoutes(r, o, l, jwt)
+	NewCartRoutes(r, c, l, jwt)
+	NewPromoRoutes(r, p, l, jwt)
+	NewWishlistRoutes(r, w, l, jwt)
+	NewNotificationRoutes(r, n, l, jwt)
+	NewReviewRoutes(r, rv, l, jwt)
+	NewStatsRoutes(r, st, l, jwt)
+	NewWebhookRoutes(r, wh, l, jwt)
+	NewStreamRoutes(r, mf, l, jwt)
+	NewAdminRoutes(r, t, a, p, wh, l, jwt)
 	handler.Mount("/v1", r)
 }
@@ -0,0 +1,119 @@
+package v1
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/Klef99/bhs-task/internal/controller/claims"
+	"github.com/Klef99/bhs-task/internal/entity"
+	"github.com/Klef99/bhs-task/internal/usecase"
+	"github.com/Klef99/bhs-task/pkg/jwtgenerator"
+	"github.com/Klef99/bhs-task/pkg/logger"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/jwtauth/v5"
+)
+
+// _defaultStatsRange - of the stats when the start of the range is omitted.
+const _defaultStatsRange = 30 * 24 * time.Hour
+
+type statsRoutes struct {
+	s   usecase.Stats
+	l   logger.Interface
+	jtg jwtgenerator.Interface
+}
+
+func NewStatsRoutes(handler chi.Router, s usecase.Stats, l logger.Interface, jtg jwtgenerator.Interface) {
+	rt := &statsRoutes{s: s, l: l, jtg: jtg}
+	tokenAuth := rt.jtg.GetJWTAuth()
+	router := chi.NewRouter()
+	router.Use(jwtauth.Verifier(tokenAuth))
+	router.Use(jwtauth.Authenticator(tokenAuth))
+	router.Group(func(r chi.Router) {
+		r.Get("/stats", rt.GetSellerStats)
+	})
+	handler.Mount("/seller", router)
+}
+
+// parseStatsTime - accepts RFC 3339 timestamps and dates. A date given as the end of the range includes the whole day.
+func parseStatsTime(value string, end bool) (time.Time, error) {
+	t, err := time.Parse(time.RFC3339, value)
+	if err == nil {
+		return t, nil
+	}
+	t, err = time.Parse(time.DateOnly, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("expected a date or an RFC 3339 timestamp: %q", value)
+	}
+	if end {
+		t = t.AddDate(0, 0, 1)
+	}
+	return t, nil
+}
+
+// @Summary     Seller Stats
+// @Description Retrieves the sales of the user's assets within a date range: units sold and revenue per asset, in total and per day, week or month. Revenue is what buyers paid after discounts. Buckets are in UTC, weeks start on Monday.
+// @ID          SellerStats
+// @Security    ApiKeyAuth
+// @Tags        Seller
+// @Accept      json
+// @Produce     json
+// @Success     200 {object} entity.SellerStats "Sales of the seller"
+// @Failure     400 {object} response "Invalid range or bucket"
+// @Failure     500 {object} response "Internal server error"
+// @Router      /seller/stats [get]
+// @Param       from query string false "Start of the range, a date or an RFC 3339 timestamp, 30 days before its end by default"
+// @Param       to query string false "End of the range, exclusive; a date includes the whole day. Now by default"
+// @Param       bucket query string false "Length of a point of the series" Enums(day, week, month) default(day)
+func (rt *statsRoutes) GetSellerStats(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	var err error
+	to := time.Now()
+	if v := query.Get("to"); v != "" {
+		to, err = parseStatsTime(v, true)
+		if err != nil {
+			errorResponse(w, http.StatusBadRequest, "invalid to: "+err.Error())
+			return
+		}
+	}
+	from := to.Add(-_defaultStatsRange)
+	if v := query.Get("from"); v != "" {
+		from, err = parseStatsTime(v, false)
+		if err != nil {
+			errorResponse(w, http.StatusBadRequest, "invalid from: "+err.Error())
+			return
+		}
+	}
+	if !from.Before(to) {
+		errorResponse(w, http.StatusBadRequest, "from must be before to")
+		return
+	}
+	bucket := entity.StatsBucketDay
+	if v := query.Get("bucket"); v != "" {
+		bucket = entity.StatsBucket(v)
+	}
+	if !bucket.Valid() {
+		errorResponse(w, http.StatusBadRequest, "bucket should be one of day, week, month")
+		return
+	}
+	usr, err := claims.User(r.Context())
+	if err != nil {
+		rt.l.WithContext(r.Context()).Error(err, "http - v1 - GetSellerStats - claims.User")
+		errorResponse(w, http.StatusInternalServerError, "error getting token claims")
+		return
+	}
+	stats, err := rt.s.GetSellerStats(r.Context(), usr, from, to, bucket)
+	if errors.Is(err, entity.ErrStatsRangeTooLong) {
+		errorResponse(w, http.StatusBadRequest, "range is too long for the bucket, use a longer bucket")
+		return
+	}
+	if err != nil {
+		rt.l.WithContext(r.Context()).Error(err, "http - v1 - GetSellerStats - rt.s.GetSellerStats")
+		errorResponse(w, http.StatusInternalServerError, "error getting seller stats")
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(stats)
+}
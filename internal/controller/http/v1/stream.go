@@ -0,0 +1,197 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/Klef99/bhs-task/internal/controller/claims"
+	"github.com/Klef99/bhs-task/internal/entity"
+	"github.com/Klef99/bhs-task/internal/usecase"
+	"github.com/Klef99/bhs-task/pkg/jwtgenerator"
+	"github.com/Klef99/bhs-task/pkg/logger"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/jwtauth/v5"
+	"golang.org/x/net/websocket"
+)
+
+const (
+	_heartbeatInterval  = 15 * time.Second
+	_streamWriteTimeout = 10 * time.Second // a client that takes longer to accept a message is disconnected
+)
+
+type streamRoutes struct {
+	m   usecase.MarketFeed
+	l   logger.Interface
+	jtg jwtgenerator.Interface
+}
+
+func NewStreamRoutes(handler chi.Router, m usecase.MarketFeed, l logger.Interface, jtg jwtgenerator.Interface) {
+	rt := &streamRoutes{m: m, l: l, jtg: jtg}
+	tokenAuth := rt.jtg.GetJWTAuth()
+	router := chi.NewRouter()
+	// Browsers can't set headers on EventSource and WebSocket, so the token may come in the jwt query parameter.
+	router.Use(jwtauth.Verify(tokenAuth, jwtauth.TokenFromHeader, jwtauth.TokenFromQuery))
+	router.Use(jwtauth.Authenticator(tokenAuth))
+	router.Group(func(r chi.Router) {
+		r.Get("/market", rt.MarketEvents)
+		r.Get("/market/ws", rt.MarketWebSocket)
+	})
+	handler.Mount("/stream", router)
+}
+
+// streamNotice - message of the feed itself rather than of the market.
+type streamNotice struct {
+	Type string `json:"type" enums:"heartbeat,lagged"` // lagged: the client fell behind and is disconnected
+}
+
+// @Summary     Market Feed (SSE)
+// @Description Streams market changes as Server-Sent Events named after their type: asset.created, asset.updated, asset.deleted, asset.sold. A comment line is sent every 15 seconds as a heartbeat. A client that falls behind gets a "lagged" event and is disconnected. The token can be passed in the jwt query parameter.
+// @ID          MarketEvents
+// @Security    ApiKeyAuth
+// @Tags        Stream
+// @Produce     text/event-stream
+// @Success     200 {object} entity.MarketEvent "Stream of market events"
+// @Failure     401 {object} response "Unauthorized"
+// @Failure     500 {object} response "Internal server error"
+// @Router      /stream/market [get]
+// @Param       jwt query string false "Token, when the Authorization header can't be set"
+func (rt *streamRoutes) MarketEvents(w http.ResponseWriter, r *http.Request) {
+	usr, err := claims.User(r.Context())
+	if err != nil {
+		rt.l.WithContext(r.Context()).Error(err, "http - v1 - MarketEvents - claims.User")
+		errorResponse(w, http.StatusInternalServerError, "error getting token claims")
+		return
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		errorResponse(w, http.StatusInternalServerError, "streaming is not supported")
+		return
+	}
+	events, err := rt.m.Subscribe(r.Context(), usr)
+	if err != nil {
+		rt.l.WithContext(r.Context()).Error(err, "http - v1 - MarketEvents - rt.m.Subscribe")
+		errorResponse(w, http.StatusInternalServerError, "error subscribing to market events")
+		return
+	}
+
+	rc := http.NewResponseController(w)
+	send := func(format string, args ...interface{}) error {
+		err := rc.SetWriteDeadline(time.Now().Add(_streamWriteTimeout))
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(w, format, args...)
+		if err != nil {
+			return err
+		}
+		flusher.Flush()
+		return nil
+	}
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	if send(": connected\n\n") != nil {
+		return
+	}
+
+	heartbeat := time.NewTicker(_heartbeatInterval)
+	defer heartbeat.Stop()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case event, ok := <-events:
+			if !ok {
+				send("event: lagged\ndata: {}\n\n")
+				return
+			}
+			data, err := json.Marshal(event)
+			if err != nil {
+				rt.l.WithContext(r.Context()).Error(err, "http - v1 - MarketEvents - json.Marshal")
+				return
+			}
+			if send("event: %s\ndata: %s\n\n", event.Type, data) != nil {
+				return
+			}
+		case <-heartbeat.C:
+			if send(": heartbeat\n\n") != nil {
+				return
+			}
+		}
+	}
+}
+
+// @Summary     Market Feed (WebSocket)
+// @Description Upgrades to a WebSocket streaming market changes as JSON messages, the same as the SSE feed. {"type":"heartbeat"} is sent every 15 seconds; a client that falls behind gets {"type":"lagged"} and is disconnected. Messages from the client are ignored. The token can be passed in the jwt query parameter.
+// @ID          MarketWebSocket
+// @Security    ApiKeyAuth
+// @Tags        Stream
+// @Success     101 {object} entity.MarketEvent "Switching protocols"
+// @Failure     401 {object} response "Unauthorized"
+// @Failure     500 {object} response "Internal server error"
+// @Router      /stream/market/ws [get]
+// @Param       jwt query string false "Token, when the Authorization header can't be set"
+func (rt *streamRoutes) MarketWebSocket(w http.ResponseWriter, r *http.Request) {
+	usr, err := claims.User(r.Context())
+	if err != nil {
+		rt.l.WithContext(r.Context()).Error(err, "http - v1 - MarketWebSocket - claims.User")
+		errorResponse(w, http.StatusInternalServerError, "error getting token claims")
+		return
+	}
+	websocket.Server{
+		// Any origin is accepted, the connection is authorized by the token.
+		Handshake: func(*websocket.Config, *http.Request) error { return nil },
+		Handler:   func(ws *websocket.Conn) { rt.serveMarket(ws, usr) },
+	}.ServeHTTP(w, r)
+}
+
+func (rt *streamRoutes) serveMarket(ws *websocket.Conn, usr entity.User) {
+	// The connection is hijacked: the request context no longer ends with it, reading does.
+	ctx, cancel := context.WithCancel(ws.Request().Context())
+	defer cancel()
+	ws.SetReadDeadline(time.Time{})
+	go func() {
+		defer cancel()
+		var msg []byte
+		for websocket.Message.Receive(ws, &msg) == nil {
+		}
+	}()
+
+	events, err := rt.m.Subscribe(ctx, usr)
+	if err != nil {
+		rt.l.WithContext(ctx).Error(err, "http - v1 - MarketWebSocket - rt.m.Subscribe")
+		return
+	}
+	send := func(v interface{}) error {
+		err := ws.SetWriteDeadline(time.Now().Add(_streamWriteTimeout))
+		if err != nil {
+			return err
+		}
+		return websocket.JSON.Send(ws, v)
+	}
+
+	heartbeat := time.NewTicker(_heartbeatInterval)
+	defer heartbeat.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event, ok := <-events:
+			if !ok {
+				send(streamNotice{Type: "lagged"})
+				return
+			}
+			if send(event) != nil {
+				return
+			}
+		case <-heartbeat.C:
+			if send(streamNotice{Type: "heartbeat"}) != nil {
+				return
+			}
+		}
+	}
+}
@@ -50,18 +50,18 @@ func (rt *userRoutes) Register(w http.ResponseWriter, r *http.Request) {
 	decoder := json.NewDecoder(r.Body)
 	err := decoder.Decode(&crd)
 	if err != nil {
-		rt.l.Error(err, "http - v1 - register")
+		rt.l.WithContext(r.Context()).Error(err, "http - v1 - register")
 		errorResponse(w, http.StatusInternalServerError, "error decoding request body")
 		return
 	}
 	if err != nil {
-		rt.l.Error(err, "http - v1 - register - crd.Validate")
+		rt.l.WithContext(r.Context()).Error(err, "http - v1 - register - crd.Validate")
 		errorResponse(w, http.StatusBadRequest, err.Error())
 		return
 	}
 	status, err := rt.t.Register(r.Context(), crd)
 	if err != nil || !status {
-		rt.l.Error(err, "http - v1 - register")
+		rt.l.WithContext(r.Context()).Error(err, "http - v1 - register")
 		errorResponse(w, http.StatusInternalServerError, "error registering user or user already exists")
 		return
 	}
@@ -91,20 +91,20 @@ func (rt *userRoutes) Login(w http.ResponseWriter, r *http.Request) {
 	decoder := json.NewDecoder(r.Body)
 	err := decoder.Decode(&crd)
 	if err != nil {
-		rt.l.Error(err, "http - v1 - login - decoder.Decode")
+		rt.l.WithContext(r.Context()).Error(err, "http - v1 - login - decoder.Decode")
 		errorResponse(w, http.StatusInternalServerError, "error decoding request body")
 		return
 	}
 	user, err := rt.t.Login(r.Context(), crd)
 	if err != nil {
-		rt.l.Error(err, "http - v1 - login - rt.t.Login")
+		rt.l.WithContext(r.Context()).Error(err, "http - v1 - login - rt.t.Login")
 		errorResponse(w, http.StatusInternalServerError, "error login user")
 		return
 	}
 	if user.Id != 0 {
 		token, err := rt.jtg.GenerateToken(user.Username, user.Id)
 		if err != nil {
-			rt.l.Error(err, "http - v1 - login - rt.jtg.GenerateToken")
+			rt.l.WithContext(r.Context()).Error(err, "http - v1 - login - rt.jtg.GenerateToken")
 			errorResponse(w, http.StatusInternalServerError, "error generating token")
 			return
 		}
@@ -142,38 +142,38 @@ func (rt *userRoutes) Deposit(w http.ResponseWriter, r *http.Request) {
 	decoder := json.NewDecoder(r.Body)
 	err := decoder.Decode(&req)
 	if err != nil {
-		rt.l.Error(err, "http - v1 - Deposit")
+		rt.l.WithContext(r.Context()).Error(err, "http - v1 - Deposit")
 		errorResponse(w, http.StatusInternalServerError, "error decoding request body")
 		return
 	}
 	status := req.Validate()
 	if !status {
-		rt.l.Error(err, "http - v1 - Deposit - Validate")
+		rt.l.WithContext(r.Context()).Error(err, "http - v1 - Deposit - Validate")
 		errorResponse(w, http.StatusInternalServerError, "amount should be positive")
 		return
 	}
 	_, claims, err := jwtauth.FromContext(r.Context())
 	if err != nil {
-		rt.l.Error(err, "http - v1 - Deposit - jwtauth.FromContext")
+		rt.l.WithContext(r.Context()).Error(err, "http - v1 - Deposit - jwtauth.FromContext")
 		errorResponse(w, http.StatusInternalServerError, "error getting token claims")
 		return
 	}
 	id, ok := claims["id"].(float64)
 	if !ok {
-		rt.l.Error(err, "http - v1 - Deposit - .(float64)")
+		rt.l.WithContext(r.Context()).Error(err, "http - v1 - Deposit - .(float64)")
 		errorResponse(w, http.StatusInternalServerError, "error getting token claims")
 		return
 	}
 	name, ok := claims["name"].(string)
 	if !ok {
-		rt.l.Error(err, "http - v1 - Deposit - .(string)")
+		rt.l.WithContext(r.Context()).Error(err, "http - v1 - Deposit - .(string)")
 		errorResponse(w, http.StatusInternalServerError, "error getting token claims")
 		return
 	}
 	usr := entity.User{Username: name, Id: int64(id)}
 	balance, err := rt.t.MakeDeposit(r.Context(), usr, req.Amount)
 	if err != nil {
-		rt.l.Error(err, "http - v1 - Deposit - rt.t.Deposit")
+		rt.l.WithContext(r.Context()).Error(err, "http - v1 - Deposit - rt.t.Deposit")
 		errorResponse(w, http.StatusInternalServerError, "error depositing money")
 		return
 	}
@@ -199,26 +199,26 @@ func (rt *userRoutes) Deposit(w http.ResponseWriter, r *http.Request) {
 func (rt *userRoutes) CheckDeposit(w http.ResponseWriter, r *http.Request) {
 	_, claims, err := jwtauth.FromContext(r.Context())
 	if err != nil {
-		rt.l.Error(err, "http - v1 - CheckDeposit - jwtauth.FromContext")
+		rt.l.WithContext(r.Context()).Error(err, "http - v1 - CheckDeposit - jwtauth.FromContext")
 		errorResponse(w, http.StatusInternalServerError, "error getting token claims")
 		return
 	}
 	id, ok := claims["id"].(float64)
 	if !ok {
-		rt.l.Error(err, "http - v1 - CheckDeposit - .(float64)")
+		rt.l.WithContext(r.Context()).Error(err, "http - v1 - CheckDeposit - .(float64)")
 		errorResponse(w, http.StatusInternalServerError, "error getting token claims")
 		return
 	}
 	name, ok := claims["name"].(string)
 	if !ok {
-		rt.l.Error(err, "http - v1 - CheckDeposit - .(string)")
+		rt.l.WithContext(r.Context()).Error(err, "http - v1 - CheckDeposit - .(string)")
 		errorResponse(w, http.StatusInternalServerError, "error getting token claims")
 		return
 	}
 	usr := entity.User{Username: name, Id: int64(id)}
 	deposit, err := rt.t.CheckDeposit(r.Context(), usr)
 	if err != nil {
-		rt.l.Error(err, "http - v1 - CheckDeposit - rt.t.CheckDeposit")
+		rt.l.WithContext(r.Context()).Error(err, "http - v1 - CheckDeposit - rt.t.CheckDeposit")
 		errorResponse(w, http.StatusInternalServerError, "error depositing money")
 		return
 	}
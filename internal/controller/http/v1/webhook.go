@@ -0,0 +1,240 @@
+package v1
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/Klef99/bhs-task/internal/controller/claims"
+	"github.com/Klef99/bhs-task/internal/entity"
+	"github.com/Klef99/bhs-task/internal/usecase"
+	"github.com/Klef99/bhs-task/pkg/jwtgenerator"
+	"github.com/Klef99/bhs-task/pkg/logger"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/jwtauth/v5"
+)
+
+type webhookRoutes struct {
+	wh  usecase.Webhook
+	l   logger.Interface
+	jtg jwtgenerator.Interface
+}
+
+func NewWebhookRoutes(handler chi.Router, wh usecase.Webhook, l logger.Interface, jtg jwtgenerator.Interface) {
+	rt := &webhookRoutes{wh: wh, l: l, jtg: jtg}
+	tokenAuth := rt.jtg.GetJWTAuth()
+	router := chi.NewRouter()
+	router.Use(jwtauth.Verifier(tokenAuth))
+	router.Use(jwtauth.Authenticator(tokenAuth))
+	router.Group(func(r chi.Router) {
+		r.Post("/", rt.CreateWebhook)
+		r.Get("/", rt.GetWebhooks)
+		r.Delete("/{id}", rt.DeleteWebhook)
+		r.Get("/{id}/deliveries", rt.GetDeliveries)
+		r.Post("/deliveries/{id}/redeliver", rt.Redeliver)
+	})
+	handler.Mount("/webhooks", router)
+}
+
+type webhookRequest struct {
+	URL    string             `json:"url" example:"https://game.example.com/hooks/market"`
+	Events []entity.EventType `json:"events" example:"asset.purchased"`
+}
+
+func (req webhookRequest) toEntity() entity.Webhook {
+	return entity.Webhook{URL: req.URL, Events: req.Events}
+}
+
+type listOfWebhooksResponse struct {
+	Webhooks []entity.Webhook `json:"webhooks"`
+}
+
+type listOfDeliveriesResponse struct {
+	Deliveries []entity.WebhookDelivery `json:"deliveries"`
+}
+
+// @Summary     Create Webhook
+// @Description Registers an endpoint receiving the user's events. Deliveries are signed with HMAC-SHA256 of "<X-Webhook-Timestamp>.<body>" in the X-Webhook-Signature header; the secret is returned only in this response.
+// @ID          CreateWebhook
+// @Security    ApiKeyAuth
+// @Tags        Webhook
+// @Accept      json
+// @Produce     json
+// @Success     200 {object} entity.Webhook "Webhook created"
+// @Failure     400 {object} response "Invalid url or event types"
+// @Failure     500 {object} response "Internal server error"
+// @Router      /webhooks [post]
+// @Param       request body webhookRequest true "Endpoint and event types: asset.created, asset.purchased, deposit.completed"
+func (rt *webhookRoutes) CreateWebhook(w http.ResponseWriter, r *http.Request) {
+	req := webhookRequest{}
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		rt.l.WithContext(r.Context()).Error(err, "http - v1 - CreateWebhook - decoder.Decode")
+		errorResponse(w, http.StatusInternalServerError, "error decoding request body")
+		return
+	}
+	wh := req.toEntity()
+	if err = wh.Validate(); err != nil {
+		errorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	usr, err := claims.User(r.Context())
+	if err != nil {
+		rt.l.WithContext(r.Context()).Error(err, "http - v1 - CreateWebhook - claims.User")
+		errorResponse(w, http.StatusInternalServerError, "error getting token claims")
+		return
+	}
+	created, err := rt.wh.CreateWebhook(r.Context(), usr, wh)
+	if err != nil {
+		rt.l.WithContext(r.Context()).Error(err, "http - v1 - CreateWebhook - rt.wh.CreateWebhook")
+		errorResponse(w, http.StatusInternalServerError, "error creating webhook")
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(created)
+}
+
+// @Summary     List Webhooks
+// @Description Retrieves the webhooks registered by the user, without their secrets.
+// @ID          ListWebhooks
+// @Security    ApiKeyAuth
+// @Tags        Webhook
+// @Accept      json
+// @Produce     json
+// @Success     200 {object} listOfWebhooksResponse "Webhooks of the user"
+// @Failure     500 {object} response "Internal server error"
+// @Router      /webhooks [get]
+func (rt *webhookRoutes) GetWebhooks(w http.ResponseWriter, r *http.Request) {
+	usr, err := claims.User(r.Context())
+	if err != nil {
+		rt.l.WithContext(r.Context()).Error(err, "http - v1 - GetWebhooks - claims.User")
+		errorResponse(w, http.StatusInternalServerError, "error getting token claims")
+		return
+	}
+	webhooks, err := rt.wh.GetWebhooks(r.Context(), usr)
+	if err != nil {
+		rt.l.WithContext(r.Context()).Error(err, "http - v1 - GetWebhooks - rt.wh.GetWebhooks")
+		errorResponse(w, http.StatusInternalServerError, "error getting webhooks")
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(listOfWebhooksResponse{webhooks})
+}
+
+// @Summary     Delete Webhook
+// @Description Removes one of the user's webhooks together with its deliveries.
+// @ID          DeleteWebhook
+// @Security    ApiKeyAuth
+// @Tags        Webhook
+// @Accept      json
+// @Produce     json
+// @Success     200 {object} response "Webhook deleted"
+// @Failure     404 {object} response "Webhook not found"
+// @Failure     500 {object} response "Internal server error"
+// @Router      /webhooks/{id} [delete]
+// @Param       id path int true "Webhook ID"
+func (rt *webhookRoutes) DeleteWebhook(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		rt.l.WithContext(r.Context()).Error(err, "http - v1 - DeleteWebhook")
+		errorResponse(w, http.StatusInternalServerError, "error decoding request parameters")
+		return
+	}
+	usr, err := claims.User(r.Context())
+	if err != nil {
+		rt.l.WithContext(r.Context()).Error(err, "http - v1 - DeleteWebhook - claims.User")
+		errorResponse(w, http.StatusInternalServerError, "error getting token claims")
+		return
+	}
+	status, err := rt.wh.DeleteWebhook(r.Context(), usr, id)
+	if err != nil {
+		rt.l.WithContext(r.Context()).Error(err, "http - v1 - DeleteWebhook - rt.wh.DeleteWebhook")
+		errorResponse(w, http.StatusInternalServerError, "error deleting webhook")
+		return
+	}
+	if !status {
+		errorResponse(w, http.StatusNotFound, "Webhook not found")
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response{"Webhook deleted"})
+}
+
+// @Summary     List Webhook Deliveries
+// @Description Retrieves the deliveries of one of the user's webhooks with their status, attempts and last response, newest first.
+// @ID          ListWebhookDeliveries
+// @Security    ApiKeyAuth
+// @Tags        Webhook
+// @Accept      json
+// @Produce     json
+// @Success     200 {object} listOfDeliveriesResponse "Deliveries of the webhook"
+// @Failure     404 {object} response "Webhook not found"
+// @Failure     500 {object} response "Internal server error"
+// @Router      /webhooks/{id}/deliveries [get]
+// @Param       id path int true "Webhook ID"
+func (rt *webhookRoutes) GetDeliveries(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		rt.l.WithContext(r.Context()).Error(err, "http - v1 - GetDeliveries")
+		errorResponse(w, http.StatusInternalServerError, "error decoding request parameters")
+		return
+	}
+	usr, err := claims.User(r.Context())
+	if err != nil {
+		rt.l.WithContext(r.Context()).Error(err, "http - v1 - GetDeliveries - claims.User")
+		errorResponse(w, http.StatusInternalServerError, "error getting token claims")
+		return
+	}
+	deliveries, err := rt.wh.GetDeliveries(r.Context(), usr, id)
+	if errors.Is(err, entity.ErrWebhookNotFound) {
+		errorResponse(w, http.StatusNotFound, "Webhook not found")
+		return
+	}
+	if err != nil {
+		rt.l.WithContext(r.Context()).Error(err, "http - v1 - GetDeliveries - rt.wh.GetDeliveries")
+		errorResponse(w, http.StatusInternalServerError, "error getting webhook deliveries")
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(listOfDeliveriesResponse{deliveries})
+}
+
+// @Summary     Redeliver Webhook Delivery
+// @Description Queues a delivery of one of the user's webhooks to be sent again with a fresh set of attempts.
+// @ID          RedeliverWebhookDelivery
+// @Security    ApiKeyAuth
+// @Tags        Webhook
+// @Accept      json
+// @Produce     json
+// @Success     200 {object} entity.WebhookDelivery "Delivery queued"
+// @Failure     404 {object} response "Delivery not found"
+// @Failure     500 {object} response "Internal server error"
+// @Router      /webhooks/deliveries/{id}/redeliver [post]
+// @Param       id path int true "Delivery ID"
+func (rt *webhookRoutes) Redeliver(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		rt.l.WithContext(r.Context()).Error(err, "http - v1 - Redeliver")
+		errorResponse(w, http.StatusInternalServerError, "error decoding request parameters")
+		return
+	}
+	usr, err := claims.User(r.Context())
+	if err != nil {
+		rt.l.WithContext(r.Context()).Error(err, "http - v1 - Redeliver - claims.User")
+		errorResponse(w, http.StatusInternalServerError, "error getting token claims")
+		return
+	}
+	d, err := rt.wh.Redeliver(r.Context(), usr, id)
+	if errors.Is(err, entity.ErrWebhookDeliveryNotFound) {
+		errorResponse(w, http.StatusNotFound, "Delivery not found")
+		return
+	}
+	if err != nil {
+		rt.l.WithContext(r.Context()).Error(err, "http - v1 - Redeliver - rt.wh.Redeliver")
+		errorResponse(w, http.StatusInternalServerError, "error redelivering webhook delivery")
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(d)
+}
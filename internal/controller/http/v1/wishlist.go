@@ -0,0 +1,159 @@
+package v1
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/Klef99/bhs-task/internal/controller/claims"
+	"github.com/Klef99/bhs-task/internal/entity"
+	"github.com/Klef99/bhs-task/internal/usecase"
+	"github.com/Klef99/bhs-task/pkg/jwtgenerator"
+	"github.com/Klef99/bhs-task/pkg/logger"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/jwtauth/v5"
+)
+
+type wishlistRoutes struct {
+	w   usecase.Wishlist
+	l   logger.Interface
+	jtg jwtgenerator.Interface
+}
+
+func NewWishlistRoutes(handler chi.Router, w usecase.Wishlist, l logger.Interface, jtg jwtgenerator.Interface) {
+	rt := &wishlistRoutes{w: w, l: l, jtg: jtg}
+	tokenAuth := rt.jtg.GetJWTAuth()
+	router := chi.NewRouter()
+	router.Use(jwtauth.Verifier(tokenAuth))
+	router.Use(jwtauth.Authenticator(tokenAuth))
+	router.Group(func(r chi.Router) {
+		r.Get("/", rt.GetWishlist)
+		r.Post("/", rt.AddToWishlist)
+		r.Delete("/{id}", rt.RemoveFromWishlist)
+	})
+	handler.Mount("/wishlist", router)
+}
+
+type addToWishlistRequest struct {
+	AssetId int64 `json:"asset_id"`
+}
+
+type wishlistResponse struct {
+	Items []entity.WishlistItem `json:"items"`
+}
+
+// @Summary     Get Wishlist
+// @Description Retrieves the assets the user saved for later with their current prices and whether they are listed on the market.
+// @ID          GetWishlist
+// @Security    ApiKeyAuth
+// @Tags        Wishlist
+// @Accept      json
+// @Produce     json
+// @Success     200 {object} wishlistResponse "Wishlist of the user"
+// @Failure     500 {object} response "Internal server error"
+// @Router      /wishlist [get]
+func (rt *wishlistRoutes) GetWishlist(w http.ResponseWriter, r *http.Request) {
+	usr, err := claims.User(r.Context())
+	if err != nil {
+		rt.l.WithContext(r.Context()).Error(err, "http - v1 - GetWishlist - claims.User")
+		errorResponse(w, http.StatusInternalServerError, "error getting token claims")
+		return
+	}
+	items, err := rt.w.GetWishlist(r.Context(), usr)
+	if err != nil {
+		rt.l.WithContext(r.Context()).Error(err, "http - v1 - GetWishlist - rt.w.GetWishlist")
+		errorResponse(w, http.StatusInternalServerError, "error getting wishlist")
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(wishlistResponse{items})
+}
+
+// @Summary     Add To Wishlist
+// @Description Saves a published asset of another user for later. The user is notified when its price goes down. Adding an asset that is already on the wishlist has no effect.
+// @ID          AddToWishlist
+// @Security    ApiKeyAuth
+// @Tags        Wishlist
+// @Accept      json
+// @Produce     json
+// @Success     200 {object} response "Asset added to wishlist"
+// @Failure     400 {object} response "Invalid asset id"
+// @Failure     404 {object} response "Asset not found"
+// @Failure     409 {object} response "Asset is not published"
+// @Failure     500 {object} response "Internal server error"
+// @Router      /wishlist [post]
+// @Param       request body addToWishlistRequest true "Asset to add"
+func (rt *wishlistRoutes) AddToWishlist(w http.ResponseWriter, r *http.Request) {
+	req := addToWishlistRequest{}
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		rt.l.WithContext(r.Context()).Error(err, "http - v1 - AddToWishlist - decoder.Decode")
+		errorResponse(w, http.StatusInternalServerError, "error decoding request body")
+		return
+	}
+	if req.AssetId <= 0 {
+		errorResponse(w, http.StatusBadRequest, "asset id should be positive")
+		return
+	}
+	usr, err := claims.User(r.Context())
+	if err != nil {
+		rt.l.WithContext(r.Context()).Error(err, "http - v1 - AddToWishlist - claims.User")
+		errorResponse(w, http.StatusInternalServerError, "error getting token claims")
+		return
+	}
+	_, err = rt.w.AddToWishlist(r.Context(), usr, req.AssetId)
+	if err != nil {
+		switch {
+		case errors.Is(err, entity.ErrAssetNotFound):
+			errorResponse(w, http.StatusNotFound, "Asset not found")
+		case errors.Is(err, entity.ErrAssetNotAvailable):
+			errorResponse(w, http.StatusConflict, "Only published assets can be wishlisted")
+		default:
+			rt.l.WithContext(r.Context()).Error(err, "http - v1 - AddToWishlist - rt.w.AddToWishlist")
+			errorResponse(w, http.StatusInternalServerError, "error adding asset to wishlist")
+		}
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response{"Asset added to wishlist"})
+}
+
+// @Summary     Remove From Wishlist
+// @Description Removes an asset from the user's wishlist.
+// @ID          RemoveFromWishlist
+// @Security    ApiKeyAuth
+// @Tags        Wishlist
+// @Accept      json
+// @Produce     json
+// @Success     200 {object} response "Asset removed from wishlist"
+// @Failure     404 {object} response "Asset is not on the wishlist"
+// @Failure     500 {object} response "Internal server error"
+// @Router      /wishlist/{id} [delete]
+// @Param       id path int true "Asset ID"
+func (rt *wishlistRoutes) RemoveFromWishlist(w http.ResponseWriter, r *http.Request) {
+	idAsset, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		rt.l.WithContext(r.Context()).Error(err, "http - v1 - RemoveFromWishlist")
+		errorResponse(w, http.StatusInternalServerError, "error decoding request parameters")
+		return
+	}
+	usr, err := claims.User(r.Context())
+	if err != nil {
+		rt.l.WithContext(r.Context()).Error(err, "http - v1 - RemoveFromWishlist - claims.User")
+		errorResponse(w, http.StatusInternalServerError, "error getting token claims")
+		return
+	}
+	status, err := rt.w.RemoveFromWishlist(r.Context(), usr, idAsset)
+	if err != nil {
+		rt.l.WithContext(r.Context()).Error(err, "http - v1 - RemoveFromWishlist - rt.w.RemoveFromWishlist")
+		errorResponse(w, http.StatusInternalServerError, "error removing asset from wishlist")
+		return
+	}
+	if !status {
+		errorResponse(w, http.StatusNotFound, "Asset is not on the wishlist")
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response{"Asset removed from wishlist"})
+}
@@ -0,0 +1,28 @@
+package entity
+
+import "time"
+
+// BalanceAdjustment - manual change of a user's balance made by an operator.
+type BalanceAdjustment struct {
+	Id        int64     `json:"id"`
+	UserId    int64     `json:"user_id"`
+	Amount    float64   `json:"amount"` // negative to take funds away
+	Reason    string    `json:"reason"`
+	Actor     string    `json:"actor"`   // operator who made the change
+	Balance   float64   `json:"balance"` // after the adjustment
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// AssetFilter - selection of assets for operators, empty fields match any asset.
+type AssetFilter struct {
+	Owner          string
+	Status         AssetStatus
+	IncludeDeleted bool
+}
+
+// ReconcileIssue - inconsistency found by a reconciliation check.
+type ReconcileIssue struct {
+	Check   string `json:"check"`
+	Subject string `json:"subject"` // e.g. "asset 12"
+	Detail  string `json:"detail"`
+}
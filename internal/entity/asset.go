@@ -1,9 +1,80 @@
 package entity
 
+import "time"
+
+// AssetStatus - lifecycle state of an asset.
+type AssetStatus string
+
+const (
+	AssetStatusDraft     AssetStatus = "draft"     // visible only to the owner
+	AssetStatusPublished AssetStatus = "published" // listed on the market and available for purchase
+	AssetStatusUnlisted  AssetStatus = "unlisted"  // hidden from the market, still accessible to buyers
+	AssetStatusArchived  AssetStatus = "archived"  // withdrawn for good, still accessible to buyers
+)
+
+// assetStatusTransitions - allowed moves of the asset state machine.
+var assetStatusTransitions = map[AssetStatus][]AssetStatus{
+	AssetStatusDraft:     {AssetStatusPublished, AssetStatusUnlisted, AssetStatusArchived},
+	AssetStatusPublished: {AssetStatusUnlisted, AssetStatusArchived},
+	AssetStatusUnlisted:  {AssetStatusPublished, AssetStatusArchived},
+	AssetStatusArchived:  {},
+}
+
+// Valid -.
+func (s AssetStatus) Valid() bool {
+	_, ok := assetStatusTransitions[s]
+	return ok
+}
+
+// CanTransitionTo reports whether an asset in status s may be moved to next.
+func (s AssetStatus) CanTransitionTo(next AssetStatus) bool {
+	for _, st := range assetStatusTransitions[s] {
+		if st == next {
+			return true
+		}
+	}
+	return false
+}
+
 type Asset struct {
-	Id          int64   `json:"id"`
-	Name        string  `json:"name"`
-	Description string  `json:"description"`
-	Price       float32 `json:"price"`
-	Owner_id    int64   `json:"owner_id"`
+	Id          int64       `json:"id"`
+	Name        string      `json:"name"`
+	Description string      `json:"description"`
+	Price       float32     `json:"price"`
+	Owner_id    int64       `json:"owner_id"`
+	Status      AssetStatus `json:"status"`
+	SaleMode    SaleMode    `json:"sale_mode"`
+	Stock       *int64      `json:"stock,omitempty"`       // total editions, unlimited if not set
+	Sold        int64       `json:"sold"`                  // editions sold so far
+	Remaining   *int64      `json:"remaining,omitempty"`   // editions left for sale, unlimited if not set
+	Edition     *int64      `json:"edition,omitempty"`     // edition number owned by the user, in purchased listings
+	DeletedAt   *time.Time  `json:"deleted_at,omitempty"`  // set once the owner has deleted the asset
+	AccessDays  *int64      `json:"access_days,omitempty"` // rental or subscription period, permanent access if not set
+	// AccessExpiresAt - end of the user's access, in purchased listings of rented assets.
+	AccessExpiresAt *time.Time `json:"access_expires_at,omitempty"`
+	Rating          *float64   `json:"rating,omitempty"` // average of the reviews, not set until the first one
+	RatingCount     int64      `json:"rating_count"`
+}
+
+// SetRemaining - fills Remaining from Stock and Sold.
+func (a *Asset) SetRemaining() {
+	if a.Stock == nil {
+		a.Remaining = nil
+		return
+	}
+	remaining := *a.Stock - a.Sold
+	a.Remaining = &remaining
+}
+
+// SaleMode - what a buyer gets for the price of an asset.
+type SaleMode string
+
+const (
+	SaleModeLicense  SaleMode = "license"  // buyer gets access, the owner keeps the asset and can sell it again
+	SaleModeTransfer SaleMode = "transfer" // ownership passes to the buyer, one-of-a-kind goods
+)
+
+// Valid -.
+func (m SaleMode) Valid() bool {
+	return m == SaleModeLicense || m == SaleModeTransfer
 }
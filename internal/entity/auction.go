@@ -0,0 +1,43 @@
+package entity
+
+import "time"
+
+// AuctionStatus -.
+type AuctionStatus string
+
+const (
+	AuctionStatusOpen   AuctionStatus = "open"   // accepting bids until EndAt
+	AuctionStatusSold   AuctionStatus = "sold"   // settled, the highest bidder bought the asset
+	AuctionStatusUnsold AuctionStatus = "unsold" // settled without a sale, the reserve price was not met
+)
+
+type Auction struct {
+	Id              int64         `json:"id"`
+	AssetId         int64         `json:"asset_id"`
+	SellerId        int64         `json:"seller_id"`
+	ReservePrice    float64       `json:"reserve_price"`
+	EndAt           time.Time     `json:"end_at"`
+	Status          AuctionStatus `json:"status"`
+	HighestBid      *float64      `json:"highest_bid,omitempty"`
+	HighestBidderId *int64        `json:"highest_bidder_id,omitempty"`
+	CreatedAt       time.Time     `json:"created_at"`
+	SettledAt       *time.Time    `json:"settled_at,omitempty"`
+}
+
+// BidStatus -.
+type BidStatus string
+
+const (
+	BidStatusHeld     BidStatus = "held"     // highest bid, the amount is held from the bidder's balance
+	BidStatusReleased BidStatus = "released" // outbid or auction unsold, the amount is returned
+	BidStatusWon      BidStatus = "won"      // the amount paid for the asset
+)
+
+type Bid struct {
+	Id        int64     `json:"id"`
+	AuctionId int64     `json:"auction_id"`
+	BidderId  int64     `json:"bidder_id"`
+	Amount    float64   `json:"amount"`
+	Status    BidStatus `json:"status"`
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -0,0 +1,27 @@
+package entity
+
+import "time"
+
+// CartItem - an asset the user intends to buy at checkout.
+type CartItem struct {
+	AssetId   int64       `json:"asset_id"`
+	Name      string      `json:"name"`
+	Price     float64     `json:"price"`
+	Status    AssetStatus `json:"status"`
+	Available bool        `json:"available"` // can be bought right now
+	AddedAt   time.Time   `json:"added_at"`
+}
+
+type Cart struct {
+	Items []CartItem `json:"items"`
+	Total float64    `json:"total"`
+}
+
+// Receipt - the purchases made by a single checkout.
+type Receipt struct {
+	Id        int64      `json:"id"`
+	BuyerId   int64      `json:"buyer_id"`
+	Total     float64    `json:"total"`
+	Purchases []Purchase `json:"purchases"`
+	CreatedAt time.Time  `json:"created_at"`
+}
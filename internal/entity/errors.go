@@ -0,0 +1,46 @@
+package entity
+
+import "errors"
+
+var (
+	ErrUserNotFound = errors.New("user not found")
+
+	ErrAssetNotFound           = errors.New("asset not found")
+	ErrAssetNotAvailable       = errors.New("asset is not available for purchase")
+	ErrOwnAsset                = errors.New("user can't buy their own asset")
+	ErrAssetAlreadyPurchased   = errors.New("user already has access to the asset")
+	ErrAssetSoldOut            = errors.New("asset is sold out")
+	ErrInvalidStatusTransition = errors.New("invalid asset status transition")
+	ErrInsufficientFunds       = errors.New("insufficient funds")
+	ErrAssetNotRenewable       = errors.New("asset access is permanent and can't be renewed")
+	ErrAccessNotFound          = errors.New("user has no access to the asset")
+	ErrRecipientNotFound       = errors.New("gift recipient not found")
+	ErrRecipientOwnsAsset      = errors.New("gift recipient already owns the asset")
+
+	ErrAuctionNotFound = errors.New("auction not found")
+	ErrAuctionExists   = errors.New("asset already has an open auction")
+	ErrAuctionClosed   = errors.New("auction is closed")
+	ErrBidTooLow       = errors.New("bid must be higher than the current highest bid")
+
+	ErrOfferNotFound = errors.New("offer not found")
+	ErrOfferExists   = errors.New("user already has a pending offer for the asset")
+	ErrOfferClosed   = errors.New("offer is no longer pending")
+
+	ErrCartEmpty = errors.New("cart is empty")
+
+	ErrPromoCodeNotFound      = errors.New("promo code not found")
+	ErrPromoCodeExists        = errors.New("promo code already exists")
+	ErrPromoCodeExpired       = errors.New("promo code has expired or is used up")
+	ErrPromoCodeNotApplicable = errors.New("promo code does not apply to the asset")
+
+	ErrReviewNotFound = errors.New("review not found")
+	ErrReviewExists   = errors.New("user already reviewed the asset")
+	ErrReviewReplied  = errors.New("review already has a reply")
+
+	ErrStatsRangeTooLong = errors.New("range is too long for the bucket")
+
+	ErrWebhookNotFound         = errors.New("webhook not found")
+	ErrWebhookDeliveryNotFound = errors.New("webhook delivery not found")
+
+	ErrInvalidRateLimitPolicy = errors.New("rate limit policy needs a name, a positive rate and a burst of at least 1")
+)
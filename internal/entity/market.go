@@ -0,0 +1,22 @@
+package entity
+
+import "time"
+
+// MarketEventType - change of the market pushed to the live feed.
+type MarketEventType string
+
+const (
+	MarketAssetCreated MarketEventType = "asset.created" // a new asset is listed right away
+	MarketAssetUpdated MarketEventType = "asset.updated" // status or price changed
+	MarketAssetDeleted MarketEventType = "asset.deleted"
+	MarketAssetSold    MarketEventType = "asset.sold"
+)
+
+// MarketEvent - a market change, only what is needed to refresh a listing; details are fetched through the API.
+type MarketEvent struct {
+	Type       MarketEventType `json:"type"`
+	AssetId    int64           `json:"asset_id"`
+	Status     AssetStatus     `json:"status,omitempty"`
+	Price      *float64        `json:"price,omitempty"`
+	OccurredAt time.Time       `json:"occurred_at"`
+}
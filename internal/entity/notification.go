@@ -0,0 +1,22 @@
+package entity
+
+import "time"
+
+type NotificationKind string
+
+const (
+	// NotificationKindPriceDrop - the price of a wishlisted asset went down.
+	NotificationKindPriceDrop NotificationKind = "price_drop"
+)
+
+// Notification - an in-app message for the user.
+type Notification struct {
+	Id        int64            `json:"id"`
+	UserId    int64            `json:"user_id"`
+	Kind      NotificationKind `json:"kind"`
+	AssetId   *int64           `json:"asset_id,omitempty"`
+	OldPrice  *float64         `json:"old_price,omitempty"`
+	NewPrice  *float64         `json:"new_price,omitempty"`
+	CreatedAt time.Time        `json:"created_at"`
+	ReadAt    *time.Time       `json:"read_at,omitempty"`
+}
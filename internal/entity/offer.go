@@ -0,0 +1,31 @@
+package entity
+
+import "time"
+
+// OfferStatus -.
+type OfferStatus string
+
+const (
+	OfferStatusPending   OfferStatus = "pending"   // waiting for the other party until ExpiresAt
+	OfferStatusAccepted  OfferStatus = "accepted"  // the buyer bought the asset for Price
+	OfferStatusRejected  OfferStatus = "rejected"  // declined by the other party
+	OfferStatusCountered OfferStatus = "countered" // replaced by a counter-offer with another price
+	OfferStatusExpired   OfferStatus = "expired"   // not answered in time
+)
+
+// Offer - a price proposed for an asset by either the buyer or the owner (counter-offer).
+// Only the party that did not propose it can accept, reject or counter it.
+type Offer struct {
+	Id          int64       `json:"id"`
+	AssetId     int64       `json:"asset_id"`
+	BuyerId     int64       `json:"buyer_id"`
+	SellerId    int64       `json:"seller_id"`
+	ProposedBy  int64       `json:"proposed_by"`
+	ParentId    *int64      `json:"parent_id,omitempty"` // the offer this one counters
+	Price       float64     `json:"price"`
+	Status      OfferStatus `json:"status"`
+	PurchaseId  *int64      `json:"purchase_id,omitempty"`
+	ExpiresAt   time.Time   `json:"expires_at"`
+	CreatedAt   time.Time   `json:"created_at"`
+	RespondedAt *time.Time  `json:"responded_at,omitempty"`
+}
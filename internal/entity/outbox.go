@@ -0,0 +1,26 @@
+package entity
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Aggregates events are ordered by: events of the same aggregate are published in the order they were written.
+const (
+	AggregateAsset = "asset"
+	AggregateUser  = "user"
+)
+
+// OutboxEvent - an event written in the transaction that produced it, published by the relay afterwards.
+type OutboxEvent struct {
+	Id            int64
+	AggregateType string
+	AggregateId   int64
+	Type          EventType
+	Payload       json.RawMessage // the Event as delivered to subscribers
+	UserIds       []int64
+	Attempts      int
+	NextAttemptAt time.Time
+	LastError     string
+	CreatedAt     time.Time
+}
@@ -0,0 +1,100 @@
+package entity
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+)
+
+var _promoCodeFormat = regexp.MustCompile(`^[A-Z0-9_-]{3,32}$`)
+
+// DiscountKind - how a promo code reduces the price.
+type DiscountKind string
+
+const (
+	DiscountPercent DiscountKind = "percent" // Value percent off the price
+	DiscountFixed   DiscountKind = "fixed"   // Value off the price, never below zero
+)
+
+// Valid -.
+func (k DiscountKind) Valid() bool {
+	return k == DiscountPercent || k == DiscountFixed
+}
+
+// PromoCode - a discount redeemable at purchase. AssetId limits it to one asset, SellerId to the
+// assets of one seller; a code with neither set applies to the whole market and can only be
+// created by an admin.
+type PromoCode struct {
+	Id        int64        `json:"id"`
+	Code      string       `json:"code"`
+	Kind      DiscountKind `json:"kind"`
+	Value     float64      `json:"value"`
+	AssetId   *int64       `json:"asset_id,omitempty"`
+	SellerId  *int64       `json:"seller_id,omitempty"`
+	CreatedBy int64        `json:"created_by"`
+	MaxUses   *int64       `json:"max_uses,omitempty"` // unlimited if not set
+	Used      int64        `json:"used"`
+	ExpiresAt *time.Time   `json:"expires_at,omitempty"` // never expires if not set
+	CreatedAt time.Time    `json:"created_at"`
+}
+
+// Validate checks the fields set by the creator, Code must already be normalized.
+func (p PromoCode) Validate() error {
+	if !_promoCodeFormat.MatchString(p.Code) {
+		return errors.New("code must be 3 to 32 letters, digits, '-' or '_'")
+	}
+	if !p.Kind.Valid() {
+		return errors.New("kind must be percent or fixed")
+	}
+	if p.Value <= 0 || (p.Kind == DiscountPercent && p.Value > 100) {
+		return errors.New("value must be positive and at most 100 percent")
+	}
+	if p.AssetId != nil && *p.AssetId <= 0 {
+		return errors.New("asset id must be positive")
+	}
+	if p.MaxUses != nil && *p.MaxUses <= 0 {
+		return errors.New("max uses must be positive")
+	}
+	if p.ExpiresAt != nil && !p.ExpiresAt.After(time.Now()) {
+		return errors.New("expiry time must be in the future")
+	}
+	return nil
+}
+
+// Discount - the amount taken off price by the code.
+func (p PromoCode) Discount(price float64) float64 {
+	discount := p.Value
+	if p.Kind == DiscountPercent {
+		discount = price * p.Value / 100
+	}
+	if discount > price {
+		discount = price
+	}
+	return discount
+}
+
+// AppliesTo reports whether the code can be redeemed for the asset of the seller.
+func (p PromoCode) AppliesTo(assetId, sellerId int64) bool {
+	return (p.AssetId == nil || *p.AssetId == assetId) && (p.SellerId == nil || *p.SellerId == sellerId)
+}
+
+// UsedUp reports whether the code has reached its usage limit.
+func (p PromoCode) UsedUp() bool {
+	return p.MaxUses != nil && p.Used >= *p.MaxUses
+}
+
+// NormalizePromoCode - codes are case-insensitive and stored upper case.
+func NormalizePromoCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// PromoRedemption - a use of a promo code in a purchase.
+type PromoRedemption struct {
+	Id          int64     `json:"id"`
+	PromoCodeId int64     `json:"promo_code_id"`
+	PurchaseId  int64     `json:"purchase_id"`
+	UserId      int64     `json:"user_id"`
+	Discount    float64   `json:"discount"`
+	RedeemedAt  time.Time `json:"redeemed_at"`
+}
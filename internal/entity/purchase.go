@@ -0,0 +1,30 @@
+package entity
+
+import "time"
+
+// Purchase - record of a single sale of an asset.
+type Purchase struct {
+	Id        int64    `json:"id"`
+	AssetId   int64    `json:"asset_id"` // 0 once the asset is purged
+	BuyerId   int64    `json:"buyer_id"`
+	SellerId  int64    `json:"seller_id"`
+	Price     float64  `json:"price"`
+	SaleMode  SaleMode `json:"sale_mode"`
+	Edition   *int64   `json:"edition,omitempty"`    // set for limited-stock assets
+	ReceiptId *int64   `json:"receipt_id,omitempty"` // set for purchases made by a cart checkout
+	Discount  float64  `json:"discount,omitempty"`   // taken off the listed price by a promo code
+	// RecipientId - set for gifts, the user who got the asset paid for by the buyer.
+	RecipientId *int64 `json:"recipient_id,omitempty"`
+	GiftMessage string `json:"gift_message,omitempty"`
+	// AccessExpiresAt - end of the access bought, set for rented assets.
+	AccessExpiresAt *time.Time `json:"access_expires_at,omitempty"`
+	PurchasedAt     time.Time  `json:"purchased_at"`
+}
+
+// Holder - the user who got the asset.
+func (p Purchase) Holder() int64 {
+	if p.RecipientId != nil {
+		return *p.RecipientId
+	}
+	return p.BuyerId
+}
@@ -0,0 +1,30 @@
+package entity
+
+import "time"
+
+// RateLimitPolicy - token bucket holding up to Burst requests, refilled at Rate requests per second.
+type RateLimitPolicy struct {
+	Name  string
+	Rate  float64
+	Burst int
+}
+
+// Valid -.
+func (p RateLimitPolicy) Valid() bool {
+	return p.Name != "" && p.Rate > 0 && p.Burst >= 1
+}
+
+// RateLimitBucket - state of a bucket once a request tried to take a token from it.
+type RateLimitBucket struct {
+	Tokens  float64 // left in the bucket
+	Allowed bool    // the request took a token
+}
+
+// RateLimitResult - decision on a request and what is reported in the RateLimit headers.
+type RateLimitResult struct {
+	Allowed    bool
+	Limit      int
+	Remaining  int
+	Reset      time.Duration // until the bucket is full again
+	RetryAfter time.Duration // until the next request is allowed, zero if this one was
+}
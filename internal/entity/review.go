@@ -0,0 +1,38 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	MinRating = 1
+	MaxRating = 5
+
+	// MaxReviewLength - of the review text and of the seller's reply, in characters.
+	MaxReviewLength = 2000
+)
+
+// Review - a buyer's rating of an asset with an optional text and the seller's reply.
+type Review struct {
+	Id        int64      `json:"id"`
+	AssetId   int64      `json:"asset_id"`
+	UserId    int64      `json:"user_id"`
+	Rating    int        `json:"rating"`
+	Text      string     `json:"text"`
+	Reply     *string    `json:"reply,omitempty"`
+	RepliedAt *time.Time `json:"replied_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// Validate - checks the fields set by the reviewer.
+func (r Review) Validate() error {
+	if r.Rating < MinRating || r.Rating > MaxRating {
+		return fmt.Errorf("rating must be between %d and %d", MinRating, MaxRating)
+	}
+	if utf8.RuneCountInString(r.Text) > MaxReviewLength {
+		return fmt.Errorf("review is longer than %d characters", MaxReviewLength)
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package entity
+
+import "time"
+
+// StatsBucket - length of a point of the sales series.
+type StatsBucket string
+
+const (
+	StatsBucketDay   StatsBucket = "day"
+	StatsBucketWeek  StatsBucket = "week"  // weeks start on Monday
+	StatsBucketMonth StatsBucket = "month" // calendar months
+)
+
+// Valid -.
+func (b StatsBucket) Valid() bool {
+	return b == StatsBucketDay || b == StatsBucketWeek || b == StatsBucketMonth
+}
+
+// Truncate - start of the bucket t falls into, in UTC.
+func (b StatsBucket) Truncate(t time.Time) time.Time {
+	t = t.UTC()
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	switch b {
+	case StatsBucketWeek:
+		return day.AddDate(0, 0, -(int(day.Weekday())+6)%7)
+	case StatsBucketMonth:
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	default:
+		return day
+	}
+}
+
+// Next - start of the bucket following the one starting at start.
+func (b StatsBucket) Next(start time.Time) time.Time {
+	switch b {
+	case StatsBucketWeek:
+		return start.AddDate(0, 0, 7)
+	case StatsBucketMonth:
+		return start.AddDate(0, 1, 0)
+	default:
+		return start.AddDate(0, 0, 1)
+	}
+}
+
+// AssetSales - sales of a single asset of the seller.
+type AssetSales struct {
+	AssetId int64   `json:"asset_id"`
+	Name    string  `json:"name"`
+	Units   int64   `json:"units"`
+	Revenue float64 `json:"revenue"`
+}
+
+// SalesPoint - sales within one bucket of the series.
+type SalesPoint struct {
+	Start   time.Time `json:"start"`
+	Units   int64     `json:"units"`
+	Revenue float64   `json:"revenue"`
+}
+
+// SellerStats - sales of the seller within [From, To). Revenue is what buyers paid, after discounts.
+type SellerStats struct {
+	From         time.Time    `json:"from"`
+	To           time.Time    `json:"to"`
+	Bucket       StatsBucket  `json:"bucket"`
+	TotalUnits   int64        `json:"total_units"`
+	TotalRevenue float64      `json:"total_revenue"`
+	Assets       []AssetSales `json:"assets"`
+	Series       []SalesPoint `json:"series"`
+}
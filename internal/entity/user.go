@@ -1,5 +1,13 @@
 package entity
 
+// Role - access level of a user.
+type Role string
+
+const (
+	RoleUser  Role = "user"
+	RoleAdmin Role = "admin"
+)
+
 type User struct {
 	Id       int64
 	Username string
@@ -9,3 +17,10 @@ type Credentials struct {
 	Username string `json:"username"`
 	Password string `json:"password"`
 }
+
+// Deposit - funds added to the user's balance.
+type Deposit struct {
+	UserId  int64   `json:"user_id"`
+	Amount  float64 `json:"amount"`
+	Balance float64 `json:"balance"` // after the deposit
+}
@@ -0,0 +1,101 @@
+package entity
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/netip"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// EventType - marketplace event a webhook can subscribe to.
+type EventType string
+
+const (
+	EventAssetCreated     EventType = "asset.created"
+	EventAssetPurchased   EventType = "asset.purchased"
+	EventDepositCompleted EventType = "deposit.completed"
+)
+
+// Valid -.
+func (t EventType) Valid() bool {
+	return t == EventAssetCreated || t == EventAssetPurchased || t == EventDepositCompleted
+}
+
+// Event - something that happened on the marketplace, delivered to the webhooks subscribed to its type.
+type Event struct {
+	Type       EventType   `json:"type"`
+	OccurredAt time.Time   `json:"occurred_at"`
+	Data       interface{} `json:"data"`
+	// UserIds - users the event concerns, their webhooks receive it along with the global ones.
+	UserIds []int64 `json:"-"`
+}
+
+// Webhook - an endpoint receiving signed event deliveries.
+type Webhook struct {
+	Id     int64       `json:"id"`
+	UserId int64       `json:"user_id"`
+	URL    string      `json:"url"`
+	Events []EventType `json:"events"`
+	// Secret - key of the HMAC-SHA256 signature of deliveries, returned only when the webhook is created.
+	Secret    string    `json:"secret,omitempty"`
+	Global    bool      `json:"global"` // receives the events of every user, registered by admins
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Validate - the URL must be absolute http(s) and at least one known event type given. Hosts that are
+// obviously internal are refused here, the webhook client checks the resolved address of every delivery.
+func (w Webhook) Validate() error {
+	u, err := url.Parse(w.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return errors.New("url must be an absolute http or https url")
+	}
+	host := strings.ToLower(u.Hostname())
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return errors.New("url must point to a public host")
+	}
+	if addr, err := netip.ParseAddr(host); err == nil {
+		addr = addr.Unmap()
+		if addr.IsLoopback() || addr.IsPrivate() || addr.IsLinkLocalUnicast() || addr.IsUnspecified() || addr.IsMulticast() {
+			return errors.New("url must point to a public host")
+		}
+	}
+	if len(w.Events) == 0 {
+		return errors.New("at least one event type is required")
+	}
+	for _, e := range w.Events {
+		if !e.Valid() {
+			return fmt.Errorf("unknown event type %q", e)
+		}
+	}
+	return nil
+}
+
+// DeliveryStatus - state of a webhook delivery.
+type DeliveryStatus string
+
+const (
+	DeliveryStatusPending   DeliveryStatus = "pending"   // waiting for the next attempt
+	DeliveryStatusSucceeded DeliveryStatus = "succeeded" // the endpoint answered with 2xx
+	DeliveryStatusFailed    DeliveryStatus = "failed"    // every attempt failed, can be redelivered manually
+)
+
+// WebhookDelivery - an event sent to a webhook, with the outcome of the last attempt.
+type WebhookDelivery struct {
+	Id             int64           `json:"id"`
+	WebhookId      int64           `json:"webhook_id"`
+	EventType      EventType       `json:"event_type"`
+	Payload        json.RawMessage `json:"payload" swaggertype:"object"`
+	Status         DeliveryStatus  `json:"status"`
+	Attempts       int             `json:"attempts"`
+	NextAttemptAt  time.Time       `json:"next_attempt_at"`
+	LastStatusCode *int            `json:"last_status_code,omitempty"`
+	LastError      string          `json:"last_error,omitempty"`
+	CreatedAt      time.Time       `json:"created_at"`
+	DeliveredAt    *time.Time      `json:"delivered_at,omitempty"`
+	// URL and Secret of the webhook, set on deliveries claimed for sending.
+	URL    string `json:"-"`
+	Secret string `json:"-"`
+}
@@ -0,0 +1,13 @@
+package entity
+
+import "time"
+
+// WishlistItem - an asset the user saved for later.
+type WishlistItem struct {
+	AssetId   int64       `json:"asset_id"`
+	Name      string      `json:"name"`
+	Price     float64     `json:"price"`
+	Status    AssetStatus `json:"status"`
+	Available bool        `json:"available"` // listed on the market right now
+	AddedAt   time.Time   `json:"added_at"`
+}
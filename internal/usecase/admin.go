@@ -0,0 +1,128 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Klef99/bhs-task/internal/entity"
+)
+
+// AdminUseCase -.
+type AdminUseCase struct {
+	repo AdminRepository
+}
+
+var _ Admin = (*AdminUseCase)(nil)
+
+// New -.
+func NewAdminUseCase(r AdminRepository) *AdminUseCase {
+	return &AdminUseCase{repo: r}
+}
+
+// SetRole -.
+func (uc *AdminUseCase) SetRole(ctx context.Context, username string, role entity.Role) error {
+	if username == "" {
+		return fmt.Errorf("AdminUseCase - SetRole - username is required")
+	}
+	if role != entity.RoleUser && role != entity.RoleAdmin {
+		return fmt.Errorf("AdminUseCase - SetRole - unknown role %q", role)
+	}
+	err := uc.repo.SetRole(ctx, username, role)
+	if err != nil {
+		return fmt.Errorf("AdminUseCase - SetRole - uc.repo.SetRole: %w", err)
+	}
+	return nil
+}
+
+// ResetPassword - sets a new password without knowing the old one.
+func (uc *AdminUseCase) ResetPassword(ctx context.Context, username, password string) error {
+	if username == "" || password == "" {
+		return fmt.Errorf("AdminUseCase - ResetPassword - username and password are required")
+	}
+	err := uc.repo.SetPassword(ctx, username, password)
+	if err != nil {
+		return fmt.Errorf("AdminUseCase - ResetPassword - uc.repo.SetPassword: %w", err)
+	}
+	return nil
+}
+
+// AdjustBalance - adds amount to the balance, a negative amount takes funds away. The reason and the
+// operator are kept with the adjustment.
+func (uc *AdminUseCase) AdjustBalance(ctx context.Context, username string, amount float64, reason, actor string) (entity.BalanceAdjustment, error) {
+	reason = strings.TrimSpace(reason)
+	if username == "" {
+		return entity.BalanceAdjustment{}, fmt.Errorf("AdminUseCase - AdjustBalance - username is required")
+	}
+	if amount == 0 {
+		return entity.BalanceAdjustment{}, fmt.Errorf("AdminUseCase - AdjustBalance - amount can't be zero")
+	}
+	if reason == "" || actor == "" {
+		return entity.BalanceAdjustment{}, fmt.Errorf("AdminUseCase - AdjustBalance - reason and actor are required")
+	}
+	adj, err := uc.repo.AdjustBalance(ctx, entity.BalanceAdjustment{Amount: amount, Reason: reason, Actor: actor}, username)
+	if err != nil {
+		return entity.BalanceAdjustment{}, fmt.Errorf("AdminUseCase - AdjustBalance - uc.repo.AdjustBalance: %w", err)
+	}
+	return adj, nil
+}
+
+// ListAssets - assets of every user in any status.
+func (uc *AdminUseCase) ListAssets(ctx context.Context, filter entity.AssetFilter) ([]entity.Asset, error) {
+	if filter.Status != "" && !filter.Status.Valid() {
+		return nil, fmt.Errorf("AdminUseCase - ListAssets - invalid asset status")
+	}
+	assets, err := uc.repo.ListAssets(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("AdminUseCase - ListAssets - uc.repo.ListAssets: %w", err)
+	}
+	return assets, nil
+}
+
+// TakeDownAsset - removes the asset from the market as if the owner deleted it, buyers keep their access.
+func (uc *AdminUseCase) TakeDownAsset(ctx context.Context, id int64) (bool, error) {
+	if id <= 0 {
+		return false, fmt.Errorf("AdminUseCase - TakeDownAsset - invalid asset id")
+	}
+	status, err := uc.repo.TakeDown(ctx, id)
+	if err != nil {
+		return false, fmt.Errorf("AdminUseCase - TakeDownAsset - uc.repo.TakeDown: %w", err)
+	}
+	return status, nil
+}
+
+// GrantAccess - gives the user access to the asset without a purchase, for days or for good if days is nil.
+func (uc *AdminUseCase) GrantAccess(ctx context.Context, username string, assetId int64, days *int64) error {
+	if username == "" || assetId <= 0 {
+		return fmt.Errorf("AdminUseCase - GrantAccess - invalid username or asset id")
+	}
+	if days != nil && *days <= 0 {
+		return fmt.Errorf("AdminUseCase - GrantAccess - days should be positive")
+	}
+	err := uc.repo.GrantAccess(ctx, username, assetId, days)
+	if err != nil {
+		return fmt.Errorf("AdminUseCase - GrantAccess - uc.repo.GrantAccess: %w", err)
+	}
+	return nil
+}
+
+// RevokeAccess - returns false if the user had no access to the asset.
+func (uc *AdminUseCase) RevokeAccess(ctx context.Context, username string, assetId int64) (bool, error) {
+	if username == "" || assetId <= 0 {
+		return false, fmt.Errorf("AdminUseCase - RevokeAccess - invalid username or asset id")
+	}
+	status, err := uc.repo.RevokeAccess(ctx, username, assetId)
+	if err != nil {
+		return false, fmt.Errorf("AdminUseCase - RevokeAccess - uc.repo.RevokeAccess: %w", err)
+	}
+	return status, nil
+}
+
+// Reconcile - runs the consistency checks, no issues means the data is consistent.
+func (uc *AdminUseCase) Reconcile(ctx context.Context) ([]entity.ReconcileIssue, error) {
+	issues, err := uc.repo.Reconcile(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("AdminUseCase - Reconcile - uc.repo.Reconcile: %w", err)
+	}
+	return issues, nil
+}
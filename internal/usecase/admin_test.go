@@ -0,0 +1,261 @@
+package usecase_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/Klef99/bhs-task/internal/entity"
+	"github.com/Klef99/bhs-task/internal/usecase"
+	"github.com/stretchr/testify/require"
+	gomock "go.uber.org/mock/gomock"
+)
+
+type setRoleTest struct {
+	name     string
+	username string
+	role     entity.Role
+	mock     func()
+	err      error
+}
+
+type adjustBalanceTest struct {
+	name     string
+	username string
+	amount   float64
+	reason   string
+	actor    string
+	mock     func()
+	res      entity.BalanceAdjustment
+	err      error
+}
+
+type grantAccessTest struct {
+	name     string
+	username string
+	assetId  int64
+	days     *int64
+	mock     func()
+	err      error
+}
+
+func AdminUseCase(t *testing.T) (*usecase.AdminUseCase, *MockAdminRepository) {
+	t.Helper()
+
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+
+	repo := NewMockAdminRepository(mockCtl)
+
+	AdminUseCase := usecase.NewAdminUseCase(repo)
+	return AdminUseCase, repo
+}
+
+func TestSetRole(t *testing.T) {
+	t.Parallel()
+
+	admin, repo := AdminUseCase(t)
+	tests := []setRoleTest{
+		{
+			name:     "admin",
+			username: "test",
+			role:     entity.RoleAdmin,
+			mock: func() {
+				repo.EXPECT().SetRole(context.Background(), "test", entity.RoleAdmin).Return(nil)
+			},
+			err: nil,
+		},
+		{
+			name:     "user not found",
+			username: "missing",
+			role:     entity.RoleUser,
+			mock: func() {
+				repo.EXPECT().SetRole(context.Background(), "missing", entity.RoleUser).Return(entity.ErrUserNotFound)
+			},
+			err: entity.ErrUserNotFound,
+		},
+		{
+			name:     "unknown role",
+			username: "test",
+			role:     "root",
+			mock:     func() {},
+			err:      fmt.Errorf("AdminUseCase - SetRole - unknown role"),
+		},
+		{
+			name:     "empty username",
+			username: "",
+			role:     entity.RoleAdmin,
+			mock:     func() {},
+			err:      fmt.Errorf("AdminUseCase - SetRole - username is required"),
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			tc.mock()
+			err := admin.SetRole(context.Background(), tc.username, tc.role)
+			if err != nil {
+				require.ErrorContains(t, err, tc.err.Error())
+			} else {
+				require.Nil(t, tc.err)
+			}
+		})
+	}
+}
+
+func TestAdjustBalance(t *testing.T) {
+	t.Parallel()
+
+	admin, repo := AdminUseCase(t)
+	tests := []adjustBalanceTest{
+		{
+			name:     "credit",
+			username: "test",
+			amount:   50,
+			reason:   "  refund for #12 ",
+			actor:    "ops",
+			mock: func() {
+				repo.EXPECT().AdjustBalance(context.Background(), entity.BalanceAdjustment{Amount: 50, Reason: "refund for #12", Actor: "ops"}, "test").
+					Return(entity.BalanceAdjustment{Id: 1, UserId: 1, Amount: 50, Reason: "refund for #12", Actor: "ops", Balance: 150}, nil)
+			},
+			res: entity.BalanceAdjustment{Id: 1, UserId: 1, Amount: 50, Reason: "refund for #12", Actor: "ops", Balance: 150},
+			err: nil,
+		},
+		{
+			name:     "debit below zero",
+			username: "test",
+			amount:   -500,
+			reason:   "chargeback",
+			actor:    "ops",
+			mock: func() {
+				repo.EXPECT().AdjustBalance(context.Background(), entity.BalanceAdjustment{Amount: -500, Reason: "chargeback", Actor: "ops"}, "test").
+					Return(entity.BalanceAdjustment{}, entity.ErrInsufficientFunds)
+			},
+			res: entity.BalanceAdjustment{},
+			err: entity.ErrInsufficientFunds,
+		},
+		{
+			name:     "zero amount",
+			username: "test",
+			amount:   0,
+			reason:   "nothing",
+			actor:    "ops",
+			mock:     func() {},
+			res:      entity.BalanceAdjustment{},
+			err:      fmt.Errorf("AdminUseCase - AdjustBalance - amount can't be zero"),
+		},
+		{
+			name:     "blank reason",
+			username: "test",
+			amount:   10,
+			reason:   "   ",
+			actor:    "ops",
+			mock:     func() {},
+			res:      entity.BalanceAdjustment{},
+			err:      fmt.Errorf("AdminUseCase - AdjustBalance - reason and actor are required"),
+		},
+		{
+			name:     "repository error",
+			username: "test",
+			amount:   10,
+			reason:   "bonus",
+			actor:    "ops",
+			mock: func() {
+				repo.EXPECT().AdjustBalance(context.Background(), entity.BalanceAdjustment{Amount: 10, Reason: "bonus", Actor: "ops"}, "test").
+					Return(entity.BalanceAdjustment{}, errInternalServErr)
+			},
+			res: entity.BalanceAdjustment{},
+			err: errInternalServErr,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			tc.mock()
+			res, err := admin.AdjustBalance(context.Background(), tc.username, tc.amount, tc.reason, tc.actor)
+			require.Equal(t, res, tc.res)
+			if err != nil {
+				require.ErrorContains(t, err, tc.err.Error())
+			} else {
+				require.Nil(t, err)
+			}
+		})
+	}
+}
+
+func TestGrantAccess(t *testing.T) {
+	t.Parallel()
+
+	admin, repo := AdminUseCase(t)
+	days := int64(30)
+	zero := int64(0)
+	tests := []grantAccessTest{
+		{
+			name:     "permanent",
+			username: "test",
+			assetId:  1,
+			days:     nil,
+			mock: func() {
+				repo.EXPECT().GrantAccess(context.Background(), "test", int64(1), nil).Return(nil)
+			},
+			err: nil,
+		},
+		{
+			name:     "for days",
+			username: "test",
+			assetId:  2,
+			days:     &days,
+			mock: func() {
+				repo.EXPECT().GrantAccess(context.Background(), "test", int64(2), &days).Return(nil)
+			},
+			err: nil,
+		},
+		{
+			name:     "asset not found",
+			username: "test",
+			assetId:  3,
+			days:     nil,
+			mock: func() {
+				repo.EXPECT().GrantAccess(context.Background(), "test", int64(3), nil).Return(entity.ErrAssetNotFound)
+			},
+			err: entity.ErrAssetNotFound,
+		},
+		{
+			name:     "zero days",
+			username: "test",
+			assetId:  1,
+			days:     &zero,
+			mock:     func() {},
+			err:      fmt.Errorf("AdminUseCase - GrantAccess - days should be positive"),
+		},
+		{
+			name:     "invalid asset id",
+			username: "test",
+			assetId:  0,
+			days:     nil,
+			mock:     func() {},
+			err:      fmt.Errorf("AdminUseCase - GrantAccess - invalid username or asset id"),
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			tc.mock()
+			err := admin.GrantAccess(context.Background(), tc.username, tc.assetId, tc.days)
+			if err != nil {
+				require.ErrorContains(t, err, tc.err.Error())
+			} else {
+				require.Nil(t, tc.err)
+			}
+		})
+	}
+}
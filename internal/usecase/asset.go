@@ -3,6 +3,8 @@ package usecase
 import (
 	"context"
 	"fmt"
+	"strings"
+	"unicode/utf8"
 
 	"github.com/Klef99/bhs-task/internal/entity"
 )
@@ -14,6 +16,9 @@ type AssetUseCase struct {
 
 var _ Asset = (*AssetUseCase)(nil)
 
+// _maxGiftMessageLength - in characters.
+const _maxGiftMessageLength = 500
+
 // New -.
 func NewAssetUseCase(r AssetRepository) *AssetUseCase {
 	return &AssetUseCase{repo: r}
@@ -23,13 +28,32 @@ func (uc *AssetUseCase) CreateAsset(ctx context.Context, ast entity.Asset) (bool
 	if ast.Name == "" || ast.Owner_id <= 0 {
 		return false, fmt.Errorf("AssetUseCase - CreateAsset - invalid asset data")
 	}
-	status, err := uc.repo.Store(ctx, ast)
+	if ast.Status == "" {
+		ast.Status = entity.AssetStatusDraft
+	}
+	if !ast.Status.Valid() || ast.Status == entity.AssetStatusArchived {
+		return false, fmt.Errorf("AssetUseCase - CreateAsset - invalid asset status")
+	}
+	if ast.SaleMode == "" {
+		ast.SaleMode = entity.SaleModeLicense
+	}
+	if !ast.SaleMode.Valid() {
+		return false, fmt.Errorf("AssetUseCase - CreateAsset - invalid sale mode")
+	}
+	if ast.Stock != nil && (*ast.Stock <= 0 || ast.SaleMode != entity.SaleModeLicense) {
+		return false, fmt.Errorf("AssetUseCase - CreateAsset - stock must be positive and is only available in license mode")
+	}
+	if ast.AccessDays != nil && (*ast.AccessDays <= 0 || ast.SaleMode != entity.SaleModeLicense) {
+		return false, fmt.Errorf("AssetUseCase - CreateAsset - access days must be positive and are only available in license mode")
+	}
+	_, err := uc.repo.Store(ctx, ast)
 	if err != nil {
 		return false, fmt.Errorf("AssetUseCase - CreateAsset - uc.repo.Store: %w", err)
 	}
-	return status, err
+	return true, nil
 }
 
+// DeleteAsset - soft deletes the asset, buyers keep their access to it.
 func (uc *AssetUseCase) DeleteAsset(ctx context.Context, user entity.User, id int64) (bool, error) {
 	if id <= 0 || user.Id <= 0 {
 		return false, fmt.Errorf("AssetUseCase - DeleteAsset - invalid user or asset id")
@@ -63,18 +87,19 @@ func (uc *AssetUseCase) GetAssetsToBuying(ctx context.Context, user entity.User)
 	return assets, nil
 }
 
-func (uc *AssetUseCase) BuyAsset(ctx context.Context, user entity.User, id int64) (bool, error) {
+// BuyAsset - promoCode is optional, empty for the listed price.
+func (uc *AssetUseCase) BuyAsset(ctx context.Context, user entity.User, id int64, promoCode string) (bool, error) {
 	if user.Id <= 0 {
 		return false, fmt.Errorf("AssetUseCase - BuyAsset - invalid user id")
 	}
 	if id <= 0 {
 		return false, fmt.Errorf("AssetUseCase - BuyAsset - invalid asset id")
 	}
-	status, err := uc.repo.BuyAsset(ctx, user, id)
+	_, err := uc.repo.BuyAsset(ctx, user, id, entity.NormalizePromoCode(promoCode))
 	if err != nil {
 		return false, fmt.Errorf("AssetUseCase - BuyAsset - uc.repo.BuyAsset: %w", err)
 	}
-	return status, err
+	return true, nil
 }
 
 func (uc *AssetUseCase) GetPurchasedAssets(ctx context.Context, user entity.User) ([]entity.Asset, error) {
@@ -88,13 +113,141 @@ func (uc *AssetUseCase) GetPurchasedAssets(ctx context.Context, user entity.User
 	return assets, nil
 }
 
-func (uc *AssetUseCase) GetAssetById(ctx context.Context, id int64) (entity.Asset, error) {
+func (uc *AssetUseCase) GetAssetById(ctx context.Context, user entity.User, id int64) (entity.Asset, error) {
 	if id <= 0 {
 		return entity.Asset{}, fmt.Errorf("AssetUseCase - GetAssetById - invalid asset id")
 	}
-	asset, err := uc.repo.GetAssetById(ctx, id)
+	if user.Id <= 0 {
+		return entity.Asset{}, fmt.Errorf("AssetUseCase - GetAssetById - invalid user id")
+	}
+	asset, err := uc.repo.GetAssetById(ctx, user, id)
 	if err != nil {
 		return entity.Asset{}, fmt.Errorf("AssetUseCase - GetAssetById - uc.repo.GetAssetById: %w", err)
 	}
 	return asset, nil
 }
+
+// GetAssetsByIds - for batch loading, assets that don't exist or the user can't see are left out.
+func (uc *AssetUseCase) GetAssetsByIds(ctx context.Context, user entity.User, ids []int64) ([]entity.Asset, error) {
+	if user.Id <= 0 {
+		return nil, fmt.Errorf("AssetUseCase - GetAssetsByIds - invalid user id")
+	}
+	if len(ids) == 0 {
+		return []entity.Asset{}, nil
+	}
+	assets, err := uc.repo.GetAssetsByIds(ctx, user, ids)
+	if err != nil {
+		return nil, fmt.Errorf("AssetUseCase - GetAssetsByIds - uc.repo.GetAssetsByIds: %w", err)
+	}
+	return assets, nil
+}
+
+func (uc *AssetUseCase) ChangeAssetStatus(ctx context.Context, user entity.User, id int64, status entity.AssetStatus) (bool, error) {
+	if id <= 0 || user.Id <= 0 {
+		return false, fmt.Errorf("AssetUseCase - ChangeAssetStatus - invalid user or asset id")
+	}
+	if !status.Valid() {
+		return false, fmt.Errorf("AssetUseCase - ChangeAssetStatus - invalid asset status")
+	}
+	ok, err := uc.repo.UpdateStatus(ctx, user, id, status)
+	if err != nil {
+		return false, fmt.Errorf("AssetUseCase - ChangeAssetStatus - uc.repo.UpdateStatus: %w", err)
+	}
+	return ok, nil
+}
+
+// PurgeAsset - removes the asset permanently together with every buyer's access, for admins only.
+func (uc *AssetUseCase) PurgeAsset(ctx context.Context, id int64) (bool, error) {
+	if id <= 0 {
+		return false, fmt.Errorf("AssetUseCase - PurgeAsset - invalid asset id")
+	}
+	status, err := uc.repo.Purge(ctx, id)
+	if err != nil {
+		return false, fmt.Errorf("AssetUseCase - PurgeAsset - uc.repo.Purge: %w", err)
+	}
+	return status, nil
+}
+
+// GetProvenance - chain of ownership transfers of the asset, the user must be able to see the asset.
+func (uc *AssetUseCase) GetProvenance(ctx context.Context, user entity.User, id int64) ([]entity.Purchase, error) {
+	if id <= 0 || user.Id <= 0 {
+		return nil, fmt.Errorf("AssetUseCase - GetProvenance - invalid user or asset id")
+	}
+	_, err := uc.repo.GetAssetById(ctx, user, id)
+	if err != nil {
+		return nil, fmt.Errorf("AssetUseCase - GetProvenance - uc.repo.GetAssetById: %w", err)
+	}
+	transfers, err := uc.repo.GetProvenance(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("AssetUseCase - GetProvenance - uc.repo.GetProvenance: %w", err)
+	}
+	return transfers, nil
+}
+
+// RenewAccess - buys another access period of a rented asset.
+func (uc *AssetUseCase) RenewAccess(ctx context.Context, user entity.User, id int64) (entity.Purchase, error) {
+	if user.Id <= 0 || id <= 0 {
+		return entity.Purchase{}, fmt.Errorf("AssetUseCase - RenewAccess - invalid user or asset id")
+	}
+	p, err := uc.repo.RenewAccess(ctx, user, id)
+	if err != nil {
+		return entity.Purchase{}, fmt.Errorf("AssetUseCase - RenewAccess - uc.repo.RenewAccess: %w", err)
+	}
+	return p, nil
+}
+
+// SweepExpiredAccess - removes expired rentals, returns how many were removed.
+func (uc *AssetUseCase) SweepExpiredAccess(ctx context.Context) (int64, error) {
+	removed, err := uc.repo.DeleteExpiredAccess(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("AssetUseCase - SweepExpiredAccess - uc.repo.DeleteExpiredAccess: %w", err)
+	}
+	return removed, nil
+}
+
+// GiftAsset - the user pays for the asset and the recipient, given by username, gets it.
+func (uc *AssetUseCase) GiftAsset(ctx context.Context, user entity.User, id int64, recipient string, message string) (entity.Purchase, error) {
+	if user.Id <= 0 || id <= 0 {
+		return entity.Purchase{}, fmt.Errorf("AssetUseCase - GiftAsset - invalid user or asset id")
+	}
+	recipient = strings.TrimSpace(recipient)
+	if recipient == "" || recipient == user.Username {
+		return entity.Purchase{}, fmt.Errorf("AssetUseCase - GiftAsset - invalid recipient")
+	}
+	message = strings.TrimSpace(message)
+	if utf8.RuneCountInString(message) > _maxGiftMessageLength {
+		return entity.Purchase{}, fmt.Errorf("AssetUseCase - GiftAsset - message is longer than %d characters", _maxGiftMessageLength)
+	}
+	p, err := uc.repo.Gift(ctx, user, id, recipient, message)
+	if err != nil {
+		return entity.Purchase{}, fmt.Errorf("AssetUseCase - GiftAsset - uc.repo.Gift: %w", err)
+	}
+	return p, nil
+}
+
+// GetPurchaseHistory - purchases made by the user and gifts they received.
+func (uc *AssetUseCase) GetPurchaseHistory(ctx context.Context, user entity.User) ([]entity.Purchase, error) {
+	if user.Id <= 0 {
+		return nil, fmt.Errorf("AssetUseCase - GetPurchaseHistory - invalid user id")
+	}
+	history, err := uc.repo.GetPurchaseHistory(ctx, user)
+	if err != nil {
+		return nil, fmt.Errorf("AssetUseCase - GetPurchaseHistory - uc.repo.GetPurchaseHistory: %w", err)
+	}
+	return history, nil
+}
+
+// ChangeAssetPrice - sets a new price of the owner's asset. Users who wishlisted the asset are notified when it goes down.
+func (uc *AssetUseCase) ChangeAssetPrice(ctx context.Context, user entity.User, id int64, price float64) (bool, error) {
+	if id <= 0 || user.Id <= 0 {
+		return false, fmt.Errorf("AssetUseCase - ChangeAssetPrice - invalid user or asset id")
+	}
+	if price < 0 {
+		return false, fmt.Errorf("AssetUseCase - ChangeAssetPrice - price can't be negative")
+	}
+	ok, err := uc.repo.UpdatePrice(ctx, user, id, price)
+	if err != nil {
+		return false, fmt.Errorf("AssetUseCase - ChangeAssetPrice - uc.repo.UpdatePrice: %w", err)
+	}
+	return ok, nil
+}
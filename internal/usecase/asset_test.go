@@ -3,7 +3,9 @@ package usecase_test
 import (
 	"context"
 	"fmt"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/Klef99/bhs-task/internal/entity"
 	"github.com/Klef99/bhs-task/internal/usecase"
@@ -52,30 +54,106 @@ type getAssetsToBuyingTest struct {
 }
 
 type buyAssetTest struct {
+	name  string
+	user  entity.User
+	id    int64
+	promo string
+	mock  func()
+	res   bool
+	err   error
+}
+
+type getPurchasedAssetTest struct {
+	name string
+	user entity.User
+	mock func()
+	res  []entity.Asset
+	err  error
+}
+
+type getAssetByIdTest struct {
 	name string
 	user entity.User
 	id   int64
 	mock func()
-	res  bool
+	res  entity.Asset
 	err  error
 }
 
-type getPurchasedAssetTest struct {
+type getAssetsByIdsTest struct {
 	name string
 	user entity.User
+	ids  []int64
 	mock func()
 	res  []entity.Asset
 	err  error
 }
 
-type getAssetByIdTest struct {
+type purgeAssetTest struct {
 	name string
 	id   int64
 	mock func()
-	res  entity.Asset
+	res  bool
+	err  error
+}
+
+type getProvenanceTest struct {
+	name string
+	user entity.User
+	id   int64
+	mock func()
+	res  []entity.Purchase
 	err  error
 }
 
+type renewAccessTest struct {
+	name string
+	user entity.User
+	id   int64
+	mock func()
+	res  entity.Purchase
+	err  error
+}
+
+type giftAssetTest struct {
+	name      string
+	user      entity.User
+	id        int64
+	recipient string
+	message   string
+	mock      func()
+	res       entity.Purchase
+	err       error
+}
+
+type getPurchaseHistoryTest struct {
+	name string
+	user entity.User
+	mock func()
+	res  []entity.Purchase
+	err  error
+}
+
+type changeAssetPriceTest struct {
+	name  string
+	user  entity.User
+	id    int64
+	price float64
+	mock  func()
+	res   bool
+	err   error
+}
+
+type changeAssetStatusTest struct {
+	name   string
+	user   entity.User
+	id     int64
+	status entity.AssetStatus
+	mock   func()
+	res    bool
+	err    error
+}
+
 func AssetUseCase(t *testing.T) (*usecase.AssetUseCase, *MockAssetRepository) {
 	t.Helper()
 
@@ -92,12 +170,14 @@ func TestCreateAsset(t *testing.T) {
 	t.Parallel()
 
 	asset, repo := AssetUseCase(t)
+	stockTen, stockZero := int64(10), int64(0)
+	days, noDays := int64(30), int64(0)
 	tests := []createAssetTest{
 		{
 			name: "empty asset",
 			ast:  entity.Asset{},
 			mock: func() {
-				repo.EXPECT().Store(context.Background(), entity.Asset{}).Return(false, errInternalServErr)
+				repo.EXPECT().Store(context.Background(), entity.Asset{}).Return(int64(0), errInternalServErr)
 			},
 			res: false,
 			err: fmt.Errorf("AssetUseCase - CreateAsset - invalid asset data"),
@@ -106,7 +186,7 @@ func TestCreateAsset(t *testing.T) {
 			name: "success",
 			ast:  entity.Asset{Owner_id: 1, Name: "Sword", Description: "Rare", Price: 100},
 			mock: func() {
-				repo.EXPECT().Store(context.Background(), entity.Asset{Owner_id: 1, Name: "Sword", Description: "Rare", Price: 100}).Return(true, nil)
+				repo.EXPECT().Store(context.Background(), entity.Asset{Owner_id: 1, Name: "Sword", Description: "Rare", Price: 100, Status: entity.AssetStatusDraft, SaleMode: entity.SaleModeLicense}).Return(int64(1), nil)
 			},
 			res: true,
 			err: nil,
@@ -115,7 +195,7 @@ func TestCreateAsset(t *testing.T) {
 			name: "success without description",
 			ast:  entity.Asset{Owner_id: 1, Name: "Sword", Price: 100},
 			mock: func() {
-				repo.EXPECT().Store(context.Background(), entity.Asset{Owner_id: 1, Name: "Sword", Price: 100}).Return(true, nil)
+				repo.EXPECT().Store(context.Background(), entity.Asset{Owner_id: 1, Name: "Sword", Price: 100, Status: entity.AssetStatusDraft, SaleMode: entity.SaleModeLicense}).Return(int64(1), nil)
 			},
 			res: true,
 			err: nil,
@@ -124,7 +204,7 @@ func TestCreateAsset(t *testing.T) {
 			name: "success with only name",
 			ast:  entity.Asset{Owner_id: 1, Name: "Sword"},
 			mock: func() {
-				repo.EXPECT().Store(context.Background(), entity.Asset{Owner_id: 1, Name: "Sword"}).Return(true, nil)
+				repo.EXPECT().Store(context.Background(), entity.Asset{Owner_id: 1, Name: "Sword", Status: entity.AssetStatusDraft, SaleMode: entity.SaleModeLicense}).Return(int64(1), nil)
 			},
 			res: true,
 			err: nil,
@@ -133,16 +213,101 @@ func TestCreateAsset(t *testing.T) {
 			name: "without owner id",
 			ast:  entity.Asset{Name: "Sword"},
 			mock: func() {
-				repo.EXPECT().Store(context.Background(), entity.Asset{Name: "Sword"}).Return(false, errInternalServErr)
+				repo.EXPECT().Store(context.Background(), entity.Asset{Name: "Sword"}).Return(int64(0), errInternalServErr)
 			},
 			res: false,
 			err: fmt.Errorf("AssetUseCase - CreateAsset - invalid asset data"),
 		},
+		{
+			name: "success published",
+			ast:  entity.Asset{Owner_id: 1, Name: "Sword", Status: entity.AssetStatusPublished},
+			mock: func() {
+				repo.EXPECT().Store(context.Background(), entity.Asset{Owner_id: 1, Name: "Sword", Status: entity.AssetStatusPublished, SaleMode: entity.SaleModeLicense}).Return(int64(1), nil)
+			},
+			res: true,
+			err: nil,
+		},
+		{
+			name: "success transfer mode",
+			ast:  entity.Asset{Owner_id: 1, Name: "Crown", SaleMode: entity.SaleModeTransfer},
+			mock: func() {
+				repo.EXPECT().Store(context.Background(), entity.Asset{Owner_id: 1, Name: "Crown", Status: entity.AssetStatusDraft, SaleMode: entity.SaleModeTransfer}).Return(int64(1), nil)
+			},
+			res: true,
+			err: nil,
+		},
+		{
+			name: "success limited stock",
+			ast:  entity.Asset{Owner_id: 1, Name: "Sword", Stock: &stockTen},
+			mock: func() {
+				repo.EXPECT().Store(context.Background(), entity.Asset{Owner_id: 1, Name: "Sword", Status: entity.AssetStatusDraft, SaleMode: entity.SaleModeLicense, Stock: &stockTen}).Return(int64(1), nil)
+			},
+			res: true,
+			err: nil,
+		},
+		{
+			name: "zero stock",
+			ast:  entity.Asset{Owner_id: 1, Name: "Sword", Stock: &stockZero},
+			mock: func() {},
+			res:  false,
+			err:  fmt.Errorf("AssetUseCase - CreateAsset - stock must be positive and is only available in license mode"),
+		},
+		{
+			name: "stock in transfer mode",
+			ast:  entity.Asset{Owner_id: 1, Name: "Crown", SaleMode: entity.SaleModeTransfer, Stock: &stockTen},
+			mock: func() {},
+			res:  false,
+			err:  fmt.Errorf("AssetUseCase - CreateAsset - stock must be positive and is only available in license mode"),
+		},
+		{
+			name: "success rental",
+			ast:  entity.Asset{Owner_id: 1, Name: "Sword", AccessDays: &days},
+			mock: func() {
+				repo.EXPECT().Store(context.Background(), entity.Asset{Owner_id: 1, Name: "Sword", Status: entity.AssetStatusDraft, SaleMode: entity.SaleModeLicense, AccessDays: &days}).Return(int64(1), nil)
+			},
+			res: true,
+			err: nil,
+		},
+		{
+			name: "zero access days",
+			ast:  entity.Asset{Owner_id: 1, Name: "Sword", AccessDays: &noDays},
+			mock: func() {},
+			res:  false,
+			err:  fmt.Errorf("AssetUseCase - CreateAsset - access days must be positive and are only available in license mode"),
+		},
+		{
+			name: "rental in transfer mode",
+			ast:  entity.Asset{Owner_id: 1, Name: "Crown", SaleMode: entity.SaleModeTransfer, AccessDays: &days},
+			mock: func() {},
+			res:  false,
+			err:  fmt.Errorf("AssetUseCase - CreateAsset - access days must be positive and are only available in license mode"),
+		},
+		{
+			name: "unknown sale mode",
+			ast:  entity.Asset{Owner_id: 1, Name: "Sword", SaleMode: "rent"},
+			mock: func() {},
+			res:  false,
+			err:  fmt.Errorf("AssetUseCase - CreateAsset - invalid sale mode"),
+		},
+		{
+			name: "archived on creation",
+			ast:  entity.Asset{Owner_id: 1, Name: "Sword", Status: entity.AssetStatusArchived},
+			mock: func() {},
+			res:  false,
+			err:  fmt.Errorf("AssetUseCase - CreateAsset - invalid asset status"),
+		},
+		{
+			name: "unknown status",
+			ast:  entity.Asset{Owner_id: 1, Name: "Sword", Status: "sold"},
+			mock: func() {},
+			res:  false,
+			err:  fmt.Errorf("AssetUseCase - CreateAsset - invalid asset status"),
+		},
 		{
 			name: "negative price",
 			ast:  entity.Asset{Owner_id: 1, Name: "Sword", Price: -1},
 			mock: func() {
-				repo.EXPECT().Store(context.Background(), entity.Asset{Owner_id: 1, Name: "Sword", Price: -1}).Return(false, errInternalServErr)
+				repo.EXPECT().Store(context.Background(), entity.Asset{Owner_id: 1, Name: "Sword", Price: -1, Status: entity.AssetStatusDraft, SaleMode: entity.SaleModeLicense}).Return(int64(0), errInternalServErr)
 			},
 			res: false,
 			err: errInternalServErr,
@@ -401,7 +566,7 @@ func TestBuyAsset(t *testing.T) {
 			user: entity.User{},
 			id:   1,
 			mock: func() {
-				repo.EXPECT().BuyAsset(context.Background(), entity.User{}, int64(1)).Return(false, errInternalServErr)
+				repo.EXPECT().BuyAsset(context.Background(), entity.User{}, int64(1), "").Return(entity.Purchase{}, errInternalServErr)
 			},
 			res: false,
 			err: fmt.Errorf("AssetUseCase - BuyAsset - invalid user id"),
@@ -411,7 +576,7 @@ func TestBuyAsset(t *testing.T) {
 			user: entity.User{Id: 1, Username: "test"},
 			id:   0,
 			mock: func() {
-				repo.EXPECT().BuyAsset(context.Background(), entity.User{Id: 1, Username: "test"}, int64(0)).Return(false, errInternalServErr)
+				repo.EXPECT().BuyAsset(context.Background(), entity.User{Id: 1, Username: "test"}, int64(0), "").Return(entity.Purchase{}, errInternalServErr)
 			},
 			res: false,
 			err: fmt.Errorf("AssetUseCase - BuyAsset - invalid asset id"),
@@ -421,7 +586,7 @@ func TestBuyAsset(t *testing.T) {
 			user: entity.User{Id: 0, Username: "test"},
 			id:   1,
 			mock: func() {
-				repo.EXPECT().BuyAsset(context.Background(), entity.User{Id: 0, Username: "test"}, int64(1)).Return(false, errInternalServErr)
+				repo.EXPECT().BuyAsset(context.Background(), entity.User{Id: 0, Username: "test"}, int64(1), "").Return(entity.Purchase{}, errInternalServErr)
 			},
 			res: false,
 			err: fmt.Errorf("AssetUseCase - BuyAsset - invalid user id"),
@@ -431,7 +596,7 @@ func TestBuyAsset(t *testing.T) {
 			user: entity.User{Id: 1, Username: "test"},
 			id:   1,
 			mock: func() {
-				repo.EXPECT().BuyAsset(context.Background(), entity.User{Id: 1, Username: "test"}, int64(1)).Return(true, nil)
+				repo.EXPECT().BuyAsset(context.Background(), entity.User{Id: 1, Username: "test"}, int64(1), "").Return(entity.Purchase{Id: 1, AssetId: 1, BuyerId: 1, SellerId: 2, Price: 100, SaleMode: entity.SaleModeLicense}, nil)
 			},
 			res: true,
 			err: nil,
@@ -441,7 +606,7 @@ func TestBuyAsset(t *testing.T) {
 			user: entity.User{Id: 1, Username: "test"},
 			id:   3,
 			mock: func() {
-				repo.EXPECT().BuyAsset(context.Background(), entity.User{Id: 1, Username: "test"}, int64(3)).Return(false, errInternalServErr)
+				repo.EXPECT().BuyAsset(context.Background(), entity.User{Id: 1, Username: "test"}, int64(3), "").Return(entity.Purchase{}, errInternalServErr)
 			},
 			res: false,
 			err: errInternalServErr,
@@ -451,11 +616,21 @@ func TestBuyAsset(t *testing.T) {
 			user: entity.User{Id: 1, Username: "test"},
 			id:   2,
 			mock: func() {
-				repo.EXPECT().BuyAsset(context.Background(), entity.User{Id: 1, Username: "test"}, int64(2)).Return(false, errInternalServErr)
+				repo.EXPECT().BuyAsset(context.Background(), entity.User{Id: 1, Username: "test"}, int64(2), "").Return(entity.Purchase{}, errInternalServErr)
 			},
 			res: false,
 			err: errInternalServErr,
 		},
+		{
+			name: "sold out",
+			user: entity.User{Id: 1, Username: "test"},
+			id:   4,
+			mock: func() {
+				repo.EXPECT().BuyAsset(context.Background(), entity.User{Id: 1, Username: "test"}, int64(4), "").Return(entity.Purchase{}, entity.ErrAssetSoldOut)
+			},
+			res: false,
+			err: entity.ErrAssetSoldOut,
+		},
 	}
 	for _, tc := range tests {
 		tc := tc
@@ -464,7 +639,7 @@ func TestBuyAsset(t *testing.T) {
 			t.Parallel()
 
 			tc.mock()
-			res, err := asset.BuyAsset(context.Background(), tc.user, tc.id)
+			res, err := asset.BuyAsset(context.Background(), tc.user, tc.id, tc.promo)
 			require.Equal(t, res, tc.res)
 			if err != nil {
 				require.ErrorContains(t, err, tc.err.Error())
@@ -551,27 +726,38 @@ func TestGetAssetById(t *testing.T) {
 	tests := []getAssetByIdTest{
 		{
 			name: "success",
+			user: entity.User{Id: 1, Username: "test"},
 			id:   1,
 			mock: func() {
-				repo.EXPECT().GetAssetById(context.Background(), int64(1)).Return(entity.Asset{Id: 1, Name: "Sword"}, nil)
+				repo.EXPECT().GetAssetById(context.Background(), entity.User{Id: 1, Username: "test"}, int64(1)).Return(entity.Asset{Id: 1, Name: "Sword"}, nil)
 			},
 			res: entity.Asset{Id: 1, Name: "Sword"},
 			err: nil,
 		},
 		{
 			name: "invalid asset id",
+			user: entity.User{Id: 1, Username: "test"},
 			id:   -1,
 			mock: func() {
-				repo.EXPECT().GetAssetById(context.Background(), int64(-1)).Return(entity.Asset{}, errInternalServErr)
+				repo.EXPECT().GetAssetById(context.Background(), entity.User{Id: 1, Username: "test"}, int64(-1)).Return(entity.Asset{}, errInternalServErr)
 			},
 			res: entity.Asset{},
 			err: fmt.Errorf("AssetUseCase - GetAssetById - invalid asset id"),
 		},
+		{
+			name: "invalid user id",
+			user: entity.User{},
+			id:   1,
+			mock: func() {},
+			res:  entity.Asset{},
+			err:  fmt.Errorf("AssetUseCase - GetAssetById - invalid user id"),
+		},
 		{
 			name: "assets not found",
+			user: entity.User{Id: 1, Username: "test"},
 			id:   2,
 			mock: func() {
-				repo.EXPECT().GetAssetById(context.Background(), int64(2)).Return(entity.Asset{}, errInternalServErr)
+				repo.EXPECT().GetAssetById(context.Background(), entity.User{Id: 1, Username: "test"}, int64(2)).Return(entity.Asset{}, errInternalServErr)
 			},
 			res: entity.Asset{},
 			err: errInternalServErr,
@@ -584,7 +770,574 @@ func TestGetAssetById(t *testing.T) {
 			t.Parallel()
 
 			tc.mock()
-			res, err := asset.GetAssetById(context.Background(), tc.id)
+			res, err := asset.GetAssetById(context.Background(), tc.user, tc.id)
+			require.Equal(t, res, tc.res)
+			if err != nil {
+				require.ErrorContains(t, err, tc.err.Error())
+			} else {
+				require.Nil(t, err)
+			}
+		})
+	}
+}
+
+func TestGetAssetsByIds(t *testing.T) {
+	t.Parallel()
+
+	asset, repo := AssetUseCase(t)
+	tests := []getAssetsByIdsTest{
+		{
+			name: "success",
+			user: entity.User{Id: 1, Username: "test"},
+			ids:  []int64{1, 2},
+			mock: func() {
+				repo.EXPECT().GetAssetsByIds(context.Background(), entity.User{Id: 1, Username: "test"}, []int64{1, 2}).Return([]entity.Asset{{Id: 2, Name: "Shield"}}, nil)
+			},
+			res: []entity.Asset{{Id: 2, Name: "Shield"}},
+			err: nil,
+		},
+		{
+			name: "no ids",
+			user: entity.User{Id: 1, Username: "test"},
+			ids:  nil,
+			mock: func() {},
+			res:  []entity.Asset{},
+			err:  nil,
+		},
+		{
+			name: "invalid user id",
+			user: entity.User{},
+			ids:  []int64{1},
+			mock: func() {},
+			res:  nil,
+			err:  fmt.Errorf("AssetUseCase - GetAssetsByIds - invalid user id"),
+		},
+		{
+			name: "repository error",
+			user: entity.User{Id: 2, Username: "test"},
+			ids:  []int64{3},
+			mock: func() {
+				repo.EXPECT().GetAssetsByIds(context.Background(), entity.User{Id: 2, Username: "test"}, []int64{3}).Return(nil, errInternalServErr)
+			},
+			res: nil,
+			err: errInternalServErr,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			tc.mock()
+			res, err := asset.GetAssetsByIds(context.Background(), tc.user, tc.ids)
+			require.Equal(t, res, tc.res)
+			if err != nil {
+				require.ErrorContains(t, err, tc.err.Error())
+			} else {
+				require.Nil(t, err)
+			}
+		})
+	}
+}
+
+func TestChangeAssetStatus(t *testing.T) {
+	t.Parallel()
+
+	asset, repo := AssetUseCase(t)
+	tests := []changeAssetStatusTest{
+		{
+			name:   "success",
+			user:   entity.User{Id: 1, Username: "test"},
+			id:     1,
+			status: entity.AssetStatusPublished,
+			mock: func() {
+				repo.EXPECT().UpdateStatus(context.Background(), entity.User{Id: 1, Username: "test"}, int64(1), entity.AssetStatusPublished).Return(true, nil)
+			},
+			res: true,
+			err: nil,
+		},
+		{
+			name:   "invalid user id",
+			user:   entity.User{},
+			id:     1,
+			status: entity.AssetStatusPublished,
+			mock:   func() {},
+			res:    false,
+			err:    fmt.Errorf("AssetUseCase - ChangeAssetStatus - invalid user or asset id"),
+		},
+		{
+			name:   "invalid asset id",
+			user:   entity.User{Id: 1, Username: "test"},
+			id:     0,
+			status: entity.AssetStatusPublished,
+			mock:   func() {},
+			res:    false,
+			err:    fmt.Errorf("AssetUseCase - ChangeAssetStatus - invalid user or asset id"),
+		},
+		{
+			name:   "unknown status",
+			user:   entity.User{Id: 1, Username: "test"},
+			id:     1,
+			status: "sold",
+			mock:   func() {},
+			res:    false,
+			err:    fmt.Errorf("AssetUseCase - ChangeAssetStatus - invalid asset status"),
+		},
+		{
+			name:   "asset not found",
+			user:   entity.User{Id: 1, Username: "test"},
+			id:     2,
+			status: entity.AssetStatusUnlisted,
+			mock: func() {
+				repo.EXPECT().UpdateStatus(context.Background(), entity.User{Id: 1, Username: "test"}, int64(2), entity.AssetStatusUnlisted).Return(false, nil)
+			},
+			res: false,
+			err: nil,
+		},
+		{
+			name:   "transition not allowed",
+			user:   entity.User{Id: 1, Username: "test"},
+			id:     3,
+			status: entity.AssetStatusDraft,
+			mock: func() {
+				repo.EXPECT().UpdateStatus(context.Background(), entity.User{Id: 1, Username: "test"}, int64(3), entity.AssetStatusDraft).Return(false, entity.ErrInvalidStatusTransition)
+			},
+			res: false,
+			err: entity.ErrInvalidStatusTransition,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			tc.mock()
+			res, err := asset.ChangeAssetStatus(context.Background(), tc.user, tc.id, tc.status)
+			require.Equal(t, res, tc.res)
+			if err != nil {
+				require.ErrorContains(t, err, tc.err.Error())
+			} else {
+				require.Nil(t, err)
+			}
+		})
+	}
+}
+
+func TestAssetStatusTransitions(t *testing.T) {
+	t.Parallel()
+
+	require.True(t, entity.AssetStatusDraft.CanTransitionTo(entity.AssetStatusPublished))
+	require.True(t, entity.AssetStatusPublished.CanTransitionTo(entity.AssetStatusUnlisted))
+	require.True(t, entity.AssetStatusUnlisted.CanTransitionTo(entity.AssetStatusPublished))
+	require.True(t, entity.AssetStatusUnlisted.CanTransitionTo(entity.AssetStatusArchived))
+	require.False(t, entity.AssetStatusPublished.CanTransitionTo(entity.AssetStatusDraft))
+	require.False(t, entity.AssetStatusArchived.CanTransitionTo(entity.AssetStatusPublished))
+	require.False(t, entity.AssetStatus("sold").Valid())
+}
+
+func TestPurgeAsset(t *testing.T) {
+	t.Parallel()
+
+	asset, repo := AssetUseCase(t)
+	tests := []purgeAssetTest{
+		{
+			name: "success",
+			id:   1,
+			mock: func() {
+				repo.EXPECT().Purge(context.Background(), int64(1)).Return(true, nil)
+			},
+			res: true,
+			err: nil,
+		},
+		{
+			name: "invalid asset id",
+			id:   0,
+			mock: func() {},
+			res:  false,
+			err:  fmt.Errorf("AssetUseCase - PurgeAsset - invalid asset id"),
+		},
+		{
+			name: "asset not found",
+			id:   2,
+			mock: func() {
+				repo.EXPECT().Purge(context.Background(), int64(2)).Return(false, nil)
+			},
+			res: false,
+			err: nil,
+		},
+		{
+			name: "repository error",
+			id:   3,
+			mock: func() {
+				repo.EXPECT().Purge(context.Background(), int64(3)).Return(false, errInternalServErr)
+			},
+			res: false,
+			err: errInternalServErr,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			tc.mock()
+			res, err := asset.PurgeAsset(context.Background(), tc.id)
+			require.Equal(t, res, tc.res)
+			if err != nil {
+				require.ErrorContains(t, err, tc.err.Error())
+			} else {
+				require.Nil(t, err)
+			}
+		})
+	}
+}
+
+func TestGetProvenance(t *testing.T) {
+	t.Parallel()
+
+	asset, repo := AssetUseCase(t)
+	tests := []getProvenanceTest{
+		{
+			name: "success",
+			user: entity.User{Id: 3, Username: "test3"},
+			id:   1,
+			mock: func() {
+				repo.EXPECT().GetAssetById(context.Background(), entity.User{Id: 3, Username: "test3"}, int64(1)).Return(entity.Asset{Id: 1, Owner_id: 3}, nil)
+				repo.EXPECT().GetProvenance(context.Background(), int64(1)).Return([]entity.Purchase{
+					{Id: 1, AssetId: 1, SellerId: 1, BuyerId: 2, Price: 10, SaleMode: entity.SaleModeTransfer},
+					{Id: 2, AssetId: 1, SellerId: 2, BuyerId: 3, Price: 15, SaleMode: entity.SaleModeTransfer},
+				}, nil)
+			},
+			res: []entity.Purchase{
+				{Id: 1, AssetId: 1, SellerId: 1, BuyerId: 2, Price: 10, SaleMode: entity.SaleModeTransfer},
+				{Id: 2, AssetId: 1, SellerId: 2, BuyerId: 3, Price: 15, SaleMode: entity.SaleModeTransfer},
+			},
+			err: nil,
+		},
+		{
+			name: "invalid asset id",
+			user: entity.User{Id: 1, Username: "test"},
+			id:   0,
+			mock: func() {},
+			res:  nil,
+			err:  fmt.Errorf("AssetUseCase - GetProvenance - invalid user or asset id"),
+		},
+		{
+			name: "asset not visible",
+			user: entity.User{Id: 1, Username: "test"},
+			id:   2,
+			mock: func() {
+				repo.EXPECT().GetAssetById(context.Background(), entity.User{Id: 1, Username: "test"}, int64(2)).Return(entity.Asset{}, entity.ErrAssetNotFound)
+			},
+			res: nil,
+			err: entity.ErrAssetNotFound,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			tc.mock()
+			res, err := asset.GetProvenance(context.Background(), tc.user, tc.id)
+			require.Equal(t, res, tc.res)
+			if err != nil {
+				require.ErrorContains(t, err, tc.err.Error())
+			} else {
+				require.Nil(t, err)
+			}
+		})
+	}
+}
+
+func TestRenewAccess(t *testing.T) {
+	t.Parallel()
+
+	asset, repo := AssetUseCase(t)
+	expiresAt := time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
+	tests := []renewAccessTest{
+		{
+			name: "success",
+			user: entity.User{Id: 2, Username: "test2"},
+			id:   1,
+			mock: func() {
+				repo.EXPECT().RenewAccess(context.Background(), entity.User{Id: 2, Username: "test2"}, int64(1)).Return(entity.Purchase{Id: 5, AssetId: 1, BuyerId: 2, SellerId: 1, Price: 10, SaleMode: entity.SaleModeLicense, AccessExpiresAt: &expiresAt}, nil)
+			},
+			res: entity.Purchase{Id: 5, AssetId: 1, BuyerId: 2, SellerId: 1, Price: 10, SaleMode: entity.SaleModeLicense, AccessExpiresAt: &expiresAt},
+			err: nil,
+		},
+		{
+			name: "invalid asset id",
+			user: entity.User{Id: 2, Username: "test2"},
+			id:   0,
+			mock: func() {},
+			res:  entity.Purchase{},
+			err:  fmt.Errorf("AssetUseCase - RenewAccess - invalid user or asset id"),
+		},
+		{
+			name: "permanent access",
+			user: entity.User{Id: 2, Username: "test2"},
+			id:   2,
+			mock: func() {
+				repo.EXPECT().RenewAccess(context.Background(), entity.User{Id: 2, Username: "test2"}, int64(2)).Return(entity.Purchase{}, entity.ErrAssetNotRenewable)
+			},
+			res: entity.Purchase{},
+			err: entity.ErrAssetNotRenewable,
+		},
+		{
+			name: "no access",
+			user: entity.User{Id: 2, Username: "test2"},
+			id:   3,
+			mock: func() {
+				repo.EXPECT().RenewAccess(context.Background(), entity.User{Id: 2, Username: "test2"}, int64(3)).Return(entity.Purchase{}, entity.ErrAccessNotFound)
+			},
+			res: entity.Purchase{},
+			err: entity.ErrAccessNotFound,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			tc.mock()
+			res, err := asset.RenewAccess(context.Background(), tc.user, tc.id)
+			require.Equal(t, res, tc.res)
+			if err != nil {
+				require.ErrorContains(t, err, tc.err.Error())
+			} else {
+				require.Nil(t, err)
+			}
+		})
+	}
+}
+
+func TestSweepExpiredAccess(t *testing.T) {
+	t.Parallel()
+
+	asset, repo := AssetUseCase(t)
+	repo.EXPECT().DeleteExpiredAccess(context.Background()).Return(int64(3), nil)
+	removed, err := asset.SweepExpiredAccess(context.Background())
+	require.Nil(t, err)
+	require.Equal(t, removed, int64(3))
+
+	repo.EXPECT().DeleteExpiredAccess(context.Background()).Return(int64(0), errInternalServErr)
+	removed, err = asset.SweepExpiredAccess(context.Background())
+	require.ErrorContains(t, err, errInternalServErr.Error())
+	require.Equal(t, removed, int64(0))
+}
+
+func TestGiftAsset(t *testing.T) {
+	t.Parallel()
+
+	asset, repo := AssetUseCase(t)
+	friend := int64(3)
+	tests := []giftAssetTest{
+		{
+			name:      "success",
+			user:      entity.User{Id: 2, Username: "test2"},
+			id:        1,
+			recipient: " test3 ",
+			message:   " Happy birthday! ",
+			mock: func() {
+				repo.EXPECT().Gift(context.Background(), entity.User{Id: 2, Username: "test2"}, int64(1), "test3", "Happy birthday!").Return(entity.Purchase{Id: 7, AssetId: 1, BuyerId: 2, SellerId: 1, Price: 10, SaleMode: entity.SaleModeLicense, RecipientId: &friend, GiftMessage: "Happy birthday!"}, nil)
+			},
+			res: entity.Purchase{Id: 7, AssetId: 1, BuyerId: 2, SellerId: 1, Price: 10, SaleMode: entity.SaleModeLicense, RecipientId: &friend, GiftMessage: "Happy birthday!"},
+			err: nil,
+		},
+		{
+			name:      "gift to themselves",
+			user:      entity.User{Id: 2, Username: "test2"},
+			id:        1,
+			recipient: "test2",
+			mock:      func() {},
+			res:       entity.Purchase{},
+			err:       fmt.Errorf("AssetUseCase - GiftAsset - invalid recipient"),
+		},
+		{
+			name:      "empty recipient",
+			user:      entity.User{Id: 2, Username: "test2"},
+			id:        1,
+			recipient: "  ",
+			mock:      func() {},
+			res:       entity.Purchase{},
+			err:       fmt.Errorf("AssetUseCase - GiftAsset - invalid recipient"),
+		},
+		{
+			name:      "message too long",
+			user:      entity.User{Id: 2, Username: "test2"},
+			id:        1,
+			recipient: "test3",
+			message:   strings.Repeat("a", 501),
+			mock:      func() {},
+			res:       entity.Purchase{},
+			err:       fmt.Errorf("AssetUseCase - GiftAsset - message is longer than 500 characters"),
+		},
+		{
+			name:      "invalid asset id",
+			user:      entity.User{Id: 2, Username: "test2"},
+			id:        0,
+			recipient: "test3",
+			mock:      func() {},
+			res:       entity.Purchase{},
+			err:       fmt.Errorf("AssetUseCase - GiftAsset - invalid user or asset id"),
+		},
+		{
+			name:      "recipient already owns the asset",
+			user:      entity.User{Id: 2, Username: "test2"},
+			id:        4,
+			recipient: "test3",
+			mock: func() {
+				repo.EXPECT().Gift(context.Background(), entity.User{Id: 2, Username: "test2"}, int64(4), "test3", "").Return(entity.Purchase{}, entity.ErrRecipientOwnsAsset)
+			},
+			res: entity.Purchase{},
+			err: entity.ErrRecipientOwnsAsset,
+		},
+		{
+			name:      "recipient not found",
+			user:      entity.User{Id: 2, Username: "test2"},
+			id:        5,
+			recipient: "nobody",
+			mock: func() {
+				repo.EXPECT().Gift(context.Background(), entity.User{Id: 2, Username: "test2"}, int64(5), "nobody", "").Return(entity.Purchase{}, entity.ErrRecipientNotFound)
+			},
+			res: entity.Purchase{},
+			err: entity.ErrRecipientNotFound,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			tc.mock()
+			res, err := asset.GiftAsset(context.Background(), tc.user, tc.id, tc.recipient, tc.message)
+			require.Equal(t, res, tc.res)
+			if err != nil {
+				require.ErrorContains(t, err, tc.err.Error())
+			} else {
+				require.Nil(t, err)
+			}
+		})
+	}
+}
+
+func TestGetPurchaseHistory(t *testing.T) {
+	t.Parallel()
+
+	asset, repo := AssetUseCase(t)
+	friend := int64(3)
+	tests := []getPurchaseHistoryTest{
+		{
+			name: "gift seen by the recipient",
+			user: entity.User{Id: 3, Username: "test3"},
+			mock: func() {
+				repo.EXPECT().GetPurchaseHistory(context.Background(), entity.User{Id: 3, Username: "test3"}).Return([]entity.Purchase{
+					{Id: 7, AssetId: 1, BuyerId: 2, SellerId: 1, Price: 10, SaleMode: entity.SaleModeLicense, RecipientId: &friend},
+				}, nil)
+			},
+			res: []entity.Purchase{
+				{Id: 7, AssetId: 1, BuyerId: 2, SellerId: 1, Price: 10, SaleMode: entity.SaleModeLicense, RecipientId: &friend},
+			},
+			err: nil,
+		},
+		{
+			name: "invalid user id",
+			user: entity.User{},
+			mock: func() {},
+			res:  nil,
+			err:  fmt.Errorf("AssetUseCase - GetPurchaseHistory - invalid user id"),
+		},
+		{
+			name: "repository error",
+			user: entity.User{Id: 4, Username: "test4"},
+			mock: func() {
+				repo.EXPECT().GetPurchaseHistory(context.Background(), entity.User{Id: 4, Username: "test4"}).Return(nil, errInternalServErr)
+			},
+			res: nil,
+			err: errInternalServErr,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			tc.mock()
+			res, err := asset.GetPurchaseHistory(context.Background(), tc.user)
+			require.Equal(t, res, tc.res)
+			if err != nil {
+				require.ErrorContains(t, err, tc.err.Error())
+			} else {
+				require.Nil(t, err)
+			}
+		})
+	}
+}
+
+func TestChangeAssetPrice(t *testing.T) {
+	t.Parallel()
+
+	asset, repo := AssetUseCase(t)
+	tests := []changeAssetPriceTest{
+		{
+			name:  "success",
+			user:  entity.User{Id: 1, Username: "test"},
+			id:    1,
+			price: 7.5,
+			mock: func() {
+				repo.EXPECT().UpdatePrice(context.Background(), entity.User{Id: 1, Username: "test"}, int64(1), 7.5).Return(true, nil)
+			},
+			res: true,
+			err: nil,
+		},
+		{
+			name:  "free",
+			user:  entity.User{Id: 1, Username: "test"},
+			id:    2,
+			price: 0,
+			mock: func() {
+				repo.EXPECT().UpdatePrice(context.Background(), entity.User{Id: 1, Username: "test"}, int64(2), 0.0).Return(true, nil)
+			},
+			res: true,
+			err: nil,
+		},
+		{
+			name:  "negative price",
+			user:  entity.User{Id: 1, Username: "test"},
+			id:    1,
+			price: -1,
+			mock:  func() {},
+			res:   false,
+			err:   fmt.Errorf("AssetUseCase - ChangeAssetPrice - price can't be negative"),
+		},
+		{
+			name:  "asset not found",
+			user:  entity.User{Id: 1, Username: "test"},
+			id:    3,
+			price: 5,
+			mock: func() {
+				repo.EXPECT().UpdatePrice(context.Background(), entity.User{Id: 1, Username: "test"}, int64(3), 5.0).Return(false, nil)
+			},
+			res: false,
+			err: nil,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			tc.mock()
+			res, err := asset.ChangeAssetPrice(context.Background(), tc.user, tc.id, tc.price)
 			require.Equal(t, res, tc.res)
 			if err != nil {
 				require.ErrorContains(t, err, tc.err.Error())
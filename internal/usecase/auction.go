@@ -0,0 +1,110 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Klef99/bhs-task/internal/entity"
+)
+
+const (
+	_maxAuctionDuration = 30 * 24 * time.Hour
+	_settleBatchSize    = 100
+)
+
+// AuctionUseCase -.
+type AuctionUseCase struct {
+	repo AuctionRepository
+}
+
+var _ Auction = (*AuctionUseCase)(nil)
+
+// New -.
+func NewAuctionUseCase(r AuctionRepository) *AuctionUseCase {
+	return &AuctionUseCase{repo: r}
+}
+
+func (uc *AuctionUseCase) OpenAuction(ctx context.Context, user entity.User, assetId int64, reservePrice float64, endAt time.Time) (entity.Auction, error) {
+	if user.Id <= 0 || assetId <= 0 {
+		return entity.Auction{}, fmt.Errorf("AuctionUseCase - OpenAuction - invalid user or asset id")
+	}
+	if reservePrice < 0 {
+		return entity.Auction{}, fmt.Errorf("AuctionUseCase - OpenAuction - reserve price can't be negative")
+	}
+	now := time.Now()
+	if !endAt.After(now) || endAt.Sub(now) > _maxAuctionDuration {
+		return entity.Auction{}, fmt.Errorf("AuctionUseCase - OpenAuction - end time must be in the next %s", _maxAuctionDuration)
+	}
+	auction, err := uc.repo.Open(ctx, entity.Auction{AssetId: assetId, SellerId: user.Id, ReservePrice: reservePrice, EndAt: endAt})
+	if err != nil {
+		return entity.Auction{}, fmt.Errorf("AuctionUseCase - OpenAuction - uc.repo.Open: %w", err)
+	}
+	return auction, nil
+}
+
+// GetAuction - the current or the last auction of the asset, if the user can see the asset.
+func (uc *AuctionUseCase) GetAuction(ctx context.Context, user entity.User, assetId int64) (entity.Auction, error) {
+	if user.Id <= 0 || assetId <= 0 {
+		return entity.Auction{}, fmt.Errorf("AuctionUseCase - GetAuction - invalid user or asset id")
+	}
+	auction, err := uc.repo.GetLatestByAsset(ctx, user, assetId)
+	if err != nil {
+		return entity.Auction{}, fmt.Errorf("AuctionUseCase - GetAuction - uc.repo.GetLatestByAsset: %w", err)
+	}
+	return auction, nil
+}
+
+func (uc *AuctionUseCase) PlaceBid(ctx context.Context, user entity.User, assetId int64, amount float64) (entity.Bid, error) {
+	if user.Id <= 0 || assetId <= 0 {
+		return entity.Bid{}, fmt.Errorf("AuctionUseCase - PlaceBid - invalid user or asset id")
+	}
+	if amount <= 0 {
+		return entity.Bid{}, fmt.Errorf("AuctionUseCase - PlaceBid - amount must be greater than zero")
+	}
+	bid, err := uc.repo.PlaceBid(ctx, user, assetId, amount)
+	if err != nil {
+		return entity.Bid{}, fmt.Errorf("AuctionUseCase - PlaceBid - uc.repo.PlaceBid: %w", err)
+	}
+	return bid, nil
+}
+
+// GetBids - bids of the current or the last auction of the asset, if the user can see the asset.
+func (uc *AuctionUseCase) GetBids(ctx context.Context, user entity.User, assetId int64) ([]entity.Bid, error) {
+	if user.Id <= 0 || assetId <= 0 {
+		return nil, fmt.Errorf("AuctionUseCase - GetBids - invalid user or asset id")
+	}
+	auction, err := uc.repo.GetLatestByAsset(ctx, user, assetId)
+	if err != nil {
+		return nil, fmt.Errorf("AuctionUseCase - GetBids - uc.repo.GetLatestByAsset: %w", err)
+	}
+	bids, err := uc.repo.GetBids(ctx, auction.Id)
+	if err != nil {
+		return nil, fmt.Errorf("AuctionUseCase - GetBids - uc.repo.GetBids: %w", err)
+	}
+	return bids, nil
+}
+
+// SettleExpired - closes auctions past their end time, returns how many were settled.
+// A failing auction does not stop the others, it is retried on the next run.
+func (uc *AuctionUseCase) SettleExpired(ctx context.Context) (int, error) {
+	ids, err := uc.repo.GetExpired(ctx, _settleBatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("AuctionUseCase - SettleExpired - uc.repo.GetExpired: %w", err)
+	}
+	settled := 0
+	var errs []error
+	for _, id := range ids {
+		_, err := uc.repo.Settle(ctx, id)
+		if errors.Is(err, entity.ErrAuctionNotFound) {
+			continue // settled concurrently by another instance
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("AuctionUseCase - SettleExpired - uc.repo.Settle(%d): %w", id, err))
+			continue
+		}
+		settled++
+	}
+	return settled, errors.Join(errs...)
+}
@@ -0,0 +1,339 @@
+package usecase_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/Klef99/bhs-task/internal/entity"
+	"github.com/Klef99/bhs-task/internal/usecase"
+	"github.com/stretchr/testify/require"
+	gomock "go.uber.org/mock/gomock"
+)
+
+type openAuctionTest struct {
+	name    string
+	user    entity.User
+	assetId int64
+	reserve float64
+	endAt   time.Time
+	mock    func()
+	res     entity.Auction
+	err     error
+}
+
+type placeBidTest struct {
+	name    string
+	user    entity.User
+	assetId int64
+	amount  float64
+	mock    func()
+	res     entity.Bid
+	err     error
+}
+
+type getBidsTest struct {
+	name    string
+	user    entity.User
+	assetId int64
+	mock    func()
+	res     []entity.Bid
+	err     error
+}
+
+type settleExpiredTest struct {
+	name string
+	mock func(repo *MockAuctionRepository)
+	res  int
+	err  error
+}
+
+func AuctionUseCase(t *testing.T) (*usecase.AuctionUseCase, *MockAuctionRepository) {
+	t.Helper()
+
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+
+	repo := NewMockAuctionRepository(mockCtl)
+
+	AuctionUseCase := usecase.NewAuctionUseCase(repo)
+	return AuctionUseCase, repo
+}
+
+func TestOpenAuction(t *testing.T) {
+	t.Parallel()
+
+	auction, repo := AuctionUseCase(t)
+	endAt := time.Now().Add(time.Hour).Truncate(time.Second)
+	tests := []openAuctionTest{
+		{
+			name:    "success",
+			user:    entity.User{Id: 1, Username: "test"},
+			assetId: 1,
+			reserve: 50,
+			endAt:   endAt,
+			mock: func() {
+				repo.EXPECT().Open(context.Background(), entity.Auction{AssetId: 1, SellerId: 1, ReservePrice: 50, EndAt: endAt}).
+					Return(entity.Auction{Id: 1, AssetId: 1, SellerId: 1, ReservePrice: 50, EndAt: endAt, Status: entity.AuctionStatusOpen}, nil)
+			},
+			res: entity.Auction{Id: 1, AssetId: 1, SellerId: 1, ReservePrice: 50, EndAt: endAt, Status: entity.AuctionStatusOpen},
+			err: nil,
+		},
+		{
+			name:    "invalid user id",
+			user:    entity.User{},
+			assetId: 1,
+			endAt:   endAt,
+			mock:    func() {},
+			res:     entity.Auction{},
+			err:     fmt.Errorf("AuctionUseCase - OpenAuction - invalid user or asset id"),
+		},
+		{
+			name:    "negative reserve price",
+			user:    entity.User{Id: 1, Username: "test"},
+			assetId: 1,
+			reserve: -1,
+			endAt:   endAt,
+			mock:    func() {},
+			res:     entity.Auction{},
+			err:     fmt.Errorf("AuctionUseCase - OpenAuction - reserve price can't be negative"),
+		},
+		{
+			name:    "end time in the past",
+			user:    entity.User{Id: 1, Username: "test"},
+			assetId: 1,
+			endAt:   time.Now().Add(-time.Minute),
+			mock:    func() {},
+			res:     entity.Auction{},
+			err:     fmt.Errorf("AuctionUseCase - OpenAuction - end time must be in the next"),
+		},
+		{
+			name:    "end time too far",
+			user:    entity.User{Id: 1, Username: "test"},
+			assetId: 1,
+			endAt:   time.Now().Add(365 * 24 * time.Hour),
+			mock:    func() {},
+			res:     entity.Auction{},
+			err:     fmt.Errorf("AuctionUseCase - OpenAuction - end time must be in the next"),
+		},
+		{
+			name:    "already on auction",
+			user:    entity.User{Id: 1, Username: "test"},
+			assetId: 2,
+			endAt:   endAt,
+			mock: func() {
+				repo.EXPECT().Open(context.Background(), entity.Auction{AssetId: 2, SellerId: 1, EndAt: endAt}).Return(entity.Auction{}, entity.ErrAuctionExists)
+			},
+			res: entity.Auction{},
+			err: entity.ErrAuctionExists,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			tc.mock()
+			res, err := auction.OpenAuction(context.Background(), tc.user, tc.assetId, tc.reserve, tc.endAt)
+			require.Equal(t, res, tc.res)
+			if err != nil {
+				require.ErrorContains(t, err, tc.err.Error())
+			} else {
+				require.Nil(t, err)
+			}
+		})
+	}
+}
+
+func TestPlaceBid(t *testing.T) {
+	t.Parallel()
+
+	auction, repo := AuctionUseCase(t)
+	tests := []placeBidTest{
+		{
+			name:    "success",
+			user:    entity.User{Id: 2, Username: "test2"},
+			assetId: 1,
+			amount:  60,
+			mock: func() {
+				repo.EXPECT().PlaceBid(context.Background(), entity.User{Id: 2, Username: "test2"}, int64(1), float64(60)).
+					Return(entity.Bid{Id: 1, AuctionId: 1, BidderId: 2, Amount: 60, Status: entity.BidStatusHeld}, nil)
+			},
+			res: entity.Bid{Id: 1, AuctionId: 1, BidderId: 2, Amount: 60, Status: entity.BidStatusHeld},
+			err: nil,
+		},
+		{
+			name:    "invalid asset id",
+			user:    entity.User{Id: 2, Username: "test2"},
+			assetId: 0,
+			amount:  60,
+			mock:    func() {},
+			res:     entity.Bid{},
+			err:     fmt.Errorf("AuctionUseCase - PlaceBid - invalid user or asset id"),
+		},
+		{
+			name:    "zero amount",
+			user:    entity.User{Id: 2, Username: "test2"},
+			assetId: 1,
+			amount:  0,
+			mock:    func() {},
+			res:     entity.Bid{},
+			err:     fmt.Errorf("AuctionUseCase - PlaceBid - amount must be greater than zero"),
+		},
+		{
+			name:    "bid too low",
+			user:    entity.User{Id: 2, Username: "test2"},
+			assetId: 2,
+			amount:  10,
+			mock: func() {
+				repo.EXPECT().PlaceBid(context.Background(), entity.User{Id: 2, Username: "test2"}, int64(2), float64(10)).Return(entity.Bid{}, entity.ErrBidTooLow)
+			},
+			res: entity.Bid{},
+			err: entity.ErrBidTooLow,
+		},
+		{
+			name:    "insufficient funds",
+			user:    entity.User{Id: 2, Username: "test2"},
+			assetId: 3,
+			amount:  1000,
+			mock: func() {
+				repo.EXPECT().PlaceBid(context.Background(), entity.User{Id: 2, Username: "test2"}, int64(3), float64(1000)).Return(entity.Bid{}, entity.ErrInsufficientFunds)
+			},
+			res: entity.Bid{},
+			err: entity.ErrInsufficientFunds,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			tc.mock()
+			res, err := auction.PlaceBid(context.Background(), tc.user, tc.assetId, tc.amount)
+			require.Equal(t, res, tc.res)
+			if err != nil {
+				require.ErrorContains(t, err, tc.err.Error())
+			} else {
+				require.Nil(t, err)
+			}
+		})
+	}
+}
+
+func TestGetBids(t *testing.T) {
+	t.Parallel()
+
+	auction, repo := AuctionUseCase(t)
+	tests := []getBidsTest{
+		{
+			name:    "success",
+			user:    entity.User{Id: 1, Username: "test"},
+			assetId: 1,
+			mock: func() {
+				repo.EXPECT().GetLatestByAsset(context.Background(), entity.User{Id: 1, Username: "test"}, int64(1)).Return(entity.Auction{Id: 5, AssetId: 1}, nil)
+				repo.EXPECT().GetBids(context.Background(), int64(5)).Return([]entity.Bid{{Id: 2, AuctionId: 5, Amount: 20}, {Id: 1, AuctionId: 5, Amount: 10}}, nil)
+			},
+			res: []entity.Bid{{Id: 2, AuctionId: 5, Amount: 20}, {Id: 1, AuctionId: 5, Amount: 10}},
+			err: nil,
+		},
+		{
+			name:    "no auction",
+			user:    entity.User{Id: 1, Username: "test"},
+			assetId: 2,
+			mock: func() {
+				repo.EXPECT().GetLatestByAsset(context.Background(), entity.User{Id: 1, Username: "test"}, int64(2)).Return(entity.Auction{}, entity.ErrAuctionNotFound)
+			},
+			res: nil,
+			err: entity.ErrAuctionNotFound,
+		},
+		{
+			name:    "asset not visible",
+			user:    entity.User{Id: 1, Username: "test"},
+			assetId: 3,
+			mock: func() {
+				repo.EXPECT().GetLatestByAsset(context.Background(), entity.User{Id: 1, Username: "test"}, int64(3)).Return(entity.Auction{}, entity.ErrAssetNotFound)
+			},
+			res: nil,
+			err: entity.ErrAssetNotFound,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			tc.mock()
+			res, err := auction.GetBids(context.Background(), tc.user, tc.assetId)
+			require.Equal(t, res, tc.res)
+			if err != nil {
+				require.ErrorContains(t, err, tc.err.Error())
+			} else {
+				require.Nil(t, err)
+			}
+		})
+	}
+}
+
+func TestSettleExpired(t *testing.T) {
+	t.Parallel()
+
+	tests := []settleExpiredTest{
+		{
+			name: "nothing to settle",
+			mock: func(repo *MockAuctionRepository) {
+				repo.EXPECT().GetExpired(context.Background(), uint64(100)).Return([]int64{}, nil)
+			},
+			res: 0,
+			err: nil,
+		},
+		{
+			name: "settled concurrently is skipped",
+			mock: func(repo *MockAuctionRepository) {
+				repo.EXPECT().GetExpired(context.Background(), uint64(100)).Return([]int64{1, 2}, nil)
+				repo.EXPECT().Settle(context.Background(), int64(1)).Return(entity.Auction{Id: 1, Status: entity.AuctionStatusSold}, nil)
+				repo.EXPECT().Settle(context.Background(), int64(2)).Return(entity.Auction{}, entity.ErrAuctionNotFound)
+			},
+			res: 1,
+			err: nil,
+		},
+		{
+			name: "failure does not stop the others",
+			mock: func(repo *MockAuctionRepository) {
+				repo.EXPECT().GetExpired(context.Background(), uint64(100)).Return([]int64{1, 2}, nil)
+				repo.EXPECT().Settle(context.Background(), int64(1)).Return(entity.Auction{}, errInternalServErr)
+				repo.EXPECT().Settle(context.Background(), int64(2)).Return(entity.Auction{Id: 2, Status: entity.AuctionStatusUnsold}, nil)
+			},
+			res: 1,
+			err: errInternalServErr,
+		},
+		{
+			name: "repository error",
+			mock: func(repo *MockAuctionRepository) {
+				repo.EXPECT().GetExpired(context.Background(), uint64(100)).Return(nil, errInternalServErr)
+			},
+			res: 0,
+			err: errInternalServErr,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			auction, repo := AuctionUseCase(t)
+			tc.mock(repo)
+			res, err := auction.SettleExpired(context.Background())
+			require.Equal(t, res, tc.res)
+			if err != nil {
+				require.ErrorContains(t, err, tc.err.Error())
+			} else {
+				require.Nil(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Klef99/bhs-task/internal/entity"
+)
+
+// CartUseCase -.
+type CartUseCase struct {
+	repo CartRepository
+}
+
+var _ Cart = (*CartUseCase)(nil)
+
+// New -.
+func NewCartUseCase(r CartRepository) *CartUseCase {
+	return &CartUseCase{repo: r}
+}
+
+func (uc *CartUseCase) AddToCart(ctx context.Context, user entity.User, assetId int64) (bool, error) {
+	if user.Id <= 0 || assetId <= 0 {
+		return false, fmt.Errorf("CartUseCase - AddToCart - invalid user or asset id")
+	}
+	status, err := uc.repo.Add(ctx, user, assetId)
+	if err != nil {
+		return false, fmt.Errorf("CartUseCase - AddToCart - uc.repo.Add: %w", err)
+	}
+	return status, nil
+}
+
+func (uc *CartUseCase) RemoveFromCart(ctx context.Context, user entity.User, assetId int64) (bool, error) {
+	if user.Id <= 0 || assetId <= 0 {
+		return false, fmt.Errorf("CartUseCase - RemoveFromCart - invalid user or asset id")
+	}
+	status, err := uc.repo.Remove(ctx, user, assetId)
+	if err != nil {
+		return false, fmt.Errorf("CartUseCase - RemoveFromCart - uc.repo.Remove: %w", err)
+	}
+	return status, nil
+}
+
+// GetCart - the items in the cart and their total at the current prices.
+func (uc *CartUseCase) GetCart(ctx context.Context, user entity.User) (entity.Cart, error) {
+	if user.Id <= 0 {
+		return entity.Cart{}, fmt.Errorf("CartUseCase - GetCart - invalid user id")
+	}
+	items, err := uc.repo.GetItems(ctx, user)
+	if err != nil {
+		return entity.Cart{}, fmt.Errorf("CartUseCase - GetCart - uc.repo.GetItems: %w", err)
+	}
+	cart := entity.Cart{Items: items}
+	for _, item := range items {
+		cart.Total += item.Price
+	}
+	return cart, nil
+}
+
+// Checkout - buys every asset in the cart in one transaction, either all of them or none.
+// promoCode is optional and discounts the assets it applies to.
+func (uc *CartUseCase) Checkout(ctx context.Context, user entity.User, promoCode string) (entity.Receipt, error) {
+	if user.Id <= 0 {
+		return entity.Receipt{}, fmt.Errorf("CartUseCase - Checkout - invalid user id")
+	}
+	receipt, err := uc.repo.Checkout(ctx, user, entity.NormalizePromoCode(promoCode))
+	if err != nil {
+		return entity.Receipt{}, fmt.Errorf("CartUseCase - Checkout - uc.repo.Checkout: %w", err)
+	}
+	return receipt, nil
+}
@@ -0,0 +1,273 @@
+package usecase_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/Klef99/bhs-task/internal/entity"
+	"github.com/Klef99/bhs-task/internal/usecase"
+	"github.com/stretchr/testify/require"
+	gomock "go.uber.org/mock/gomock"
+)
+
+type cartItemTest struct {
+	name    string
+	user    entity.User
+	assetId int64
+	mock    func()
+	res     bool
+	err     error
+}
+
+type getCartTest struct {
+	name string
+	user entity.User
+	mock func()
+	res  entity.Cart
+	err  error
+}
+
+type checkoutTest struct {
+	name  string
+	user  entity.User
+	promo string
+	mock  func()
+	res   entity.Receipt
+	err   error
+}
+
+func CartUseCase(t *testing.T) (*usecase.CartUseCase, *MockCartRepository) {
+	t.Helper()
+
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+
+	repo := NewMockCartRepository(mockCtl)
+
+	CartUseCase := usecase.NewCartUseCase(repo)
+	return CartUseCase, repo
+}
+
+func TestAddToCart(t *testing.T) {
+	t.Parallel()
+
+	cart, repo := CartUseCase(t)
+	tests := []cartItemTest{
+		{
+			name:    "success",
+			user:    entity.User{Id: 1, Username: "test"},
+			assetId: 2,
+			mock: func() {
+				repo.EXPECT().Add(context.Background(), entity.User{Id: 1, Username: "test"}, int64(2)).Return(true, nil)
+			},
+			res: true,
+			err: nil,
+		},
+		{
+			name:    "invalid asset id",
+			user:    entity.User{Id: 1, Username: "test"},
+			assetId: 0,
+			mock:    func() {},
+			res:     false,
+			err:     fmt.Errorf("CartUseCase - AddToCart - invalid user or asset id"),
+		},
+		{
+			name:    "asset not available",
+			user:    entity.User{Id: 1, Username: "test"},
+			assetId: 3,
+			mock: func() {
+				repo.EXPECT().Add(context.Background(), entity.User{Id: 1, Username: "test"}, int64(3)).Return(false, entity.ErrAssetNotAvailable)
+			},
+			res: false,
+			err: entity.ErrAssetNotAvailable,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			tc.mock()
+			res, err := cart.AddToCart(context.Background(), tc.user, tc.assetId)
+			require.Equal(t, res, tc.res)
+			if err != nil {
+				require.ErrorContains(t, err, tc.err.Error())
+			} else {
+				require.Nil(t, err)
+			}
+		})
+	}
+}
+
+func TestRemoveFromCart(t *testing.T) {
+	t.Parallel()
+
+	cart, repo := CartUseCase(t)
+	tests := []cartItemTest{
+		{
+			name:    "success",
+			user:    entity.User{Id: 1, Username: "test"},
+			assetId: 2,
+			mock: func() {
+				repo.EXPECT().Remove(context.Background(), entity.User{Id: 1, Username: "test"}, int64(2)).Return(true, nil)
+			},
+			res: true,
+			err: nil,
+		},
+		{
+			name:    "not in cart",
+			user:    entity.User{Id: 1, Username: "test"},
+			assetId: 3,
+			mock: func() {
+				repo.EXPECT().Remove(context.Background(), entity.User{Id: 1, Username: "test"}, int64(3)).Return(false, nil)
+			},
+			res: false,
+			err: nil,
+		},
+		{
+			name:    "invalid user id",
+			user:    entity.User{},
+			assetId: 2,
+			mock:    func() {},
+			res:     false,
+			err:     fmt.Errorf("CartUseCase - RemoveFromCart - invalid user or asset id"),
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			tc.mock()
+			res, err := cart.RemoveFromCart(context.Background(), tc.user, tc.assetId)
+			require.Equal(t, res, tc.res)
+			if err != nil {
+				require.ErrorContains(t, err, tc.err.Error())
+			} else {
+				require.Nil(t, err)
+			}
+		})
+	}
+}
+
+func TestGetCart(t *testing.T) {
+	t.Parallel()
+
+	cart, repo := CartUseCase(t)
+	tests := []getCartTest{
+		{
+			name: "success",
+			user: entity.User{Id: 1, Username: "test"},
+			mock: func() {
+				repo.EXPECT().GetItems(context.Background(), entity.User{Id: 1, Username: "test"}).
+					Return([]entity.CartItem{{AssetId: 2, Price: 10, Available: true}, {AssetId: 3, Price: 15.5, Available: true}}, nil)
+			},
+			res: entity.Cart{Items: []entity.CartItem{{AssetId: 2, Price: 10, Available: true}, {AssetId: 3, Price: 15.5, Available: true}}, Total: 25.5},
+			err: nil,
+		},
+		{
+			name: "empty cart",
+			user: entity.User{Id: 2, Username: "test2"},
+			mock: func() {
+				repo.EXPECT().GetItems(context.Background(), entity.User{Id: 2, Username: "test2"}).Return([]entity.CartItem{}, nil)
+			},
+			res: entity.Cart{Items: []entity.CartItem{}},
+			err: nil,
+		},
+		{
+			name: "invalid user id",
+			user: entity.User{Id: -1},
+			mock: func() {},
+			res:  entity.Cart{},
+			err:  fmt.Errorf("CartUseCase - GetCart - invalid user id"),
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			tc.mock()
+			res, err := cart.GetCart(context.Background(), tc.user)
+			require.Equal(t, res, tc.res)
+			if err != nil {
+				require.ErrorContains(t, err, tc.err.Error())
+			} else {
+				require.Nil(t, err)
+			}
+		})
+	}
+}
+
+func TestCheckout(t *testing.T) {
+	t.Parallel()
+
+	cart, repo := CartUseCase(t)
+	tests := []checkoutTest{
+		{
+			name: "success",
+			user: entity.User{Id: 1, Username: "test"},
+			mock: func() {
+				repo.EXPECT().Checkout(context.Background(), entity.User{Id: 1, Username: "test"}, "").
+					Return(entity.Receipt{Id: 1, BuyerId: 1, Total: 25, Purchases: []entity.Purchase{{AssetId: 2, Price: 10}, {AssetId: 3, Price: 15}}}, nil)
+			},
+			res: entity.Receipt{Id: 1, BuyerId: 1, Total: 25, Purchases: []entity.Purchase{{AssetId: 2, Price: 10}, {AssetId: 3, Price: 15}}},
+			err: nil,
+		},
+		{
+			name: "empty cart",
+			user: entity.User{Id: 2, Username: "test2"},
+			mock: func() {
+				repo.EXPECT().Checkout(context.Background(), entity.User{Id: 2, Username: "test2"}, "").Return(entity.Receipt{}, entity.ErrCartEmpty)
+			},
+			res: entity.Receipt{},
+			err: entity.ErrCartEmpty,
+		},
+		{
+			name: "insufficient funds",
+			user: entity.User{Id: 3, Username: "test3"},
+			mock: func() {
+				repo.EXPECT().Checkout(context.Background(), entity.User{Id: 3, Username: "test3"}, "").Return(entity.Receipt{}, entity.ErrInsufficientFunds)
+			},
+			res: entity.Receipt{},
+			err: entity.ErrInsufficientFunds,
+		},
+		{
+			name:  "promo code not found",
+			user:  entity.User{Id: 4, Username: "test4"},
+			promo: "nope",
+			mock: func() {
+				repo.EXPECT().Checkout(context.Background(), entity.User{Id: 4, Username: "test4"}, "NOPE").Return(entity.Receipt{}, entity.ErrPromoCodeNotFound)
+			},
+			res: entity.Receipt{},
+			err: entity.ErrPromoCodeNotFound,
+		},
+		{
+			name: "invalid user id",
+			user: entity.User{},
+			mock: func() {},
+			res:  entity.Receipt{},
+			err:  fmt.Errorf("CartUseCase - Checkout - invalid user id"),
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			tc.mock()
+			res, err := cart.Checkout(context.Background(), tc.user, tc.promo)
+			require.Equal(t, res, tc.res)
+			if err != nil {
+				require.ErrorContains(t, err, tc.err.Error())
+			} else {
+				require.Nil(t, err)
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package usecase
 
 import (
 	"context"
+	"time"
 
 	"github.com/Klef99/bhs-task/internal/entity"
 )
@@ -13,6 +14,8 @@ type (
 
 		MakeDeposit(ctx context.Context, user entity.User, amount float64) (float64, error)
 		CheckDeposit(ctx context.Context, user entity.User) (float64, error)
+		IsAdmin(ctx context.Context, user entity.User) (bool, error)
+		GetUsersByIds(ctx context.Context, ids []int64) ([]entity.User, error)
 	}
 
 	UserRepository interface {
@@ -21,27 +24,237 @@ type (
 
 		MakeDeposit(ctx context.Context, user entity.User, amount float64) (float64, error)
 		CheckDeposit(ctx context.Context, user entity.User) (float64, error)
+		GetRole(ctx context.Context, user entity.User) (entity.Role, error)
+		GetUsersByIds(ctx context.Context, ids []int64) ([]entity.User, error)
 	}
 
 	Asset interface {
 		CreateAsset(ctx context.Context, ast entity.Asset) (bool, error)
 		DeleteAsset(ctx context.Context, user entity.User, id int64) (bool, error)
-		BuyAsset(ctx context.Context, user entity.User, id int64) (bool, error)
+		BuyAsset(ctx context.Context, user entity.User, id int64, promoCode string) (bool, error)
 		UserAssetsList(ctx context.Context, user entity.User) ([]entity.Asset, error)
-		GetAssetById(ctx context.Context, id int64) (entity.Asset, error)
+		GetAssetById(ctx context.Context, user entity.User, id int64) (entity.Asset, error)
+		GetAssetsByIds(ctx context.Context, user entity.User, ids []int64) ([]entity.Asset, error)
 		GetAssetsToBuying(ctx context.Context, user entity.User) ([]entity.Asset, error)
 		GetPurchasedAssets(ctx context.Context, user entity.User) ([]entity.Asset, error)
+		ChangeAssetStatus(ctx context.Context, user entity.User, id int64, status entity.AssetStatus) (bool, error)
+		PurgeAsset(ctx context.Context, id int64) (bool, error)
+		GetProvenance(ctx context.Context, user entity.User, id int64) ([]entity.Purchase, error)
+		RenewAccess(ctx context.Context, user entity.User, id int64) (entity.Purchase, error)
+		SweepExpiredAccess(ctx context.Context) (int64, error)
+		GiftAsset(ctx context.Context, user entity.User, id int64, recipient string, message string) (entity.Purchase, error)
+		GetPurchaseHistory(ctx context.Context, user entity.User) ([]entity.Purchase, error)
+		ChangeAssetPrice(ctx context.Context, user entity.User, id int64, price float64) (bool, error)
 		// UpdateAssetById(ctx context.Context, asset entity.Asset) (entity.Asset, error)
 	}
 
 	AssetRepository interface {
-		Store(ctx context.Context, ast entity.Asset) (bool, error)
+		Store(ctx context.Context, ast entity.Asset) (int64, error)
 		Erase(ctx context.Context, user entity.User, id int64) (bool, error)
 		UserAssetsList(ctx context.Context, user entity.User) ([]entity.Asset, error)
-		GetAssetById(ctx context.Context, id int64) (entity.Asset, error)
+		GetAssetById(ctx context.Context, user entity.User, id int64) (entity.Asset, error)
+		GetAssetsByIds(ctx context.Context, user entity.User, ids []int64) ([]entity.Asset, error)
 		GetOtherUsersAssets(ctx context.Context, user entity.User) ([]entity.Asset, error)
-		BuyAsset(ctx context.Context, user entity.User, id int64) (bool, error)
+		BuyAsset(ctx context.Context, user entity.User, id int64, promoCode string) (entity.Purchase, error)
 		GetPurchasedAssets(ctx context.Context, user entity.User) ([]entity.Asset, error)
+		UpdateStatus(ctx context.Context, user entity.User, id int64, status entity.AssetStatus) (bool, error)
+		Purge(ctx context.Context, id int64) (bool, error)
+		GetProvenance(ctx context.Context, id int64) ([]entity.Purchase, error)
+		RenewAccess(ctx context.Context, user entity.User, id int64) (entity.Purchase, error)
+		DeleteExpiredAccess(ctx context.Context) (int64, error)
+		Gift(ctx context.Context, user entity.User, id int64, recipient string, message string) (entity.Purchase, error)
+		GetPurchaseHistory(ctx context.Context, user entity.User) ([]entity.Purchase, error)
+		UpdatePrice(ctx context.Context, user entity.User, id int64, price float64) (bool, error)
 		// UpdateAssetById(ctx context.Context, asset entity.Asset) (entity.Asset, error)
 	}
+
+	Auction interface {
+		OpenAuction(ctx context.Context, user entity.User, assetId int64, reservePrice float64, endAt time.Time) (entity.Auction, error)
+		GetAuction(ctx context.Context, user entity.User, assetId int64) (entity.Auction, error)
+		PlaceBid(ctx context.Context, user entity.User, assetId int64, amount float64) (entity.Bid, error)
+		GetBids(ctx context.Context, user entity.User, assetId int64) ([]entity.Bid, error)
+		SettleExpired(ctx context.Context) (int, error)
+	}
+
+	AuctionRepository interface {
+		Open(ctx context.Context, auction entity.Auction) (entity.Auction, error)
+		GetLatestByAsset(ctx context.Context, user entity.User, assetId int64) (entity.Auction, error)
+		PlaceBid(ctx context.Context, user entity.User, assetId int64, amount float64) (entity.Bid, error)
+		GetBids(ctx context.Context, auctionId int64) ([]entity.Bid, error)
+		GetExpired(ctx context.Context, limit uint64) ([]int64, error)
+		Settle(ctx context.Context, id int64) (entity.Auction, error)
+	}
+
+	Offer interface {
+		MakeOffer(ctx context.Context, user entity.User, assetId int64, price float64) (entity.Offer, error)
+		GetAssetOffers(ctx context.Context, user entity.User, assetId int64) ([]entity.Offer, error)
+		GetInbox(ctx context.Context, user entity.User) ([]entity.Offer, error)
+		GetOutbox(ctx context.Context, user entity.User) ([]entity.Offer, error)
+		AcceptOffer(ctx context.Context, user entity.User, id int64) (entity.Offer, error)
+		RejectOffer(ctx context.Context, user entity.User, id int64) (entity.Offer, error)
+		CounterOffer(ctx context.Context, user entity.User, id int64, price float64) (entity.Offer, error)
+		ExpireOffers(ctx context.Context) (int64, error)
+	}
+
+	OfferRepository interface {
+		Create(ctx context.Context, offer entity.Offer) (entity.Offer, error)
+		GetByAsset(ctx context.Context, user entity.User, assetId int64) ([]entity.Offer, error)
+		GetInbox(ctx context.Context, user entity.User) ([]entity.Offer, error)
+		GetOutbox(ctx context.Context, user entity.User) ([]entity.Offer, error)
+		Accept(ctx context.Context, user entity.User, id int64) (entity.Offer, error)
+		Reject(ctx context.Context, user entity.User, id int64) (entity.Offer, error)
+		Counter(ctx context.Context, user entity.User, id int64, price float64, expiresAt time.Time) (entity.Offer, error)
+		Expire(ctx context.Context) (int64, error)
+	}
+
+	Cart interface {
+		AddToCart(ctx context.Context, user entity.User, assetId int64) (bool, error)
+		RemoveFromCart(ctx context.Context, user entity.User, assetId int64) (bool, error)
+		GetCart(ctx context.Context, user entity.User) (entity.Cart, error)
+		Checkout(ctx context.Context, user entity.User, promoCode string) (entity.Receipt, error)
+	}
+
+	CartRepository interface {
+		Add(ctx context.Context, user entity.User, assetId int64) (bool, error)
+		Remove(ctx context.Context, user entity.User, assetId int64) (bool, error)
+		GetItems(ctx context.Context, user entity.User) ([]entity.CartItem, error)
+		Checkout(ctx context.Context, user entity.User, promoCode string) (entity.Receipt, error)
+	}
+
+	Promo interface {
+		CreatePromoCode(ctx context.Context, user entity.User, promo entity.PromoCode) (entity.PromoCode, error)
+		CreateSitePromoCode(ctx context.Context, user entity.User, promo entity.PromoCode) (entity.PromoCode, error)
+		GetPromoCodes(ctx context.Context, user entity.User) ([]entity.PromoCode, error)
+	}
+
+	PromoRepository interface {
+		Create(ctx context.Context, promo entity.PromoCode) (entity.PromoCode, error)
+		GetByCreator(ctx context.Context, user entity.User) ([]entity.PromoCode, error)
+	}
+
+	Wishlist interface {
+		AddToWishlist(ctx context.Context, user entity.User, assetId int64) (bool, error)
+		RemoveFromWishlist(ctx context.Context, user entity.User, assetId int64) (bool, error)
+		GetWishlist(ctx context.Context, user entity.User) ([]entity.WishlistItem, error)
+	}
+
+	WishlistRepository interface {
+		Add(ctx context.Context, user entity.User, assetId int64) (bool, error)
+		Remove(ctx context.Context, user entity.User, assetId int64) (bool, error)
+		GetItems(ctx context.Context, user entity.User) ([]entity.WishlistItem, error)
+	}
+
+	Notification interface {
+		GetNotifications(ctx context.Context, user entity.User, unreadOnly bool) ([]entity.Notification, error)
+		MarkRead(ctx context.Context, user entity.User, id int64) (bool, error)
+	}
+
+	NotificationRepository interface {
+		GetByUser(ctx context.Context, user entity.User, unreadOnly bool) ([]entity.Notification, error)
+		MarkRead(ctx context.Context, user entity.User, id int64) (bool, error)
+	}
+
+	Review interface {
+		PostReview(ctx context.Context, user entity.User, review entity.Review) (entity.Review, error)
+		GetReviews(ctx context.Context, user entity.User, assetId int64) ([]entity.Review, error)
+		ReplyToReview(ctx context.Context, user entity.User, id int64, reply string) (entity.Review, error)
+	}
+
+	ReviewRepository interface {
+		Create(ctx context.Context, review entity.Review) (entity.Review, error)
+		GetByAsset(ctx context.Context, user entity.User, assetId int64) ([]entity.Review, error)
+		Reply(ctx context.Context, user entity.User, id int64, reply string) (entity.Review, error)
+	}
+
+	Stats interface {
+		GetSellerStats(ctx context.Context, user entity.User, from, to time.Time, bucket entity.StatsBucket) (entity.SellerStats, error)
+	}
+
+	StatsRepository interface {
+		GetAssetSales(ctx context.Context, user entity.User, from, to time.Time) ([]entity.AssetSales, error)
+		GetSalesSeries(ctx context.Context, user entity.User, from, to time.Time, bucket entity.StatsBucket) ([]entity.SalesPoint, error)
+	}
+
+	Webhook interface {
+		CreateWebhook(ctx context.Context, user entity.User, wh entity.Webhook) (entity.Webhook, error)
+		CreateGlobalWebhook(ctx context.Context, user entity.User, wh entity.Webhook) (entity.Webhook, error)
+		GetWebhooks(ctx context.Context, user entity.User) ([]entity.Webhook, error)
+		DeleteWebhook(ctx context.Context, user entity.User, id int64) (bool, error)
+		GetDeliveries(ctx context.Context, user entity.User, webhookId int64) ([]entity.WebhookDelivery, error)
+		Redeliver(ctx context.Context, user entity.User, deliveryId int64) (entity.WebhookDelivery, error)
+		DeliverDue(ctx context.Context) (int, error)
+	}
+
+	WebhookRepository interface {
+		Create(ctx context.Context, wh entity.Webhook) (entity.Webhook, error)
+		GetByUser(ctx context.Context, user entity.User) ([]entity.Webhook, error)
+		Delete(ctx context.Context, user entity.User, id int64) (bool, error)
+		Enqueue(ctx context.Context, eventType entity.EventType, payload []byte, userIds []int64) (int64, error)
+		GetDeliveries(ctx context.Context, user entity.User, webhookId int64) ([]entity.WebhookDelivery, error)
+		Redeliver(ctx context.Context, user entity.User, id int64) (entity.WebhookDelivery, error)
+		ClaimDue(ctx context.Context, limit uint64, lease time.Duration) ([]entity.WebhookDelivery, error)
+		RecordAttempt(ctx context.Context, delivery entity.WebhookDelivery) error
+	}
+
+	WebhookSender interface {
+		Send(ctx context.Context, url, secret string, headers map[string]string, body []byte) (int, error)
+	}
+
+	// EventPublisher - receives the events relayed from the outbox, an event is published again until it returns nil.
+	EventPublisher interface {
+		Publish(ctx context.Context, event entity.OutboxEvent) error
+	}
+
+	Outbox interface {
+		Relay(ctx context.Context) (int, error)
+	}
+
+	OutboxRepository interface {
+		ClaimPending(ctx context.Context, limit uint64, lease time.Duration) ([]entity.OutboxEvent, error)
+		MarkPublished(ctx context.Context, id int64) error
+		RecordFailure(ctx context.Context, event entity.OutboxEvent) error
+	}
+
+	MarketFeed interface {
+		Subscribe(ctx context.Context, user entity.User) (<-chan entity.MarketEvent, error)
+		Run(ctx context.Context) error
+	}
+
+	MarketFeedRepository interface {
+		Listen(ctx context.Context, handle func(entity.MarketEvent)) error
+	}
+
+	// Admin - operations run by operators from bhsctl, users are looked up by username.
+	Admin interface {
+		SetRole(ctx context.Context, username string, role entity.Role) error
+		ResetPassword(ctx context.Context, username, password string) error
+		AdjustBalance(ctx context.Context, username string, amount float64, reason, actor string) (entity.BalanceAdjustment, error)
+		ListAssets(ctx context.Context, filter entity.AssetFilter) ([]entity.Asset, error)
+		TakeDownAsset(ctx context.Context, id int64) (bool, error)
+		GrantAccess(ctx context.Context, username string, assetId int64, days *int64) error
+		RevokeAccess(ctx context.Context, username string, assetId int64) (bool, error)
+		Reconcile(ctx context.Context) ([]entity.ReconcileIssue, error)
+	}
+
+	AdminRepository interface {
+		SetRole(ctx context.Context, username string, role entity.Role) error
+		SetPassword(ctx context.Context, username, password string) error
+		AdjustBalance(ctx context.Context, adj entity.BalanceAdjustment, username string) (entity.BalanceAdjustment, error)
+		ListAssets(ctx context.Context, filter entity.AssetFilter) ([]entity.Asset, error)
+		TakeDown(ctx context.Context, id int64) (bool, error)
+		GrantAccess(ctx context.Context, username string, assetId int64, days *int64) error
+		RevokeAccess(ctx context.Context, username string, assetId int64) (bool, error)
+		Reconcile(ctx context.Context) ([]entity.ReconcileIssue, error)
+	}
+
+	// RateLimit - buckets are keyed by the policy name and the key, so policies sharing a key don't share a bucket.
+	RateLimit interface {
+		Allow(ctx context.Context, policy entity.RateLimitPolicy, key string) (entity.RateLimitResult, error)
+		SweepFull(ctx context.Context) (int64, error)
+	}
+
+	RateLimitRepository interface {
+		Take(ctx context.Context, key string, policy entity.RateLimitPolicy) (entity.RateLimitBucket, error)
+		DeleteFull(ctx context.Context) (int64, error)
+	}
 )
@@ -0,0 +1,73 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/Klef99/bhs-task/internal/entity"
+)
+
+// MarketFeedUseCase - fans the market events of every instance out to the connected clients.
+type MarketFeedUseCase struct {
+	repo   MarketFeedRepository
+	buffer int
+
+	mu          sync.Mutex
+	subscribers map[chan entity.MarketEvent]struct{}
+}
+
+var _ MarketFeed = (*MarketFeedUseCase)(nil)
+
+// New - buffer is the number of events a subscriber may fall behind before it is dropped.
+func NewMarketFeedUseCase(r MarketFeedRepository, buffer int) *MarketFeedUseCase {
+	return &MarketFeedUseCase{repo: r, buffer: buffer, subscribers: make(map[chan entity.MarketEvent]struct{})}
+}
+
+// Subscribe - the channel receives market events until ctx is done. A subscriber that can't keep up
+// is dropped instead of slowing everyone down: its channel is closed before ctx is done.
+func (uc *MarketFeedUseCase) Subscribe(ctx context.Context, user entity.User) (<-chan entity.MarketEvent, error) {
+	if user.Id <= 0 {
+		return nil, fmt.Errorf("MarketFeedUseCase - Subscribe - invalid user id")
+	}
+	ch := make(chan entity.MarketEvent, uc.buffer)
+	uc.mu.Lock()
+	uc.subscribers[ch] = struct{}{}
+	uc.mu.Unlock()
+	go func() {
+		<-ctx.Done()
+		uc.unsubscribe(ch)
+	}()
+	return ch, nil
+}
+
+// Run - listens for market events and broadcasts them until ctx is done or the listener fails.
+func (uc *MarketFeedUseCase) Run(ctx context.Context) error {
+	err := uc.repo.Listen(ctx, uc.broadcast)
+	if err != nil {
+		return fmt.Errorf("MarketFeedUseCase - Run - uc.repo.Listen: %w", err)
+	}
+	return nil
+}
+
+func (uc *MarketFeedUseCase) broadcast(event entity.MarketEvent) {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+	for ch := range uc.subscribers {
+		select {
+		case ch <- event:
+		default:
+			delete(uc.subscribers, ch)
+			close(ch)
+		}
+	}
+}
+
+func (uc *MarketFeedUseCase) unsubscribe(ch chan entity.MarketEvent) {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+	if _, ok := uc.subscribers[ch]; ok {
+		delete(uc.subscribers, ch)
+		close(ch)
+	}
+}
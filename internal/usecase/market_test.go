@@ -0,0 +1,127 @@
+package usecase_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/Klef99/bhs-task/internal/entity"
+	"github.com/Klef99/bhs-task/internal/usecase"
+	"github.com/stretchr/testify/require"
+	gomock "go.uber.org/mock/gomock"
+)
+
+type marketFeedTest struct {
+	name     string
+	user     entity.User
+	events   []entity.MarketEvent // broadcast after subscribing
+	received []entity.MarketEvent
+	dropped  bool // the channel is closed because the subscriber fell behind
+	err      error
+}
+
+func MarketFeedUseCase(t *testing.T, buffer int) (*usecase.MarketFeedUseCase, *MockMarketFeedRepository) {
+	t.Helper()
+
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+
+	repo := NewMockMarketFeedRepository(mockCtl)
+
+	MarketFeedUseCase := usecase.NewMarketFeedUseCase(repo, buffer)
+	return MarketFeedUseCase, repo
+}
+
+func TestMarketFeed(t *testing.T) {
+	t.Parallel()
+
+	created := entity.MarketEvent{Type: entity.MarketAssetCreated, AssetId: 1, Status: entity.AssetStatusPublished}
+	sold := entity.MarketEvent{Type: entity.MarketAssetSold, AssetId: 1}
+	deleted := entity.MarketEvent{Type: entity.MarketAssetDeleted, AssetId: 2}
+	tests := []marketFeedTest{
+		{
+			name:     "events in order",
+			user:     entity.User{Id: 1, Username: "test"},
+			events:   []entity.MarketEvent{created, sold},
+			received: []entity.MarketEvent{created, sold},
+			dropped:  false,
+			err:      nil,
+		},
+		{
+			name:     "slow subscriber is dropped",
+			user:     entity.User{Id: 1, Username: "test"},
+			events:   []entity.MarketEvent{created, sold, deleted},
+			received: []entity.MarketEvent{created, sold},
+			dropped:  true,
+			err:      nil,
+		},
+		{
+			name: "invalid user id",
+			user: entity.User{},
+			err:  fmt.Errorf("MarketFeedUseCase - Subscribe - invalid user id"),
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			feed, repo := MarketFeedUseCase(t, 2)
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			events, err := feed.Subscribe(ctx, tc.user)
+			if err != nil {
+				require.ErrorContains(t, err, tc.err.Error())
+				return
+			}
+			require.Nil(t, err)
+
+			repo.EXPECT().Listen(context.Background(), gomock.Any()).DoAndReturn(func(_ context.Context, handle func(entity.MarketEvent)) error {
+				for _, e := range tc.events {
+					handle(e)
+				}
+				return nil
+			})
+			require.Nil(t, feed.Run(context.Background()))
+
+			received := make([]entity.MarketEvent, 0)
+			for len(received) < len(tc.received) {
+				received = append(received, <-events)
+			}
+			require.Equal(t, tc.received, received)
+			select {
+			case _, ok := <-events:
+				require.True(t, tc.dropped)
+				require.False(t, ok)
+			default:
+				require.False(t, tc.dropped)
+			}
+		})
+	}
+}
+
+func TestMarketFeedUnsubscribe(t *testing.T) {
+	t.Parallel()
+
+	feed, repo := MarketFeedUseCase(t, 2)
+	ctx, cancel := context.WithCancel(context.Background())
+	events, err := feed.Subscribe(ctx, entity.User{Id: 1, Username: "test"})
+	require.Nil(t, err)
+	cancel()
+
+	select {
+	case _, ok := <-events:
+		require.False(t, ok)
+	case <-time.After(time.Second):
+		t.Fatal("channel is not closed after the context is done")
+	}
+
+	// Broadcasting after the subscriber left must not panic on the closed channel.
+	repo.EXPECT().Listen(context.Background(), gomock.Any()).DoAndReturn(func(_ context.Context, handle func(entity.MarketEvent)) error {
+		handle(entity.MarketEvent{Type: entity.MarketAssetDeleted, AssetId: 1})
+		return nil
+	})
+	require.Nil(t, feed.Run(context.Background()))
+}
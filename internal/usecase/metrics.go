@@ -0,0 +1,105 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/Klef99/bhs-task/internal/entity"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const _metricsNamespace = "bhs"
+
+// Metrics - business metrics of the marketplace.
+type Metrics struct {
+	registrations prometheus.Counter
+	failedLogins  prometheus.Counter
+	deposits      prometheus.Counter
+	depositAmount prometheus.Counter
+	purchases     *prometheus.CounterVec
+	revenue       *prometheus.CounterVec
+}
+
+// NewMetrics - registers the metrics in reg.
+func NewMetrics(reg prometheus.Registerer) *Metrics {
+	f := promauto.With(reg)
+	return &Metrics{
+		registrations: f.NewCounter(prometheus.CounterOpts{
+			Namespace: _metricsNamespace,
+			Name:      "registrations_total",
+			Help:      "Users registered.",
+		}),
+		failedLogins: f.NewCounter(prometheus.CounterOpts{
+			Namespace: _metricsNamespace,
+			Name:      "failed_logins_total",
+			Help:      "Login attempts that failed.",
+		}),
+		deposits: f.NewCounter(prometheus.CounterOpts{
+			Namespace: _metricsNamespace,
+			Name:      "deposits_total",
+			Help:      "Deposits made.",
+		}),
+		depositAmount: f.NewCounter(prometheus.CounterOpts{
+			Namespace: _metricsNamespace,
+			Name:      "deposits_amount_total",
+			Help:      "Sum of the deposited amounts.",
+		}),
+		purchases: f.NewCounterVec(prometheus.CounterOpts{
+			Namespace: _metricsNamespace,
+			Name:      "purchases_total",
+			Help:      "Purchases of any kind: direct, gifts, renewals, checkouts, auctions and offers.",
+		}, []string{"sale_mode"}),
+		revenue: f.NewCounterVec(prometheus.CounterOpts{
+			Namespace: _metricsNamespace,
+			Name:      "purchases_revenue_total",
+			Help:      "Sum paid for purchases, after discounts.",
+		}, []string{"sale_mode"}),
+	}
+}
+
+// ObservePurchase -.
+func (m *Metrics) ObservePurchase(p entity.Purchase) {
+	m.purchases.WithLabelValues(string(p.SaleMode)).Inc()
+	m.revenue.WithLabelValues(string(p.SaleMode)).Add(p.Price)
+}
+
+// MeteredUserUseCase - counts registrations, deposits and failed logins made through the wrapped use case.
+type MeteredUserUseCase struct {
+	User
+	m *Metrics
+}
+
+var _ User = (*MeteredUserUseCase)(nil)
+
+// New -.
+func NewMeteredUserUseCase(next User, m *Metrics) *MeteredUserUseCase {
+	return &MeteredUserUseCase{User: next, m: m}
+}
+
+// Register -.
+func (uc *MeteredUserUseCase) Register(ctx context.Context, crd entity.Credentials) (bool, error) {
+	status, err := uc.User.Register(ctx, crd)
+	if err == nil && status {
+		uc.m.registrations.Inc()
+	}
+	return status, err
+}
+
+// Login - any error counts as a failed login.
+func (uc *MeteredUserUseCase) Login(ctx context.Context, crd entity.Credentials) (entity.User, error) {
+	user, err := uc.User.Login(ctx, crd)
+	if err != nil {
+		uc.m.failedLogins.Inc()
+	}
+	return user, err
+}
+
+// MakeDeposit -.
+func (uc *MeteredUserUseCase) MakeDeposit(ctx context.Context, user entity.User, amount float64) (float64, error) {
+	balance, err := uc.User.MakeDeposit(ctx, user, amount)
+	if err == nil {
+		uc.m.deposits.Inc()
+		uc.m.depositAmount.Add(amount)
+	}
+	return balance, err
+}
@@ -0,0 +1,123 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Klef99/bhs-task/internal/entity"
+	"github.com/Klef99/bhs-task/internal/usecase"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+	gomock "go.uber.org/mock/gomock"
+)
+
+type meteredUserTest struct {
+	name    string
+	call    func(uc *usecase.MeteredUserUseCase)
+	mock    func(next *MockUser)
+	metric  string
+	counted float64
+}
+
+func MeteredUserUseCase(t *testing.T) (*usecase.MeteredUserUseCase, *MockUser, *prometheus.Registry) {
+	t.Helper()
+
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+
+	next := NewMockUser(mockCtl)
+	reg := prometheus.NewRegistry()
+
+	MeteredUserUseCase := usecase.NewMeteredUserUseCase(next, usecase.NewMetrics(reg))
+	return MeteredUserUseCase, next, reg
+}
+
+func TestMeteredUser(t *testing.T) {
+	t.Parallel()
+
+	crd := entity.Credentials{Username: "test", Password: "pass"}
+	user := entity.User{Id: 1, Username: "test"}
+	tests := []meteredUserTest{
+		{
+			name: "registration",
+			call: func(uc *usecase.MeteredUserUseCase) { uc.Register(context.Background(), crd) },
+			mock: func(next *MockUser) {
+				next.EXPECT().Register(context.Background(), crd).Return(true, nil)
+			},
+			metric:  "bhs_registrations_total",
+			counted: 1,
+		},
+		{
+			name: "failed registration",
+			call: func(uc *usecase.MeteredUserUseCase) { uc.Register(context.Background(), crd) },
+			mock: func(next *MockUser) {
+				next.EXPECT().Register(context.Background(), crd).Return(false, errInternalServErr)
+			},
+			metric:  "bhs_registrations_total",
+			counted: 0,
+		},
+		{
+			name: "failed login",
+			call: func(uc *usecase.MeteredUserUseCase) { uc.Login(context.Background(), crd) },
+			mock: func(next *MockUser) {
+				next.EXPECT().Login(context.Background(), crd).Return(entity.User{}, errInternalServErr)
+			},
+			metric:  "bhs_failed_logins_total",
+			counted: 1,
+		},
+		{
+			name: "successful login",
+			call: func(uc *usecase.MeteredUserUseCase) { uc.Login(context.Background(), crd) },
+			mock: func(next *MockUser) {
+				next.EXPECT().Login(context.Background(), crd).Return(user, nil)
+			},
+			metric:  "bhs_failed_logins_total",
+			counted: 0,
+		},
+		{
+			name: "deposit amount",
+			call: func(uc *usecase.MeteredUserUseCase) { uc.MakeDeposit(context.Background(), user, 25.5) },
+			mock: func(next *MockUser) {
+				next.EXPECT().MakeDeposit(context.Background(), user, 25.5).Return(125.5, nil)
+			},
+			metric:  "bhs_deposits_amount_total",
+			counted: 25.5,
+		},
+		{
+			name: "failed deposit",
+			call: func(uc *usecase.MeteredUserUseCase) { uc.MakeDeposit(context.Background(), user, 25.5) },
+			mock: func(next *MockUser) {
+				next.EXPECT().MakeDeposit(context.Background(), user, 25.5).Return(float64(0), errInternalServErr)
+			},
+			metric:  "bhs_deposits_total",
+			counted: 0,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			uc, next, reg := MeteredUserUseCase(t)
+			tc.mock(next)
+			tc.call(uc)
+
+			require.Equal(t, tc.counted, counterValue(t, reg, tc.metric))
+		})
+	}
+}
+
+func counterValue(t *testing.T, reg *prometheus.Registry, name string) float64 {
+	t.Helper()
+
+	families, err := reg.Gather()
+	require.NoError(t, err)
+	for _, f := range families {
+		if f.GetName() == name {
+			return f.GetMetric()[0].GetCounter().GetValue()
+		}
+	}
+	t.Fatalf("metric %s is not registered", name)
+	return 0
+}
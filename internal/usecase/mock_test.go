@@ -12,6 +12,7 @@ package usecase_test
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	entity "github.com/Klef99/bhs-task/internal/entity"
 	gomock "go.uber.org/mock/gomock"
@@ -55,6 +56,36 @@ func (mr *MockUserMockRecorder) CheckDeposit(ctx, user any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckDeposit", reflect.TypeOf((*MockUser)(nil).CheckDeposit), ctx, user)
 }
 
+// GetUsersByIds mocks base method.
+func (m *MockUser) GetUsersByIds(ctx context.Context, ids []int64) ([]entity.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUsersByIds", ctx, ids)
+	ret0, _ := ret[0].([]entity.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUsersByIds indicates an expected call of GetUsersByIds.
+func (mr *MockUserMockRecorder) GetUsersByIds(ctx, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsersByIds", reflect.TypeOf((*MockUser)(nil).GetUsersByIds), ctx, ids)
+}
+
+// IsAdmin mocks base method.
+func (m *MockUser) IsAdmin(ctx context.Context, user entity.User) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsAdmin", ctx, user)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsAdmin indicates an expected call of IsAdmin.
+func (mr *MockUserMockRecorder) IsAdmin(ctx, user any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsAdmin", reflect.TypeOf((*MockUser)(nil).IsAdmin), ctx, user)
+}
+
 // Login mocks base method.
 func (m *MockUser) Login(ctx context.Context, crd entity.Credentials) (entity.User, error) {
 	m.ctrl.T.Helper()
@@ -153,6 +184,36 @@ func (mr *MockUserRepositoryMockRecorder) CreateUser(ctx, crd any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockUserRepository)(nil).CreateUser), ctx, crd)
 }
 
+// GetRole mocks base method.
+func (m *MockUserRepository) GetRole(ctx context.Context, user entity.User) (entity.Role, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRole", ctx, user)
+	ret0, _ := ret[0].(entity.Role)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRole indicates an expected call of GetRole.
+func (mr *MockUserRepositoryMockRecorder) GetRole(ctx, user any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRole", reflect.TypeOf((*MockUserRepository)(nil).GetRole), ctx, user)
+}
+
+// GetUsersByIds mocks base method.
+func (m *MockUserRepository) GetUsersByIds(ctx context.Context, ids []int64) ([]entity.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUsersByIds", ctx, ids)
+	ret0, _ := ret[0].([]entity.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUsersByIds indicates an expected call of GetUsersByIds.
+func (mr *MockUserRepositoryMockRecorder) GetUsersByIds(ctx, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsersByIds", reflect.TypeOf((*MockUserRepository)(nil).GetUsersByIds), ctx, ids)
+}
+
 // LoginUser mocks base method.
 func (m *MockUserRepository) LoginUser(ctx context.Context, crd entity.Credentials) (int64, error) {
 	m.ctrl.T.Helper()
@@ -207,18 +268,48 @@ func (m *MockAsset) EXPECT() *MockAssetMockRecorder {
 }
 
 // BuyAsset mocks base method.
-func (m *MockAsset) BuyAsset(ctx context.Context, user entity.User, id int64) (bool, error) {
+func (m *MockAsset) BuyAsset(ctx context.Context, user entity.User, id int64, promoCode string) (bool, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "BuyAsset", ctx, user, id)
+	ret := m.ctrl.Call(m, "BuyAsset", ctx, user, id, promoCode)
 	ret0, _ := ret[0].(bool)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // BuyAsset indicates an expected call of BuyAsset.
-func (mr *MockAssetMockRecorder) BuyAsset(ctx, user, id any) *gomock.Call {
+func (mr *MockAssetMockRecorder) BuyAsset(ctx, user, id, promoCode any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BuyAsset", reflect.TypeOf((*MockAsset)(nil).BuyAsset), ctx, user, id, promoCode)
+}
+
+// ChangeAssetPrice mocks base method.
+func (m *MockAsset) ChangeAssetPrice(ctx context.Context, user entity.User, id int64, price float64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangeAssetPrice", ctx, user, id, price)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChangeAssetPrice indicates an expected call of ChangeAssetPrice.
+func (mr *MockAssetMockRecorder) ChangeAssetPrice(ctx, user, id, price any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeAssetPrice", reflect.TypeOf((*MockAsset)(nil).ChangeAssetPrice), ctx, user, id, price)
+}
+
+// ChangeAssetStatus mocks base method.
+func (m *MockAsset) ChangeAssetStatus(ctx context.Context, user entity.User, id int64, status entity.AssetStatus) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangeAssetStatus", ctx, user, id, status)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChangeAssetStatus indicates an expected call of ChangeAssetStatus.
+func (mr *MockAssetMockRecorder) ChangeAssetStatus(ctx, user, id, status any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BuyAsset", reflect.TypeOf((*MockAsset)(nil).BuyAsset), ctx, user, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeAssetStatus", reflect.TypeOf((*MockAsset)(nil).ChangeAssetStatus), ctx, user, id, status)
 }
 
 // CreateAsset mocks base method.
@@ -252,18 +343,33 @@ func (mr *MockAssetMockRecorder) DeleteAsset(ctx, user, id any) *gomock.Call {
 }
 
 // GetAssetById mocks base method.
-func (m *MockAsset) GetAssetById(ctx context.Context, id int64) (entity.Asset, error) {
+func (m *MockAsset) GetAssetById(ctx context.Context, user entity.User, id int64) (entity.Asset, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAssetById", ctx, id)
+	ret := m.ctrl.Call(m, "GetAssetById", ctx, user, id)
 	ret0, _ := ret[0].(entity.Asset)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetAssetById indicates an expected call of GetAssetById.
-func (mr *MockAssetMockRecorder) GetAssetById(ctx, id any) *gomock.Call {
+func (mr *MockAssetMockRecorder) GetAssetById(ctx, user, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAssetById", reflect.TypeOf((*MockAsset)(nil).GetAssetById), ctx, user, id)
+}
+
+// GetAssetsByIds mocks base method.
+func (m *MockAsset) GetAssetsByIds(ctx context.Context, user entity.User, ids []int64) ([]entity.Asset, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAssetsByIds", ctx, user, ids)
+	ret0, _ := ret[0].([]entity.Asset)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAssetsByIds indicates an expected call of GetAssetsByIds.
+func (mr *MockAssetMockRecorder) GetAssetsByIds(ctx, user, ids any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAssetById", reflect.TypeOf((*MockAsset)(nil).GetAssetById), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAssetsByIds", reflect.TypeOf((*MockAsset)(nil).GetAssetsByIds), ctx, user, ids)
 }
 
 // GetAssetsToBuying mocks base method.
@@ -281,19 +387,109 @@ func (mr *MockAssetMockRecorder) GetAssetsToBuying(ctx, user any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAssetsToBuying", reflect.TypeOf((*MockAsset)(nil).GetAssetsToBuying), ctx, user)
 }
 
-// GetPurchasedAsset mocks base method.
-func (m *MockAsset) GetPurchasedAsset(ctx context.Context, user entity.User) ([]entity.Asset, error) {
+// GetProvenance mocks base method.
+func (m *MockAsset) GetProvenance(ctx context.Context, user entity.User, id int64) ([]entity.Purchase, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProvenance", ctx, user, id)
+	ret0, _ := ret[0].([]entity.Purchase)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProvenance indicates an expected call of GetProvenance.
+func (mr *MockAssetMockRecorder) GetProvenance(ctx, user, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProvenance", reflect.TypeOf((*MockAsset)(nil).GetProvenance), ctx, user, id)
+}
+
+// GetPurchaseHistory mocks base method.
+func (m *MockAsset) GetPurchaseHistory(ctx context.Context, user entity.User) ([]entity.Purchase, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPurchaseHistory", ctx, user)
+	ret0, _ := ret[0].([]entity.Purchase)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPurchaseHistory indicates an expected call of GetPurchaseHistory.
+func (mr *MockAssetMockRecorder) GetPurchaseHistory(ctx, user any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPurchaseHistory", reflect.TypeOf((*MockAsset)(nil).GetPurchaseHistory), ctx, user)
+}
+
+// GetPurchasedAssets mocks base method.
+func (m *MockAsset) GetPurchasedAssets(ctx context.Context, user entity.User) ([]entity.Asset, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetPurchasedAsset", ctx, user)
+	ret := m.ctrl.Call(m, "GetPurchasedAssets", ctx, user)
 	ret0, _ := ret[0].([]entity.Asset)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetPurchasedAsset indicates an expected call of GetPurchasedAsset.
-func (mr *MockAssetMockRecorder) GetPurchasedAsset(ctx, user any) *gomock.Call {
+// GetPurchasedAssets indicates an expected call of GetPurchasedAssets.
+func (mr *MockAssetMockRecorder) GetPurchasedAssets(ctx, user any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPurchasedAssets", reflect.TypeOf((*MockAsset)(nil).GetPurchasedAssets), ctx, user)
+}
+
+// GiftAsset mocks base method.
+func (m *MockAsset) GiftAsset(ctx context.Context, user entity.User, id int64, recipient, message string) (entity.Purchase, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GiftAsset", ctx, user, id, recipient, message)
+	ret0, _ := ret[0].(entity.Purchase)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GiftAsset indicates an expected call of GiftAsset.
+func (mr *MockAssetMockRecorder) GiftAsset(ctx, user, id, recipient, message any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GiftAsset", reflect.TypeOf((*MockAsset)(nil).GiftAsset), ctx, user, id, recipient, message)
+}
+
+// PurgeAsset mocks base method.
+func (m *MockAsset) PurgeAsset(ctx context.Context, id int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeAsset", ctx, id)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeAsset indicates an expected call of PurgeAsset.
+func (mr *MockAssetMockRecorder) PurgeAsset(ctx, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPurchasedAsset", reflect.TypeOf((*MockAsset)(nil).GetPurchasedAsset), ctx, user)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeAsset", reflect.TypeOf((*MockAsset)(nil).PurgeAsset), ctx, id)
+}
+
+// RenewAccess mocks base method.
+func (m *MockAsset) RenewAccess(ctx context.Context, user entity.User, id int64) (entity.Purchase, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RenewAccess", ctx, user, id)
+	ret0, _ := ret[0].(entity.Purchase)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RenewAccess indicates an expected call of RenewAccess.
+func (mr *MockAssetMockRecorder) RenewAccess(ctx, user, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenewAccess", reflect.TypeOf((*MockAsset)(nil).RenewAccess), ctx, user, id)
+}
+
+// SweepExpiredAccess mocks base method.
+func (m *MockAsset) SweepExpiredAccess(ctx context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SweepExpiredAccess", ctx)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SweepExpiredAccess indicates an expected call of SweepExpiredAccess.
+func (mr *MockAssetMockRecorder) SweepExpiredAccess(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SweepExpiredAccess", reflect.TypeOf((*MockAsset)(nil).SweepExpiredAccess), ctx)
 }
 
 // UserAssetsList mocks base method.
@@ -335,18 +531,33 @@ func (m *MockAssetRepository) EXPECT() *MockAssetRepositoryMockRecorder {
 }
 
 // BuyAsset mocks base method.
-func (m *MockAssetRepository) BuyAsset(ctx context.Context, user entity.User, id int64) (bool, error) {
+func (m *MockAssetRepository) BuyAsset(ctx context.Context, user entity.User, id int64, promoCode string) (entity.Purchase, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "BuyAsset", ctx, user, id)
-	ret0, _ := ret[0].(bool)
+	ret := m.ctrl.Call(m, "BuyAsset", ctx, user, id, promoCode)
+	ret0, _ := ret[0].(entity.Purchase)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // BuyAsset indicates an expected call of BuyAsset.
-func (mr *MockAssetRepositoryMockRecorder) BuyAsset(ctx, user, id any) *gomock.Call {
+func (mr *MockAssetRepositoryMockRecorder) BuyAsset(ctx, user, id, promoCode any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BuyAsset", reflect.TypeOf((*MockAssetRepository)(nil).BuyAsset), ctx, user, id, promoCode)
+}
+
+// DeleteExpiredAccess mocks base method.
+func (m *MockAssetRepository) DeleteExpiredAccess(ctx context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExpiredAccess", ctx)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteExpiredAccess indicates an expected call of DeleteExpiredAccess.
+func (mr *MockAssetRepositoryMockRecorder) DeleteExpiredAccess(ctx any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BuyAsset", reflect.TypeOf((*MockAssetRepository)(nil).BuyAsset), ctx, user, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpiredAccess", reflect.TypeOf((*MockAssetRepository)(nil).DeleteExpiredAccess), ctx)
 }
 
 // Erase mocks base method.
@@ -365,18 +576,33 @@ func (mr *MockAssetRepositoryMockRecorder) Erase(ctx, user, id any) *gomock.Call
 }
 
 // GetAssetById mocks base method.
-func (m *MockAssetRepository) GetAssetById(ctx context.Context, id int64) (entity.Asset, error) {
+func (m *MockAssetRepository) GetAssetById(ctx context.Context, user entity.User, id int64) (entity.Asset, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAssetById", ctx, id)
+	ret := m.ctrl.Call(m, "GetAssetById", ctx, user, id)
 	ret0, _ := ret[0].(entity.Asset)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetAssetById indicates an expected call of GetAssetById.
-func (mr *MockAssetRepositoryMockRecorder) GetAssetById(ctx, id any) *gomock.Call {
+func (mr *MockAssetRepositoryMockRecorder) GetAssetById(ctx, user, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAssetById", reflect.TypeOf((*MockAssetRepository)(nil).GetAssetById), ctx, user, id)
+}
+
+// GetAssetsByIds mocks base method.
+func (m *MockAssetRepository) GetAssetsByIds(ctx context.Context, user entity.User, ids []int64) ([]entity.Asset, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAssetsByIds", ctx, user, ids)
+	ret0, _ := ret[0].([]entity.Asset)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAssetsByIds indicates an expected call of GetAssetsByIds.
+func (mr *MockAssetRepositoryMockRecorder) GetAssetsByIds(ctx, user, ids any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAssetById", reflect.TypeOf((*MockAssetRepository)(nil).GetAssetById), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAssetsByIds", reflect.TypeOf((*MockAssetRepository)(nil).GetAssetsByIds), ctx, user, ids)
 }
 
 // GetOtherUsersAssets mocks base method.
@@ -394,6 +620,36 @@ func (mr *MockAssetRepositoryMockRecorder) GetOtherUsersAssets(ctx, user any) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOtherUsersAssets", reflect.TypeOf((*MockAssetRepository)(nil).GetOtherUsersAssets), ctx, user)
 }
 
+// GetProvenance mocks base method.
+func (m *MockAssetRepository) GetProvenance(ctx context.Context, id int64) ([]entity.Purchase, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProvenance", ctx, id)
+	ret0, _ := ret[0].([]entity.Purchase)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProvenance indicates an expected call of GetProvenance.
+func (mr *MockAssetRepositoryMockRecorder) GetProvenance(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProvenance", reflect.TypeOf((*MockAssetRepository)(nil).GetProvenance), ctx, id)
+}
+
+// GetPurchaseHistory mocks base method.
+func (m *MockAssetRepository) GetPurchaseHistory(ctx context.Context, user entity.User) ([]entity.Purchase, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPurchaseHistory", ctx, user)
+	ret0, _ := ret[0].([]entity.Purchase)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPurchaseHistory indicates an expected call of GetPurchaseHistory.
+func (mr *MockAssetRepositoryMockRecorder) GetPurchaseHistory(ctx, user any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPurchaseHistory", reflect.TypeOf((*MockAssetRepository)(nil).GetPurchaseHistory), ctx, user)
+}
+
 // GetPurchasedAssets mocks base method.
 func (m *MockAssetRepository) GetPurchasedAssets(ctx context.Context, user entity.User) ([]entity.Asset, error) {
 	m.ctrl.T.Helper()
@@ -409,11 +665,56 @@ func (mr *MockAssetRepositoryMockRecorder) GetPurchasedAssets(ctx, user any) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPurchasedAssets", reflect.TypeOf((*MockAssetRepository)(nil).GetPurchasedAssets), ctx, user)
 }
 
+// Gift mocks base method.
+func (m *MockAssetRepository) Gift(ctx context.Context, user entity.User, id int64, recipient, message string) (entity.Purchase, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Gift", ctx, user, id, recipient, message)
+	ret0, _ := ret[0].(entity.Purchase)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Gift indicates an expected call of Gift.
+func (mr *MockAssetRepositoryMockRecorder) Gift(ctx, user, id, recipient, message any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Gift", reflect.TypeOf((*MockAssetRepository)(nil).Gift), ctx, user, id, recipient, message)
+}
+
+// Purge mocks base method.
+func (m *MockAssetRepository) Purge(ctx context.Context, id int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Purge", ctx, id)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Purge indicates an expected call of Purge.
+func (mr *MockAssetRepositoryMockRecorder) Purge(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Purge", reflect.TypeOf((*MockAssetRepository)(nil).Purge), ctx, id)
+}
+
+// RenewAccess mocks base method.
+func (m *MockAssetRepository) RenewAccess(ctx context.Context, user entity.User, id int64) (entity.Purchase, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RenewAccess", ctx, user, id)
+	ret0, _ := ret[0].(entity.Purchase)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RenewAccess indicates an expected call of RenewAccess.
+func (mr *MockAssetRepositoryMockRecorder) RenewAccess(ctx, user, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenewAccess", reflect.TypeOf((*MockAssetRepository)(nil).RenewAccess), ctx, user, id)
+}
+
 // Store mocks base method.
-func (m *MockAssetRepository) Store(ctx context.Context, ast entity.Asset) (bool, error) {
+func (m *MockAssetRepository) Store(ctx context.Context, ast entity.Asset) (int64, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Store", ctx, ast)
-	ret0, _ := ret[0].(bool)
+	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -424,6 +725,36 @@ func (mr *MockAssetRepositoryMockRecorder) Store(ctx, ast any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Store", reflect.TypeOf((*MockAssetRepository)(nil).Store), ctx, ast)
 }
 
+// UpdatePrice mocks base method.
+func (m *MockAssetRepository) UpdatePrice(ctx context.Context, user entity.User, id int64, price float64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePrice", ctx, user, id, price)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdatePrice indicates an expected call of UpdatePrice.
+func (mr *MockAssetRepositoryMockRecorder) UpdatePrice(ctx, user, id, price any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePrice", reflect.TypeOf((*MockAssetRepository)(nil).UpdatePrice), ctx, user, id, price)
+}
+
+// UpdateStatus mocks base method.
+func (m *MockAssetRepository) UpdateStatus(ctx context.Context, user entity.User, id int64, status entity.AssetStatus) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateStatus", ctx, user, id, status)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateStatus indicates an expected call of UpdateStatus.
+func (mr *MockAssetRepositoryMockRecorder) UpdateStatus(ctx, user, id, status any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatus", reflect.TypeOf((*MockAssetRepository)(nil).UpdateStatus), ctx, user, id, status)
+}
+
 // UserAssetsList mocks base method.
 func (m *MockAssetRepository) UserAssetsList(ctx context.Context, user entity.User) ([]entity.Asset, error) {
 	m.ctrl.T.Helper()
@@ -438,3 +769,2180 @@ func (mr *MockAssetRepositoryMockRecorder) UserAssetsList(ctx, user any) *gomock
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserAssetsList", reflect.TypeOf((*MockAssetRepository)(nil).UserAssetsList), ctx, user)
 }
+
+// MockAuction is a mock of Auction interface.
+type MockAuction struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuctionMockRecorder
+}
+
+// MockAuctionMockRecorder is the mock recorder for MockAuction.
+type MockAuctionMockRecorder struct {
+	mock *MockAuction
+}
+
+// NewMockAuction creates a new mock instance.
+func NewMockAuction(ctrl *gomock.Controller) *MockAuction {
+	mock := &MockAuction{ctrl: ctrl}
+	mock.recorder = &MockAuctionMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuction) EXPECT() *MockAuctionMockRecorder {
+	return m.recorder
+}
+
+// GetAuction mocks base method.
+func (m *MockAuction) GetAuction(ctx context.Context, user entity.User, assetId int64) (entity.Auction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAuction", ctx, user, assetId)
+	ret0, _ := ret[0].(entity.Auction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAuction indicates an expected call of GetAuction.
+func (mr *MockAuctionMockRecorder) GetAuction(ctx, user, assetId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuction", reflect.TypeOf((*MockAuction)(nil).GetAuction), ctx, user, assetId)
+}
+
+// GetBids mocks base method.
+func (m *MockAuction) GetBids(ctx context.Context, user entity.User, assetId int64) ([]entity.Bid, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBids", ctx, user, assetId)
+	ret0, _ := ret[0].([]entity.Bid)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBids indicates an expected call of GetBids.
+func (mr *MockAuctionMockRecorder) GetBids(ctx, user, assetId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBids", reflect.TypeOf((*MockAuction)(nil).GetBids), ctx, user, assetId)
+}
+
+// OpenAuction mocks base method.
+func (m *MockAuction) OpenAuction(ctx context.Context, user entity.User, assetId int64, reservePrice float64, endAt time.Time) (entity.Auction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OpenAuction", ctx, user, assetId, reservePrice, endAt)
+	ret0, _ := ret[0].(entity.Auction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// OpenAuction indicates an expected call of OpenAuction.
+func (mr *MockAuctionMockRecorder) OpenAuction(ctx, user, assetId, reservePrice, endAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OpenAuction", reflect.TypeOf((*MockAuction)(nil).OpenAuction), ctx, user, assetId, reservePrice, endAt)
+}
+
+// PlaceBid mocks base method.
+func (m *MockAuction) PlaceBid(ctx context.Context, user entity.User, assetId int64, amount float64) (entity.Bid, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PlaceBid", ctx, user, assetId, amount)
+	ret0, _ := ret[0].(entity.Bid)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PlaceBid indicates an expected call of PlaceBid.
+func (mr *MockAuctionMockRecorder) PlaceBid(ctx, user, assetId, amount any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PlaceBid", reflect.TypeOf((*MockAuction)(nil).PlaceBid), ctx, user, assetId, amount)
+}
+
+// SettleExpired mocks base method.
+func (m *MockAuction) SettleExpired(ctx context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SettleExpired", ctx)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SettleExpired indicates an expected call of SettleExpired.
+func (mr *MockAuctionMockRecorder) SettleExpired(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SettleExpired", reflect.TypeOf((*MockAuction)(nil).SettleExpired), ctx)
+}
+
+// MockAuctionRepository is a mock of AuctionRepository interface.
+type MockAuctionRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuctionRepositoryMockRecorder
+}
+
+// MockAuctionRepositoryMockRecorder is the mock recorder for MockAuctionRepository.
+type MockAuctionRepositoryMockRecorder struct {
+	mock *MockAuctionRepository
+}
+
+// NewMockAuctionRepository creates a new mock instance.
+func NewMockAuctionRepository(ctrl *gomock.Controller) *MockAuctionRepository {
+	mock := &MockAuctionRepository{ctrl: ctrl}
+	mock.recorder = &MockAuctionRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuctionRepository) EXPECT() *MockAuctionRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetBids mocks base method.
+func (m *MockAuctionRepository) GetBids(ctx context.Context, auctionId int64) ([]entity.Bid, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBids", ctx, auctionId)
+	ret0, _ := ret[0].([]entity.Bid)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBids indicates an expected call of GetBids.
+func (mr *MockAuctionRepositoryMockRecorder) GetBids(ctx, auctionId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBids", reflect.TypeOf((*MockAuctionRepository)(nil).GetBids), ctx, auctionId)
+}
+
+// GetExpired mocks base method.
+func (m *MockAuctionRepository) GetExpired(ctx context.Context, limit uint64) ([]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExpired", ctx, limit)
+	ret0, _ := ret[0].([]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExpired indicates an expected call of GetExpired.
+func (mr *MockAuctionRepositoryMockRecorder) GetExpired(ctx, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExpired", reflect.TypeOf((*MockAuctionRepository)(nil).GetExpired), ctx, limit)
+}
+
+// GetLatestByAsset mocks base method.
+func (m *MockAuctionRepository) GetLatestByAsset(ctx context.Context, user entity.User, assetId int64) (entity.Auction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLatestByAsset", ctx, user, assetId)
+	ret0, _ := ret[0].(entity.Auction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLatestByAsset indicates an expected call of GetLatestByAsset.
+func (mr *MockAuctionRepositoryMockRecorder) GetLatestByAsset(ctx, user, assetId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLatestByAsset", reflect.TypeOf((*MockAuctionRepository)(nil).GetLatestByAsset), ctx, user, assetId)
+}
+
+// Open mocks base method.
+func (m *MockAuctionRepository) Open(ctx context.Context, auction entity.Auction) (entity.Auction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Open", ctx, auction)
+	ret0, _ := ret[0].(entity.Auction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Open indicates an expected call of Open.
+func (mr *MockAuctionRepositoryMockRecorder) Open(ctx, auction any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Open", reflect.TypeOf((*MockAuctionRepository)(nil).Open), ctx, auction)
+}
+
+// PlaceBid mocks base method.
+func (m *MockAuctionRepository) PlaceBid(ctx context.Context, user entity.User, assetId int64, amount float64) (entity.Bid, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PlaceBid", ctx, user, assetId, amount)
+	ret0, _ := ret[0].(entity.Bid)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PlaceBid indicates an expected call of PlaceBid.
+func (mr *MockAuctionRepositoryMockRecorder) PlaceBid(ctx, user, assetId, amount any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PlaceBid", reflect.TypeOf((*MockAuctionRepository)(nil).PlaceBid), ctx, user, assetId, amount)
+}
+
+// Settle mocks base method.
+func (m *MockAuctionRepository) Settle(ctx context.Context, id int64) (entity.Auction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Settle", ctx, id)
+	ret0, _ := ret[0].(entity.Auction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Settle indicates an expected call of Settle.
+func (mr *MockAuctionRepositoryMockRecorder) Settle(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Settle", reflect.TypeOf((*MockAuctionRepository)(nil).Settle), ctx, id)
+}
+
+// MockOffer is a mock of Offer interface.
+type MockOffer struct {
+	ctrl     *gomock.Controller
+	recorder *MockOfferMockRecorder
+}
+
+// MockOfferMockRecorder is the mock recorder for MockOffer.
+type MockOfferMockRecorder struct {
+	mock *MockOffer
+}
+
+// NewMockOffer creates a new mock instance.
+func NewMockOffer(ctrl *gomock.Controller) *MockOffer {
+	mock := &MockOffer{ctrl: ctrl}
+	mock.recorder = &MockOfferMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOffer) EXPECT() *MockOfferMockRecorder {
+	return m.recorder
+}
+
+// AcceptOffer mocks base method.
+func (m *MockOffer) AcceptOffer(ctx context.Context, user entity.User, id int64) (entity.Offer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcceptOffer", ctx, user, id)
+	ret0, _ := ret[0].(entity.Offer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AcceptOffer indicates an expected call of AcceptOffer.
+func (mr *MockOfferMockRecorder) AcceptOffer(ctx, user, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcceptOffer", reflect.TypeOf((*MockOffer)(nil).AcceptOffer), ctx, user, id)
+}
+
+// CounterOffer mocks base method.
+func (m *MockOffer) CounterOffer(ctx context.Context, user entity.User, id int64, price float64) (entity.Offer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CounterOffer", ctx, user, id, price)
+	ret0, _ := ret[0].(entity.Offer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CounterOffer indicates an expected call of CounterOffer.
+func (mr *MockOfferMockRecorder) CounterOffer(ctx, user, id, price any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CounterOffer", reflect.TypeOf((*MockOffer)(nil).CounterOffer), ctx, user, id, price)
+}
+
+// ExpireOffers mocks base method.
+func (m *MockOffer) ExpireOffers(ctx context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExpireOffers", ctx)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExpireOffers indicates an expected call of ExpireOffers.
+func (mr *MockOfferMockRecorder) ExpireOffers(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpireOffers", reflect.TypeOf((*MockOffer)(nil).ExpireOffers), ctx)
+}
+
+// GetAssetOffers mocks base method.
+func (m *MockOffer) GetAssetOffers(ctx context.Context, user entity.User, assetId int64) ([]entity.Offer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAssetOffers", ctx, user, assetId)
+	ret0, _ := ret[0].([]entity.Offer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAssetOffers indicates an expected call of GetAssetOffers.
+func (mr *MockOfferMockRecorder) GetAssetOffers(ctx, user, assetId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAssetOffers", reflect.TypeOf((*MockOffer)(nil).GetAssetOffers), ctx, user, assetId)
+}
+
+// GetInbox mocks base method.
+func (m *MockOffer) GetInbox(ctx context.Context, user entity.User) ([]entity.Offer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInbox", ctx, user)
+	ret0, _ := ret[0].([]entity.Offer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInbox indicates an expected call of GetInbox.
+func (mr *MockOfferMockRecorder) GetInbox(ctx, user any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInbox", reflect.TypeOf((*MockOffer)(nil).GetInbox), ctx, user)
+}
+
+// GetOutbox mocks base method.
+func (m *MockOffer) GetOutbox(ctx context.Context, user entity.User) ([]entity.Offer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOutbox", ctx, user)
+	ret0, _ := ret[0].([]entity.Offer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOutbox indicates an expected call of GetOutbox.
+func (mr *MockOfferMockRecorder) GetOutbox(ctx, user any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOutbox", reflect.TypeOf((*MockOffer)(nil).GetOutbox), ctx, user)
+}
+
+// MakeOffer mocks base method.
+func (m *MockOffer) MakeOffer(ctx context.Context, user entity.User, assetId int64, price float64) (entity.Offer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MakeOffer", ctx, user, assetId, price)
+	ret0, _ := ret[0].(entity.Offer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MakeOffer indicates an expected call of MakeOffer.
+func (mr *MockOfferMockRecorder) MakeOffer(ctx, user, assetId, price any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MakeOffer", reflect.TypeOf((*MockOffer)(nil).MakeOffer), ctx, user, assetId, price)
+}
+
+// RejectOffer mocks base method.
+func (m *MockOffer) RejectOffer(ctx context.Context, user entity.User, id int64) (entity.Offer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RejectOffer", ctx, user, id)
+	ret0, _ := ret[0].(entity.Offer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RejectOffer indicates an expected call of RejectOffer.
+func (mr *MockOfferMockRecorder) RejectOffer(ctx, user, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RejectOffer", reflect.TypeOf((*MockOffer)(nil).RejectOffer), ctx, user, id)
+}
+
+// MockOfferRepository is a mock of OfferRepository interface.
+type MockOfferRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockOfferRepositoryMockRecorder
+}
+
+// MockOfferRepositoryMockRecorder is the mock recorder for MockOfferRepository.
+type MockOfferRepositoryMockRecorder struct {
+	mock *MockOfferRepository
+}
+
+// NewMockOfferRepository creates a new mock instance.
+func NewMockOfferRepository(ctrl *gomock.Controller) *MockOfferRepository {
+	mock := &MockOfferRepository{ctrl: ctrl}
+	mock.recorder = &MockOfferRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOfferRepository) EXPECT() *MockOfferRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Accept mocks base method.
+func (m *MockOfferRepository) Accept(ctx context.Context, user entity.User, id int64) (entity.Offer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Accept", ctx, user, id)
+	ret0, _ := ret[0].(entity.Offer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Accept indicates an expected call of Accept.
+func (mr *MockOfferRepositoryMockRecorder) Accept(ctx, user, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Accept", reflect.TypeOf((*MockOfferRepository)(nil).Accept), ctx, user, id)
+}
+
+// Counter mocks base method.
+func (m *MockOfferRepository) Counter(ctx context.Context, user entity.User, id int64, price float64, expiresAt time.Time) (entity.Offer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Counter", ctx, user, id, price, expiresAt)
+	ret0, _ := ret[0].(entity.Offer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Counter indicates an expected call of Counter.
+func (mr *MockOfferRepositoryMockRecorder) Counter(ctx, user, id, price, expiresAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Counter", reflect.TypeOf((*MockOfferRepository)(nil).Counter), ctx, user, id, price, expiresAt)
+}
+
+// Create mocks base method.
+func (m *MockOfferRepository) Create(ctx context.Context, offer entity.Offer) (entity.Offer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, offer)
+	ret0, _ := ret[0].(entity.Offer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockOfferRepositoryMockRecorder) Create(ctx, offer any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockOfferRepository)(nil).Create), ctx, offer)
+}
+
+// Expire mocks base method.
+func (m *MockOfferRepository) Expire(ctx context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Expire", ctx)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Expire indicates an expected call of Expire.
+func (mr *MockOfferRepositoryMockRecorder) Expire(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Expire", reflect.TypeOf((*MockOfferRepository)(nil).Expire), ctx)
+}
+
+// GetByAsset mocks base method.
+func (m *MockOfferRepository) GetByAsset(ctx context.Context, user entity.User, assetId int64) ([]entity.Offer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByAsset", ctx, user, assetId)
+	ret0, _ := ret[0].([]entity.Offer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByAsset indicates an expected call of GetByAsset.
+func (mr *MockOfferRepositoryMockRecorder) GetByAsset(ctx, user, assetId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByAsset", reflect.TypeOf((*MockOfferRepository)(nil).GetByAsset), ctx, user, assetId)
+}
+
+// GetInbox mocks base method.
+func (m *MockOfferRepository) GetInbox(ctx context.Context, user entity.User) ([]entity.Offer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInbox", ctx, user)
+	ret0, _ := ret[0].([]entity.Offer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInbox indicates an expected call of GetInbox.
+func (mr *MockOfferRepositoryMockRecorder) GetInbox(ctx, user any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInbox", reflect.TypeOf((*MockOfferRepository)(nil).GetInbox), ctx, user)
+}
+
+// GetOutbox mocks base method.
+func (m *MockOfferRepository) GetOutbox(ctx context.Context, user entity.User) ([]entity.Offer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOutbox", ctx, user)
+	ret0, _ := ret[0].([]entity.Offer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOutbox indicates an expected call of GetOutbox.
+func (mr *MockOfferRepositoryMockRecorder) GetOutbox(ctx, user any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOutbox", reflect.TypeOf((*MockOfferRepository)(nil).GetOutbox), ctx, user)
+}
+
+// Reject mocks base method.
+func (m *MockOfferRepository) Reject(ctx context.Context, user entity.User, id int64) (entity.Offer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reject", ctx, user, id)
+	ret0, _ := ret[0].(entity.Offer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Reject indicates an expected call of Reject.
+func (mr *MockOfferRepositoryMockRecorder) Reject(ctx, user, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reject", reflect.TypeOf((*MockOfferRepository)(nil).Reject), ctx, user, id)
+}
+
+// MockCart is a mock of Cart interface.
+type MockCart struct {
+	ctrl     *gomock.Controller
+	recorder *MockCartMockRecorder
+}
+
+// MockCartMockRecorder is the mock recorder for MockCart.
+type MockCartMockRecorder struct {
+	mock *MockCart
+}
+
+// NewMockCart creates a new mock instance.
+func NewMockCart(ctrl *gomock.Controller) *MockCart {
+	mock := &MockCart{ctrl: ctrl}
+	mock.recorder = &MockCartMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCart) EXPECT() *MockCartMockRecorder {
+	return m.recorder
+}
+
+// AddToCart mocks base method.
+func (m *MockCart) AddToCart(ctx context.Context, user entity.User, assetId int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddToCart", ctx, user, assetId)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddToCart indicates an expected call of AddToCart.
+func (mr *MockCartMockRecorder) AddToCart(ctx, user, assetId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddToCart", reflect.TypeOf((*MockCart)(nil).AddToCart), ctx, user, assetId)
+}
+
+// Checkout mocks base method.
+func (m *MockCart) Checkout(ctx context.Context, user entity.User, promoCode string) (entity.Receipt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Checkout", ctx, user, promoCode)
+	ret0, _ := ret[0].(entity.Receipt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Checkout indicates an expected call of Checkout.
+func (mr *MockCartMockRecorder) Checkout(ctx, user, promoCode any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Checkout", reflect.TypeOf((*MockCart)(nil).Checkout), ctx, user, promoCode)
+}
+
+// GetCart mocks base method.
+func (m *MockCart) GetCart(ctx context.Context, user entity.User) (entity.Cart, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCart", ctx, user)
+	ret0, _ := ret[0].(entity.Cart)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCart indicates an expected call of GetCart.
+func (mr *MockCartMockRecorder) GetCart(ctx, user any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCart", reflect.TypeOf((*MockCart)(nil).GetCart), ctx, user)
+}
+
+// RemoveFromCart mocks base method.
+func (m *MockCart) RemoveFromCart(ctx context.Context, user entity.User, assetId int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveFromCart", ctx, user, assetId)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemoveFromCart indicates an expected call of RemoveFromCart.
+func (mr *MockCartMockRecorder) RemoveFromCart(ctx, user, assetId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveFromCart", reflect.TypeOf((*MockCart)(nil).RemoveFromCart), ctx, user, assetId)
+}
+
+// MockCartRepository is a mock of CartRepository interface.
+type MockCartRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockCartRepositoryMockRecorder
+}
+
+// MockCartRepositoryMockRecorder is the mock recorder for MockCartRepository.
+type MockCartRepositoryMockRecorder struct {
+	mock *MockCartRepository
+}
+
+// NewMockCartRepository creates a new mock instance.
+func NewMockCartRepository(ctrl *gomock.Controller) *MockCartRepository {
+	mock := &MockCartRepository{ctrl: ctrl}
+	mock.recorder = &MockCartRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCartRepository) EXPECT() *MockCartRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Add mocks base method.
+func (m *MockCartRepository) Add(ctx context.Context, user entity.User, assetId int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Add", ctx, user, assetId)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Add indicates an expected call of Add.
+func (mr *MockCartRepositoryMockRecorder) Add(ctx, user, assetId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockCartRepository)(nil).Add), ctx, user, assetId)
+}
+
+// Checkout mocks base method.
+func (m *MockCartRepository) Checkout(ctx context.Context, user entity.User, promoCode string) (entity.Receipt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Checkout", ctx, user, promoCode)
+	ret0, _ := ret[0].(entity.Receipt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Checkout indicates an expected call of Checkout.
+func (mr *MockCartRepositoryMockRecorder) Checkout(ctx, user, promoCode any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Checkout", reflect.TypeOf((*MockCartRepository)(nil).Checkout), ctx, user, promoCode)
+}
+
+// GetItems mocks base method.
+func (m *MockCartRepository) GetItems(ctx context.Context, user entity.User) ([]entity.CartItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetItems", ctx, user)
+	ret0, _ := ret[0].([]entity.CartItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetItems indicates an expected call of GetItems.
+func (mr *MockCartRepositoryMockRecorder) GetItems(ctx, user any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetItems", reflect.TypeOf((*MockCartRepository)(nil).GetItems), ctx, user)
+}
+
+// Remove mocks base method.
+func (m *MockCartRepository) Remove(ctx context.Context, user entity.User, assetId int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Remove", ctx, user, assetId)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Remove indicates an expected call of Remove.
+func (mr *MockCartRepositoryMockRecorder) Remove(ctx, user, assetId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Remove", reflect.TypeOf((*MockCartRepository)(nil).Remove), ctx, user, assetId)
+}
+
+// MockPromo is a mock of Promo interface.
+type MockPromo struct {
+	ctrl     *gomock.Controller
+	recorder *MockPromoMockRecorder
+}
+
+// MockPromoMockRecorder is the mock recorder for MockPromo.
+type MockPromoMockRecorder struct {
+	mock *MockPromo
+}
+
+// NewMockPromo creates a new mock instance.
+func NewMockPromo(ctrl *gomock.Controller) *MockPromo {
+	mock := &MockPromo{ctrl: ctrl}
+	mock.recorder = &MockPromoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPromo) EXPECT() *MockPromoMockRecorder {
+	return m.recorder
+}
+
+// CreatePromoCode mocks base method.
+func (m *MockPromo) CreatePromoCode(ctx context.Context, user entity.User, promo entity.PromoCode) (entity.PromoCode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePromoCode", ctx, user, promo)
+	ret0, _ := ret[0].(entity.PromoCode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePromoCode indicates an expected call of CreatePromoCode.
+func (mr *MockPromoMockRecorder) CreatePromoCode(ctx, user, promo any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePromoCode", reflect.TypeOf((*MockPromo)(nil).CreatePromoCode), ctx, user, promo)
+}
+
+// CreateSitePromoCode mocks base method.
+func (m *MockPromo) CreateSitePromoCode(ctx context.Context, user entity.User, promo entity.PromoCode) (entity.PromoCode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSitePromoCode", ctx, user, promo)
+	ret0, _ := ret[0].(entity.PromoCode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSitePromoCode indicates an expected call of CreateSitePromoCode.
+func (mr *MockPromoMockRecorder) CreateSitePromoCode(ctx, user, promo any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSitePromoCode", reflect.TypeOf((*MockPromo)(nil).CreateSitePromoCode), ctx, user, promo)
+}
+
+// GetPromoCodes mocks base method.
+func (m *MockPromo) GetPromoCodes(ctx context.Context, user entity.User) ([]entity.PromoCode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPromoCodes", ctx, user)
+	ret0, _ := ret[0].([]entity.PromoCode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPromoCodes indicates an expected call of GetPromoCodes.
+func (mr *MockPromoMockRecorder) GetPromoCodes(ctx, user any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPromoCodes", reflect.TypeOf((*MockPromo)(nil).GetPromoCodes), ctx, user)
+}
+
+// MockPromoRepository is a mock of PromoRepository interface.
+type MockPromoRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockPromoRepositoryMockRecorder
+}
+
+// MockPromoRepositoryMockRecorder is the mock recorder for MockPromoRepository.
+type MockPromoRepositoryMockRecorder struct {
+	mock *MockPromoRepository
+}
+
+// NewMockPromoRepository creates a new mock instance.
+func NewMockPromoRepository(ctrl *gomock.Controller) *MockPromoRepository {
+	mock := &MockPromoRepository{ctrl: ctrl}
+	mock.recorder = &MockPromoRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPromoRepository) EXPECT() *MockPromoRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockPromoRepository) Create(ctx context.Context, promo entity.PromoCode) (entity.PromoCode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, promo)
+	ret0, _ := ret[0].(entity.PromoCode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockPromoRepositoryMockRecorder) Create(ctx, promo any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockPromoRepository)(nil).Create), ctx, promo)
+}
+
+// GetByCreator mocks base method.
+func (m *MockPromoRepository) GetByCreator(ctx context.Context, user entity.User) ([]entity.PromoCode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByCreator", ctx, user)
+	ret0, _ := ret[0].([]entity.PromoCode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByCreator indicates an expected call of GetByCreator.
+func (mr *MockPromoRepositoryMockRecorder) GetByCreator(ctx, user any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByCreator", reflect.TypeOf((*MockPromoRepository)(nil).GetByCreator), ctx, user)
+}
+
+// MockWishlist is a mock of Wishlist interface.
+type MockWishlist struct {
+	ctrl     *gomock.Controller
+	recorder *MockWishlistMockRecorder
+}
+
+// MockWishlistMockRecorder is the mock recorder for MockWishlist.
+type MockWishlistMockRecorder struct {
+	mock *MockWishlist
+}
+
+// NewMockWishlist creates a new mock instance.
+func NewMockWishlist(ctrl *gomock.Controller) *MockWishlist {
+	mock := &MockWishlist{ctrl: ctrl}
+	mock.recorder = &MockWishlistMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWishlist) EXPECT() *MockWishlistMockRecorder {
+	return m.recorder
+}
+
+// AddToWishlist mocks base method.
+func (m *MockWishlist) AddToWishlist(ctx context.Context, user entity.User, assetId int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddToWishlist", ctx, user, assetId)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddToWishlist indicates an expected call of AddToWishlist.
+func (mr *MockWishlistMockRecorder) AddToWishlist(ctx, user, assetId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddToWishlist", reflect.TypeOf((*MockWishlist)(nil).AddToWishlist), ctx, user, assetId)
+}
+
+// GetWishlist mocks base method.
+func (m *MockWishlist) GetWishlist(ctx context.Context, user entity.User) ([]entity.WishlistItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWishlist", ctx, user)
+	ret0, _ := ret[0].([]entity.WishlistItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWishlist indicates an expected call of GetWishlist.
+func (mr *MockWishlistMockRecorder) GetWishlist(ctx, user any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWishlist", reflect.TypeOf((*MockWishlist)(nil).GetWishlist), ctx, user)
+}
+
+// RemoveFromWishlist mocks base method.
+func (m *MockWishlist) RemoveFromWishlist(ctx context.Context, user entity.User, assetId int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveFromWishlist", ctx, user, assetId)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemoveFromWishlist indicates an expected call of RemoveFromWishlist.
+func (mr *MockWishlistMockRecorder) RemoveFromWishlist(ctx, user, assetId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveFromWishlist", reflect.TypeOf((*MockWishlist)(nil).RemoveFromWishlist), ctx, user, assetId)
+}
+
+// MockWishlistRepository is a mock of WishlistRepository interface.
+type MockWishlistRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockWishlistRepositoryMockRecorder
+}
+
+// MockWishlistRepositoryMockRecorder is the mock recorder for MockWishlistRepository.
+type MockWishlistRepositoryMockRecorder struct {
+	mock *MockWishlistRepository
+}
+
+// NewMockWishlistRepository creates a new mock instance.
+func NewMockWishlistRepository(ctrl *gomock.Controller) *MockWishlistRepository {
+	mock := &MockWishlistRepository{ctrl: ctrl}
+	mock.recorder = &MockWishlistRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWishlistRepository) EXPECT() *MockWishlistRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Add mocks base method.
+func (m *MockWishlistRepository) Add(ctx context.Context, user entity.User, assetId int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Add", ctx, user, assetId)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Add indicates an expected call of Add.
+func (mr *MockWishlistRepositoryMockRecorder) Add(ctx, user, assetId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockWishlistRepository)(nil).Add), ctx, user, assetId)
+}
+
+// GetItems mocks base method.
+func (m *MockWishlistRepository) GetItems(ctx context.Context, user entity.User) ([]entity.WishlistItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetItems", ctx, user)
+	ret0, _ := ret[0].([]entity.WishlistItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetItems indicates an expected call of GetItems.
+func (mr *MockWishlistRepositoryMockRecorder) GetItems(ctx, user any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetItems", reflect.TypeOf((*MockWishlistRepository)(nil).GetItems), ctx, user)
+}
+
+// Remove mocks base method.
+func (m *MockWishlistRepository) Remove(ctx context.Context, user entity.User, assetId int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Remove", ctx, user, assetId)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Remove indicates an expected call of Remove.
+func (mr *MockWishlistRepositoryMockRecorder) Remove(ctx, user, assetId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Remove", reflect.TypeOf((*MockWishlistRepository)(nil).Remove), ctx, user, assetId)
+}
+
+// MockNotification is a mock of Notification interface.
+type MockNotification struct {
+	ctrl     *gomock.Controller
+	recorder *MockNotificationMockRecorder
+}
+
+// MockNotificationMockRecorder is the mock recorder for MockNotification.
+type MockNotificationMockRecorder struct {
+	mock *MockNotification
+}
+
+// NewMockNotification creates a new mock instance.
+func NewMockNotification(ctrl *gomock.Controller) *MockNotification {
+	mock := &MockNotification{ctrl: ctrl}
+	mock.recorder = &MockNotificationMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNotification) EXPECT() *MockNotificationMockRecorder {
+	return m.recorder
+}
+
+// GetNotifications mocks base method.
+func (m *MockNotification) GetNotifications(ctx context.Context, user entity.User, unreadOnly bool) ([]entity.Notification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNotifications", ctx, user, unreadOnly)
+	ret0, _ := ret[0].([]entity.Notification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNotifications indicates an expected call of GetNotifications.
+func (mr *MockNotificationMockRecorder) GetNotifications(ctx, user, unreadOnly any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNotifications", reflect.TypeOf((*MockNotification)(nil).GetNotifications), ctx, user, unreadOnly)
+}
+
+// MarkRead mocks base method.
+func (m *MockNotification) MarkRead(ctx context.Context, user entity.User, id int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkRead", ctx, user, id)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkRead indicates an expected call of MarkRead.
+func (mr *MockNotificationMockRecorder) MarkRead(ctx, user, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkRead", reflect.TypeOf((*MockNotification)(nil).MarkRead), ctx, user, id)
+}
+
+// MockNotificationRepository is a mock of NotificationRepository interface.
+type MockNotificationRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockNotificationRepositoryMockRecorder
+}
+
+// MockNotificationRepositoryMockRecorder is the mock recorder for MockNotificationRepository.
+type MockNotificationRepositoryMockRecorder struct {
+	mock *MockNotificationRepository
+}
+
+// NewMockNotificationRepository creates a new mock instance.
+func NewMockNotificationRepository(ctrl *gomock.Controller) *MockNotificationRepository {
+	mock := &MockNotificationRepository{ctrl: ctrl}
+	mock.recorder = &MockNotificationRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNotificationRepository) EXPECT() *MockNotificationRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetByUser mocks base method.
+func (m *MockNotificationRepository) GetByUser(ctx context.Context, user entity.User, unreadOnly bool) ([]entity.Notification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByUser", ctx, user, unreadOnly)
+	ret0, _ := ret[0].([]entity.Notification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByUser indicates an expected call of GetByUser.
+func (mr *MockNotificationRepositoryMockRecorder) GetByUser(ctx, user, unreadOnly any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUser", reflect.TypeOf((*MockNotificationRepository)(nil).GetByUser), ctx, user, unreadOnly)
+}
+
+// MarkRead mocks base method.
+func (m *MockNotificationRepository) MarkRead(ctx context.Context, user entity.User, id int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkRead", ctx, user, id)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkRead indicates an expected call of MarkRead.
+func (mr *MockNotificationRepositoryMockRecorder) MarkRead(ctx, user, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkRead", reflect.TypeOf((*MockNotificationRepository)(nil).MarkRead), ctx, user, id)
+}
+
+// MockReview is a mock of Review interface.
+type MockReview struct {
+	ctrl     *gomock.Controller
+	recorder *MockReviewMockRecorder
+}
+
+// MockReviewMockRecorder is the mock recorder for MockReview.
+type MockReviewMockRecorder struct {
+	mock *MockReview
+}
+
+// NewMockReview creates a new mock instance.
+func NewMockReview(ctrl *gomock.Controller) *MockReview {
+	mock := &MockReview{ctrl: ctrl}
+	mock.recorder = &MockReviewMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReview) EXPECT() *MockReviewMockRecorder {
+	return m.recorder
+}
+
+// GetReviews mocks base method.
+func (m *MockReview) GetReviews(ctx context.Context, user entity.User, assetId int64) ([]entity.Review, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReviews", ctx, user, assetId)
+	ret0, _ := ret[0].([]entity.Review)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReviews indicates an expected call of GetReviews.
+func (mr *MockReviewMockRecorder) GetReviews(ctx, user, assetId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReviews", reflect.TypeOf((*MockReview)(nil).GetReviews), ctx, user, assetId)
+}
+
+// PostReview mocks base method.
+func (m *MockReview) PostReview(ctx context.Context, user entity.User, review entity.Review) (entity.Review, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PostReview", ctx, user, review)
+	ret0, _ := ret[0].(entity.Review)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PostReview indicates an expected call of PostReview.
+func (mr *MockReviewMockRecorder) PostReview(ctx, user, review any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PostReview", reflect.TypeOf((*MockReview)(nil).PostReview), ctx, user, review)
+}
+
+// ReplyToReview mocks base method.
+func (m *MockReview) ReplyToReview(ctx context.Context, user entity.User, id int64, reply string) (entity.Review, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplyToReview", ctx, user, id, reply)
+	ret0, _ := ret[0].(entity.Review)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReplyToReview indicates an expected call of ReplyToReview.
+func (mr *MockReviewMockRecorder) ReplyToReview(ctx, user, id, reply any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplyToReview", reflect.TypeOf((*MockReview)(nil).ReplyToReview), ctx, user, id, reply)
+}
+
+// MockReviewRepository is a mock of ReviewRepository interface.
+type MockReviewRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockReviewRepositoryMockRecorder
+}
+
+// MockReviewRepositoryMockRecorder is the mock recorder for MockReviewRepository.
+type MockReviewRepositoryMockRecorder struct {
+	mock *MockReviewRepository
+}
+
+// NewMockReviewRepository creates a new mock instance.
+func NewMockReviewRepository(ctrl *gomock.Controller) *MockReviewRepository {
+	mock := &MockReviewRepository{ctrl: ctrl}
+	mock.recorder = &MockReviewRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReviewRepository) EXPECT() *MockReviewRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockReviewRepository) Create(ctx context.Context, review entity.Review) (entity.Review, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, review)
+	ret0, _ := ret[0].(entity.Review)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockReviewRepositoryMockRecorder) Create(ctx, review any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockReviewRepository)(nil).Create), ctx, review)
+}
+
+// GetByAsset mocks base method.
+func (m *MockReviewRepository) GetByAsset(ctx context.Context, user entity.User, assetId int64) ([]entity.Review, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByAsset", ctx, user, assetId)
+	ret0, _ := ret[0].([]entity.Review)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByAsset indicates an expected call of GetByAsset.
+func (mr *MockReviewRepositoryMockRecorder) GetByAsset(ctx, user, assetId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByAsset", reflect.TypeOf((*MockReviewRepository)(nil).GetByAsset), ctx, user, assetId)
+}
+
+// Reply mocks base method.
+func (m *MockReviewRepository) Reply(ctx context.Context, user entity.User, id int64, reply string) (entity.Review, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reply", ctx, user, id, reply)
+	ret0, _ := ret[0].(entity.Review)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Reply indicates an expected call of Reply.
+func (mr *MockReviewRepositoryMockRecorder) Reply(ctx, user, id, reply any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reply", reflect.TypeOf((*MockReviewRepository)(nil).Reply), ctx, user, id, reply)
+}
+
+// MockStats is a mock of Stats interface.
+type MockStats struct {
+	ctrl     *gomock.Controller
+	recorder *MockStatsMockRecorder
+}
+
+// MockStatsMockRecorder is the mock recorder for MockStats.
+type MockStatsMockRecorder struct {
+	mock *MockStats
+}
+
+// NewMockStats creates a new mock instance.
+func NewMockStats(ctrl *gomock.Controller) *MockStats {
+	mock := &MockStats{ctrl: ctrl}
+	mock.recorder = &MockStatsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStats) EXPECT() *MockStatsMockRecorder {
+	return m.recorder
+}
+
+// GetSellerStats mocks base method.
+func (m *MockStats) GetSellerStats(ctx context.Context, user entity.User, from, to time.Time, bucket entity.StatsBucket) (entity.SellerStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSellerStats", ctx, user, from, to, bucket)
+	ret0, _ := ret[0].(entity.SellerStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSellerStats indicates an expected call of GetSellerStats.
+func (mr *MockStatsMockRecorder) GetSellerStats(ctx, user, from, to, bucket any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSellerStats", reflect.TypeOf((*MockStats)(nil).GetSellerStats), ctx, user, from, to, bucket)
+}
+
+// MockStatsRepository is a mock of StatsRepository interface.
+type MockStatsRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockStatsRepositoryMockRecorder
+}
+
+// MockStatsRepositoryMockRecorder is the mock recorder for MockStatsRepository.
+type MockStatsRepositoryMockRecorder struct {
+	mock *MockStatsRepository
+}
+
+// NewMockStatsRepository creates a new mock instance.
+func NewMockStatsRepository(ctrl *gomock.Controller) *MockStatsRepository {
+	mock := &MockStatsRepository{ctrl: ctrl}
+	mock.recorder = &MockStatsRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStatsRepository) EXPECT() *MockStatsRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetAssetSales mocks base method.
+func (m *MockStatsRepository) GetAssetSales(ctx context.Context, user entity.User, from, to time.Time) ([]entity.AssetSales, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAssetSales", ctx, user, from, to)
+	ret0, _ := ret[0].([]entity.AssetSales)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAssetSales indicates an expected call of GetAssetSales.
+func (mr *MockStatsRepositoryMockRecorder) GetAssetSales(ctx, user, from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAssetSales", reflect.TypeOf((*MockStatsRepository)(nil).GetAssetSales), ctx, user, from, to)
+}
+
+// GetSalesSeries mocks base method.
+func (m *MockStatsRepository) GetSalesSeries(ctx context.Context, user entity.User, from, to time.Time, bucket entity.StatsBucket) ([]entity.SalesPoint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSalesSeries", ctx, user, from, to, bucket)
+	ret0, _ := ret[0].([]entity.SalesPoint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSalesSeries indicates an expected call of GetSalesSeries.
+func (mr *MockStatsRepositoryMockRecorder) GetSalesSeries(ctx, user, from, to, bucket any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSalesSeries", reflect.TypeOf((*MockStatsRepository)(nil).GetSalesSeries), ctx, user, from, to, bucket)
+}
+
+// MockWebhook is a mock of Webhook interface.
+type MockWebhook struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebhookMockRecorder
+}
+
+// MockWebhookMockRecorder is the mock recorder for MockWebhook.
+type MockWebhookMockRecorder struct {
+	mock *MockWebhook
+}
+
+// NewMockWebhook creates a new mock instance.
+func NewMockWebhook(ctrl *gomock.Controller) *MockWebhook {
+	mock := &MockWebhook{ctrl: ctrl}
+	mock.recorder = &MockWebhookMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebhook) EXPECT() *MockWebhookMockRecorder {
+	return m.recorder
+}
+
+// CreateGlobalWebhook mocks base method.
+func (m *MockWebhook) CreateGlobalWebhook(ctx context.Context, user entity.User, wh entity.Webhook) (entity.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateGlobalWebhook", ctx, user, wh)
+	ret0, _ := ret[0].(entity.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateGlobalWebhook indicates an expected call of CreateGlobalWebhook.
+func (mr *MockWebhookMockRecorder) CreateGlobalWebhook(ctx, user, wh any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateGlobalWebhook", reflect.TypeOf((*MockWebhook)(nil).CreateGlobalWebhook), ctx, user, wh)
+}
+
+// CreateWebhook mocks base method.
+func (m *MockWebhook) CreateWebhook(ctx context.Context, user entity.User, wh entity.Webhook) (entity.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWebhook", ctx, user, wh)
+	ret0, _ := ret[0].(entity.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateWebhook indicates an expected call of CreateWebhook.
+func (mr *MockWebhookMockRecorder) CreateWebhook(ctx, user, wh any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWebhook", reflect.TypeOf((*MockWebhook)(nil).CreateWebhook), ctx, user, wh)
+}
+
+// DeleteWebhook mocks base method.
+func (m *MockWebhook) DeleteWebhook(ctx context.Context, user entity.User, id int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWebhook", ctx, user, id)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteWebhook indicates an expected call of DeleteWebhook.
+func (mr *MockWebhookMockRecorder) DeleteWebhook(ctx, user, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWebhook", reflect.TypeOf((*MockWebhook)(nil).DeleteWebhook), ctx, user, id)
+}
+
+// DeliverDue mocks base method.
+func (m *MockWebhook) DeliverDue(ctx context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeliverDue", ctx)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeliverDue indicates an expected call of DeliverDue.
+func (mr *MockWebhookMockRecorder) DeliverDue(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeliverDue", reflect.TypeOf((*MockWebhook)(nil).DeliverDue), ctx)
+}
+
+// GetDeliveries mocks base method.
+func (m *MockWebhook) GetDeliveries(ctx context.Context, user entity.User, webhookId int64) ([]entity.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeliveries", ctx, user, webhookId)
+	ret0, _ := ret[0].([]entity.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeliveries indicates an expected call of GetDeliveries.
+func (mr *MockWebhookMockRecorder) GetDeliveries(ctx, user, webhookId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeliveries", reflect.TypeOf((*MockWebhook)(nil).GetDeliveries), ctx, user, webhookId)
+}
+
+// GetWebhooks mocks base method.
+func (m *MockWebhook) GetWebhooks(ctx context.Context, user entity.User) ([]entity.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebhooks", ctx, user)
+	ret0, _ := ret[0].([]entity.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWebhooks indicates an expected call of GetWebhooks.
+func (mr *MockWebhookMockRecorder) GetWebhooks(ctx, user any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhooks", reflect.TypeOf((*MockWebhook)(nil).GetWebhooks), ctx, user)
+}
+
+// Redeliver mocks base method.
+func (m *MockWebhook) Redeliver(ctx context.Context, user entity.User, deliveryId int64) (entity.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Redeliver", ctx, user, deliveryId)
+	ret0, _ := ret[0].(entity.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Redeliver indicates an expected call of Redeliver.
+func (mr *MockWebhookMockRecorder) Redeliver(ctx, user, deliveryId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Redeliver", reflect.TypeOf((*MockWebhook)(nil).Redeliver), ctx, user, deliveryId)
+}
+
+// MockWebhookRepository is a mock of WebhookRepository interface.
+type MockWebhookRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebhookRepositoryMockRecorder
+}
+
+// MockWebhookRepositoryMockRecorder is the mock recorder for MockWebhookRepository.
+type MockWebhookRepositoryMockRecorder struct {
+	mock *MockWebhookRepository
+}
+
+// NewMockWebhookRepository creates a new mock instance.
+func NewMockWebhookRepository(ctrl *gomock.Controller) *MockWebhookRepository {
+	mock := &MockWebhookRepository{ctrl: ctrl}
+	mock.recorder = &MockWebhookRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebhookRepository) EXPECT() *MockWebhookRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ClaimDue mocks base method.
+func (m *MockWebhookRepository) ClaimDue(ctx context.Context, limit uint64, lease time.Duration) ([]entity.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimDue", ctx, limit, lease)
+	ret0, _ := ret[0].([]entity.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimDue indicates an expected call of ClaimDue.
+func (mr *MockWebhookRepositoryMockRecorder) ClaimDue(ctx, limit, lease any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimDue", reflect.TypeOf((*MockWebhookRepository)(nil).ClaimDue), ctx, limit, lease)
+}
+
+// Create mocks base method.
+func (m *MockWebhookRepository) Create(ctx context.Context, wh entity.Webhook) (entity.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, wh)
+	ret0, _ := ret[0].(entity.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockWebhookRepositoryMockRecorder) Create(ctx, wh any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockWebhookRepository)(nil).Create), ctx, wh)
+}
+
+// Delete mocks base method.
+func (m *MockWebhookRepository) Delete(ctx context.Context, user entity.User, id int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, user, id)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockWebhookRepositoryMockRecorder) Delete(ctx, user, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockWebhookRepository)(nil).Delete), ctx, user, id)
+}
+
+// Enqueue mocks base method.
+func (m *MockWebhookRepository) Enqueue(ctx context.Context, eventType entity.EventType, payload []byte, userIds []int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Enqueue", ctx, eventType, payload, userIds)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Enqueue indicates an expected call of Enqueue.
+func (mr *MockWebhookRepositoryMockRecorder) Enqueue(ctx, eventType, payload, userIds any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Enqueue", reflect.TypeOf((*MockWebhookRepository)(nil).Enqueue), ctx, eventType, payload, userIds)
+}
+
+// GetByUser mocks base method.
+func (m *MockWebhookRepository) GetByUser(ctx context.Context, user entity.User) ([]entity.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByUser", ctx, user)
+	ret0, _ := ret[0].([]entity.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByUser indicates an expected call of GetByUser.
+func (mr *MockWebhookRepositoryMockRecorder) GetByUser(ctx, user any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUser", reflect.TypeOf((*MockWebhookRepository)(nil).GetByUser), ctx, user)
+}
+
+// GetDeliveries mocks base method.
+func (m *MockWebhookRepository) GetDeliveries(ctx context.Context, user entity.User, webhookId int64) ([]entity.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeliveries", ctx, user, webhookId)
+	ret0, _ := ret[0].([]entity.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeliveries indicates an expected call of GetDeliveries.
+func (mr *MockWebhookRepositoryMockRecorder) GetDeliveries(ctx, user, webhookId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeliveries", reflect.TypeOf((*MockWebhookRepository)(nil).GetDeliveries), ctx, user, webhookId)
+}
+
+// RecordAttempt mocks base method.
+func (m *MockWebhookRepository) RecordAttempt(ctx context.Context, delivery entity.WebhookDelivery) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordAttempt", ctx, delivery)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordAttempt indicates an expected call of RecordAttempt.
+func (mr *MockWebhookRepositoryMockRecorder) RecordAttempt(ctx, delivery any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordAttempt", reflect.TypeOf((*MockWebhookRepository)(nil).RecordAttempt), ctx, delivery)
+}
+
+// Redeliver mocks base method.
+func (m *MockWebhookRepository) Redeliver(ctx context.Context, user entity.User, id int64) (entity.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Redeliver", ctx, user, id)
+	ret0, _ := ret[0].(entity.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Redeliver indicates an expected call of Redeliver.
+func (mr *MockWebhookRepositoryMockRecorder) Redeliver(ctx, user, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Redeliver", reflect.TypeOf((*MockWebhookRepository)(nil).Redeliver), ctx, user, id)
+}
+
+// MockWebhookSender is a mock of WebhookSender interface.
+type MockWebhookSender struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebhookSenderMockRecorder
+}
+
+// MockWebhookSenderMockRecorder is the mock recorder for MockWebhookSender.
+type MockWebhookSenderMockRecorder struct {
+	mock *MockWebhookSender
+}
+
+// NewMockWebhookSender creates a new mock instance.
+func NewMockWebhookSender(ctrl *gomock.Controller) *MockWebhookSender {
+	mock := &MockWebhookSender{ctrl: ctrl}
+	mock.recorder = &MockWebhookSenderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebhookSender) EXPECT() *MockWebhookSenderMockRecorder {
+	return m.recorder
+}
+
+// Send mocks base method.
+func (m *MockWebhookSender) Send(ctx context.Context, url, secret string, headers map[string]string, body []byte) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Send", ctx, url, secret, headers, body)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Send indicates an expected call of Send.
+func (mr *MockWebhookSenderMockRecorder) Send(ctx, url, secret, headers, body any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockWebhookSender)(nil).Send), ctx, url, secret, headers, body)
+}
+
+// MockEventPublisher is a mock of EventPublisher interface.
+type MockEventPublisher struct {
+	ctrl     *gomock.Controller
+	recorder *MockEventPublisherMockRecorder
+}
+
+// MockEventPublisherMockRecorder is the mock recorder for MockEventPublisher.
+type MockEventPublisherMockRecorder struct {
+	mock *MockEventPublisher
+}
+
+// NewMockEventPublisher creates a new mock instance.
+func NewMockEventPublisher(ctrl *gomock.Controller) *MockEventPublisher {
+	mock := &MockEventPublisher{ctrl: ctrl}
+	mock.recorder = &MockEventPublisherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEventPublisher) EXPECT() *MockEventPublisherMockRecorder {
+	return m.recorder
+}
+
+// Publish mocks base method.
+func (m *MockEventPublisher) Publish(ctx context.Context, event entity.OutboxEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Publish", ctx, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Publish indicates an expected call of Publish.
+func (mr *MockEventPublisherMockRecorder) Publish(ctx, event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Publish", reflect.TypeOf((*MockEventPublisher)(nil).Publish), ctx, event)
+}
+
+// MockOutbox is a mock of Outbox interface.
+type MockOutbox struct {
+	ctrl     *gomock.Controller
+	recorder *MockOutboxMockRecorder
+}
+
+// MockOutboxMockRecorder is the mock recorder for MockOutbox.
+type MockOutboxMockRecorder struct {
+	mock *MockOutbox
+}
+
+// NewMockOutbox creates a new mock instance.
+func NewMockOutbox(ctrl *gomock.Controller) *MockOutbox {
+	mock := &MockOutbox{ctrl: ctrl}
+	mock.recorder = &MockOutboxMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOutbox) EXPECT() *MockOutboxMockRecorder {
+	return m.recorder
+}
+
+// Relay mocks base method.
+func (m *MockOutbox) Relay(ctx context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Relay", ctx)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Relay indicates an expected call of Relay.
+func (mr *MockOutboxMockRecorder) Relay(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Relay", reflect.TypeOf((*MockOutbox)(nil).Relay), ctx)
+}
+
+// MockOutboxRepository is a mock of OutboxRepository interface.
+type MockOutboxRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockOutboxRepositoryMockRecorder
+}
+
+// MockOutboxRepositoryMockRecorder is the mock recorder for MockOutboxRepository.
+type MockOutboxRepositoryMockRecorder struct {
+	mock *MockOutboxRepository
+}
+
+// NewMockOutboxRepository creates a new mock instance.
+func NewMockOutboxRepository(ctrl *gomock.Controller) *MockOutboxRepository {
+	mock := &MockOutboxRepository{ctrl: ctrl}
+	mock.recorder = &MockOutboxRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOutboxRepository) EXPECT() *MockOutboxRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ClaimPending mocks base method.
+func (m *MockOutboxRepository) ClaimPending(ctx context.Context, limit uint64, lease time.Duration) ([]entity.OutboxEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimPending", ctx, limit, lease)
+	ret0, _ := ret[0].([]entity.OutboxEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimPending indicates an expected call of ClaimPending.
+func (mr *MockOutboxRepositoryMockRecorder) ClaimPending(ctx, limit, lease any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimPending", reflect.TypeOf((*MockOutboxRepository)(nil).ClaimPending), ctx, limit, lease)
+}
+
+// MarkPublished mocks base method.
+func (m *MockOutboxRepository) MarkPublished(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkPublished", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkPublished indicates an expected call of MarkPublished.
+func (mr *MockOutboxRepositoryMockRecorder) MarkPublished(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkPublished", reflect.TypeOf((*MockOutboxRepository)(nil).MarkPublished), ctx, id)
+}
+
+// RecordFailure mocks base method.
+func (m *MockOutboxRepository) RecordFailure(ctx context.Context, event entity.OutboxEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordFailure", ctx, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordFailure indicates an expected call of RecordFailure.
+func (mr *MockOutboxRepositoryMockRecorder) RecordFailure(ctx, event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordFailure", reflect.TypeOf((*MockOutboxRepository)(nil).RecordFailure), ctx, event)
+}
+
+// MockMarketFeed is a mock of MarketFeed interface.
+type MockMarketFeed struct {
+	ctrl     *gomock.Controller
+	recorder *MockMarketFeedMockRecorder
+}
+
+// MockMarketFeedMockRecorder is the mock recorder for MockMarketFeed.
+type MockMarketFeedMockRecorder struct {
+	mock *MockMarketFeed
+}
+
+// NewMockMarketFeed creates a new mock instance.
+func NewMockMarketFeed(ctrl *gomock.Controller) *MockMarketFeed {
+	mock := &MockMarketFeed{ctrl: ctrl}
+	mock.recorder = &MockMarketFeedMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMarketFeed) EXPECT() *MockMarketFeedMockRecorder {
+	return m.recorder
+}
+
+// Run mocks base method.
+func (m *MockMarketFeed) Run(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Run", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Run indicates an expected call of Run.
+func (mr *MockMarketFeedMockRecorder) Run(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Run", reflect.TypeOf((*MockMarketFeed)(nil).Run), ctx)
+}
+
+// Subscribe mocks base method.
+func (m *MockMarketFeed) Subscribe(ctx context.Context, user entity.User) (<-chan entity.MarketEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Subscribe", ctx, user)
+	ret0, _ := ret[0].(<-chan entity.MarketEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Subscribe indicates an expected call of Subscribe.
+func (mr *MockMarketFeedMockRecorder) Subscribe(ctx, user any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Subscribe", reflect.TypeOf((*MockMarketFeed)(nil).Subscribe), ctx, user)
+}
+
+// MockMarketFeedRepository is a mock of MarketFeedRepository interface.
+type MockMarketFeedRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockMarketFeedRepositoryMockRecorder
+}
+
+// MockMarketFeedRepositoryMockRecorder is the mock recorder for MockMarketFeedRepository.
+type MockMarketFeedRepositoryMockRecorder struct {
+	mock *MockMarketFeedRepository
+}
+
+// NewMockMarketFeedRepository creates a new mock instance.
+func NewMockMarketFeedRepository(ctrl *gomock.Controller) *MockMarketFeedRepository {
+	mock := &MockMarketFeedRepository{ctrl: ctrl}
+	mock.recorder = &MockMarketFeedRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMarketFeedRepository) EXPECT() *MockMarketFeedRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Listen mocks base method.
+func (m *MockMarketFeedRepository) Listen(ctx context.Context, handle func(entity.MarketEvent)) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Listen", ctx, handle)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Listen indicates an expected call of Listen.
+func (mr *MockMarketFeedRepositoryMockRecorder) Listen(ctx, handle any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Listen", reflect.TypeOf((*MockMarketFeedRepository)(nil).Listen), ctx, handle)
+}
+
+// MockAdmin is a mock of Admin interface.
+type MockAdmin struct {
+	ctrl     *gomock.Controller
+	recorder *MockAdminMockRecorder
+}
+
+// MockAdminMockRecorder is the mock recorder for MockAdmin.
+type MockAdminMockRecorder struct {
+	mock *MockAdmin
+}
+
+// NewMockAdmin creates a new mock instance.
+func NewMockAdmin(ctrl *gomock.Controller) *MockAdmin {
+	mock := &MockAdmin{ctrl: ctrl}
+	mock.recorder = &MockAdminMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAdmin) EXPECT() *MockAdminMockRecorder {
+	return m.recorder
+}
+
+// AdjustBalance mocks base method.
+func (m *MockAdmin) AdjustBalance(ctx context.Context, username string, amount float64, reason, actor string) (entity.BalanceAdjustment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdjustBalance", ctx, username, amount, reason, actor)
+	ret0, _ := ret[0].(entity.BalanceAdjustment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AdjustBalance indicates an expected call of AdjustBalance.
+func (mr *MockAdminMockRecorder) AdjustBalance(ctx, username, amount, reason, actor any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdjustBalance", reflect.TypeOf((*MockAdmin)(nil).AdjustBalance), ctx, username, amount, reason, actor)
+}
+
+// GrantAccess mocks base method.
+func (m *MockAdmin) GrantAccess(ctx context.Context, username string, assetId int64, days *int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GrantAccess", ctx, username, assetId, days)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GrantAccess indicates an expected call of GrantAccess.
+func (mr *MockAdminMockRecorder) GrantAccess(ctx, username, assetId, days any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GrantAccess", reflect.TypeOf((*MockAdmin)(nil).GrantAccess), ctx, username, assetId, days)
+}
+
+// ListAssets mocks base method.
+func (m *MockAdmin) ListAssets(ctx context.Context, filter entity.AssetFilter) ([]entity.Asset, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAssets", ctx, filter)
+	ret0, _ := ret[0].([]entity.Asset)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAssets indicates an expected call of ListAssets.
+func (mr *MockAdminMockRecorder) ListAssets(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAssets", reflect.TypeOf((*MockAdmin)(nil).ListAssets), ctx, filter)
+}
+
+// Reconcile mocks base method.
+func (m *MockAdmin) Reconcile(ctx context.Context) ([]entity.ReconcileIssue, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reconcile", ctx)
+	ret0, _ := ret[0].([]entity.ReconcileIssue)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Reconcile indicates an expected call of Reconcile.
+func (mr *MockAdminMockRecorder) Reconcile(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reconcile", reflect.TypeOf((*MockAdmin)(nil).Reconcile), ctx)
+}
+
+// ResetPassword mocks base method.
+func (m *MockAdmin) ResetPassword(ctx context.Context, username, password string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetPassword", ctx, username, password)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetPassword indicates an expected call of ResetPassword.
+func (mr *MockAdminMockRecorder) ResetPassword(ctx, username, password any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetPassword", reflect.TypeOf((*MockAdmin)(nil).ResetPassword), ctx, username, password)
+}
+
+// RevokeAccess mocks base method.
+func (m *MockAdmin) RevokeAccess(ctx context.Context, username string, assetId int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeAccess", ctx, username, assetId)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeAccess indicates an expected call of RevokeAccess.
+func (mr *MockAdminMockRecorder) RevokeAccess(ctx, username, assetId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAccess", reflect.TypeOf((*MockAdmin)(nil).RevokeAccess), ctx, username, assetId)
+}
+
+// SetRole mocks base method.
+func (m *MockAdmin) SetRole(ctx context.Context, username string, role entity.Role) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetRole", ctx, username, role)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetRole indicates an expected call of SetRole.
+func (mr *MockAdminMockRecorder) SetRole(ctx, username, role any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRole", reflect.TypeOf((*MockAdmin)(nil).SetRole), ctx, username, role)
+}
+
+// TakeDownAsset mocks base method.
+func (m *MockAdmin) TakeDownAsset(ctx context.Context, id int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TakeDownAsset", ctx, id)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TakeDownAsset indicates an expected call of TakeDownAsset.
+func (mr *MockAdminMockRecorder) TakeDownAsset(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TakeDownAsset", reflect.TypeOf((*MockAdmin)(nil).TakeDownAsset), ctx, id)
+}
+
+// MockAdminRepository is a mock of AdminRepository interface.
+type MockAdminRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockAdminRepositoryMockRecorder
+}
+
+// MockAdminRepositoryMockRecorder is the mock recorder for MockAdminRepository.
+type MockAdminRepositoryMockRecorder struct {
+	mock *MockAdminRepository
+}
+
+// NewMockAdminRepository creates a new mock instance.
+func NewMockAdminRepository(ctrl *gomock.Controller) *MockAdminRepository {
+	mock := &MockAdminRepository{ctrl: ctrl}
+	mock.recorder = &MockAdminRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAdminRepository) EXPECT() *MockAdminRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AdjustBalance mocks base method.
+func (m *MockAdminRepository) AdjustBalance(ctx context.Context, adj entity.BalanceAdjustment, username string) (entity.BalanceAdjustment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdjustBalance", ctx, adj, username)
+	ret0, _ := ret[0].(entity.BalanceAdjustment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AdjustBalance indicates an expected call of AdjustBalance.
+func (mr *MockAdminRepositoryMockRecorder) AdjustBalance(ctx, adj, username any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdjustBalance", reflect.TypeOf((*MockAdminRepository)(nil).AdjustBalance), ctx, adj, username)
+}
+
+// GrantAccess mocks base method.
+func (m *MockAdminRepository) GrantAccess(ctx context.Context, username string, assetId int64, days *int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GrantAccess", ctx, username, assetId, days)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GrantAccess indicates an expected call of GrantAccess.
+func (mr *MockAdminRepositoryMockRecorder) GrantAccess(ctx, username, assetId, days any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GrantAccess", reflect.TypeOf((*MockAdminRepository)(nil).GrantAccess), ctx, username, assetId, days)
+}
+
+// ListAssets mocks base method.
+func (m *MockAdminRepository) ListAssets(ctx context.Context, filter entity.AssetFilter) ([]entity.Asset, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAssets", ctx, filter)
+	ret0, _ := ret[0].([]entity.Asset)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAssets indicates an expected call of ListAssets.
+func (mr *MockAdminRepositoryMockRecorder) ListAssets(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAssets", reflect.TypeOf((*MockAdminRepository)(nil).ListAssets), ctx, filter)
+}
+
+// Reconcile mocks base method.
+func (m *MockAdminRepository) Reconcile(ctx context.Context) ([]entity.ReconcileIssue, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reconcile", ctx)
+	ret0, _ := ret[0].([]entity.ReconcileIssue)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Reconcile indicates an expected call of Reconcile.
+func (mr *MockAdminRepositoryMockRecorder) Reconcile(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reconcile", reflect.TypeOf((*MockAdminRepository)(nil).Reconcile), ctx)
+}
+
+// RevokeAccess mocks base method.
+func (m *MockAdminRepository) RevokeAccess(ctx context.Context, username string, assetId int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeAccess", ctx, username, assetId)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeAccess indicates an expected call of RevokeAccess.
+func (mr *MockAdminRepositoryMockRecorder) RevokeAccess(ctx, username, assetId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAccess", reflect.TypeOf((*MockAdminRepository)(nil).RevokeAccess), ctx, username, assetId)
+}
+
+// SetPassword mocks base method.
+func (m *MockAdminRepository) SetPassword(ctx context.Context, username, password string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPassword", ctx, username, password)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetPassword indicates an expected call of SetPassword.
+func (mr *MockAdminRepositoryMockRecorder) SetPassword(ctx, username, password any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPassword", reflect.TypeOf((*MockAdminRepository)(nil).SetPassword), ctx, username, password)
+}
+
+// SetRole mocks base method.
+func (m *MockAdminRepository) SetRole(ctx context.Context, username string, role entity.Role) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetRole", ctx, username, role)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetRole indicates an expected call of SetRole.
+func (mr *MockAdminRepositoryMockRecorder) SetRole(ctx, username, role any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRole", reflect.TypeOf((*MockAdminRepository)(nil).SetRole), ctx, username, role)
+}
+
+// TakeDown mocks base method.
+func (m *MockAdminRepository) TakeDown(ctx context.Context, id int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TakeDown", ctx, id)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TakeDown indicates an expected call of TakeDown.
+func (mr *MockAdminRepositoryMockRecorder) TakeDown(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TakeDown", reflect.TypeOf((*MockAdminRepository)(nil).TakeDown), ctx, id)
+}
+
+// MockRateLimit is a mock of RateLimit interface.
+type MockRateLimit struct {
+	ctrl     *gomock.Controller
+	recorder *MockRateLimitMockRecorder
+}
+
+// MockRateLimitMockRecorder is the mock recorder for MockRateLimit.
+type MockRateLimitMockRecorder struct {
+	mock *MockRateLimit
+}
+
+// NewMockRateLimit creates a new mock instance.
+func NewMockRateLimit(ctrl *gomock.Controller) *MockRateLimit {
+	mock := &MockRateLimit{ctrl: ctrl}
+	mock.recorder = &MockRateLimitMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRateLimit) EXPECT() *MockRateLimitMockRecorder {
+	return m.recorder
+}
+
+// Allow mocks base method.
+func (m *MockRateLimit) Allow(ctx context.Context, policy entity.RateLimitPolicy, key string) (entity.RateLimitResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Allow", ctx, policy, key)
+	ret0, _ := ret[0].(entity.RateLimitResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Allow indicates an expected call of Allow.
+func (mr *MockRateLimitMockRecorder) Allow(ctx, policy, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Allow", reflect.TypeOf((*MockRateLimit)(nil).Allow), ctx, policy, key)
+}
+
+// SweepFull mocks base method.
+func (m *MockRateLimit) SweepFull(ctx context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SweepFull", ctx)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SweepFull indicates an expected call of SweepFull.
+func (mr *MockRateLimitMockRecorder) SweepFull(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SweepFull", reflect.TypeOf((*MockRateLimit)(nil).SweepFull), ctx)
+}
+
+// MockRateLimitRepository is a mock of RateLimitRepository interface.
+type MockRateLimitRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRateLimitRepositoryMockRecorder
+}
+
+// MockRateLimitRepositoryMockRecorder is the mock recorder for MockRateLimitRepository.
+type MockRateLimitRepositoryMockRecorder struct {
+	mock *MockRateLimitRepository
+}
+
+// NewMockRateLimitRepository creates a new mock instance.
+func NewMockRateLimitRepository(ctrl *gomock.Controller) *MockRateLimitRepository {
+	mock := &MockRateLimitRepository{ctrl: ctrl}
+	mock.recorder = &MockRateLimitRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRateLimitRepository) EXPECT() *MockRateLimitRepositoryMockRecorder {
+	return m.recorder
+}
+
+// DeleteFull mocks base method.
+func (m *MockRateLimitRepository) DeleteFull(ctx context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteFull", ctx)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteFull indicates an expected call of DeleteFull.
+func (mr *MockRateLimitRepositoryMockRecorder) DeleteFull(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFull", reflect.TypeOf((*MockRateLimitRepository)(nil).DeleteFull), ctx)
+}
+
+// Take mocks base method.
+func (m *MockRateLimitRepository) Take(ctx context.Context, key string, policy entity.RateLimitPolicy) (entity.RateLimitBucket, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Take", ctx, key, policy)
+	ret0, _ := ret[0].(entity.RateLimitBucket)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Take indicates an expected call of Take.
+func (mr *MockRateLimitRepositoryMockRecorder) Take(ctx, key, policy any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Take", reflect.TypeOf((*MockRateLimitRepository)(nil).Take), ctx, key, policy)
+}
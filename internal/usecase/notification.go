@@ -0,0 +1,42 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Klef99/bhs-task/internal/entity"
+)
+
+// NotificationUseCase -.
+type NotificationUseCase struct {
+	repo NotificationRepository
+}
+
+var _ Notification = (*NotificationUseCase)(nil)
+
+// New -.
+func NewNotificationUseCase(r NotificationRepository) *NotificationUseCase {
+	return &NotificationUseCase{repo: r}
+}
+
+func (uc *NotificationUseCase) GetNotifications(ctx context.Context, user entity.User, unreadOnly bool) ([]entity.Notification, error) {
+	if user.Id <= 0 {
+		return nil, fmt.Errorf("NotificationUseCase - GetNotifications - invalid user id")
+	}
+	notifications, err := uc.repo.GetByUser(ctx, user, unreadOnly)
+	if err != nil {
+		return nil, fmt.Errorf("NotificationUseCase - GetNotifications - uc.repo.GetByUser: %w", err)
+	}
+	return notifications, nil
+}
+
+func (uc *NotificationUseCase) MarkRead(ctx context.Context, user entity.User, id int64) (bool, error) {
+	if user.Id <= 0 || id <= 0 {
+		return false, fmt.Errorf("NotificationUseCase - MarkRead - invalid user or notification id")
+	}
+	status, err := uc.repo.MarkRead(ctx, user, id)
+	if err != nil {
+		return false, fmt.Errorf("NotificationUseCase - MarkRead - uc.repo.MarkRead: %w", err)
+	}
+	return status, nil
+}
@@ -0,0 +1,149 @@
+package usecase_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/Klef99/bhs-task/internal/entity"
+	"github.com/Klef99/bhs-task/internal/usecase"
+	"github.com/stretchr/testify/require"
+	gomock "go.uber.org/mock/gomock"
+)
+
+type getNotificationsTest struct {
+	name       string
+	user       entity.User
+	unreadOnly bool
+	mock       func()
+	res        []entity.Notification
+	err        error
+}
+
+type markReadTest struct {
+	name string
+	user entity.User
+	id   int64
+	mock func()
+	res  bool
+	err  error
+}
+
+func NotificationUseCase(t *testing.T) (*usecase.NotificationUseCase, *MockNotificationRepository) {
+	t.Helper()
+
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+
+	repo := NewMockNotificationRepository(mockCtl)
+
+	NotificationUseCase := usecase.NewNotificationUseCase(repo)
+	return NotificationUseCase, repo
+}
+
+func TestGetNotifications(t *testing.T) {
+	t.Parallel()
+
+	notification, repo := NotificationUseCase(t)
+	assetId, oldPrice, newPrice := int64(2), 10.0, 7.5
+	tests := []getNotificationsTest{
+		{
+			name:       "unread price drops",
+			user:       entity.User{Id: 1, Username: "test"},
+			unreadOnly: true,
+			mock: func() {
+				repo.EXPECT().GetByUser(context.Background(), entity.User{Id: 1, Username: "test"}, true).Return([]entity.Notification{
+					{Id: 1, UserId: 1, Kind: entity.NotificationKindPriceDrop, AssetId: &assetId, OldPrice: &oldPrice, NewPrice: &newPrice},
+				}, nil)
+			},
+			res: []entity.Notification{
+				{Id: 1, UserId: 1, Kind: entity.NotificationKindPriceDrop, AssetId: &assetId, OldPrice: &oldPrice, NewPrice: &newPrice},
+			},
+			err: nil,
+		},
+		{
+			name: "invalid user id",
+			user: entity.User{},
+			mock: func() {},
+			res:  nil,
+			err:  fmt.Errorf("NotificationUseCase - GetNotifications - invalid user id"),
+		},
+		{
+			name: "repository error",
+			user: entity.User{Id: 2, Username: "test2"},
+			mock: func() {
+				repo.EXPECT().GetByUser(context.Background(), entity.User{Id: 2, Username: "test2"}, false).Return(nil, errInternalServErr)
+			},
+			res: nil,
+			err: errInternalServErr,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			tc.mock()
+			res, err := notification.GetNotifications(context.Background(), tc.user, tc.unreadOnly)
+			require.Equal(t, res, tc.res)
+			if err != nil {
+				require.ErrorContains(t, err, tc.err.Error())
+			} else {
+				require.Nil(t, err)
+			}
+		})
+	}
+}
+
+func TestMarkRead(t *testing.T) {
+	t.Parallel()
+
+	notification, repo := NotificationUseCase(t)
+	tests := []markReadTest{
+		{
+			name: "success",
+			user: entity.User{Id: 1, Username: "test"},
+			id:   1,
+			mock: func() {
+				repo.EXPECT().MarkRead(context.Background(), entity.User{Id: 1, Username: "test"}, int64(1)).Return(true, nil)
+			},
+			res: true,
+			err: nil,
+		},
+		{
+			name: "not found",
+			user: entity.User{Id: 1, Username: "test"},
+			id:   2,
+			mock: func() {
+				repo.EXPECT().MarkRead(context.Background(), entity.User{Id: 1, Username: "test"}, int64(2)).Return(false, nil)
+			},
+			res: false,
+			err: nil,
+		},
+		{
+			name: "invalid notification id",
+			user: entity.User{Id: 1, Username: "test"},
+			id:   0,
+			mock: func() {},
+			res:  false,
+			err:  fmt.Errorf("NotificationUseCase - MarkRead - invalid user or notification id"),
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			tc.mock()
+			res, err := notification.MarkRead(context.Background(), tc.user, tc.id)
+			require.Equal(t, res, tc.res)
+			if err != nil {
+				require.ErrorContains(t, err, tc.err.Error())
+			} else {
+				require.Nil(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,119 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Klef99/bhs-task/internal/entity"
+)
+
+// OfferUseCase -.
+type OfferUseCase struct {
+	repo OfferRepository
+	ttl  time.Duration
+}
+
+var _ Offer = (*OfferUseCase)(nil)
+
+// New -.
+func NewOfferUseCase(r OfferRepository, ttl time.Duration) *OfferUseCase {
+	return &OfferUseCase{repo: r, ttl: ttl}
+}
+
+func (uc *OfferUseCase) MakeOffer(ctx context.Context, user entity.User, assetId int64, price float64) (entity.Offer, error) {
+	if user.Id <= 0 || assetId <= 0 {
+		return entity.Offer{}, fmt.Errorf("OfferUseCase - MakeOffer - invalid user or asset id")
+	}
+	if price <= 0 {
+		return entity.Offer{}, fmt.Errorf("OfferUseCase - MakeOffer - price must be greater than zero")
+	}
+	offer, err := uc.repo.Create(ctx, entity.Offer{AssetId: assetId, BuyerId: user.Id, ProposedBy: user.Id, Price: price, ExpiresAt: time.Now().Add(uc.ttl)})
+	if err != nil {
+		return entity.Offer{}, fmt.Errorf("OfferUseCase - MakeOffer - uc.repo.Create: %w", err)
+	}
+	return offer, nil
+}
+
+// GetAssetOffers - offers on the asset the user takes part in, all of them for the owner.
+func (uc *OfferUseCase) GetAssetOffers(ctx context.Context, user entity.User, assetId int64) ([]entity.Offer, error) {
+	if user.Id <= 0 || assetId <= 0 {
+		return nil, fmt.Errorf("OfferUseCase - GetAssetOffers - invalid user or asset id")
+	}
+	offers, err := uc.repo.GetByAsset(ctx, user, assetId)
+	if err != nil {
+		return nil, fmt.Errorf("OfferUseCase - GetAssetOffers - uc.repo.GetByAsset: %w", err)
+	}
+	return offers, nil
+}
+
+// GetInbox - offers proposed to the user.
+func (uc *OfferUseCase) GetInbox(ctx context.Context, user entity.User) ([]entity.Offer, error) {
+	if user.Id <= 0 {
+		return nil, fmt.Errorf("OfferUseCase - GetInbox - invalid user id")
+	}
+	offers, err := uc.repo.GetInbox(ctx, user)
+	if err != nil {
+		return nil, fmt.Errorf("OfferUseCase - GetInbox - uc.repo.GetInbox: %w", err)
+	}
+	return offers, nil
+}
+
+// GetOutbox - offers proposed by the user.
+func (uc *OfferUseCase) GetOutbox(ctx context.Context, user entity.User) ([]entity.Offer, error) {
+	if user.Id <= 0 {
+		return nil, fmt.Errorf("OfferUseCase - GetOutbox - invalid user id")
+	}
+	offers, err := uc.repo.GetOutbox(ctx, user)
+	if err != nil {
+		return nil, fmt.Errorf("OfferUseCase - GetOutbox - uc.repo.GetOutbox: %w", err)
+	}
+	return offers, nil
+}
+
+// AcceptOffer - the buyer purchases the asset for the offered price.
+func (uc *OfferUseCase) AcceptOffer(ctx context.Context, user entity.User, id int64) (entity.Offer, error) {
+	if user.Id <= 0 || id <= 0 {
+		return entity.Offer{}, fmt.Errorf("OfferUseCase - AcceptOffer - invalid user or offer id")
+	}
+	offer, err := uc.repo.Accept(ctx, user, id)
+	if err != nil {
+		return entity.Offer{}, fmt.Errorf("OfferUseCase - AcceptOffer - uc.repo.Accept: %w", err)
+	}
+	return offer, nil
+}
+
+func (uc *OfferUseCase) RejectOffer(ctx context.Context, user entity.User, id int64) (entity.Offer, error) {
+	if user.Id <= 0 || id <= 0 {
+		return entity.Offer{}, fmt.Errorf("OfferUseCase - RejectOffer - invalid user or offer id")
+	}
+	offer, err := uc.repo.Reject(ctx, user, id)
+	if err != nil {
+		return entity.Offer{}, fmt.Errorf("OfferUseCase - RejectOffer - uc.repo.Reject: %w", err)
+	}
+	return offer, nil
+}
+
+// CounterOffer - closes the offer and proposes another price to the other party, returns the new offer.
+func (uc *OfferUseCase) CounterOffer(ctx context.Context, user entity.User, id int64, price float64) (entity.Offer, error) {
+	if user.Id <= 0 || id <= 0 {
+		return entity.Offer{}, fmt.Errorf("OfferUseCase - CounterOffer - invalid user or offer id")
+	}
+	if price <= 0 {
+		return entity.Offer{}, fmt.Errorf("OfferUseCase - CounterOffer - price must be greater than zero")
+	}
+	offer, err := uc.repo.Counter(ctx, user, id, price, time.Now().Add(uc.ttl))
+	if err != nil {
+		return entity.Offer{}, fmt.Errorf("OfferUseCase - CounterOffer - uc.repo.Counter: %w", err)
+	}
+	return offer, nil
+}
+
+// ExpireOffers - marks pending offers past their expiry time as expired, returns how many were expired.
+func (uc *OfferUseCase) ExpireOffers(ctx context.Context) (int64, error) {
+	expired, err := uc.repo.Expire(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("OfferUseCase - ExpireOffers - uc.repo.Expire: %w", err)
+	}
+	return expired, nil
+}
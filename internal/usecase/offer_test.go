@@ -0,0 +1,324 @@
+package usecase_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/Klef99/bhs-task/internal/entity"
+	"github.com/Klef99/bhs-task/internal/usecase"
+	"github.com/stretchr/testify/require"
+	gomock "go.uber.org/mock/gomock"
+)
+
+type offerTest struct {
+	name  string
+	user  entity.User
+	id    int64
+	price float64
+	mock  func()
+	res   entity.Offer
+	err   error
+}
+
+type listOffersTest struct {
+	name string
+	user entity.User
+	mock func()
+	res  []entity.Offer
+	err  error
+}
+
+func OfferUseCase(t *testing.T) (*usecase.OfferUseCase, *MockOfferRepository) {
+	t.Helper()
+
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+
+	repo := NewMockOfferRepository(mockCtl)
+
+	OfferUseCase := usecase.NewOfferUseCase(repo, time.Hour)
+	return OfferUseCase, repo
+}
+
+func TestMakeOffer(t *testing.T) {
+	t.Parallel()
+
+	offer, repo := OfferUseCase(t)
+	tests := []offerTest{
+		{
+			name:  "success",
+			user:  entity.User{Id: 2, Username: "test2"},
+			id:    1,
+			price: 40,
+			mock: func() {
+				repo.EXPECT().Create(context.Background(), gomock.Cond(func(x any) bool {
+					o := x.(entity.Offer)
+					return o.AssetId == 1 && o.BuyerId == 2 && o.ProposedBy == 2 && o.Price == 40 && time.Until(o.ExpiresAt) > 59*time.Minute
+				})).Return(entity.Offer{Id: 1, AssetId: 1, BuyerId: 2, SellerId: 1, ProposedBy: 2, Price: 40, Status: entity.OfferStatusPending}, nil)
+			},
+			res: entity.Offer{Id: 1, AssetId: 1, BuyerId: 2, SellerId: 1, ProposedBy: 2, Price: 40, Status: entity.OfferStatusPending},
+			err: nil,
+		},
+		{
+			name:  "invalid asset id",
+			user:  entity.User{Id: 2, Username: "test2"},
+			id:    0,
+			price: 40,
+			mock:  func() {},
+			res:   entity.Offer{},
+			err:   fmt.Errorf("OfferUseCase - MakeOffer - invalid user or asset id"),
+		},
+		{
+			name:  "zero price",
+			user:  entity.User{Id: 2, Username: "test2"},
+			id:    1,
+			price: 0,
+			mock:  func() {},
+			res:   entity.Offer{},
+			err:   fmt.Errorf("OfferUseCase - MakeOffer - price must be greater than zero"),
+		},
+		{
+			name:  "pending offer exists",
+			user:  entity.User{Id: 3, Username: "test3"},
+			id:    1,
+			price: 40,
+			mock: func() {
+				repo.EXPECT().Create(context.Background(), gomock.Any()).Return(entity.Offer{}, entity.ErrOfferExists)
+			},
+			res: entity.Offer{},
+			err: entity.ErrOfferExists,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			tc.mock()
+			res, err := offer.MakeOffer(context.Background(), tc.user, tc.id, tc.price)
+			require.Equal(t, res, tc.res)
+			if err != nil {
+				require.ErrorContains(t, err, tc.err.Error())
+			} else {
+				require.Nil(t, err)
+			}
+		})
+	}
+}
+
+func TestAcceptOffer(t *testing.T) {
+	t.Parallel()
+
+	offer, repo := OfferUseCase(t)
+	purchaseId := int64(7)
+	tests := []offerTest{
+		{
+			name: "success",
+			user: entity.User{Id: 1, Username: "test"},
+			id:   1,
+			mock: func() {
+				repo.EXPECT().Accept(context.Background(), entity.User{Id: 1, Username: "test"}, int64(1)).
+					Return(entity.Offer{Id: 1, Status: entity.OfferStatusAccepted, PurchaseId: &purchaseId}, nil)
+			},
+			res: entity.Offer{Id: 1, Status: entity.OfferStatusAccepted, PurchaseId: &purchaseId},
+			err: nil,
+		},
+		{
+			name: "invalid offer id",
+			user: entity.User{Id: 1, Username: "test"},
+			id:   -1,
+			mock: func() {},
+			res:  entity.Offer{},
+			err:  fmt.Errorf("OfferUseCase - AcceptOffer - invalid user or offer id"),
+		},
+		{
+			name: "offer closed",
+			user: entity.User{Id: 1, Username: "test"},
+			id:   2,
+			mock: func() {
+				repo.EXPECT().Accept(context.Background(), entity.User{Id: 1, Username: "test"}, int64(2)).Return(entity.Offer{}, entity.ErrOfferClosed)
+			},
+			res: entity.Offer{},
+			err: entity.ErrOfferClosed,
+		},
+		{
+			name: "insufficient funds",
+			user: entity.User{Id: 1, Username: "test"},
+			id:   3,
+			mock: func() {
+				repo.EXPECT().Accept(context.Background(), entity.User{Id: 1, Username: "test"}, int64(3)).Return(entity.Offer{}, entity.ErrInsufficientFunds)
+			},
+			res: entity.Offer{},
+			err: entity.ErrInsufficientFunds,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			tc.mock()
+			res, err := offer.AcceptOffer(context.Background(), tc.user, tc.id)
+			require.Equal(t, res, tc.res)
+			if err != nil {
+				require.ErrorContains(t, err, tc.err.Error())
+			} else {
+				require.Nil(t, err)
+			}
+		})
+	}
+}
+
+func TestRejectOffer(t *testing.T) {
+	t.Parallel()
+
+	offer, repo := OfferUseCase(t)
+	tests := []offerTest{
+		{
+			name: "success",
+			user: entity.User{Id: 1, Username: "test"},
+			id:   1,
+			mock: func() {
+				repo.EXPECT().Reject(context.Background(), entity.User{Id: 1, Username: "test"}, int64(1)).Return(entity.Offer{Id: 1, Status: entity.OfferStatusRejected}, nil)
+			},
+			res: entity.Offer{Id: 1, Status: entity.OfferStatusRejected},
+			err: nil,
+		},
+		{
+			name: "not found",
+			user: entity.User{Id: 1, Username: "test"},
+			id:   2,
+			mock: func() {
+				repo.EXPECT().Reject(context.Background(), entity.User{Id: 1, Username: "test"}, int64(2)).Return(entity.Offer{}, entity.ErrOfferNotFound)
+			},
+			res: entity.Offer{},
+			err: entity.ErrOfferNotFound,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			tc.mock()
+			res, err := offer.RejectOffer(context.Background(), tc.user, tc.id)
+			require.Equal(t, res, tc.res)
+			if err != nil {
+				require.ErrorContains(t, err, tc.err.Error())
+			} else {
+				require.Nil(t, err)
+			}
+		})
+	}
+}
+
+func TestCounterOffer(t *testing.T) {
+	t.Parallel()
+
+	offer, repo := OfferUseCase(t)
+	parentId := int64(1)
+	tests := []offerTest{
+		{
+			name:  "success",
+			user:  entity.User{Id: 1, Username: "test"},
+			id:    1,
+			price: 45,
+			mock: func() {
+				repo.EXPECT().Counter(context.Background(), entity.User{Id: 1, Username: "test"}, int64(1), float64(45), gomock.Any()).
+					Return(entity.Offer{Id: 2, ParentId: &parentId, ProposedBy: 1, Price: 45, Status: entity.OfferStatusPending}, nil)
+			},
+			res: entity.Offer{Id: 2, ParentId: &parentId, ProposedBy: 1, Price: 45, Status: entity.OfferStatusPending},
+			err: nil,
+		},
+		{
+			name:  "negative price",
+			user:  entity.User{Id: 1, Username: "test"},
+			id:    1,
+			price: -5,
+			mock:  func() {},
+			res:   entity.Offer{},
+			err:   fmt.Errorf("OfferUseCase - CounterOffer - price must be greater than zero"),
+		},
+		{
+			name:  "own offer",
+			user:  entity.User{Id: 2, Username: "test2"},
+			id:    3,
+			price: 45,
+			mock: func() {
+				repo.EXPECT().Counter(context.Background(), entity.User{Id: 2, Username: "test2"}, int64(3), float64(45), gomock.Any()).Return(entity.Offer{}, entity.ErrOfferNotFound)
+			},
+			res: entity.Offer{},
+			err: entity.ErrOfferNotFound,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			tc.mock()
+			res, err := offer.CounterOffer(context.Background(), tc.user, tc.id, tc.price)
+			require.Equal(t, res, tc.res)
+			if err != nil {
+				require.ErrorContains(t, err, tc.err.Error())
+			} else {
+				require.Nil(t, err)
+			}
+		})
+	}
+}
+
+func TestGetInbox(t *testing.T) {
+	t.Parallel()
+
+	offer, repo := OfferUseCase(t)
+	tests := []listOffersTest{
+		{
+			name: "success",
+			user: entity.User{Id: 1, Username: "test"},
+			mock: func() {
+				repo.EXPECT().GetInbox(context.Background(), entity.User{Id: 1, Username: "test"}).Return([]entity.Offer{{Id: 2}, {Id: 1}}, nil)
+			},
+			res: []entity.Offer{{Id: 2}, {Id: 1}},
+			err: nil,
+		},
+		{
+			name: "invalid user id",
+			user: entity.User{},
+			mock: func() {},
+			res:  nil,
+			err:  fmt.Errorf("OfferUseCase - GetInbox - invalid user id"),
+		},
+		{
+			name: "repository error",
+			user: entity.User{Id: 2, Username: "test2"},
+			mock: func() {
+				repo.EXPECT().GetInbox(context.Background(), entity.User{Id: 2, Username: "test2"}).Return(nil, errInternalServErr)
+			},
+			res: nil,
+			err: errInternalServErr,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			tc.mock()
+			res, err := offer.GetInbox(context.Background(), tc.user)
+			require.Equal(t, res, tc.res)
+			if err != nil {
+				require.ErrorContains(t, err, tc.err.Error())
+			} else {
+				require.Nil(t, err)
+			}
+		})
+	}
+}
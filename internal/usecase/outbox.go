@@ -0,0 +1,68 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/Klef99/bhs-task/pkg/logger"
+)
+
+// _outboxLease - how long claimed events are hidden from other instances, a relay that crashes
+// while publishing leaves them to be published again after it.
+const _outboxLease = time.Minute
+
+// OutboxUseCase -.
+type OutboxUseCase struct {
+	repo      OutboxRepository
+	publisher EventPublisher
+	l         logger.Interface
+	batch     uint64
+	backoff   time.Duration
+}
+
+var _ Outbox = (*OutboxUseCase)(nil)
+
+// New - batch is the number of events relayed per Relay call, an event the publisher fails is retried
+// after backoff, doubling with every attempt.
+func NewOutboxUseCase(r OutboxRepository, p EventPublisher, l logger.Interface, batch uint64, backoff time.Duration) *OutboxUseCase {
+	return &OutboxUseCase{repo: r, publisher: p, l: l, batch: batch, backoff: backoff}
+}
+
+// Relay - publishes pending outbox events, returns how many were published. Delivery is at least once:
+// an event is published again until the publisher accepts it and that is recorded. Events of an aggregate
+// are published in order, so once one of them fails the rest of that aggregate waits for its retry.
+func (uc *OutboxUseCase) Relay(ctx context.Context) (int, error) {
+	events, err := uc.repo.ClaimPending(ctx, uc.batch, _outboxLease)
+	if err != nil {
+		return 0, fmt.Errorf("OutboxUseCase - Relay - uc.repo.ClaimPending: %w", err)
+	}
+	blocked := make(map[string]bool)
+	published := 0
+	for _, event := range events {
+		aggregate := event.AggregateType + ":" + strconv.FormatInt(event.AggregateId, 10)
+		if blocked[aggregate] {
+			continue
+		}
+		err := uc.publisher.Publish(ctx, event)
+		if err != nil {
+			blocked[aggregate] = true
+			uc.l.WithContext(ctx).Error(fmt.Errorf("OutboxUseCase - Relay - uc.publisher.Publish: event %d: %w", event.Id, err))
+			event.Attempts++
+			event.NextAttemptAt = time.Now().Add(retryAfter(uc.backoff, event.Attempts))
+			event.LastError = lastError(err)
+			err = uc.repo.RecordFailure(ctx, event)
+			if err != nil {
+				return published, fmt.Errorf("OutboxUseCase - Relay - uc.repo.RecordFailure: %w", err)
+			}
+			continue
+		}
+		err = uc.repo.MarkPublished(ctx, event.Id)
+		if err != nil {
+			return published, fmt.Errorf("OutboxUseCase - Relay - uc.repo.MarkPublished: %w", err)
+		}
+		published++
+	}
+	return published, nil
+}
@@ -0,0 +1,130 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Klef99/bhs-task/internal/entity"
+	"github.com/Klef99/bhs-task/internal/usecase"
+	"github.com/Klef99/bhs-task/pkg/logger"
+	"github.com/stretchr/testify/require"
+	gomock "go.uber.org/mock/gomock"
+)
+
+type relayTest struct {
+	name      string
+	pending   []entity.OutboxEvent
+	failing   map[int64]bool // ids of events the publisher rejects
+	claimErr  error
+	published []int64 // ids handed to the publisher, in order
+	marked    []int64 // ids marked as published
+	retried   []int64 // ids recorded as failed
+	res       int
+	err       error
+}
+
+func OutboxUseCase(t *testing.T) (*usecase.OutboxUseCase, *MockOutboxRepository, *MockEventPublisher) {
+	t.Helper()
+
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+
+	repo := NewMockOutboxRepository(mockCtl)
+	publisher := NewMockEventPublisher(mockCtl)
+
+	OutboxUseCase := usecase.NewOutboxUseCase(repo, publisher, logger.New("error"), 10, time.Second)
+	return OutboxUseCase, repo, publisher
+}
+
+func TestRelay(t *testing.T) {
+	t.Parallel()
+
+	asset := func(id, assetId int64) entity.OutboxEvent {
+		return entity.OutboxEvent{Id: id, AggregateType: entity.AggregateAsset, AggregateId: assetId, Type: entity.EventAssetPurchased}
+	}
+	deposit := func(id, userId int64) entity.OutboxEvent {
+		return entity.OutboxEvent{Id: id, AggregateType: entity.AggregateUser, AggregateId: userId, Type: entity.EventDepositCompleted}
+	}
+	tests := []relayTest{
+		{
+			name:      "all published in order",
+			pending:   []entity.OutboxEvent{asset(1, 1), deposit(2, 1), asset(3, 1)},
+			published: []int64{1, 2, 3},
+			marked:    []int64{1, 2, 3},
+			retried:   []int64{},
+			res:       3,
+			err:       nil,
+		},
+		{
+			name:      "failure holds back later events of the aggregate only",
+			pending:   []entity.OutboxEvent{asset(1, 1), asset(2, 2), asset(3, 1), deposit(4, 1)},
+			failing:   map[int64]bool{1: true},
+			published: []int64{1, 2, 4},
+			marked:    []int64{2, 4},
+			retried:   []int64{1},
+			res:       2,
+			err:       nil,
+		},
+		{
+			name:      "same id of another aggregate type is not held back",
+			pending:   []entity.OutboxEvent{deposit(1, 1), asset(2, 1)},
+			failing:   map[int64]bool{1: true},
+			published: []int64{1, 2},
+			marked:    []int64{2},
+			retried:   []int64{1},
+			res:       1,
+			err:       nil,
+		},
+		{
+			name:     "claim error",
+			pending:  []entity.OutboxEvent{},
+			claimErr: errInternalServErr,
+			res:      0,
+			err:      errInternalServErr,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			outbox, repo, publisher := OutboxUseCase(t)
+			published := make([]int64, 0)
+			publisher.EXPECT().Publish(context.Background(), gomock.Any()).DoAndReturn(func(_ context.Context, e entity.OutboxEvent) error {
+				published = append(published, e.Id)
+				if tc.failing[e.Id] {
+					return errors.New("broker unavailable")
+				}
+				return nil
+			}).AnyTimes()
+			repo.EXPECT().ClaimPending(context.Background(), uint64(10), gomock.Any()).Return(tc.pending, tc.claimErr)
+			marked := make([]int64, 0)
+			repo.EXPECT().MarkPublished(context.Background(), gomock.Any()).DoAndReturn(func(_ context.Context, id int64) error {
+				marked = append(marked, id)
+				return nil
+			}).AnyTimes()
+			retried := make([]int64, 0)
+			repo.EXPECT().RecordFailure(context.Background(), gomock.Any()).DoAndReturn(func(_ context.Context, e entity.OutboxEvent) error {
+				retried = append(retried, e.Id)
+				require.Equal(t, 1, e.Attempts)
+				require.True(t, e.NextAttemptAt.After(time.Now()))
+				require.Equal(t, "broker unavailable", e.LastError)
+				return nil
+			}).AnyTimes()
+
+			res, err := outbox.Relay(context.Background())
+			require.Equal(t, res, tc.res)
+			if err != nil {
+				require.ErrorContains(t, err, tc.err.Error())
+			} else {
+				require.Nil(t, err)
+				require.Equal(t, tc.published, published)
+				require.Equal(t, tc.marked, marked)
+				require.Equal(t, tc.retried, retried)
+			}
+		})
+	}
+}
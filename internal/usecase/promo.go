@@ -0,0 +1,73 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Klef99/bhs-task/internal/entity"
+)
+
+// PromoUseCase -.
+type PromoUseCase struct {
+	repo PromoRepository
+}
+
+var _ Promo = (*PromoUseCase)(nil)
+
+// New -.
+func NewPromoUseCase(r PromoRepository) *PromoUseCase {
+	return &PromoUseCase{repo: r}
+}
+
+// CreatePromoCode - a seller's code, valid for all of their assets or the one given by AssetId.
+func (uc *PromoUseCase) CreatePromoCode(ctx context.Context, user entity.User, promo entity.PromoCode) (entity.PromoCode, error) {
+	if user.Id <= 0 {
+		return entity.PromoCode{}, fmt.Errorf("PromoUseCase - CreatePromoCode - invalid user id")
+	}
+	promo.SellerId = &user.Id
+	promo.CreatedBy = user.Id
+	promo, err := uc.create(ctx, promo)
+	if err != nil {
+		return entity.PromoCode{}, fmt.Errorf("PromoUseCase - CreatePromoCode - %w", err)
+	}
+	return promo, nil
+}
+
+// CreateSitePromoCode - an admin's code, for any asset or seller, or the whole market if neither is set.
+func (uc *PromoUseCase) CreateSitePromoCode(ctx context.Context, user entity.User, promo entity.PromoCode) (entity.PromoCode, error) {
+	if user.Id <= 0 {
+		return entity.PromoCode{}, fmt.Errorf("PromoUseCase - CreateSitePromoCode - invalid user id")
+	}
+	promo.CreatedBy = user.Id
+	promo, err := uc.create(ctx, promo)
+	if err != nil {
+		return entity.PromoCode{}, fmt.Errorf("PromoUseCase - CreateSitePromoCode - %w", err)
+	}
+	return promo, nil
+}
+
+// GetPromoCodes - codes created by the user.
+func (uc *PromoUseCase) GetPromoCodes(ctx context.Context, user entity.User) ([]entity.PromoCode, error) {
+	if user.Id <= 0 {
+		return nil, fmt.Errorf("PromoUseCase - GetPromoCodes - invalid user id")
+	}
+	codes, err := uc.repo.GetByCreator(ctx, user)
+	if err != nil {
+		return nil, fmt.Errorf("PromoUseCase - GetPromoCodes - uc.repo.GetByCreator: %w", err)
+	}
+	return codes, nil
+}
+
+func (uc *PromoUseCase) create(ctx context.Context, promo entity.PromoCode) (entity.PromoCode, error) {
+	promo.Code = entity.NormalizePromoCode(promo.Code)
+	err := promo.Validate()
+	if err != nil {
+		return entity.PromoCode{}, fmt.Errorf("promo.Validate: %w", err)
+	}
+	promo.Used = 0
+	promo, err = uc.repo.Create(ctx, promo)
+	if err != nil {
+		return entity.PromoCode{}, fmt.Errorf("uc.repo.Create: %w", err)
+	}
+	return promo, nil
+}
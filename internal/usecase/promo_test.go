@@ -0,0 +1,230 @@
+package usecase_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/Klef99/bhs-task/internal/entity"
+	"github.com/Klef99/bhs-task/internal/usecase"
+	"github.com/stretchr/testify/require"
+	gomock "go.uber.org/mock/gomock"
+)
+
+type createPromoCodeTest struct {
+	name  string
+	user  entity.User
+	promo entity.PromoCode
+	mock  func()
+	res   entity.PromoCode
+	err   error
+}
+
+type discountTest struct {
+	name  string
+	promo entity.PromoCode
+	price float64
+	res   float64
+}
+
+func PromoUseCase(t *testing.T) (*usecase.PromoUseCase, *MockPromoRepository) {
+	t.Helper()
+
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+
+	repo := NewMockPromoRepository(mockCtl)
+
+	PromoUseCase := usecase.NewPromoUseCase(repo)
+	return PromoUseCase, repo
+}
+
+func TestCreatePromoCode(t *testing.T) {
+	t.Parallel()
+
+	promo, repo := PromoUseCase(t)
+	sellerId := int64(1)
+	assetId := int64(3)
+	maxUses := int64(0)
+	past := time.Now().Add(-time.Hour)
+	tests := []createPromoCodeTest{
+		{
+			name:  "seller-wide",
+			user:  entity.User{Id: 1, Username: "test"},
+			promo: entity.PromoCode{Code: "summer10", Kind: entity.DiscountPercent, Value: 10},
+			mock: func() {
+				repo.EXPECT().Create(context.Background(), entity.PromoCode{Code: "SUMMER10", Kind: entity.DiscountPercent, Value: 10, SellerId: &sellerId, CreatedBy: 1}).
+					Return(entity.PromoCode{Id: 1, Code: "SUMMER10", Kind: entity.DiscountPercent, Value: 10, SellerId: &sellerId, CreatedBy: 1}, nil)
+			},
+			res: entity.PromoCode{Id: 1, Code: "SUMMER10", Kind: entity.DiscountPercent, Value: 10, SellerId: &sellerId, CreatedBy: 1},
+			err: nil,
+		},
+		{
+			name:  "asset not owned",
+			user:  entity.User{Id: 1, Username: "test"},
+			promo: entity.PromoCode{Code: "ASSET5", Kind: entity.DiscountFixed, Value: 5, AssetId: &assetId},
+			mock: func() {
+				repo.EXPECT().Create(context.Background(), entity.PromoCode{Code: "ASSET5", Kind: entity.DiscountFixed, Value: 5, AssetId: &assetId, SellerId: &sellerId, CreatedBy: 1}).
+					Return(entity.PromoCode{}, entity.ErrAssetNotFound)
+			},
+			res: entity.PromoCode{},
+			err: entity.ErrAssetNotFound,
+		},
+		{
+			name:  "invalid code",
+			user:  entity.User{Id: 1, Username: "test"},
+			promo: entity.PromoCode{Code: "no spaces", Kind: entity.DiscountFixed, Value: 5},
+			mock:  func() {},
+			res:   entity.PromoCode{},
+			err:   fmt.Errorf("code must be 3 to 32 letters, digits, '-' or '_'"),
+		},
+		{
+			name:  "invalid kind",
+			user:  entity.User{Id: 1, Username: "test"},
+			promo: entity.PromoCode{Code: "CODE", Kind: "bogo", Value: 5},
+			mock:  func() {},
+			res:   entity.PromoCode{},
+			err:   fmt.Errorf("kind must be percent or fixed"),
+		},
+		{
+			name:  "percent over 100",
+			user:  entity.User{Id: 1, Username: "test"},
+			promo: entity.PromoCode{Code: "CODE", Kind: entity.DiscountPercent, Value: 150},
+			mock:  func() {},
+			res:   entity.PromoCode{},
+			err:   fmt.Errorf("value must be positive and at most 100 percent"),
+		},
+		{
+			name:  "zero max uses",
+			user:  entity.User{Id: 1, Username: "test"},
+			promo: entity.PromoCode{Code: "CODE", Kind: entity.DiscountFixed, Value: 5, MaxUses: &maxUses},
+			mock:  func() {},
+			res:   entity.PromoCode{},
+			err:   fmt.Errorf("max uses must be positive"),
+		},
+		{
+			name:  "expired",
+			user:  entity.User{Id: 1, Username: "test"},
+			promo: entity.PromoCode{Code: "CODE", Kind: entity.DiscountFixed, Value: 5, ExpiresAt: &past},
+			mock:  func() {},
+			res:   entity.PromoCode{},
+			err:   fmt.Errorf("expiry time must be in the future"),
+		},
+		{
+			name:  "code exists",
+			user:  entity.User{Id: 1, Username: "test"},
+			promo: entity.PromoCode{Code: "TAKEN", Kind: entity.DiscountFixed, Value: 5},
+			mock: func() {
+				repo.EXPECT().Create(context.Background(), entity.PromoCode{Code: "TAKEN", Kind: entity.DiscountFixed, Value: 5, SellerId: &sellerId, CreatedBy: 1}).
+					Return(entity.PromoCode{}, entity.ErrPromoCodeExists)
+			},
+			res: entity.PromoCode{},
+			err: entity.ErrPromoCodeExists,
+		},
+		{
+			name:  "invalid user id",
+			user:  entity.User{},
+			promo: entity.PromoCode{Code: "CODE", Kind: entity.DiscountFixed, Value: 5},
+			mock:  func() {},
+			res:   entity.PromoCode{},
+			err:   fmt.Errorf("PromoUseCase - CreatePromoCode - invalid user id"),
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			tc.mock()
+			res, err := promo.CreatePromoCode(context.Background(), tc.user, tc.promo)
+			require.Equal(t, res, tc.res)
+			if err != nil {
+				require.ErrorContains(t, err, tc.err.Error())
+			} else {
+				require.Nil(t, err)
+			}
+		})
+	}
+}
+
+func TestCreateSitePromoCode(t *testing.T) {
+	t.Parallel()
+
+	promo, repo := PromoUseCase(t)
+	tests := []createPromoCodeTest{
+		{
+			name:  "market-wide",
+			user:  entity.User{Id: 9, Username: "admin"},
+			promo: entity.PromoCode{Code: "BLACKFRIDAY", Kind: entity.DiscountPercent, Value: 30},
+			mock: func() {
+				repo.EXPECT().Create(context.Background(), entity.PromoCode{Code: "BLACKFRIDAY", Kind: entity.DiscountPercent, Value: 30, CreatedBy: 9}).
+					Return(entity.PromoCode{Id: 2, Code: "BLACKFRIDAY", Kind: entity.DiscountPercent, Value: 30, CreatedBy: 9}, nil)
+			},
+			res: entity.PromoCode{Id: 2, Code: "BLACKFRIDAY", Kind: entity.DiscountPercent, Value: 30, CreatedBy: 9},
+			err: nil,
+		},
+		{
+			name:  "negative value",
+			user:  entity.User{Id: 9, Username: "admin"},
+			promo: entity.PromoCode{Code: "NEG", Kind: entity.DiscountFixed, Value: -1},
+			mock:  func() {},
+			res:   entity.PromoCode{},
+			err:   fmt.Errorf("value must be positive and at most 100 percent"),
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			tc.mock()
+			res, err := promo.CreateSitePromoCode(context.Background(), tc.user, tc.promo)
+			require.Equal(t, res, tc.res)
+			if err != nil {
+				require.ErrorContains(t, err, tc.err.Error())
+			} else {
+				require.Nil(t, err)
+			}
+		})
+	}
+}
+
+func TestPromoCodeDiscount(t *testing.T) {
+	t.Parallel()
+
+	tests := []discountTest{
+		{name: "percent", promo: entity.PromoCode{Kind: entity.DiscountPercent, Value: 25}, price: 80, res: 20},
+		{name: "full percent", promo: entity.PromoCode{Kind: entity.DiscountPercent, Value: 100}, price: 80, res: 80},
+		{name: "fixed", promo: entity.PromoCode{Kind: entity.DiscountFixed, Value: 15}, price: 80, res: 15},
+		{name: "fixed above price", promo: entity.PromoCode{Kind: entity.DiscountFixed, Value: 100}, price: 80, res: 80},
+	}
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, tc.res, tc.promo.Discount(tc.price))
+		})
+	}
+}
+
+func TestPromoCodeAppliesTo(t *testing.T) {
+	t.Parallel()
+
+	assetId := int64(3)
+	sellerId := int64(1)
+	market := entity.PromoCode{}
+	seller := entity.PromoCode{SellerId: &sellerId}
+	asset := entity.PromoCode{AssetId: &assetId, SellerId: &sellerId}
+
+	require.True(t, market.AppliesTo(5, 2))
+	require.True(t, seller.AppliesTo(5, 1))
+	require.False(t, seller.AppliesTo(5, 2))
+	require.True(t, asset.AppliesTo(3, 1))
+	require.False(t, asset.AppliesTo(4, 1))
+	require.False(t, asset.AppliesTo(3, 2)) // the asset changed owner
+}
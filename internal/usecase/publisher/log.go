@@ -0,0 +1,26 @@
+package publisher
+
+import (
+	"context"
+
+	"github.com/Klef99/bhs-task/internal/entity"
+	"github.com/Klef99/bhs-task/internal/usecase"
+	"github.com/Klef99/bhs-task/pkg/logger"
+)
+
+// LogPublisher - writes every event to the log, for development and debugging.
+type LogPublisher struct {
+	l logger.Interface
+}
+
+var _ usecase.EventPublisher = (*LogPublisher)(nil)
+
+// New -.
+func NewLogPublisher(l logger.Interface) *LogPublisher {
+	return &LogPublisher{l: l}
+}
+
+func (p *LogPublisher) Publish(_ context.Context, event entity.OutboxEvent) error {
+	p.l.Info("publisher - event %d %s of %s %d: %s", event.Id, event.Type, event.AggregateType, event.AggregateId, event.Payload)
+	return nil
+}
@@ -0,0 +1,36 @@
+package publisher
+
+import (
+	"context"
+	"sync"
+
+	"github.com/Klef99/bhs-task/internal/entity"
+	"github.com/Klef99/bhs-task/internal/usecase"
+)
+
+// MemoryPublisher - keeps published events in memory, for tests and local runs.
+type MemoryPublisher struct {
+	mu     sync.Mutex
+	events []entity.OutboxEvent
+}
+
+var _ usecase.EventPublisher = (*MemoryPublisher)(nil)
+
+// New -.
+func NewMemoryPublisher() *MemoryPublisher {
+	return &MemoryPublisher{}
+}
+
+func (p *MemoryPublisher) Publish(_ context.Context, event entity.OutboxEvent) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.events = append(p.events, event)
+	return nil
+}
+
+// Events - copy of the events published so far, in order.
+func (p *MemoryPublisher) Events() []entity.OutboxEvent {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]entity.OutboxEvent(nil), p.events...)
+}
@@ -0,0 +1,41 @@
+package publisher
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/Klef99/bhs-task/internal/entity"
+	"github.com/Klef99/bhs-task/internal/usecase"
+)
+
+// MetricsPublisher - counts purchases from the asset.purchased events it passes on. Every way of buying
+// writes the event in the purchase transaction, so each committed purchase is counted once it is relayed.
+type MetricsPublisher struct {
+	next usecase.EventPublisher
+	m    *usecase.Metrics
+}
+
+var _ usecase.EventPublisher = (*MetricsPublisher)(nil)
+
+// New -.
+func NewMetricsPublisher(next usecase.EventPublisher, m *usecase.Metrics) *MetricsPublisher {
+	return &MetricsPublisher{next: next, m: m}
+}
+
+// Publish - the purchase is counted only when next accepts the event, a rejected one is counted on the retry.
+func (p *MetricsPublisher) Publish(ctx context.Context, event entity.OutboxEvent) error {
+	err := p.next.Publish(ctx, event)
+	if err != nil {
+		return err
+	}
+	if event.Type != entity.EventAssetPurchased {
+		return nil
+	}
+	payload := struct {
+		Data entity.Purchase `json:"data"`
+	}{}
+	if json.Unmarshal([]byte(event.Payload), &payload) == nil {
+		p.m.ObservePurchase(payload.Data)
+	}
+	return nil
+}
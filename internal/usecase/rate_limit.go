@@ -0,0 +1,60 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/Klef99/bhs-task/internal/entity"
+)
+
+// RateLimitUseCase -.
+type RateLimitUseCase struct {
+	repo RateLimitRepository
+}
+
+var _ RateLimit = (*RateLimitUseCase)(nil)
+
+// New -.
+func NewRateLimitUseCase(r RateLimitRepository) *RateLimitUseCase {
+	return &RateLimitUseCase{repo: r}
+}
+
+// Allow - takes a token from the bucket of key under the policy, the request is allowed if there was one.
+func (uc *RateLimitUseCase) Allow(ctx context.Context, policy entity.RateLimitPolicy, key string) (entity.RateLimitResult, error) {
+	if !policy.Valid() {
+		return entity.RateLimitResult{}, fmt.Errorf("RateLimitUseCase - Allow - %w", entity.ErrInvalidRateLimitPolicy)
+	}
+	bucket, err := uc.repo.Take(ctx, policy.Name+":"+key, policy)
+	if err != nil {
+		return entity.RateLimitResult{}, fmt.Errorf("RateLimitUseCase - Allow - uc.repo.Take: %w", err)
+	}
+	res := entity.RateLimitResult{
+		Allowed:   bucket.Allowed,
+		Limit:     policy.Burst,
+		Remaining: int(math.Floor(bucket.Tokens)),
+		Reset:     refillTime(float64(policy.Burst)-bucket.Tokens, policy.Rate),
+	}
+	if !bucket.Allowed {
+		res.RetryAfter = refillTime(1-bucket.Tokens, policy.Rate)
+	}
+	return res, nil
+}
+
+// SweepFull - removes the buckets refilled to the full burst, they are the same as ones never used.
+func (uc *RateLimitUseCase) SweepFull(ctx context.Context) (int64, error) {
+	removed, err := uc.repo.DeleteFull(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("RateLimitUseCase - SweepFull - uc.repo.DeleteFull: %w", err)
+	}
+	return removed, nil
+}
+
+// refillTime - time it takes to refill the number of tokens at rate tokens per second.
+func refillTime(tokens, rate float64) time.Duration {
+	if tokens <= 0 {
+		return 0
+	}
+	return time.Duration(tokens / rate * float64(time.Second))
+}
@@ -0,0 +1,112 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Klef99/bhs-task/internal/entity"
+	"github.com/Klef99/bhs-task/internal/usecase"
+	"github.com/stretchr/testify/require"
+	gomock "go.uber.org/mock/gomock"
+)
+
+type allowTest struct {
+	name    string
+	policy  entity.RateLimitPolicy
+	bucket  entity.RateLimitBucket
+	repoErr error
+	res     entity.RateLimitResult
+	err     error
+}
+
+func RateLimitUseCase(t *testing.T) (*usecase.RateLimitUseCase, *MockRateLimitRepository) {
+	t.Helper()
+
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+
+	repo := NewMockRateLimitRepository(mockCtl)
+
+	RateLimitUseCase := usecase.NewRateLimitUseCase(repo)
+	return RateLimitUseCase, repo
+}
+
+func TestAllow(t *testing.T) {
+	t.Parallel()
+
+	// 10 requests per second with bursts of 5.
+	policy := entity.RateLimitPolicy{Name: "api", Rate: 10, Burst: 5}
+	tests := []allowTest{
+		{
+			name:   "allowed",
+			policy: policy,
+			bucket: entity.RateLimitBucket{Tokens: 3.5, Allowed: true},
+			res: entity.RateLimitResult{
+				Allowed:   true,
+				Limit:     5,
+				Remaining: 3,
+				Reset:     150 * time.Millisecond,
+			},
+			err: nil,
+		},
+		{
+			name:   "last token taken",
+			policy: policy,
+			bucket: entity.RateLimitBucket{Tokens: 0, Allowed: true},
+			res: entity.RateLimitResult{
+				Allowed:   true,
+				Limit:     5,
+				Remaining: 0,
+				Reset:     500 * time.Millisecond,
+			},
+			err: nil,
+		},
+		{
+			name:   "denied until a token is refilled",
+			policy: policy,
+			bucket: entity.RateLimitBucket{Tokens: 0.25, Allowed: false},
+			res: entity.RateLimitResult{
+				Allowed:    false,
+				Limit:      5,
+				Remaining:  0,
+				Reset:      475 * time.Millisecond,
+				RetryAfter: 75 * time.Millisecond,
+			},
+			err: nil,
+		},
+		{
+			name:   "invalid policy",
+			policy: entity.RateLimitPolicy{Name: "api", Rate: 0, Burst: 5},
+			res:    entity.RateLimitResult{},
+			err:    entity.ErrInvalidRateLimitPolicy,
+		},
+		{
+			name:    "repository error",
+			policy:  policy,
+			repoErr: errInternalServErr,
+			res:     entity.RateLimitResult{},
+			err:     errInternalServErr,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			RateLimitUseCase, repo := RateLimitUseCase(t)
+			if tc.policy.Valid() {
+				repo.EXPECT().Take(context.Background(), "api:user:1", tc.policy).Return(tc.bucket, tc.repoErr)
+			}
+
+			res, err := RateLimitUseCase.Allow(context.Background(), tc.policy, "user:1")
+			require.Equal(t, tc.res, res)
+			if tc.err == nil {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tc.err.Error())
+			}
+		})
+	}
+}
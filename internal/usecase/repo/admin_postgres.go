@@ -0,0 +1,320 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Klef99/bhs-task/internal/entity"
+	"github.com/Klef99/bhs-task/internal/usecase"
+	"github.com/Klef99/bhs-task/pkg/hasher"
+	"github.com/Klef99/bhs-task/pkg/postgres"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+)
+
+// _reconcileChecks - each query returns the subject and the detail of every inconsistency it finds.
+var _reconcileChecks = []struct {
+	name  string
+	query string
+}{
+	{
+		name: "sold",
+		// Renewals extend an access already sold and aren't counted in assets.sold.
+		query: `SELECT 'asset ' || assets.id, 'sold ' || assets.sold || ', purchases ' || count(purchases.id)
+			FROM assets LEFT JOIN purchases ON purchases.asset_id = assets.id AND NOT purchases.renewal
+			GROUP BY assets.id HAVING assets.sold <> count(purchases.id)`,
+	},
+	{
+		name: "rating",
+		query: `SELECT 'asset ' || assets.id,
+				'rating ' || assets.rating_sum || '/' || assets.rating_count || ', reviews ' || coalesce(sum(reviews.rating), 0) || '/' || count(reviews.id)
+			FROM assets LEFT JOIN reviews ON reviews.asset_id = assets.id
+			GROUP BY assets.id HAVING assets.rating_sum <> coalesce(sum(reviews.rating), 0) OR assets.rating_count <> count(reviews.id)`,
+	},
+	{
+		name: "promo",
+		query: `SELECT 'promo code ' || promo_codes.code, 'used ' || promo_codes.used || ', redemptions ' || count(promo_redemptions.id)
+			FROM promo_codes LEFT JOIN promo_redemptions ON promo_redemptions.promo_code_id = promo_codes.id
+			GROUP BY promo_codes.id HAVING promo_codes.used <> count(promo_redemptions.id)`,
+	},
+	{
+		name: "bids",
+		query: `SELECT 'bid ' || bids.id, 'held ' || bids.amount || ' on ' || auctions.status || ' auction ' || auctions.id
+			FROM bids JOIN auctions ON auctions.id = bids.auction_id
+			WHERE bids.status = 'held' AND auctions.status <> 'open'`,
+	},
+}
+
+// AdminRepository -.
+type AdminRepository struct {
+	*postgres.Postgres
+	Hasher hasher.Interface
+}
+
+var _ usecase.AdminRepository = (*AdminRepository)(nil)
+
+// New -.
+func NewAdminRepository(pg *postgres.Postgres, hs hasher.Interface) *AdminRepository {
+	return &AdminRepository{pg, hs}
+}
+
+// SetRole -.
+func (r *AdminRepository) SetRole(ctx context.Context, username string, role entity.Role) error {
+	sql, args, err := r.Builder.
+		Update("users").
+		Set("role", role).
+		Where(sq.Eq{"username": username}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("AdminRepository - SetRole - r.Builder: %w", err)
+	}
+	res, err := r.Pool.Exec(ctx, sql, args...)
+	if err != nil {
+		return fmt.Errorf("AdminRepository - SetRole - r.Pool.Exec: %w", err)
+	}
+	if res.RowsAffected() == 0 {
+		return entity.ErrUserNotFound
+	}
+	return nil
+}
+
+// SetPassword -.
+func (r *AdminRepository) SetPassword(ctx context.Context, username, password string) error {
+	hashedBytes, err := r.Hasher.HashPassword(ctx, password)
+	if err != nil {
+		return fmt.Errorf("AdminRepository - SetPassword - bcrypt.GenerateFromPassword: %w", err)
+	}
+	sql, args, err := r.Builder.
+		Update("users").
+		Set("password_hash", hashedBytes).
+		Where(sq.Eq{"username": username}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("AdminRepository - SetPassword - r.Builder: %w", err)
+	}
+	res, err := r.Pool.Exec(ctx, sql, args...)
+	if err != nil {
+		return fmt.Errorf("AdminRepository - SetPassword - r.Pool.Exec: %w", err)
+	}
+	if res.RowsAffected() == 0 {
+		return entity.ErrUserNotFound
+	}
+	return nil
+}
+
+// AdjustBalance - changes the balance and records the adjustment in one transaction.
+// The balance can't go below zero.
+func (r *AdminRepository) AdjustBalance(ctx context.Context, adj entity.BalanceAdjustment, username string) (entity.BalanceAdjustment, error) {
+	tx, err := r.Pool.Begin(ctx)
+	if err != nil {
+		return entity.BalanceAdjustment{}, fmt.Errorf("AdminRepository - AdjustBalance - r.Pool.Begin: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	sql, args, err := r.Builder.
+		Update("users").
+		Set("balance", sq.Expr("balance + ?", adj.Amount)).
+		Where(sq.Eq{"username": username}).
+		Where(sq.Expr("balance + ? >= 0", adj.Amount)).
+		Suffix("RETURNING id, balance").
+		ToSql()
+	if err != nil {
+		return entity.BalanceAdjustment{}, fmt.Errorf("AdminRepository - AdjustBalance - r.Builder.Update: %w", err)
+	}
+	err = tx.QueryRow(ctx, sql, args...).Scan(&adj.UserId, &adj.Balance)
+	if errors.Is(err, pgx.ErrNoRows) {
+		var exists bool
+		err = tx.QueryRow(ctx, "SELECT EXISTS (SELECT 1 FROM users WHERE username = $1)", username).Scan(&exists)
+		if err != nil {
+			return entity.BalanceAdjustment{}, fmt.Errorf("AdminRepository - AdjustBalance - tx.QueryRow('user exists'): %w", err)
+		}
+		if !exists {
+			return entity.BalanceAdjustment{}, entity.ErrUserNotFound
+		}
+		return entity.BalanceAdjustment{}, entity.ErrInsufficientFunds
+	}
+	if err != nil {
+		return entity.BalanceAdjustment{}, fmt.Errorf("AdminRepository - AdjustBalance - tx.QueryRow('users'): %w", err)
+	}
+
+	sql, args, err = r.Builder.
+		Insert("balance_adjustments").
+		Columns("user_id", "amount", "reason", "actor").
+		Values(adj.UserId, adj.Amount, adj.Reason, adj.Actor).
+		Suffix("RETURNING id, created_at").
+		ToSql()
+	if err != nil {
+		return entity.BalanceAdjustment{}, fmt.Errorf("AdminRepository - AdjustBalance - r.Builder.Insert: %w", err)
+	}
+	err = tx.QueryRow(ctx, sql, args...).Scan(&adj.Id, &adj.CreatedAt)
+	if err != nil {
+		return entity.BalanceAdjustment{}, fmt.Errorf("AdminRepository - AdjustBalance - tx.QueryRow('balance_adjustments'): %w", err)
+	}
+	err = tx.Commit(ctx)
+	if err != nil {
+		return entity.BalanceAdjustment{}, fmt.Errorf("AdminRepository - AdjustBalance - tx.Commit: %w", err)
+	}
+	return adj, nil
+}
+
+// ListAssets -.
+func (r *AdminRepository) ListAssets(ctx context.Context, filter entity.AssetFilter) ([]entity.Asset, error) {
+	query := r.Builder.
+		Select("assets.id, name, description, price, owner_id, status, sale_mode, stock, sold, deleted_at, access_days").
+		From("assets").
+		OrderBy("assets.id")
+	if filter.Owner != "" {
+		query = query.Join("users ON users.id = assets.owner_id").Where(sq.Eq{"users.username": filter.Owner})
+	}
+	if filter.Status != "" {
+		query = query.Where(sq.Eq{"status": filter.Status})
+	}
+	if !filter.IncludeDeleted {
+		query = query.Where(sq.Eq{"deleted_at": nil})
+	}
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("AdminRepository - ListAssets - r.Builder: %w", err)
+	}
+	rows, err := r.Pool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("AdminRepository - ListAssets - r.Pool.Query: %w", err)
+	}
+	defer rows.Close()
+	assets := make([]entity.Asset, 0)
+	for rows.Next() {
+		ast := entity.Asset{}
+		err := rows.Scan(&ast.Id, &ast.Name, &ast.Description, &ast.Price, &ast.Owner_id, &ast.Status, &ast.SaleMode, &ast.Stock, &ast.Sold, &ast.DeletedAt, &ast.AccessDays)
+		if err != nil {
+			return nil, fmt.Errorf("AdminRepository - ListAssets - rows.Scan: %w", err)
+		}
+		ast.SetRemaining()
+		assets = append(assets, ast)
+	}
+	return assets, nil
+}
+
+// TakeDown - soft deletes the asset of any owner, see AssetRepository.Erase.
+func (r *AdminRepository) TakeDown(ctx context.Context, id int64) (bool, error) {
+	tx, err := r.Pool.Begin(ctx)
+	if err != nil {
+		return false, fmt.Errorf("AdminRepository - TakeDown - r.Pool.Begin: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	sql, args, err := r.Builder.
+		Update("assets").
+		Set("deleted_at", sq.Expr("now()")).
+		Where(sq.Eq{"id": id, "deleted_at": nil}).
+		SuffixExpr(sq.ConcatExpr("RETURNING ", forSale())).
+		ToSql()
+	if err != nil {
+		return false, fmt.Errorf("AdminRepository - TakeDown - r.Builder: %w", err)
+	}
+	var wasListed bool
+	err = tx.QueryRow(ctx, sql, args...).Scan(&wasListed)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("AdminRepository - TakeDown - row.Scan: %w", err)
+	}
+	if wasListed {
+		err = notifyMarket(ctx, tx, entity.MarketEvent{Type: entity.MarketAssetDeleted, AssetId: id})
+		if err != nil {
+			return false, fmt.Errorf("AdminRepository - TakeDown - notifyMarket: %w", err)
+		}
+	}
+	err = tx.Commit(ctx)
+	if err != nil {
+		return false, fmt.Errorf("AdminRepository - TakeDown - tx.Commit: %w", err)
+	}
+	return true, nil
+}
+
+// GrantAccess - replaces the expiry of an existing access row.
+func (r *AdminRepository) GrantAccess(ctx context.Context, username string, assetId int64, days *int64) error {
+	expiresAt := sq.Expr("NULL")
+	if days != nil {
+		expiresAt = sq.Expr("now() + make_interval(days => ?)", *days)
+	}
+	sql, args, err := r.Builder.
+		Insert("access_assets").
+		Columns("user_id", "asset_id", "expires_at").
+		Select(r.Builder.
+			Select("users.id", "assets.id").
+			Column(expiresAt).
+			From("users").
+			Join("assets ON assets.id = ?", assetId).
+			Where(sq.Eq{"users.username": username})).
+		Suffix("ON CONFLICT (user_id, asset_id) DO UPDATE SET expires_at = excluded.expires_at").
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("AdminRepository - GrantAccess - r.Builder: %w", err)
+	}
+	res, err := r.Pool.Exec(ctx, sql, args...)
+	if err != nil {
+		return fmt.Errorf("AdminRepository - GrantAccess - r.Pool.Exec: %w", err)
+	}
+	if res.RowsAffected() > 0 {
+		return nil
+	}
+	return r.missing(ctx, username, "GrantAccess")
+}
+
+// RevokeAccess -.
+func (r *AdminRepository) RevokeAccess(ctx context.Context, username string, assetId int64) (bool, error) {
+	sql, args, err := r.Builder.
+		Delete("access_assets").
+		Where(sq.Eq{"asset_id": assetId}).
+		Where(sq.Expr("user_id = (SELECT id FROM users WHERE username = ?)", username)).
+		ToSql()
+	if err != nil {
+		return false, fmt.Errorf("AdminRepository - RevokeAccess - r.Builder: %w", err)
+	}
+	res, err := r.Pool.Exec(ctx, sql, args...)
+	if err != nil {
+		return false, fmt.Errorf("AdminRepository - RevokeAccess - r.Pool.Exec: %w", err)
+	}
+	return res.RowsAffected() > 0, nil
+}
+
+// Reconcile - compares the counters kept on assets and promo codes with the rows they count
+// and looks for amounts still held by bids on closed auctions.
+func (r *AdminRepository) Reconcile(ctx context.Context) ([]entity.ReconcileIssue, error) {
+	issues := make([]entity.ReconcileIssue, 0)
+	for _, check := range _reconcileChecks {
+		rows, err := r.Pool.Query(ctx, check.query)
+		if err != nil {
+			return nil, fmt.Errorf("AdminRepository - Reconcile - r.Pool.Query(%q): %w", check.name, err)
+		}
+		for rows.Next() {
+			issue := entity.ReconcileIssue{Check: check.name}
+			err := rows.Scan(&issue.Subject, &issue.Detail)
+			if err != nil {
+				rows.Close()
+				return nil, fmt.Errorf("AdminRepository - Reconcile - rows.Scan(%q): %w", check.name, err)
+			}
+			issues = append(issues, issue)
+		}
+		rows.Close()
+		if rows.Err() != nil {
+			return nil, fmt.Errorf("AdminRepository - Reconcile - rows.Err(%q): %w", check.name, rows.Err())
+		}
+	}
+	return issues, nil
+}
+
+// missing - tells which of the user and the asset does not exist.
+func (r *AdminRepository) missing(ctx context.Context, username, method string) error {
+	var exists bool
+	err := r.Pool.QueryRow(ctx, "SELECT EXISTS (SELECT 1 FROM users WHERE username = $1)", username).Scan(&exists)
+	if err != nil {
+		return fmt.Errorf("AdminRepository - %s - r.Pool.QueryRow('user exists'): %w", method, err)
+	}
+	if !exists {
+		return entity.ErrUserNotFound
+	}
+	return entity.ErrAssetNotFound
+}
@@ -2,12 +2,15 @@ package repo
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/Klef99/bhs-task/internal/entity"
 	"github.com/Klef99/bhs-task/internal/usecase"
 	"github.com/Klef99/bhs-task/pkg/postgres"
 	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
 )
 
 // UserRepository -.
@@ -22,52 +25,145 @@ func NewAssetRepository(pg *postgres.Postgres) *AssetRepository {
 	return &AssetRepository{pg}
 }
 
-// Store -.
-func (r *AssetRepository) Store(ctx context.Context, ast entity.Asset) (bool, error) {
+// Store - saves the asset and writes the asset.created event to the outbox, returns the new id.
+func (r *AssetRepository) Store(ctx context.Context, ast entity.Asset) (int64, error) {
+	tx, err := r.Pool.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("AssetRepository - Store - r.Pool.Begin: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
 	sql, args, err := r.Builder.
 		Insert("assets").
-		Columns("name", "description", "price", "owner_id").
-		Values(ast.Name, ast.Description, ast.Price, ast.Owner_id).
+		Columns("name", "description", "price", "owner_id", "status", "sale_mode", "stock", "access_days").
+		Values(ast.Name, ast.Description, ast.Price, ast.Owner_id, ast.Status, ast.SaleMode, ast.Stock, ast.AccessDays).
+		Suffix("RETURNING id").
 		ToSql()
 
 	if err != nil {
-		return false, fmt.Errorf("AssetRepository - Store - r.Builder: %w", err)
+		return 0, fmt.Errorf("AssetRepository - Store - r.Builder: %w", err)
 	}
 
-	_, err = r.Pool.Exec(ctx, sql, args...)
+	err = tx.QueryRow(ctx, sql, args...).Scan(&ast.Id)
 	if err != nil {
-		return false, fmt.Errorf("AssetRepository - Store - r.Pool.Exec: %w", err)
+		return 0, fmt.Errorf("AssetRepository - Store - tx.QueryRow: %w", err)
+	}
+	err = writeOutbox(ctx, tx, r.Builder, entity.AggregateAsset, ast.Id, entity.Event{
+		Type: entity.EventAssetCreated, OccurredAt: time.Now().UTC(), Data: ast, UserIds: []int64{ast.Owner_id},
+	})
+	if err != nil {
+		return 0, fmt.Errorf("AssetRepository - Store - writeOutbox: %w", err)
+	}
+	if ast.Status == entity.AssetStatusPublished {
+		err = notifyMarket(ctx, tx, entity.MarketEvent{Type: entity.MarketAssetCreated, AssetId: ast.Id, Status: ast.Status})
+		if err != nil {
+			return 0, fmt.Errorf("AssetRepository - Store - notifyMarket: %w", err)
+		}
+	}
+	err = tx.Commit(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("AssetRepository - Store - tx.Commit: %w", err)
 	}
 
-	return true, nil
+	return ast.Id, nil
 }
 
-// Erase -.
+// Erase - soft deletes the asset: it leaves the market and the owner's listing,
+// but stays available to users who have already bought it.
 func (r *AssetRepository) Erase(ctx context.Context, user entity.User, id int64) (bool, error) {
+	tx, err := r.Pool.Begin(ctx)
+	if err != nil {
+		return false, fmt.Errorf("AssetRepository - Erase - r.Pool.Begin: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
 	sql, args, err := r.Builder.
-		Delete("assets").
-		Where(sq.Eq{"id": id, "owner_id": user.Id}).
+		Update("assets").
+		Set("deleted_at", sq.Expr("now()")).
+		Where(sq.Eq{"id": id, "owner_id": user.Id, "deleted_at": nil}).
+		SuffixExpr(sq.ConcatExpr("RETURNING ", forSale())).
 		ToSql()
 
 	if err != nil {
 		return false, fmt.Errorf("AssetRepository - Erase - r.Builder: %w", err)
 	}
 
-	res, err := r.Pool.Exec(ctx, sql, args...)
+	var wasListed bool
+	err = tx.QueryRow(ctx, sql, args...).Scan(&wasListed)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("AssetRepository - Erase - row.Scan: %w", err)
+	}
+	if wasListed {
+		err = notifyMarket(ctx, tx, entity.MarketEvent{Type: entity.MarketAssetDeleted, AssetId: id})
+		if err != nil {
+			return false, fmt.Errorf("AssetRepository - Erase - notifyMarket: %w", err)
+		}
+	}
+	err = tx.Commit(ctx)
+	if err != nil {
+		return false, fmt.Errorf("AssetRepository - Erase - tx.Commit: %w", err)
+	}
+	return true, nil
+}
+
+// Purge - hard deletes the asset, access rows and auctions are removed by the foreign key cascade.
+// Its purchases are kept with the asset_id set to NULL. Amounts held by bids on its open auction are returned to the bidders first.
+func (r *AssetRepository) Purge(ctx context.Context, id int64) (bool, error) {
+	tx, err := r.Pool.Begin(ctx)
 	if err != nil {
-		return false, fmt.Errorf("AssetRepository - Erase - r.Pool.Exec: %w", err)
+		return false, fmt.Errorf("AssetRepository - Purge - r.Pool.Begin: %w", err)
 	}
+	defer tx.Rollback(ctx)
 
-	rowsAffected := res.RowsAffected()
-	return rowsAffected > 0, nil
+	_, err = tx.Exec(ctx, `UPDATE users SET balance = balance + bids.amount
+		FROM bids JOIN auctions ON auctions.id = bids.auction_id
+		WHERE users.id = bids.bidder_id AND auctions.asset_id = $1 AND bids.status = $2`,
+		id, entity.BidStatusHeld)
+	if err != nil {
+		return false, fmt.Errorf("AssetRepository - Purge - tx.Exec('refund bids'): %w", err)
+	}
+
+	sql, args, err := r.Builder.
+		Delete("assets").
+		Where(sq.Eq{"id": id}).
+		SuffixExpr(sq.ConcatExpr("RETURNING ", listed())).
+		ToSql()
+
+	if err != nil {
+		return false, fmt.Errorf("AssetRepository - Purge - r.Builder: %w", err)
+	}
+
+	var wasListed bool
+	err = tx.QueryRow(ctx, sql, args...).Scan(&wasListed)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("AssetRepository - Purge - row.Scan: %w", err)
+	}
+	if wasListed {
+		err = notifyMarket(ctx, tx, entity.MarketEvent{Type: entity.MarketAssetDeleted, AssetId: id})
+		if err != nil {
+			return false, fmt.Errorf("AssetRepository - Purge - notifyMarket: %w", err)
+		}
+	}
+	err = tx.Commit(ctx)
+	if err != nil {
+		return false, fmt.Errorf("AssetRepository - Purge - tx.Commit: %w", err)
+	}
+
+	return true, nil
 }
 
 // List -.
 func (r *AssetRepository) UserAssetsList(ctx context.Context, user entity.User) ([]entity.Asset, error) {
 	sql, args, err := r.Builder.
-		Select("*").
+		Select("id, name, description, price, owner_id, status, sale_mode, stock, sold, access_days").
 		From("assets").
-		Where(sq.Eq{"owner_id": user.Id}).
+		Where(sq.Eq{"owner_id": user.Id, "deleted_at": nil}).
 		ToSql()
 	if err != nil {
 		return nil, fmt.Errorf("AssetRepository - List - r.Builder: %w", err)
@@ -80,19 +176,20 @@ func (r *AssetRepository) UserAssetsList(ctx context.Context, user entity.User)
 	assets := make([]entity.Asset, 0)
 	for rows.Next() {
 		var ast entity.Asset
-		err := rows.Scan(&ast.Id, &ast.Name, &ast.Description, &ast.Price, &ast.Owner_id)
+		err := rows.Scan(&ast.Id, &ast.Name, &ast.Description, &ast.Price, &ast.Owner_id, &ast.Status, &ast.SaleMode, &ast.Stock, &ast.Sold, &ast.AccessDays)
 		if err != nil {
 			return nil, fmt.Errorf("AssetRepository - List - rows.Scan: %w", err)
 		}
+		ast.SetRemaining()
 		assets = append(assets, ast)
 	}
 	return assets, nil
 }
 
 func (r *AssetRepository) GetOtherUsersAssets(ctx context.Context, user entity.User) ([]entity.Asset, error) {
-	sql, args, err := r.Builder.Select("id, name, description, price").
+	sql, args, err := r.Builder.Select("id, name, description, price, owner_id, status, sale_mode, stock, sold, access_days", _rating).
 		From("assets").
-		Where(sq.NotEq{"owner_id": user.Id}).
+		Where(onMarket(user.Id)).
 		ToSql()
 	if err != nil {
 		return nil, fmt.Errorf("AssetRepository - GetOtherUserAssets - r.Builder: %w", err)
@@ -104,81 +201,147 @@ func (r *AssetRepository) GetOtherUsersAssets(ctx context.Context, user entity.U
 	defer rows.Close()
 	assets := make([]entity.Asset, 0)
 	for rows.Next() {
-		ast := entity.Asset{Owner_id: user.Id}
-		err := rows.Scan(&ast.Id, &ast.Name, &ast.Description, &ast.Price)
+		ast := entity.Asset{}
+		err := rows.Scan(&ast.Id, &ast.Name, &ast.Description, &ast.Price, &ast.Owner_id, &ast.Status, &ast.SaleMode, &ast.Stock, &ast.Sold, &ast.AccessDays, &ast.Rating, &ast.RatingCount)
 		if err != nil {
 			return nil, fmt.Errorf("AssetRepository - GetOtherUserAssets - rows.Scan: %w", err)
 		}
+		ast.SetRemaining()
 		assets = append(assets, ast)
 	}
 	return assets, nil
 }
 
-func (r *AssetRepository) BuyAsset(ctx context.Context, user entity.User, id int64) (bool, error) {
+// _rating - average rating of the asset, NULL without reviews, and the number of reviews.
+const _rating = "rating_sum::float8 / NULLIF(rating_count, 0), rating_count"
+
+// onMarket - assets listed on the market for the user, owned by someone else.
+func onMarket(userId int64) sq.And {
+	return sq.And{
+		sq.NotEq{"assets.owner_id": userId},
+		listed(),
+	}
+}
+
+// listed - assets on the market: published, not deleted and in stock. Only changes of listed assets
+// are pushed to the market feed, the others aren't public.
+func listed() sq.And {
+	return sq.And{
+		sq.Eq{"assets.deleted_at": nil},
+		forSale(),
+	}
+}
+
+// forSale - published and in stock, listed unless deleted.
+func forSale() sq.And {
+	return sq.And{
+		sq.Eq{"assets.status": entity.AssetStatusPublished},
+		sq.Or{sq.Eq{"assets.stock": nil}, sq.Expr("assets.sold < assets.stock")},
+	}
+}
+
+// BuyAsset - promoCode is optional, the code is locked before the asset as in a cart checkout.
+func (r *AssetRepository) BuyAsset(ctx context.Context, user entity.User, id int64, promoCode string) (entity.Purchase, error) {
 	tx, err := r.Pool.Begin(ctx)
 	if err != nil {
-		return false, fmt.Errorf("AssetRepository - BuyAsset - r.Pool.Begin: %w", err)
+		return entity.Purchase{}, fmt.Errorf("AssetRepository - BuyAsset - r.Pool.Begin: %w", err)
 	}
-	sql, args, err := r.Builder.Select("price, owner_id").From("assets").Where(sq.Eq{"id": id}).Suffix("FOR UPDATE").ToSql()
+	opts := purchaseOptions{}
+	if promoCode != "" {
+		opts.promo, err = lockPromoCode(ctx, tx, r.Builder, promoCode)
+		if err != nil {
+			tx.Rollback(ctx)
+			return entity.Purchase{}, fmt.Errorf("AssetRepository - BuyAsset - lockPromoCode: %w", err)
+		}
+	}
+	p, err := purchase(ctx, tx, r.Builder, user, id, opts)
 	if err != nil {
 		tx.Rollback(ctx)
-		return false, fmt.Errorf("AssetRepository - BuyAsset - r.Builder.Select('price'): %w", err)
+		return entity.Purchase{}, fmt.Errorf("AssetRepository - BuyAsset - purchase: %w", err)
 	}
-	row := tx.QueryRow(ctx, sql, args...)
-	var price float64
-	var owner_id int64
-	err = row.Scan(&price, &owner_id)
+	err = tx.Commit(ctx)
 	if err != nil {
 		tx.Rollback(ctx)
-		return false, fmt.Errorf("AssetRepository - BuyAsset - row.Scan: %w", err)
+		return entity.Purchase{}, fmt.Errorf("AssetRepository - BuyAsset - tx.Commit: %w", err)
 	}
-	if owner_id == user.Id {
-		return false, fmt.Errorf("AssetRepository - BuyAsset - user can't buy their own asset") // This validation is here, as we get information about the owner of the asset in the transaction.
+	return p, nil
+}
+
+// Gift - the user buys the asset for the recipient through the regular purchase path.
+func (r *AssetRepository) Gift(ctx context.Context, user entity.User, id int64, recipient string, message string) (entity.Purchase, error) {
+	tx, err := r.Pool.Begin(ctx)
+	if err != nil {
+		return entity.Purchase{}, fmt.Errorf("AssetRepository - Gift - r.Pool.Begin: %w", err)
 	}
-	sql, args, err = r.Builder.
-		Update("users").
-		Set("balance", sq.Expr("balance - ?", price)).
-		Where(sq.Eq{"id": user.Id}).
+	defer tx.Rollback(ctx)
+
+	sql, args, err := r.Builder.
+		Select("id").
+		From("users").
+		Where(sq.Eq{"username": recipient}).
 		ToSql()
 	if err != nil {
-		tx.Rollback(ctx)
-		return false, fmt.Errorf("AssetRepository - BuyAsset - r.Builder.Update('users'): %w", err)
+		return entity.Purchase{}, fmt.Errorf("AssetRepository - Gift - r.Builder.Select('users'): %w", err)
+	}
+	var recipientId int64
+	err = tx.QueryRow(ctx, sql, args...).Scan(&recipientId)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return entity.Purchase{}, fmt.Errorf("AssetRepository - Gift - row.Scan: %w", entity.ErrRecipientNotFound)
 	}
-	_, err = tx.Exec(ctx, sql, args...)
 	if err != nil {
-		tx.Rollback(ctx)
-		return false, fmt.Errorf("AssetRepository - BuyAsset - tx.Exec: %w", err)
+		return entity.Purchase{}, fmt.Errorf("AssetRepository - Gift - row.Scan: %w", err)
 	}
+	if recipientId == user.Id {
+		return entity.Purchase{}, fmt.Errorf("AssetRepository - Gift - user can't gift an asset to themselves")
+	}
+	p, err := purchase(ctx, tx, r.Builder, user, id, purchaseOptions{recipient: &recipientId, message: message})
+	if err != nil {
+		return entity.Purchase{}, fmt.Errorf("AssetRepository - Gift - purchase: %w", err)
+	}
+	err = tx.Commit(ctx)
+	if err != nil {
+		return entity.Purchase{}, fmt.Errorf("AssetRepository - Gift - tx.Commit: %w", err)
+	}
+	return p, nil
+}
 
-	sql, args, err = r.Builder.
-		Insert("access_assets").
-		Columns("asset_id", "user_id").
-		Values(id, user.Id).
-		Suffix("on conflict (asset_id, user_id) do nothing").
+// GetPurchaseHistory - purchases the user paid for or received as a gift, newest first.
+func (r *AssetRepository) GetPurchaseHistory(ctx context.Context, user entity.User) ([]entity.Purchase, error) {
+	sql, args, err := r.Builder.
+		Select("id, COALESCE(asset_id, 0), buyer_id, seller_id, price, sale_mode, edition, receipt_id, recipient_id, COALESCE(gift_message, ''), purchased_at").
+		Column("COALESCE((SELECT discount FROM promo_redemptions WHERE promo_redemptions.purchase_id = purchases.id), 0)").
+		From("purchases").
+		Where(sq.Or{sq.Eq{"buyer_id": user.Id}, sq.Eq{"recipient_id": user.Id}}).
+		OrderBy("purchased_at DESC", "id DESC").
 		ToSql()
 	if err != nil {
-		tx.Rollback(ctx)
-		return false, fmt.Errorf("AssetRepository - BuyAsset - r.Builder.Insert('acces_asset'): %w", err)
+		return nil, fmt.Errorf("AssetRepository - GetPurchaseHistory - r.Builder: %w", err)
 	}
-	res, err := tx.Exec(ctx, sql, args...)
-	if res.RowsAffected() == 0 || err != nil {
-		tx.Rollback(ctx)
-		return false, fmt.Errorf("AssetRepository - BuyAsset - tx.Exec: %w", err)
-	}
-	err = tx.Commit(ctx)
+	rows, err := r.Pool.Query(ctx, sql, args...)
 	if err != nil {
-		tx.Rollback(ctx)
-		return false, fmt.Errorf("AssetRepository - BuyAsset - tx.Commit: %w", err)
+		return nil, fmt.Errorf("AssetRepository - GetPurchaseHistory - r.Pool.Query: %w", err)
 	}
-	return true, nil
+	defer rows.Close()
+	history := make([]entity.Purchase, 0)
+	for rows.Next() {
+		p := entity.Purchase{}
+		err := rows.Scan(&p.Id, &p.AssetId, &p.BuyerId, &p.SellerId, &p.Price, &p.SaleMode, &p.Edition, &p.ReceiptId, &p.RecipientId, &p.GiftMessage, &p.PurchasedAt, &p.Discount)
+		if err != nil {
+			return nil, fmt.Errorf("AssetRepository - GetPurchaseHistory - rows.Scan: %w", err)
+		}
+		history = append(history, p)
+	}
+	return history, nil
 }
 
+// GetPurchasedAssets - assets the user has access to, expired rentals are left out.
 func (r *AssetRepository) GetPurchasedAssets(ctx context.Context, user entity.User) ([]entity.Asset, error) {
 	sql, args, err := r.Builder.
-		Select("id, name, description, price, owner_id").
+		Select("id, name, description, price, owner_id, status, sale_mode, stock, sold, edition, deleted_at, access_days, access_assets.expires_at").
 		From("assets").
 		Join("access_assets ON assets.id = access_assets.asset_id").
 		Where(sq.Eq{"access_assets.user_id": user.Id}).
+		Where(_activeAccess).
 		ToSql()
 	if err != nil {
 		return []entity.Asset{}, fmt.Errorf("AssetRepository - GetPurchasedAssets - r.Builder: %w", err)
@@ -191,33 +354,271 @@ func (r *AssetRepository) GetPurchasedAssets(ctx context.Context, user entity.Us
 	assets := make([]entity.Asset, 0)
 	for rows.Next() {
 		ast := entity.Asset{}
-		err := rows.Scan(&ast.Id, &ast.Name, &ast.Description, &ast.Price, &ast.Owner_id)
+		err := rows.Scan(&ast.Id, &ast.Name, &ast.Description, &ast.Price, &ast.Owner_id, &ast.Status, &ast.SaleMode, &ast.Stock, &ast.Sold, &ast.Edition, &ast.DeletedAt, &ast.AccessDays, &ast.AccessExpiresAt)
 		if err != nil {
 			return []entity.Asset{}, fmt.Errorf("AssetRepository - GetOtherUserAssets - rows.Scan: %w", err)
 		}
+		ast.SetRemaining()
 		assets = append(assets, ast)
 	}
 	return assets, nil
 }
 
-func (r *AssetRepository) GetAssetById(ctx context.Context, id int64) (entity.Asset, error) {
+// GetAssetById - owners see their assets in any status, other users see published ones.
+// Unlisted, archived and deleted assets stay visible to users whose access has not expired.
+func (r *AssetRepository) GetAssetById(ctx context.Context, user entity.User, id int64) (entity.Asset, error) {
 	sql, args, err := r.Builder.
-		Select("name, description, price, owner_id").
+		Select("name, description, price, owner_id, status, sale_mode, stock, sold, deleted_at, access_days", _rating).
 		From("assets").
 		Where(sq.Eq{"id": id}).
+		Where(visibleTo(user.Id)).
 		ToSql()
 	if err != nil {
 		return entity.Asset{}, fmt.Errorf("AssetRepository - GetAssetById - r.Builder: %w", err)
 	}
 	row := r.Pool.QueryRow(ctx, sql, args...)
 	ast := entity.Asset{Id: id}
-	err = row.Scan(&ast.Name, &ast.Description, &ast.Price, &ast.Owner_id)
+	err = row.Scan(&ast.Name, &ast.Description, &ast.Price, &ast.Owner_id, &ast.Status, &ast.SaleMode, &ast.Stock, &ast.Sold, &ast.DeletedAt, &ast.AccessDays, &ast.Rating, &ast.RatingCount)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return entity.Asset{}, fmt.Errorf("AssetRepository - GetAssetById - row.Scan: %w", entity.ErrAssetNotFound)
+	}
 	if err != nil {
 		return entity.Asset{}, fmt.Errorf("AssetRepository - GetAssetById - row.Scan: %w", err)
 	}
+	ast.SetRemaining()
 	return ast, nil
 }
 
+// visibleTo - assets the user can open, see GetAssetById.
+func visibleTo(userId int64) sq.Or {
+	return sq.Or{
+		sq.And{
+			sq.Eq{"deleted_at": nil},
+			sq.Or{sq.Eq{"owner_id": userId}, sq.Eq{"status": entity.AssetStatusPublished}},
+		},
+		sq.And{
+			sq.Or{
+				sq.Eq{"status": []entity.AssetStatus{entity.AssetStatusUnlisted, entity.AssetStatusArchived}},
+				sq.NotEq{"deleted_at": nil},
+			},
+			sq.Expr("EXISTS (SELECT 1 FROM access_assets WHERE access_assets.asset_id = assets.id AND access_assets.user_id = ? AND "+_activeAccess+")", userId),
+		},
+	}
+}
+
+// checkVisible - fails with entity.ErrAssetNotFound unless the user can see the asset, see GetAssetById.
+func checkVisible(ctx context.Context, pg *postgres.Postgres, userId, assetId int64) error {
+	sql, args, err := pg.Builder.
+		Select("1").
+		Prefix("SELECT EXISTS (").
+		From("assets").
+		Where(sq.Eq{"id": assetId}).
+		Where(visibleTo(userId)).
+		Suffix(")").
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("checkVisible - pg.Builder: %w", err)
+	}
+	var visible bool
+	err = pg.Pool.QueryRow(ctx, sql, args...).Scan(&visible)
+	if err != nil {
+		return fmt.Errorf("checkVisible - row.Scan: %w", err)
+	}
+	if !visible {
+		return entity.ErrAssetNotFound
+	}
+	return nil
+}
+
+// GetAssetsByIds - GetAssetById for several assets in one query. Assets that don't exist or the user
+// can't see are left out, the order is not kept.
+func (r *AssetRepository) GetAssetsByIds(ctx context.Context, user entity.User, ids []int64) ([]entity.Asset, error) {
+	sql, args, err := r.Builder.
+		Select("id, name, description, price, owner_id, status, sale_mode, stock, sold, deleted_at, access_days", _rating).
+		From("assets").
+		Where("id = ANY(?)", ids).
+		Where(visibleTo(user.Id)).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("AssetRepository - GetAssetsByIds - r.Builder: %w", err)
+	}
+	rows, err := r.Pool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("AssetRepository - GetAssetsByIds - r.Pool.Query: %w", err)
+	}
+	defer rows.Close()
+	assets := make([]entity.Asset, 0, len(ids))
+	for rows.Next() {
+		ast := entity.Asset{}
+		err := rows.Scan(&ast.Id, &ast.Name, &ast.Description, &ast.Price, &ast.Owner_id, &ast.Status, &ast.SaleMode, &ast.Stock, &ast.Sold, &ast.DeletedAt, &ast.AccessDays, &ast.Rating, &ast.RatingCount)
+		if err != nil {
+			return nil, fmt.Errorf("AssetRepository - GetAssetsByIds - rows.Scan: %w", err)
+		}
+		ast.SetRemaining()
+		assets = append(assets, ast)
+	}
+	return assets, nil
+}
+
+// UpdateStatus - moves the owner's asset to a new status, returns false if the asset is not found.
+func (r *AssetRepository) UpdateStatus(ctx context.Context, user entity.User, id int64, status entity.AssetStatus) (bool, error) {
+	tx, err := r.Pool.Begin(ctx)
+	if err != nil {
+		return false, fmt.Errorf("AssetRepository - UpdateStatus - r.Pool.Begin: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	sql, args, err := r.Builder.
+		Select("status").
+		Column(listed()).
+		From("assets").
+		Where(sq.Eq{"id": id, "owner_id": user.Id, "deleted_at": nil}).
+		Suffix("FOR UPDATE").
+		ToSql()
+	if err != nil {
+		return false, fmt.Errorf("AssetRepository - UpdateStatus - r.Builder.Select: %w", err)
+	}
+	var current entity.AssetStatus
+	var wasListed bool
+	err = tx.QueryRow(ctx, sql, args...).Scan(&current, &wasListed)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("AssetRepository - UpdateStatus - row.Scan: %w", err)
+	}
+	if !current.CanTransitionTo(status) {
+		return false, fmt.Errorf("AssetRepository - UpdateStatus - %s to %s: %w", current, status, entity.ErrInvalidStatusTransition)
+	}
+
+	sql, args, err = r.Builder.
+		Update("assets").
+		Set("status", status).
+		Where(sq.Eq{"id": id}).
+		SuffixExpr(sq.ConcatExpr("RETURNING ", listed())).
+		ToSql()
+	if err != nil {
+		return false, fmt.Errorf("AssetRepository - UpdateStatus - r.Builder.Update: %w", err)
+	}
+	var isListed bool
+	err = tx.QueryRow(ctx, sql, args...).Scan(&isListed)
+	if err != nil {
+		return false, fmt.Errorf("AssetRepository - UpdateStatus - row.Scan: %w", err)
+	}
+	// The feed learns of an asset entering or leaving the market, changes between the other statuses aren't public.
+	if wasListed || isListed {
+		err = notifyMarket(ctx, tx, entity.MarketEvent{Type: entity.MarketAssetUpdated, AssetId: id, Status: status})
+		if err != nil {
+			return false, fmt.Errorf("AssetRepository - UpdateStatus - notifyMarket: %w", err)
+		}
+	}
+	err = tx.Commit(ctx)
+	if err != nil {
+		return false, fmt.Errorf("AssetRepository - UpdateStatus - tx.Commit: %w", err)
+	}
+	return true, nil
+}
+
+// UpdatePrice - sets the price of the owner's asset, returns false if the asset is not found.
+// A price decrease notifies every user who has the asset on their wishlist, the market is notified only if the asset is listed.
+func (r *AssetRepository) UpdatePrice(ctx context.Context, user entity.User, id int64, price float64) (bool, error) {
+	tx, err := r.Pool.Begin(ctx)
+	if err != nil {
+		return false, fmt.Errorf("AssetRepository - UpdatePrice - r.Pool.Begin: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	sql, args, err := r.Builder.
+		Select("price").
+		Column(listed()).
+		From("assets").
+		Where(sq.Eq{"id": id, "owner_id": user.Id, "deleted_at": nil}).
+		Suffix("FOR UPDATE").
+		ToSql()
+	if err != nil {
+		return false, fmt.Errorf("AssetRepository - UpdatePrice - r.Builder.Select: %w", err)
+	}
+	var current float64
+	var onSale bool
+	err = tx.QueryRow(ctx, sql, args...).Scan(&current, &onSale)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("AssetRepository - UpdatePrice - row.Scan: %w", err)
+	}
+
+	sql, args, err = r.Builder.
+		Update("assets").
+		Set("price", price).
+		Where(sq.Eq{"id": id}).
+		ToSql()
+	if err != nil {
+		return false, fmt.Errorf("AssetRepository - UpdatePrice - r.Builder.Update: %w", err)
+	}
+	_, err = tx.Exec(ctx, sql, args...)
+	if err != nil {
+		return false, fmt.Errorf("AssetRepository - UpdatePrice - tx.Exec('assets'): %w", err)
+	}
+	if onSale {
+		err = notifyMarket(ctx, tx, entity.MarketEvent{Type: entity.MarketAssetUpdated, AssetId: id, Price: &price})
+		if err != nil {
+			return false, fmt.Errorf("AssetRepository - UpdatePrice - notifyMarket: %w", err)
+		}
+	}
+	if price < current {
+		sql, args, err = r.Builder.
+			Insert("notifications").
+			Columns("user_id", "kind", "asset_id", "old_price", "new_price").
+			Select(r.Builder.
+				Select("user_id").
+				Column("?, asset_id, ?::numeric, ?::numeric", entity.NotificationKindPriceDrop, current, price).
+				From("wishlist_items").
+				Where(sq.Eq{"asset_id": id})).
+			ToSql()
+		if err != nil {
+			return false, fmt.Errorf("AssetRepository - UpdatePrice - r.Builder.Insert('notifications'): %w", err)
+		}
+		_, err = tx.Exec(ctx, sql, args...)
+		if err != nil {
+			return false, fmt.Errorf("AssetRepository - UpdatePrice - tx.Exec('notifications'): %w", err)
+		}
+	}
+	err = tx.Commit(ctx)
+	if err != nil {
+		return false, fmt.Errorf("AssetRepository - UpdatePrice - tx.Commit: %w", err)
+	}
+	return true, nil
+}
+
+// GetProvenance - ownership transfers of the asset, oldest first. Gifted transfers name the recipient as the new owner.
+func (r *AssetRepository) GetProvenance(ctx context.Context, id int64) ([]entity.Purchase, error) {
+	sql, args, err := r.Builder.
+		Select("id, asset_id, buyer_id, seller_id, price, sale_mode, edition, recipient_id, purchased_at").
+		From("purchases").
+		Where(sq.Eq{"asset_id": id, "sale_mode": entity.SaleModeTransfer}).
+		OrderBy("purchased_at", "id").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("AssetRepository - GetProvenance - r.Builder: %w", err)
+	}
+	rows, err := r.Pool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("AssetRepository - GetProvenance - r.Pool.Query: %w", err)
+	}
+	defer rows.Close()
+	transfers := make([]entity.Purchase, 0)
+	for rows.Next() {
+		p := entity.Purchase{}
+		err := rows.Scan(&p.Id, &p.AssetId, &p.BuyerId, &p.SellerId, &p.Price, &p.SaleMode, &p.Edition, &p.RecipientId, &p.PurchasedAt)
+		if err != nil {
+			return nil, fmt.Errorf("AssetRepository - GetProvenance - rows.Scan: %w", err)
+		}
+		transfers = append(transfers, p)
+	}
+	return transfers, nil
+}
+
 // func (r *AssetRepository) UpdateAssetById(ctx context.Context, asset entity.Asset) (entity.Asset, error) {
 // 	sql, args, err := r.Builder.
 // 		Update("assets").
@@ -226,3 +627,101 @@ func (r *AssetRepository) GetAssetById(ctx context.Context, id int64) (entity.As
 // 		Suffix("RETURNING balance").
 // 		ToSql()
 // }
+
+// RenewAccess - charges the user the current price for another access period of a rented asset.
+// The period is added to the end of the current one, or starts now if it has already lapsed.
+// Access removed by the sweeper can't be renewed, the asset has to be bought again.
+func (r *AssetRepository) RenewAccess(ctx context.Context, user entity.User, id int64) (entity.Purchase, error) {
+	tx, err := r.Pool.Begin(ctx)
+	if err != nil {
+		return entity.Purchase{}, fmt.Errorf("AssetRepository - RenewAccess - r.Pool.Begin: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	sql, args, err := r.Builder.
+		Select("price, owner_id, status, deleted_at, access_days").
+		From("assets").
+		Where(sq.Eq{"id": id}).
+		Suffix("FOR UPDATE").
+		ToSql()
+	if err != nil {
+		return entity.Purchase{}, fmt.Errorf("AssetRepository - RenewAccess - r.Builder.Select('assets'): %w", err)
+	}
+	p := entity.Purchase{AssetId: id, BuyerId: user.Id, SaleMode: entity.SaleModeLicense}
+	var status entity.AssetStatus
+	var deletedAt *time.Time
+	var accessDays *int64
+	err = tx.QueryRow(ctx, sql, args...).Scan(&p.Price, &p.SellerId, &status, &deletedAt, &accessDays)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return entity.Purchase{}, fmt.Errorf("AssetRepository - RenewAccess - row.Scan: %w", entity.ErrAssetNotFound)
+	}
+	if err != nil {
+		return entity.Purchase{}, fmt.Errorf("AssetRepository - RenewAccess - row.Scan: %w", err)
+	}
+	if accessDays == nil {
+		return entity.Purchase{}, fmt.Errorf("AssetRepository - RenewAccess: %w", entity.ErrAssetNotRenewable)
+	}
+	if deletedAt != nil || status == entity.AssetStatusArchived {
+		return entity.Purchase{}, fmt.Errorf("AssetRepository - RenewAccess - status %s, deleted %t: %w", status, deletedAt != nil, entity.ErrAssetNotAvailable)
+	}
+
+	err = charge(ctx, tx, r.Builder, user.Id, p.Price)
+	if err != nil {
+		return entity.Purchase{}, fmt.Errorf("AssetRepository - RenewAccess - charge: %w", err)
+	}
+	sql, args, err = r.Builder.
+		Update("access_assets").
+		Set("expires_at", sq.Expr("GREATEST(expires_at, now()) + make_interval(days => ?)", *accessDays)).
+		Where(sq.Eq{"asset_id": id, "user_id": user.Id}).
+		Suffix("RETURNING edition, expires_at").
+		ToSql()
+	if err != nil {
+		return entity.Purchase{}, fmt.Errorf("AssetRepository - RenewAccess - r.Builder.Update: %w", err)
+	}
+	err = tx.QueryRow(ctx, sql, args...).Scan(&p.Edition, &p.AccessExpiresAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return entity.Purchase{}, fmt.Errorf("AssetRepository - RenewAccess - row.Scan: %w", entity.ErrAccessNotFound)
+	}
+	if err != nil {
+		return entity.Purchase{}, fmt.Errorf("AssetRepository - RenewAccess - row.Scan: %w", err)
+	}
+
+	sql, args, err = r.Builder.
+		Insert("purchases").
+		Columns("asset_id", "buyer_id", "seller_id", "price", "sale_mode", "edition", "renewal").
+		Values(p.AssetId, p.BuyerId, p.SellerId, p.Price, p.SaleMode, p.Edition, true).
+		Suffix("RETURNING id, purchased_at").
+		ToSql()
+	if err != nil {
+		return entity.Purchase{}, fmt.Errorf("AssetRepository - RenewAccess - r.Builder.Insert: %w", err)
+	}
+	err = tx.QueryRow(ctx, sql, args...).Scan(&p.Id, &p.PurchasedAt)
+	if err != nil {
+		return entity.Purchase{}, fmt.Errorf("AssetRepository - RenewAccess - tx.QueryRow('purchases'): %w", err)
+	}
+	err = writeOutbox(ctx, tx, r.Builder, entity.AggregateAsset, p.AssetId, purchasedEvent(p))
+	if err != nil {
+		return entity.Purchase{}, fmt.Errorf("AssetRepository - RenewAccess - writeOutbox: %w", err)
+	}
+	err = tx.Commit(ctx)
+	if err != nil {
+		return entity.Purchase{}, fmt.Errorf("AssetRepository - RenewAccess - tx.Commit: %w", err)
+	}
+	return p, nil
+}
+
+// DeleteExpiredAccess - removes access rows whose period has ended, returns how many were removed.
+func (r *AssetRepository) DeleteExpiredAccess(ctx context.Context) (int64, error) {
+	sql, args, err := r.Builder.
+		Delete("access_assets").
+		Where("expires_at <= now()").
+		ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("AssetRepository - DeleteExpiredAccess - r.Builder: %w", err)
+	}
+	res, err := r.Pool.Exec(ctx, sql, args...)
+	if err != nil {
+		return 0, fmt.Errorf("AssetRepository - DeleteExpiredAccess - r.Pool.Exec: %w", err)
+	}
+	return res.RowsAffected(), nil
+}
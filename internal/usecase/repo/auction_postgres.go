@@ -0,0 +1,389 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Klef99/bhs-task/internal/entity"
+	"github.com/Klef99/bhs-task/internal/usecase"
+	"github.com/Klef99/bhs-task/pkg/postgres"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+)
+
+// AuctionRepository -.
+type AuctionRepository struct {
+	*postgres.Postgres
+}
+
+var _ usecase.AuctionRepository = (*AuctionRepository)(nil)
+
+// New -.
+func NewAuctionRepository(pg *postgres.Postgres) *AuctionRepository {
+	return &AuctionRepository{pg}
+}
+
+const _auctionColumns = "auctions.id, auctions.asset_id, auctions.seller_id, auctions.reserve_price, auctions.end_at, auctions.status, auctions.created_at, auctions.settled_at"
+
+func scanAuction(row pgx.Row) (entity.Auction, error) {
+	a := entity.Auction{}
+	err := row.Scan(&a.Id, &a.AssetId, &a.SellerId, &a.ReservePrice, &a.EndAt, &a.Status, &a.CreatedAt, &a.SettledAt, &a.HighestBid, &a.HighestBidderId)
+	return a, err
+}
+
+// selectAuction - auctions joined with their held (highest) bid.
+func (r *AuctionRepository) selectAuction() sq.SelectBuilder {
+	return r.Builder.
+		Select(_auctionColumns, "bids.amount", "bids.bidder_id").
+		From("auctions").
+		LeftJoin("bids ON bids.auction_id = auctions.id AND bids.status = ?", entity.BidStatusHeld)
+}
+
+// Open - starts an auction for a published asset of the seller.
+func (r *AuctionRepository) Open(ctx context.Context, auction entity.Auction) (entity.Auction, error) {
+	tx, err := r.Pool.Begin(ctx)
+	if err != nil {
+		return entity.Auction{}, fmt.Errorf("AuctionRepository - Open - r.Pool.Begin: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	sql, args, err := r.Builder.
+		Select("status").
+		Column("EXISTS (SELECT 1 FROM auctions WHERE auctions.asset_id = assets.id AND auctions.status = ?)", entity.AuctionStatusOpen).
+		From("assets").
+		Where(sq.Eq{"id": auction.AssetId, "owner_id": auction.SellerId, "deleted_at": nil}).
+		Suffix("FOR UPDATE").
+		ToSql()
+	if err != nil {
+		return entity.Auction{}, fmt.Errorf("AuctionRepository - Open - r.Builder.Select('assets'): %w", err)
+	}
+	var status entity.AssetStatus
+	var onAuction bool
+	err = tx.QueryRow(ctx, sql, args...).Scan(&status, &onAuction)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return entity.Auction{}, fmt.Errorf("AuctionRepository - Open - row.Scan: %w", entity.ErrAssetNotFound)
+	}
+	if err != nil {
+		return entity.Auction{}, fmt.Errorf("AuctionRepository - Open - row.Scan: %w", err)
+	}
+	if status != entity.AssetStatusPublished {
+		return entity.Auction{}, fmt.Errorf("AuctionRepository - Open - status %s: %w", status, entity.ErrAssetNotAvailable)
+	}
+	if onAuction {
+		return entity.Auction{}, fmt.Errorf("AuctionRepository - Open: %w", entity.ErrAuctionExists)
+	}
+
+	sql, args, err = r.Builder.
+		Insert("auctions").
+		Columns("asset_id", "seller_id", "reserve_price", "end_at").
+		Values(auction.AssetId, auction.SellerId, auction.ReservePrice, auction.EndAt).
+		Suffix("RETURNING id, status, created_at").
+		ToSql()
+	if err != nil {
+		return entity.Auction{}, fmt.Errorf("AuctionRepository - Open - r.Builder.Insert: %w", err)
+	}
+	err = tx.QueryRow(ctx, sql, args...).Scan(&auction.Id, &auction.Status, &auction.CreatedAt)
+	if err != nil {
+		return entity.Auction{}, fmt.Errorf("AuctionRepository - Open - tx.QueryRow: %w", err)
+	}
+	err = tx.Commit(ctx)
+	if err != nil {
+		return entity.Auction{}, fmt.Errorf("AuctionRepository - Open - tx.Commit: %w", err)
+	}
+	return auction, nil
+}
+
+// GetLatestByAsset - the most recent auction of the asset, fails with entity.ErrAssetNotFound if the user can't see the asset.
+func (r *AuctionRepository) GetLatestByAsset(ctx context.Context, user entity.User, assetId int64) (entity.Auction, error) {
+	err := checkVisible(ctx, r.Postgres, user.Id, assetId)
+	if err != nil {
+		return entity.Auction{}, fmt.Errorf("AuctionRepository - GetLatestByAsset - checkVisible: %w", err)
+	}
+	sql, args, err := r.selectAuction().
+		Where(sq.Eq{"auctions.asset_id": assetId}).
+		OrderBy("auctions.id DESC").
+		Limit(1).
+		ToSql()
+	if err != nil {
+		return entity.Auction{}, fmt.Errorf("AuctionRepository - GetLatestByAsset - r.Builder: %w", err)
+	}
+	a, err := scanAuction(r.Pool.QueryRow(ctx, sql, args...))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return entity.Auction{}, fmt.Errorf("AuctionRepository - GetLatestByAsset - row.Scan: %w", entity.ErrAuctionNotFound)
+	}
+	if err != nil {
+		return entity.Auction{}, fmt.Errorf("AuctionRepository - GetLatestByAsset - row.Scan: %w", err)
+	}
+	return a, nil
+}
+
+// PlaceBid - holds amount from the bidder's balance and releases the previous highest bid.
+func (r *AuctionRepository) PlaceBid(ctx context.Context, user entity.User, assetId int64, amount float64) (entity.Bid, error) {
+	tx, err := r.Pool.Begin(ctx)
+	if err != nil {
+		return entity.Bid{}, fmt.Errorf("AuctionRepository - PlaceBid - r.Pool.Begin: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	sql, args, err := r.Builder.
+		Select("id, seller_id, end_at > now()").
+		From("auctions").
+		Where(sq.Eq{"asset_id": assetId, "status": entity.AuctionStatusOpen}).
+		Suffix("FOR UPDATE").
+		ToSql()
+	if err != nil {
+		return entity.Bid{}, fmt.Errorf("AuctionRepository - PlaceBid - r.Builder.Select('auctions'): %w", err)
+	}
+	var auctionId, sellerId int64
+	var running bool
+	err = tx.QueryRow(ctx, sql, args...).Scan(&auctionId, &sellerId, &running)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return entity.Bid{}, fmt.Errorf("AuctionRepository - PlaceBid - row.Scan: %w", entity.ErrAuctionNotFound)
+	}
+	if err != nil {
+		return entity.Bid{}, fmt.Errorf("AuctionRepository - PlaceBid - row.Scan: %w", err)
+	}
+	if !running {
+		return entity.Bid{}, fmt.Errorf("AuctionRepository - PlaceBid: %w", entity.ErrAuctionClosed)
+	}
+	if sellerId == user.Id {
+		return entity.Bid{}, fmt.Errorf("AuctionRepository - PlaceBid - user can't bid on their own auction")
+	}
+
+	sql, args, err = r.Builder.
+		Select("id, bidder_id, amount").
+		From("bids").
+		Where(sq.Eq{"auction_id": auctionId, "status": entity.BidStatusHeld}).
+		Suffix("FOR UPDATE").
+		ToSql()
+	if err != nil {
+		return entity.Bid{}, fmt.Errorf("AuctionRepository - PlaceBid - r.Builder.Select('bids'): %w", err)
+	}
+	var prev entity.Bid
+	err = tx.QueryRow(ctx, sql, args...).Scan(&prev.Id, &prev.BidderId, &prev.Amount)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return entity.Bid{}, fmt.Errorf("AuctionRepository - PlaceBid - row.Scan: %w", err)
+	}
+	if prev.Id != 0 {
+		if amount <= prev.Amount {
+			return entity.Bid{}, fmt.Errorf("AuctionRepository - PlaceBid - highest bid %.2f: %w", prev.Amount, entity.ErrBidTooLow)
+		}
+		// Released before the new hold, so raising one's own bid only needs the difference.
+		err = r.releaseBid(ctx, tx, prev)
+		if err != nil {
+			return entity.Bid{}, fmt.Errorf("AuctionRepository - PlaceBid - r.releaseBid: %w", err)
+		}
+	}
+
+	err = charge(ctx, tx, r.Builder, user.Id, amount)
+	if err != nil {
+		return entity.Bid{}, fmt.Errorf("AuctionRepository - PlaceBid - charge: %w", err)
+	}
+	bid := entity.Bid{AuctionId: auctionId, BidderId: user.Id, Amount: amount}
+	sql, args, err = r.Builder.
+		Insert("bids").
+		Columns("auction_id", "bidder_id", "amount", "status").
+		Values(auctionId, user.Id, amount, entity.BidStatusHeld).
+		Suffix("RETURNING id, status, created_at").
+		ToSql()
+	if err != nil {
+		return entity.Bid{}, fmt.Errorf("AuctionRepository - PlaceBid - r.Builder.Insert: %w", err)
+	}
+	err = tx.QueryRow(ctx, sql, args...).Scan(&bid.Id, &bid.Status, &bid.CreatedAt)
+	if err != nil {
+		return entity.Bid{}, fmt.Errorf("AuctionRepository - PlaceBid - tx.QueryRow: %w", err)
+	}
+	err = tx.Commit(ctx)
+	if err != nil {
+		return entity.Bid{}, fmt.Errorf("AuctionRepository - PlaceBid - tx.Commit: %w", err)
+	}
+	return bid, nil
+}
+
+// GetBids - bids of the auction, highest first.
+func (r *AuctionRepository) GetBids(ctx context.Context, auctionId int64) ([]entity.Bid, error) {
+	sql, args, err := r.Builder.
+		Select("id, auction_id, bidder_id, amount, status, created_at").
+		From("bids").
+		Where(sq.Eq{"auction_id": auctionId}).
+		OrderBy("amount DESC").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("AuctionRepository - GetBids - r.Builder: %w", err)
+	}
+	rows, err := r.Pool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("AuctionRepository - GetBids - r.Pool.Query: %w", err)
+	}
+	defer rows.Close()
+	bids := make([]entity.Bid, 0)
+	for rows.Next() {
+		b := entity.Bid{}
+		err := rows.Scan(&b.Id, &b.AuctionId, &b.BidderId, &b.Amount, &b.Status, &b.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("AuctionRepository - GetBids - rows.Scan: %w", err)
+		}
+		bids = append(bids, b)
+	}
+	return bids, nil
+}
+
+// GetExpired - ids of open auctions whose end time has passed.
+func (r *AuctionRepository) GetExpired(ctx context.Context, limit uint64) ([]int64, error) {
+	sql, args, err := r.Builder.
+		Select("id").
+		From("auctions").
+		Where(sq.Eq{"status": entity.AuctionStatusOpen}).
+		Where("end_at <= now()").
+		OrderBy("end_at").
+		Limit(limit).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("AuctionRepository - GetExpired - r.Builder: %w", err)
+	}
+	rows, err := r.Pool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("AuctionRepository - GetExpired - r.Pool.Query: %w", err)
+	}
+	defer rows.Close()
+	ids := make([]int64, 0)
+	for rows.Next() {
+		var id int64
+		err := rows.Scan(&id)
+		if err != nil {
+			return nil, fmt.Errorf("AuctionRepository - GetExpired - rows.Scan: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// Settle - closes an expired auction. If the highest bid meets the reserve price the bidder buys
+// the asset for it through the regular purchase path, otherwise the held amount is released.
+// Auctions already settled or being settled by another instance are skipped.
+func (r *AuctionRepository) Settle(ctx context.Context, id int64) (entity.Auction, error) {
+	tx, err := r.Pool.Begin(ctx)
+	if err != nil {
+		return entity.Auction{}, fmt.Errorf("AuctionRepository - Settle - r.Pool.Begin: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	sql, args, err := r.selectAuction().
+		Where(sq.Eq{"auctions.id": id, "auctions.status": entity.AuctionStatusOpen}).
+		Where("auctions.end_at <= now()").
+		Suffix("FOR UPDATE OF auctions SKIP LOCKED").
+		ToSql()
+	if err != nil {
+		return entity.Auction{}, fmt.Errorf("AuctionRepository - Settle - r.Builder: %w", err)
+	}
+	a, err := scanAuction(tx.QueryRow(ctx, sql, args...))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return entity.Auction{}, fmt.Errorf("AuctionRepository - Settle - row.Scan: %w", entity.ErrAuctionNotFound)
+	}
+	if err != nil {
+		return entity.Auction{}, fmt.Errorf("AuctionRepository - Settle - row.Scan: %w", err)
+	}
+
+	a.Status = entity.AuctionStatusUnsold
+	if a.HighestBid != nil && *a.HighestBid >= a.ReservePrice {
+		a.Status, err = r.sell(ctx, tx, a)
+		if err != nil {
+			return entity.Auction{}, fmt.Errorf("AuctionRepository - Settle - r.sell: %w", err)
+		}
+	}
+	if a.Status == entity.AuctionStatusUnsold && a.HighestBid != nil {
+		err = r.releaseBid(ctx, tx, entity.Bid{AuctionId: a.Id, BidderId: *a.HighestBidderId, Amount: *a.HighestBid})
+		if err != nil {
+			return entity.Auction{}, fmt.Errorf("AuctionRepository - Settle - r.releaseBid: %w", err)
+		}
+	}
+
+	sql, args, err = r.Builder.
+		Update("auctions").
+		Set("status", a.Status).
+		Set("settled_at", sq.Expr("now()")).
+		Where(sq.Eq{"id": a.Id}).
+		Suffix("RETURNING settled_at").
+		ToSql()
+	if err != nil {
+		return entity.Auction{}, fmt.Errorf("AuctionRepository - Settle - r.Builder.Update: %w", err)
+	}
+	err = tx.QueryRow(ctx, sql, args...).Scan(&a.SettledAt)
+	if err != nil {
+		return entity.Auction{}, fmt.Errorf("AuctionRepository - Settle - tx.QueryRow: %w", err)
+	}
+	err = tx.Commit(ctx)
+	if err != nil {
+		return entity.Auction{}, fmt.Errorf("AuctionRepository - Settle - tx.Commit: %w", err)
+	}
+	return a, nil
+}
+
+// sell - hands the asset over to the highest bidder for the held amount. The purchase runs in a
+// savepoint: if the asset can no longer be sold (deleted, unlisted, sold out) the auction ends unsold.
+func (r *AuctionRepository) sell(ctx context.Context, tx pgx.Tx, a entity.Auction) (entity.AuctionStatus, error) {
+	// Closed first, so that purchase does not reject the asset for being on auction.
+	sql, args, err := r.Builder.
+		Update("auctions").
+		Set("status", entity.AuctionStatusSold).
+		Where(sq.Eq{"id": a.Id}).
+		ToSql()
+	if err != nil {
+		return "", fmt.Errorf("r.Builder.Update('auctions'): %w", err)
+	}
+	sp, err := tx.Begin(ctx)
+	if err != nil {
+		return "", fmt.Errorf("tx.Begin: %w", err)
+	}
+	_, err = sp.Exec(ctx, sql, args...)
+	if err != nil {
+		sp.Rollback(ctx)
+		return "", fmt.Errorf("sp.Exec: %w", err)
+	}
+	_, err = purchase(ctx, sp, r.Builder, entity.User{Id: *a.HighestBidderId}, a.AssetId, purchaseOptions{price: a.HighestBid, prepaid: true})
+	if errors.Is(err, entity.ErrAssetNotAvailable) || errors.Is(err, entity.ErrAssetSoldOut) || errors.Is(err, entity.ErrAssetAlreadyPurchased) {
+		sp.Rollback(ctx)
+		return entity.AuctionStatusUnsold, nil
+	}
+	if err != nil {
+		sp.Rollback(ctx)
+		return "", fmt.Errorf("purchase: %w", err)
+	}
+	sql, args, err = r.Builder.
+		Update("bids").
+		Set("status", entity.BidStatusWon).
+		Where(sq.Eq{"auction_id": a.Id, "status": entity.BidStatusHeld}).
+		ToSql()
+	if err != nil {
+		sp.Rollback(ctx)
+		return "", fmt.Errorf("r.Builder.Update('bids'): %w", err)
+	}
+	_, err = sp.Exec(ctx, sql, args...)
+	if err != nil {
+		sp.Rollback(ctx)
+		return "", fmt.Errorf("sp.Exec: %w", err)
+	}
+	err = sp.Commit(ctx)
+	if err != nil {
+		return "", fmt.Errorf("sp.Commit: %w", err)
+	}
+	return entity.AuctionStatusSold, nil
+}
+
+// releaseBid - returns the held amount to the bidder.
+func (r *AuctionRepository) releaseBid(ctx context.Context, tx pgx.Tx, bid entity.Bid) error {
+	sql, args, err := r.Builder.
+		Update("bids").
+		Set("status", entity.BidStatusReleased).
+		Where(sq.Eq{"auction_id": bid.AuctionId, "status": entity.BidStatusHeld}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("r.Builder.Update('bids'): %w", err)
+	}
+	_, err = tx.Exec(ctx, sql, args...)
+	if err != nil {
+		return fmt.Errorf("tx.Exec: %w", err)
+	}
+	return refund(ctx, tx, r.Builder, bid.BidderId, bid.Amount)
+}
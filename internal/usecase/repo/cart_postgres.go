@@ -0,0 +1,265 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Klef99/bhs-task/internal/entity"
+	"github.com/Klef99/bhs-task/internal/usecase"
+	"github.com/Klef99/bhs-task/pkg/postgres"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+)
+
+// CartRepository -.
+type CartRepository struct {
+	*postgres.Postgres
+}
+
+var _ usecase.CartRepository = (*CartRepository)(nil)
+
+// New -.
+func NewCartRepository(pg *postgres.Postgres) *CartRepository {
+	return &CartRepository{pg}
+}
+
+// Add - puts a published asset of another user into the cart. Adding it twice is a no-op.
+func (r *CartRepository) Add(ctx context.Context, user entity.User, assetId int64) (bool, error) {
+	sql, args, err := r.Builder.
+		Select("owner_id, status").
+		Column("EXISTS (SELECT 1 FROM access_assets WHERE access_assets.asset_id = assets.id AND access_assets.user_id = ? AND "+_activeAccess+")", user.Id).
+		From("assets").
+		Where(sq.Eq{"id": assetId, "deleted_at": nil}).
+		ToSql()
+	if err != nil {
+		return false, fmt.Errorf("CartRepository - Add - r.Builder.Select: %w", err)
+	}
+	var ownerId int64
+	var status entity.AssetStatus
+	var owned bool
+	err = r.Pool.QueryRow(ctx, sql, args...).Scan(&ownerId, &status, &owned)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return false, fmt.Errorf("CartRepository - Add - row.Scan: %w", entity.ErrAssetNotFound)
+	}
+	if err != nil {
+		return false, fmt.Errorf("CartRepository - Add - row.Scan: %w", err)
+	}
+	if ownerId == user.Id {
+		return false, fmt.Errorf("CartRepository - Add - user can't buy their own asset")
+	}
+	if status != entity.AssetStatusPublished {
+		return false, fmt.Errorf("CartRepository - Add - status %s: %w", status, entity.ErrAssetNotAvailable)
+	}
+	if owned {
+		return false, fmt.Errorf("CartRepository - Add: %w", entity.ErrAssetAlreadyPurchased)
+	}
+
+	sql, args, err = r.Builder.
+		Insert("cart_items").
+		Columns("user_id", "asset_id").
+		Values(user.Id, assetId).
+		Suffix("on conflict (user_id, asset_id) do nothing").
+		ToSql()
+	if err != nil {
+		return false, fmt.Errorf("CartRepository - Add - r.Builder.Insert: %w", err)
+	}
+	_, err = r.Pool.Exec(ctx, sql, args...)
+	if err != nil {
+		return false, fmt.Errorf("CartRepository - Add - r.Pool.Exec: %w", err)
+	}
+	return true, nil
+}
+
+func (r *CartRepository) Remove(ctx context.Context, user entity.User, assetId int64) (bool, error) {
+	sql, args, err := r.Builder.
+		Delete("cart_items").
+		Where(sq.Eq{"user_id": user.Id, "asset_id": assetId}).
+		ToSql()
+	if err != nil {
+		return false, fmt.Errorf("CartRepository - Remove - r.Builder: %w", err)
+	}
+	res, err := r.Pool.Exec(ctx, sql, args...)
+	if err != nil {
+		return false, fmt.Errorf("CartRepository - Remove - r.Pool.Exec: %w", err)
+	}
+	return res.RowsAffected() > 0, nil
+}
+
+// GetItems - the cart in the order the assets were added, with their current price and availability.
+func (r *CartRepository) GetItems(ctx context.Context, user entity.User) ([]entity.CartItem, error) {
+	sql, args, err := r.Builder.
+		Select("assets.id, assets.name, assets.price, assets.status, cart_items.added_at").
+		Column(`assets.status = ? AND assets.deleted_at IS NULL AND assets.owner_id <> ?
+			AND (assets.stock IS NULL OR assets.sold < assets.stock)
+			AND NOT EXISTS (SELECT 1 FROM auctions WHERE auctions.asset_id = assets.id AND auctions.status = ?)
+			AND NOT EXISTS (SELECT 1 FROM access_assets WHERE access_assets.asset_id = assets.id AND access_assets.user_id = ? AND `+_activeAccess+`)`,
+			entity.AssetStatusPublished, user.Id, entity.AuctionStatusOpen, user.Id).
+		From("cart_items").
+		Join("assets ON assets.id = cart_items.asset_id").
+		Where(sq.Eq{"cart_items.user_id": user.Id}).
+		OrderBy("cart_items.added_at", "assets.id").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("CartRepository - GetItems - r.Builder: %w", err)
+	}
+	rows, err := r.Pool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("CartRepository - GetItems - r.Pool.Query: %w", err)
+	}
+	defer rows.Close()
+	items := make([]entity.CartItem, 0)
+	for rows.Next() {
+		i := entity.CartItem{}
+		err := rows.Scan(&i.AssetId, &i.Name, &i.Price, &i.Status, &i.AddedAt, &i.Available)
+		if err != nil {
+			return nil, fmt.Errorf("CartRepository - GetItems - rows.Scan: %w", err)
+		}
+		items = append(items, i)
+	}
+	return items, nil
+}
+
+// Checkout - buys every asset in the cart in a single transaction and empties it. The assets are
+// locked in id order, so concurrent checkouts of overlapping carts can't deadlock. The total is
+// checked against the balance up front; any asset that can't be bought fails the whole checkout.
+// The optional promo code is applied to every asset it covers and must cover at least one.
+func (r *CartRepository) Checkout(ctx context.Context, user entity.User, promoCode string) (entity.Receipt, error) {
+	tx, err := r.Pool.Begin(ctx)
+	if err != nil {
+		return entity.Receipt{}, fmt.Errorf("CartRepository - Checkout - r.Pool.Begin: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var promo *entity.PromoCode
+	if promoCode != "" {
+		promo, err = lockPromoCode(ctx, tx, r.Builder, promoCode)
+		if err != nil {
+			return entity.Receipt{}, fmt.Errorf("CartRepository - Checkout - lockPromoCode: %w", err)
+		}
+	}
+
+	sql, args, err := r.Builder.
+		Select("assets.id, assets.price, assets.owner_id").
+		From("assets").
+		Join("cart_items ON cart_items.asset_id = assets.id").
+		Where(sq.Eq{"cart_items.user_id": user.Id}).
+		OrderBy("assets.id").
+		Suffix("FOR UPDATE OF assets").
+		ToSql()
+	if err != nil {
+		return entity.Receipt{}, fmt.Errorf("CartRepository - Checkout - r.Builder.Select('assets'): %w", err)
+	}
+	rows, err := tx.Query(ctx, sql, args...)
+	if err != nil {
+		return entity.Receipt{}, fmt.Errorf("CartRepository - Checkout - tx.Query: %w", err)
+	}
+	ids := make([]int64, 0)
+	discounted := make(map[int64]bool)
+	total := float64(0)
+	uses := int64(0)
+	for rows.Next() {
+		var id, ownerId int64
+		var price float64
+		err := rows.Scan(&id, &price, &ownerId)
+		if err != nil {
+			rows.Close()
+			return entity.Receipt{}, fmt.Errorf("CartRepository - Checkout - rows.Scan: %w", err)
+		}
+		ids = append(ids, id)
+		if promo != nil && promo.AppliesTo(id, ownerId) {
+			if promo.MaxUses != nil && promo.Used+uses >= *promo.MaxUses {
+				rows.Close()
+				return entity.Receipt{}, fmt.Errorf("CartRepository - Checkout - promo code %s: %w", promo.Code, entity.ErrPromoCodeExpired)
+			}
+			discounted[id] = true
+			uses++
+			price -= promo.Discount(price)
+		}
+		total += price
+	}
+	rows.Close()
+	if rows.Err() != nil {
+		return entity.Receipt{}, fmt.Errorf("CartRepository - Checkout - rows.Err: %w", rows.Err())
+	}
+	if len(ids) == 0 {
+		return entity.Receipt{}, fmt.Errorf("CartRepository - Checkout: %w", entity.ErrCartEmpty)
+	}
+	if promo != nil && len(discounted) == 0 {
+		return entity.Receipt{}, fmt.Errorf("CartRepository - Checkout - promo code %s: %w", promo.Code, entity.ErrPromoCodeNotApplicable)
+	}
+
+	sql, args, err = r.Builder.
+		Select("balance").
+		From("users").
+		Where(sq.Eq{"id": user.Id}).
+		Suffix("FOR UPDATE").
+		ToSql()
+	if err != nil {
+		return entity.Receipt{}, fmt.Errorf("CartRepository - Checkout - r.Builder.Select('users'): %w", err)
+	}
+	var balance float64
+	err = tx.QueryRow(ctx, sql, args...).Scan(&balance)
+	if err != nil {
+		return entity.Receipt{}, fmt.Errorf("CartRepository - Checkout - row.Scan: %w", err)
+	}
+	if balance < total {
+		return entity.Receipt{}, fmt.Errorf("CartRepository - Checkout - total %.2f, balance %.2f: %w", total, balance, entity.ErrInsufficientFunds)
+	}
+
+	receipt := entity.Receipt{BuyerId: user.Id, Purchases: make([]entity.Purchase, 0, len(ids))}
+	sql, args, err = r.Builder.
+		Insert("receipts").
+		Columns("buyer_id").
+		Values(user.Id).
+		Suffix("RETURNING id, created_at").
+		ToSql()
+	if err != nil {
+		return entity.Receipt{}, fmt.Errorf("CartRepository - Checkout - r.Builder.Insert: %w", err)
+	}
+	err = tx.QueryRow(ctx, sql, args...).Scan(&receipt.Id, &receipt.CreatedAt)
+	if err != nil {
+		return entity.Receipt{}, fmt.Errorf("CartRepository - Checkout - tx.QueryRow('receipts'): %w", err)
+	}
+	for _, id := range ids {
+		opts := purchaseOptions{receiptId: &receipt.Id}
+		if discounted[id] {
+			opts.promo = promo
+		}
+		p, err := purchase(ctx, tx, r.Builder, user, id, opts)
+		if err != nil {
+			return entity.Receipt{}, fmt.Errorf("CartRepository - Checkout - purchase(%d): %w", id, err)
+		}
+		receipt.Purchases = append(receipt.Purchases, p)
+		receipt.Total += p.Price
+	}
+
+	sql, args, err = r.Builder.
+		Update("receipts").
+		Set("total", receipt.Total).
+		Where(sq.Eq{"id": receipt.Id}).
+		ToSql()
+	if err != nil {
+		return entity.Receipt{}, fmt.Errorf("CartRepository - Checkout - r.Builder.Update: %w", err)
+	}
+	_, err = tx.Exec(ctx, sql, args...)
+	if err != nil {
+		return entity.Receipt{}, fmt.Errorf("CartRepository - Checkout - tx.Exec('receipts'): %w", err)
+	}
+	sql, args, err = r.Builder.
+		Delete("cart_items").
+		Where(sq.Eq{"user_id": user.Id}).
+		ToSql()
+	if err != nil {
+		return entity.Receipt{}, fmt.Errorf("CartRepository - Checkout - r.Builder.Delete: %w", err)
+	}
+	_, err = tx.Exec(ctx, sql, args...)
+	if err != nil {
+		return entity.Receipt{}, fmt.Errorf("CartRepository - Checkout - tx.Exec('cart_items'): %w", err)
+	}
+	err = tx.Commit(ctx)
+	if err != nil {
+		return entity.Receipt{}, fmt.Errorf("CartRepository - Checkout - tx.Commit: %w", err)
+	}
+	return receipt, nil
+}
@@ -0,0 +1,71 @@
+package repo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/Klef99/bhs-task/internal/entity"
+	"github.com/Klef99/bhs-task/internal/usecase"
+	"github.com/Klef99/bhs-task/pkg/postgres"
+	"github.com/jackc/pgx/v5"
+)
+
+// _marketChannel - NOTIFY channel of market events, shared by every instance.
+const _marketChannel = "market_events"
+
+// MarketFeedRepository -.
+type MarketFeedRepository struct {
+	*postgres.Postgres
+}
+
+var _ usecase.MarketFeedRepository = (*MarketFeedRepository)(nil)
+
+// New -.
+func NewMarketFeedRepository(pg *postgres.Postgres) *MarketFeedRepository {
+	return &MarketFeedRepository{pg}
+}
+
+// Listen - passes market events of every instance to handle until ctx is done or the connection fails.
+// It uses its own connection, so it does not hold one of the pool.
+func (r *MarketFeedRepository) Listen(ctx context.Context, handle func(entity.MarketEvent)) error {
+	conn, err := pgx.ConnectConfig(ctx, r.Pool.Config().ConnConfig.Copy())
+	if err != nil {
+		return fmt.Errorf("MarketFeedRepository - Listen - pgx.ConnectConfig: %w", err)
+	}
+	defer conn.Close(context.Background())
+
+	_, err = conn.Exec(ctx, "LISTEN "+_marketChannel)
+	if err != nil {
+		return fmt.Errorf("MarketFeedRepository - Listen - conn.Exec('LISTEN'): %w", err)
+	}
+	for {
+		n, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return fmt.Errorf("MarketFeedRepository - Listen - conn.WaitForNotification: %w", err)
+		}
+		event := entity.MarketEvent{}
+		err = json.Unmarshal([]byte(n.Payload), &event)
+		if err != nil {
+			return fmt.Errorf("MarketFeedRepository - Listen - json.Unmarshal: %w", err)
+		}
+		handle(event)
+	}
+}
+
+// notifyMarket - sends the event to the listeners of every instance once tx commits.
+func notifyMarket(ctx context.Context, tx pgx.Tx, event entity.MarketEvent) error {
+	if event.OccurredAt.IsZero() {
+		event.OccurredAt = time.Now().UTC()
+	}
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("notifyMarket - json.Marshal: %w", err)
+	}
+	_, err = tx.Exec(ctx, "SELECT pg_notify($1, $2)", _marketChannel, string(payload))
+	if err != nil {
+		return fmt.Errorf("notifyMarket - tx.Exec: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Klef99/bhs-task/internal/entity"
+	"github.com/Klef99/bhs-task/internal/usecase"
+	"github.com/Klef99/bhs-task/pkg/postgres"
+	sq "github.com/Masterminds/squirrel"
+)
+
+// NotificationRepository -.
+type NotificationRepository struct {
+	*postgres.Postgres
+}
+
+var _ usecase.NotificationRepository = (*NotificationRepository)(nil)
+
+// New -.
+func NewNotificationRepository(pg *postgres.Postgres) *NotificationRepository {
+	return &NotificationRepository{pg}
+}
+
+// GetByUser - the user's notifications, newest first.
+func (r *NotificationRepository) GetByUser(ctx context.Context, user entity.User, unreadOnly bool) ([]entity.Notification, error) {
+	query := r.Builder.
+		Select("id, user_id, kind, asset_id, old_price, new_price, created_at, read_at").
+		From("notifications").
+		Where(sq.Eq{"user_id": user.Id}).
+		OrderBy("created_at DESC", "id DESC")
+	if unreadOnly {
+		query = query.Where(sq.Eq{"read_at": nil})
+	}
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("NotificationRepository - GetByUser - r.Builder: %w", err)
+	}
+	rows, err := r.Pool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("NotificationRepository - GetByUser - r.Pool.Query: %w", err)
+	}
+	defer rows.Close()
+	notifications := make([]entity.Notification, 0)
+	for rows.Next() {
+		n := entity.Notification{}
+		err := rows.Scan(&n.Id, &n.UserId, &n.Kind, &n.AssetId, &n.OldPrice, &n.NewPrice, &n.CreatedAt, &n.ReadAt)
+		if err != nil {
+			return nil, fmt.Errorf("NotificationRepository - GetByUser - rows.Scan: %w", err)
+		}
+		notifications = append(notifications, n)
+	}
+	return notifications, nil
+}
+
+// MarkRead - returns false if the user has no such notification. Marking it again keeps the first read time.
+func (r *NotificationRepository) MarkRead(ctx context.Context, user entity.User, id int64) (bool, error) {
+	sql, args, err := r.Builder.
+		Update("notifications").
+		Set("read_at", sq.Expr("COALESCE(read_at, now())")).
+		Where(sq.Eq{"id": id, "user_id": user.Id}).
+		ToSql()
+	if err != nil {
+		return false, fmt.Errorf("NotificationRepository - MarkRead - r.Builder: %w", err)
+	}
+	res, err := r.Pool.Exec(ctx, sql, args...)
+	if err != nil {
+		return false, fmt.Errorf("NotificationRepository - MarkRead - r.Pool.Exec: %w", err)
+	}
+	return res.RowsAffected() > 0, nil
+}
@@ -0,0 +1,373 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Klef99/bhs-task/internal/entity"
+	"github.com/Klef99/bhs-task/internal/usecase"
+	"github.com/Klef99/bhs-task/pkg/postgres"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+)
+
+// OfferRepository -.
+type OfferRepository struct {
+	*postgres.Postgres
+}
+
+var _ usecase.OfferRepository = (*OfferRepository)(nil)
+
+// New -.
+func NewOfferRepository(pg *postgres.Postgres) *OfferRepository {
+	return &OfferRepository{pg}
+}
+
+const _offerColumns = "id, asset_id, buyer_id, seller_id, proposed_by, parent_id, price, status, purchase_id, expires_at, created_at, responded_at"
+
+func scanOffer(row pgx.Row) (entity.Offer, error) {
+	o := entity.Offer{}
+	err := row.Scan(&o.Id, &o.AssetId, &o.BuyerId, &o.SellerId, &o.ProposedBy, &o.ParentId, &o.Price, &o.Status, &o.PurchaseId, &o.ExpiresAt, &o.CreatedAt, &o.RespondedAt)
+	return o, err
+}
+
+// Create - proposes a price for a published asset to its owner.
+func (r *OfferRepository) Create(ctx context.Context, offer entity.Offer) (entity.Offer, error) {
+	tx, err := r.Pool.Begin(ctx)
+	if err != nil {
+		return entity.Offer{}, fmt.Errorf("OfferRepository - Create - r.Pool.Begin: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	// The asset row is locked to serialize offers of the same buyer.
+	sql, args, err := r.Builder.
+		Select("owner_id, status").
+		Column("EXISTS (SELECT 1 FROM access_assets WHERE access_assets.asset_id = assets.id AND access_assets.user_id = ? AND "+_activeAccess+")", offer.BuyerId).
+		From("assets").
+		Where(sq.Eq{"id": offer.AssetId, "deleted_at": nil}).
+		Suffix("FOR UPDATE").
+		ToSql()
+	if err != nil {
+		return entity.Offer{}, fmt.Errorf("OfferRepository - Create - r.Builder.Select('assets'): %w", err)
+	}
+	var status entity.AssetStatus
+	var owned bool
+	err = tx.QueryRow(ctx, sql, args...).Scan(&offer.SellerId, &status, &owned)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return entity.Offer{}, fmt.Errorf("OfferRepository - Create - row.Scan: %w", entity.ErrAssetNotFound)
+	}
+	if err != nil {
+		return entity.Offer{}, fmt.Errorf("OfferRepository - Create - row.Scan: %w", err)
+	}
+	if offer.SellerId == offer.BuyerId {
+		return entity.Offer{}, fmt.Errorf("OfferRepository - Create - user can't make an offer on their own asset")
+	}
+	if status != entity.AssetStatusPublished {
+		return entity.Offer{}, fmt.Errorf("OfferRepository - Create - status %s: %w", status, entity.ErrAssetNotAvailable)
+	}
+	if owned {
+		return entity.Offer{}, fmt.Errorf("OfferRepository - Create: %w", entity.ErrAssetAlreadyPurchased)
+	}
+
+	err = r.expire(ctx, tx, sq.Eq{"asset_id": offer.AssetId, "buyer_id": offer.BuyerId})
+	if err != nil {
+		return entity.Offer{}, fmt.Errorf("OfferRepository - Create - r.expire: %w", err)
+	}
+	sql, args, err = r.Builder.
+		Select("1").
+		From("offers").
+		Where(sq.Eq{"asset_id": offer.AssetId, "buyer_id": offer.BuyerId, "status": entity.OfferStatusPending}).
+		Prefix("SELECT EXISTS (").
+		Suffix(")").
+		ToSql()
+	if err != nil {
+		return entity.Offer{}, fmt.Errorf("OfferRepository - Create - r.Builder.Select('offers'): %w", err)
+	}
+	var pending bool
+	err = tx.QueryRow(ctx, sql, args...).Scan(&pending)
+	if err != nil {
+		return entity.Offer{}, fmt.Errorf("OfferRepository - Create - row.Scan: %w", err)
+	}
+	if pending {
+		return entity.Offer{}, fmt.Errorf("OfferRepository - Create: %w", entity.ErrOfferExists)
+	}
+
+	offer, err = r.insert(ctx, tx, offer)
+	if err != nil {
+		return entity.Offer{}, fmt.Errorf("OfferRepository - Create - r.insert: %w", err)
+	}
+	err = tx.Commit(ctx)
+	if err != nil {
+		return entity.Offer{}, fmt.Errorf("OfferRepository - Create - tx.Commit: %w", err)
+	}
+	return offer, nil
+}
+
+// GetByAsset - offers on the asset where the user is the buyer or the seller, newest first.
+func (r *OfferRepository) GetByAsset(ctx context.Context, user entity.User, assetId int64) ([]entity.Offer, error) {
+	offers, err := r.list(ctx, sq.And{
+		sq.Eq{"asset_id": assetId},
+		sq.Or{sq.Eq{"buyer_id": user.Id}, sq.Eq{"seller_id": user.Id}},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("OfferRepository - GetByAsset - r.list: %w", err)
+	}
+	return offers, nil
+}
+
+// GetInbox - offers proposed to the user, newest first.
+func (r *OfferRepository) GetInbox(ctx context.Context, user entity.User) ([]entity.Offer, error) {
+	offers, err := r.list(ctx, sq.And{
+		sq.Or{sq.Eq{"buyer_id": user.Id}, sq.Eq{"seller_id": user.Id}},
+		sq.NotEq{"proposed_by": user.Id},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("OfferRepository - GetInbox - r.list: %w", err)
+	}
+	return offers, nil
+}
+
+// GetOutbox - offers proposed by the user, newest first.
+func (r *OfferRepository) GetOutbox(ctx context.Context, user entity.User) ([]entity.Offer, error) {
+	offers, err := r.list(ctx, sq.Eq{"proposed_by": user.Id})
+	if err != nil {
+		return nil, fmt.Errorf("OfferRepository - GetOutbox - r.list: %w", err)
+	}
+	return offers, nil
+}
+
+// Accept - the buyer purchases the asset for the offered price through the regular purchase path,
+// so the balance, stock and availability checks are the same as for a fixed-price purchase.
+// If the purchase fails the offer stays pending.
+func (r *OfferRepository) Accept(ctx context.Context, user entity.User, id int64) (entity.Offer, error) {
+	tx, err := r.Pool.Begin(ctx)
+	if err != nil {
+		return entity.Offer{}, fmt.Errorf("OfferRepository - Accept - r.Pool.Begin: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	offer, err := r.lockPending(ctx, tx, user, id)
+	if err != nil {
+		return entity.Offer{}, fmt.Errorf("OfferRepository - Accept - r.lockPending: %w", err)
+	}
+	p, err := purchase(ctx, tx, r.Builder, entity.User{Id: offer.BuyerId}, offer.AssetId, purchaseOptions{price: &offer.Price})
+	if err != nil {
+		return entity.Offer{}, fmt.Errorf("OfferRepository - Accept - purchase: %w", err)
+	}
+	if p.SellerId != offer.SellerId {
+		return entity.Offer{}, fmt.Errorf("OfferRepository - Accept - asset changed owner: %w", entity.ErrAssetNotAvailable)
+	}
+	offer.Status = entity.OfferStatusAccepted
+	offer.PurchaseId = &p.Id
+	sql, args, err := r.Builder.
+		Update("offers").
+		Set("status", offer.Status).
+		Set("purchase_id", p.Id).
+		Set("responded_at", sq.Expr("now()")).
+		Where(sq.Eq{"id": offer.Id}).
+		Suffix("RETURNING responded_at").
+		ToSql()
+	if err != nil {
+		return entity.Offer{}, fmt.Errorf("OfferRepository - Accept - r.Builder.Update: %w", err)
+	}
+	err = tx.QueryRow(ctx, sql, args...).Scan(&offer.RespondedAt)
+	if err != nil {
+		return entity.Offer{}, fmt.Errorf("OfferRepository - Accept - tx.QueryRow: %w", err)
+	}
+	err = tx.Commit(ctx)
+	if err != nil {
+		return entity.Offer{}, fmt.Errorf("OfferRepository - Accept - tx.Commit: %w", err)
+	}
+	return offer, nil
+}
+
+func (r *OfferRepository) Reject(ctx context.Context, user entity.User, id int64) (entity.Offer, error) {
+	tx, err := r.Pool.Begin(ctx)
+	if err != nil {
+		return entity.Offer{}, fmt.Errorf("OfferRepository - Reject - r.Pool.Begin: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	offer, err := r.lockPending(ctx, tx, user, id)
+	if err != nil {
+		return entity.Offer{}, fmt.Errorf("OfferRepository - Reject - r.lockPending: %w", err)
+	}
+	offer.Status = entity.OfferStatusRejected
+	offer.RespondedAt, err = r.respond(ctx, tx, offer.Id, offer.Status)
+	if err != nil {
+		return entity.Offer{}, fmt.Errorf("OfferRepository - Reject - r.respond: %w", err)
+	}
+	err = tx.Commit(ctx)
+	if err != nil {
+		return entity.Offer{}, fmt.Errorf("OfferRepository - Reject - tx.Commit: %w", err)
+	}
+	return offer, nil
+}
+
+// Counter - closes the offer as countered and proposes price to the other party in a new offer.
+func (r *OfferRepository) Counter(ctx context.Context, user entity.User, id int64, price float64, expiresAt time.Time) (entity.Offer, error) {
+	tx, err := r.Pool.Begin(ctx)
+	if err != nil {
+		return entity.Offer{}, fmt.Errorf("OfferRepository - Counter - r.Pool.Begin: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	offer, err := r.lockPending(ctx, tx, user, id)
+	if err != nil {
+		return entity.Offer{}, fmt.Errorf("OfferRepository - Counter - r.lockPending: %w", err)
+	}
+	_, err = r.respond(ctx, tx, offer.Id, entity.OfferStatusCountered)
+	if err != nil {
+		return entity.Offer{}, fmt.Errorf("OfferRepository - Counter - r.respond: %w", err)
+	}
+	counter, err := r.insert(ctx, tx, entity.Offer{
+		AssetId:    offer.AssetId,
+		BuyerId:    offer.BuyerId,
+		SellerId:   offer.SellerId,
+		ProposedBy: user.Id,
+		ParentId:   &offer.Id,
+		Price:      price,
+		ExpiresAt:  expiresAt,
+	})
+	if err != nil {
+		return entity.Offer{}, fmt.Errorf("OfferRepository - Counter - r.insert: %w", err)
+	}
+	err = tx.Commit(ctx)
+	if err != nil {
+		return entity.Offer{}, fmt.Errorf("OfferRepository - Counter - tx.Commit: %w", err)
+	}
+	return counter, nil
+}
+
+// Expire - marks every pending offer past its expiry time as expired.
+func (r *OfferRepository) Expire(ctx context.Context) (int64, error) {
+	sql, args, err := r.Builder.
+		Update("offers").
+		Set("status", entity.OfferStatusExpired).
+		Where(sq.Eq{"status": entity.OfferStatusPending}).
+		Where("expires_at <= now()").
+		ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("OfferRepository - Expire - r.Builder: %w", err)
+	}
+	res, err := r.Pool.Exec(ctx, sql, args...)
+	if err != nil {
+		return 0, fmt.Errorf("OfferRepository - Expire - r.Pool.Exec: %w", err)
+	}
+	return res.RowsAffected(), nil
+}
+
+func (r *OfferRepository) list(ctx context.Context, where sq.Sqlizer) ([]entity.Offer, error) {
+	sql, args, err := r.Builder.
+		Select(_offerColumns).
+		From("offers").
+		Where(where).
+		OrderBy("id DESC").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("r.Builder: %w", err)
+	}
+	rows, err := r.Pool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("r.Pool.Query: %w", err)
+	}
+	defer rows.Close()
+	offers := make([]entity.Offer, 0)
+	for rows.Next() {
+		o, err := scanOffer(rows)
+		if err != nil {
+			return nil, fmt.Errorf("rows.Scan: %w", err)
+		}
+		offers = append(offers, o)
+	}
+	return offers, nil
+}
+
+// lockPending - locks an offer the user can respond to: they take part in it, did not propose it,
+// and it is still pending and not expired.
+func (r *OfferRepository) lockPending(ctx context.Context, tx pgx.Tx, user entity.User, id int64) (entity.Offer, error) {
+	sql, args, err := r.Builder.
+		Select(_offerColumns, "expires_at <= now()").
+		From("offers").
+		Where(sq.Eq{"id": id}).
+		Where(sq.Or{sq.Eq{"buyer_id": user.Id}, sq.Eq{"seller_id": user.Id}}).
+		Suffix("FOR UPDATE").
+		ToSql()
+	if err != nil {
+		return entity.Offer{}, fmt.Errorf("r.Builder: %w", err)
+	}
+	o := entity.Offer{}
+	var expired bool
+	err = tx.QueryRow(ctx, sql, args...).Scan(&o.Id, &o.AssetId, &o.BuyerId, &o.SellerId, &o.ProposedBy, &o.ParentId, &o.Price, &o.Status, &o.PurchaseId, &o.ExpiresAt, &o.CreatedAt, &o.RespondedAt, &expired)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return entity.Offer{}, fmt.Errorf("row.Scan: %w", entity.ErrOfferNotFound)
+	}
+	if err != nil {
+		return entity.Offer{}, fmt.Errorf("row.Scan: %w", err)
+	}
+	if o.ProposedBy == user.Id {
+		return entity.Offer{}, fmt.Errorf("user can't respond to their own offer: %w", entity.ErrOfferNotFound)
+	}
+	if o.Status != entity.OfferStatusPending || expired {
+		return entity.Offer{}, fmt.Errorf("status %s, expired %t: %w", o.Status, expired, entity.ErrOfferClosed)
+	}
+	return o, nil
+}
+
+// respond - closes a pending offer with status.
+func (r *OfferRepository) respond(ctx context.Context, tx pgx.Tx, id int64, status entity.OfferStatus) (*time.Time, error) {
+	sql, args, err := r.Builder.
+		Update("offers").
+		Set("status", status).
+		Set("responded_at", sq.Expr("now()")).
+		Where(sq.Eq{"id": id}).
+		Suffix("RETURNING responded_at").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("r.Builder.Update: %w", err)
+	}
+	var respondedAt *time.Time
+	err = tx.QueryRow(ctx, sql, args...).Scan(&respondedAt)
+	if err != nil {
+		return nil, fmt.Errorf("tx.QueryRow: %w", err)
+	}
+	return respondedAt, nil
+}
+
+func (r *OfferRepository) insert(ctx context.Context, tx pgx.Tx, offer entity.Offer) (entity.Offer, error) {
+	sql, args, err := r.Builder.
+		Insert("offers").
+		Columns("asset_id", "buyer_id", "seller_id", "proposed_by", "parent_id", "price", "expires_at").
+		Values(offer.AssetId, offer.BuyerId, offer.SellerId, offer.ProposedBy, offer.ParentId, offer.Price, offer.ExpiresAt).
+		Suffix("RETURNING id, status, created_at").
+		ToSql()
+	if err != nil {
+		return entity.Offer{}, fmt.Errorf("r.Builder.Insert: %w", err)
+	}
+	err = tx.QueryRow(ctx, sql, args...).Scan(&offer.Id, &offer.Status, &offer.CreatedAt)
+	if err != nil {
+		return entity.Offer{}, fmt.Errorf("tx.QueryRow: %w", err)
+	}
+	return offer, nil
+}
+
+// expire - marks pending offers matching where that are past their expiry time as expired.
+func (r *OfferRepository) expire(ctx context.Context, tx pgx.Tx, where sq.Eq) error {
+	sql, args, err := r.Builder.
+		Update("offers").
+		Set("status", entity.OfferStatusExpired).
+		Where(where).
+		Where(sq.Eq{"status": entity.OfferStatusPending}).
+		Where("expires_at <= now()").
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("r.Builder.Update: %w", err)
+	}
+	_, err = tx.Exec(ctx, sql, args...)
+	if err != nil {
+		return fmt.Errorf("tx.Exec: %w", err)
+	}
+	return nil
+}
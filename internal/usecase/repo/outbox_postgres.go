@@ -0,0 +1,173 @@
+package repo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/Klef99/bhs-task/internal/entity"
+	"github.com/Klef99/bhs-task/internal/usecase"
+	"github.com/Klef99/bhs-task/pkg/postgres"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+)
+
+// _outboxLock - advisory lock held while events are claimed, claims of two instances don't interleave.
+const _outboxLock int64 = 0x6f7574626f78
+
+// OutboxRepository -.
+type OutboxRepository struct {
+	*postgres.Postgres
+}
+
+var _ usecase.OutboxRepository = (*OutboxRepository)(nil)
+
+// New -.
+func NewOutboxRepository(pg *postgres.Postgres) *OutboxRepository {
+	return &OutboxRepository{pg}
+}
+
+// ClaimPending - returns due unpublished events in the order they were written and hides them from other
+// instances for the lease. Events of an aggregate with an earlier event waiting for a retry or claimed by
+// another instance are left out, so the events of an aggregate are published in order.
+// Nothing is claimed while another instance holds the relay lock.
+func (r *OutboxRepository) ClaimPending(ctx context.Context, limit uint64, lease time.Duration) ([]entity.OutboxEvent, error) {
+	tx, err := r.Pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("OutboxRepository - ClaimPending - r.Pool.Begin: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var locked bool
+	err = tx.QueryRow(ctx, "SELECT pg_try_advisory_xact_lock($1)", _outboxLock).Scan(&locked)
+	if err != nil {
+		return nil, fmt.Errorf("OutboxRepository - ClaimPending - pg_try_advisory_xact_lock: %w", err)
+	}
+	if !locked {
+		return []entity.OutboxEvent{}, nil
+	}
+
+	sql, args, err := r.Builder.
+		Select("id, aggregate_type, aggregate_id, event_type, payload, user_ids, attempts, next_attempt_at, COALESCE(last_error, ''), created_at").
+		From("outbox").
+		Where(sq.Eq{"published_at": nil}).
+		Where("next_attempt_at <= now()").
+		Where(`NOT EXISTS (SELECT 1 FROM outbox earlier
+			WHERE earlier.aggregate_type = outbox.aggregate_type AND earlier.aggregate_id = outbox.aggregate_id
+			AND earlier.id < outbox.id AND earlier.published_at IS NULL AND earlier.next_attempt_at > now())`).
+		OrderBy("id").
+		Limit(limit).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("OutboxRepository - ClaimPending - r.Builder: %w", err)
+	}
+	rows, err := tx.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("OutboxRepository - ClaimPending - tx.Query: %w", err)
+	}
+	events := make([]entity.OutboxEvent, 0)
+	ids := make([]int64, 0)
+	for rows.Next() {
+		e := entity.OutboxEvent{}
+		err := rows.Scan(&e.Id, &e.AggregateType, &e.AggregateId, &e.Type, &e.Payload, &e.UserIds, &e.Attempts, &e.NextAttemptAt, &e.LastError, &e.CreatedAt)
+		if err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("OutboxRepository - ClaimPending - rows.Scan: %w", err)
+		}
+		events = append(events, e)
+		ids = append(ids, e.Id)
+	}
+	rows.Close()
+	if len(ids) == 0 {
+		return events, nil
+	}
+
+	sql, args, err = r.Builder.
+		Update("outbox").
+		Set("next_attempt_at", sq.Expr("now() + make_interval(secs => ?)", lease.Seconds())).
+		Where(sq.Eq{"id": ids}).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("OutboxRepository - ClaimPending - r.Builder.Update: %w", err)
+	}
+	_, err = tx.Exec(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("OutboxRepository - ClaimPending - tx.Exec: %w", err)
+	}
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("OutboxRepository - ClaimPending - tx.Commit: %w", err)
+	}
+	return events, nil
+}
+
+// MarkPublished -.
+func (r *OutboxRepository) MarkPublished(ctx context.Context, id int64) error {
+	sql, args, err := r.Builder.
+		Update("outbox").
+		Set("published_at", sq.Expr("now()")).
+		Where(sq.Eq{"id": id}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("OutboxRepository - MarkPublished - r.Builder: %w", err)
+	}
+	_, err = r.Pool.Exec(ctx, sql, args...)
+	if err != nil {
+		return fmt.Errorf("OutboxRepository - MarkPublished - r.Pool.Exec: %w", err)
+	}
+	return nil
+}
+
+// RecordFailure - stores the attempts, the time of the next one and the last error of the event.
+func (r *OutboxRepository) RecordFailure(ctx context.Context, event entity.OutboxEvent) error {
+	sql, args, err := r.Builder.
+		Update("outbox").
+		Set("attempts", event.Attempts).
+		Set("next_attempt_at", event.NextAttemptAt).
+		Set("last_error", sq.Expr("NULLIF(?, '')", event.LastError)).
+		Where(sq.Eq{"id": event.Id}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("OutboxRepository - RecordFailure - r.Builder: %w", err)
+	}
+	_, err = r.Pool.Exec(ctx, sql, args...)
+	if err != nil {
+		return fmt.Errorf("OutboxRepository - RecordFailure - r.Pool.Exec: %w", err)
+	}
+	return nil
+}
+
+// writeOutbox - records the event within tx, it is published only if tx commits.
+func writeOutbox(ctx context.Context, tx pgx.Tx, b sq.StatementBuilderType, aggregateType string, aggregateId int64, event entity.Event) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("writeOutbox - json.Marshal: %w", err)
+	}
+	userIds := event.UserIds
+	if userIds == nil {
+		userIds = []int64{}
+	}
+	sql, args, err := b.
+		Insert("outbox").
+		Columns("aggregate_type", "aggregate_id", "event_type", "payload", "user_ids").
+		Values(aggregateType, aggregateId, event.Type, string(payload), userIds).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("writeOutbox - b.Insert: %w", err)
+	}
+	_, err = tx.Exec(ctx, sql, args...)
+	if err != nil {
+		return fmt.Errorf("writeOutbox - tx.Exec: %w", err)
+	}
+	return nil
+}
+
+// purchasedEvent - the purchase concerns the buyer, the seller and the recipient of a gift.
+func purchasedEvent(p entity.Purchase) entity.Event {
+	userIds := []int64{p.BuyerId, p.SellerId}
+	if p.RecipientId != nil {
+		userIds = append(userIds, *p.RecipientId)
+	}
+	return entity.Event{Type: entity.EventAssetPurchased, OccurredAt: p.PurchasedAt, Data: p, UserIds: userIds}
+}
@@ -0,0 +1,167 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Klef99/bhs-task/internal/entity"
+	"github.com/Klef99/bhs-task/internal/usecase"
+	"github.com/Klef99/bhs-task/pkg/postgres"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+const _uniqueViolation = "23505"
+
+// PromoRepository -.
+type PromoRepository struct {
+	*postgres.Postgres
+}
+
+var _ usecase.PromoRepository = (*PromoRepository)(nil)
+
+// New -.
+func NewPromoRepository(pg *postgres.Postgres) *PromoRepository {
+	return &PromoRepository{pg}
+}
+
+const _promoColumns = "id, code, kind, value, asset_id, seller_id, created_by, max_uses, used, expires_at, created_at"
+
+func scanPromoCode(row pgx.Row) (entity.PromoCode, error) {
+	p := entity.PromoCode{}
+	err := row.Scan(&p.Id, &p.Code, &p.Kind, &p.Value, &p.AssetId, &p.SellerId, &p.CreatedBy, &p.MaxUses, &p.Used, &p.ExpiresAt, &p.CreatedAt)
+	return p, err
+}
+
+// Create - stores a new code. A per-asset code of a seller must be for an asset they own.
+func (r *PromoRepository) Create(ctx context.Context, promo entity.PromoCode) (entity.PromoCode, error) {
+	if promo.AssetId != nil {
+		where := sq.Eq{"id": *promo.AssetId, "deleted_at": nil}
+		if promo.SellerId != nil {
+			where["owner_id"] = *promo.SellerId
+		}
+		sql, args, err := r.Builder.
+			Select("1").
+			From("assets").
+			Where(where).
+			Prefix("SELECT EXISTS (").
+			Suffix(")").
+			ToSql()
+		if err != nil {
+			return entity.PromoCode{}, fmt.Errorf("PromoRepository - Create - r.Builder.Select: %w", err)
+		}
+		var exists bool
+		err = r.Pool.QueryRow(ctx, sql, args...).Scan(&exists)
+		if err != nil {
+			return entity.PromoCode{}, fmt.Errorf("PromoRepository - Create - row.Scan: %w", err)
+		}
+		if !exists {
+			return entity.PromoCode{}, fmt.Errorf("PromoRepository - Create: %w", entity.ErrAssetNotFound)
+		}
+	}
+
+	sql, args, err := r.Builder.
+		Insert("promo_codes").
+		Columns("code", "kind", "value", "asset_id", "seller_id", "created_by", "max_uses", "expires_at").
+		Values(promo.Code, promo.Kind, promo.Value, promo.AssetId, promo.SellerId, promo.CreatedBy, promo.MaxUses, promo.ExpiresAt).
+		Suffix("RETURNING id, used, created_at").
+		ToSql()
+	if err != nil {
+		return entity.PromoCode{}, fmt.Errorf("PromoRepository - Create - r.Builder.Insert: %w", err)
+	}
+	err = r.Pool.QueryRow(ctx, sql, args...).Scan(&promo.Id, &promo.Used, &promo.CreatedAt)
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == _uniqueViolation {
+		return entity.PromoCode{}, fmt.Errorf("PromoRepository - Create: %w", entity.ErrPromoCodeExists)
+	}
+	if err != nil {
+		return entity.PromoCode{}, fmt.Errorf("PromoRepository - Create - row.Scan: %w", err)
+	}
+	return promo, nil
+}
+
+// GetByCreator - codes created by the user, newest first.
+func (r *PromoRepository) GetByCreator(ctx context.Context, user entity.User) ([]entity.PromoCode, error) {
+	sql, args, err := r.Builder.
+		Select(_promoColumns).
+		From("promo_codes").
+		Where(sq.Eq{"created_by": user.Id}).
+		OrderBy("id DESC").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("PromoRepository - GetByCreator - r.Builder: %w", err)
+	}
+	rows, err := r.Pool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("PromoRepository - GetByCreator - r.Pool.Query: %w", err)
+	}
+	defer rows.Close()
+	codes := make([]entity.PromoCode, 0)
+	for rows.Next() {
+		p, err := scanPromoCode(rows)
+		if err != nil {
+			return nil, fmt.Errorf("PromoRepository - GetByCreator - rows.Scan: %w", err)
+		}
+		codes = append(codes, p)
+	}
+	return codes, nil
+}
+
+// lockPromoCode - locks an unexpired code for redemption within tx.
+func lockPromoCode(ctx context.Context, tx pgx.Tx, b sq.StatementBuilderType, code string) (*entity.PromoCode, error) {
+	sql, args, err := b.
+		Select(_promoColumns, "expires_at IS NOT NULL AND expires_at <= now()").
+		From("promo_codes").
+		Where(sq.Eq{"code": code}).
+		Suffix("FOR UPDATE").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("lockPromoCode - b.Select: %w", err)
+	}
+	p := entity.PromoCode{}
+	var expired bool
+	err = tx.QueryRow(ctx, sql, args...).Scan(&p.Id, &p.Code, &p.Kind, &p.Value, &p.AssetId, &p.SellerId, &p.CreatedBy, &p.MaxUses, &p.Used, &p.ExpiresAt, &p.CreatedAt, &expired)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, entity.ErrPromoCodeNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("lockPromoCode - row.Scan: %w", err)
+	}
+	if expired {
+		return nil, entity.ErrPromoCodeExpired
+	}
+	return &p, nil
+}
+
+// redeem - counts a use of the code and records it against the purchase.
+func redeem(ctx context.Context, tx pgx.Tx, b sq.StatementBuilderType, promo *entity.PromoCode, p entity.Purchase) error {
+	sql, args, err := b.
+		Update("promo_codes").
+		Set("used", sq.Expr("used + 1")).
+		Where(sq.Eq{"id": promo.Id}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("redeem - b.Update: %w", err)
+	}
+	_, err = tx.Exec(ctx, sql, args...)
+	if err != nil {
+		return fmt.Errorf("redeem - tx.Exec('promo_codes'): %w", err)
+	}
+	promo.Used++
+
+	sql, args, err = b.
+		Insert("promo_redemptions").
+		Columns("promo_code_id", "purchase_id", "user_id", "discount").
+		Values(promo.Id, p.Id, p.BuyerId, p.Discount).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("redeem - b.Insert: %w", err)
+	}
+	_, err = tx.Exec(ctx, sql, args...)
+	if err != nil {
+		return fmt.Errorf("redeem - tx.Exec('promo_redemptions'): %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,219 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Klef99/bhs-task/internal/entity"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+)
+
+// _activeAccess - access rows that have not expired yet.
+const _activeAccess = "(access_assets.expires_at IS NULL OR access_assets.expires_at > now())"
+
+// purchaseOptions - deviations from a regular fixed-price purchase.
+type purchaseOptions struct {
+	price     *float64          // price agreed elsewhere (auction, offer), the listed price if nil
+	prepaid   bool              // the buyer's funds are already held, do not charge them again
+	receiptId *int64            // the checkout the purchase is part of
+	promo     *entity.PromoCode // locked by the caller, redeemed on success
+	recipient *int64            // gets the asset instead of the buyer, who still pays for it
+	message   string            // note from the buyer to the recipient of a gift
+}
+
+// purchase - charges the buyer, hands the asset over and records the sale, all within tx.
+func purchase(ctx context.Context, tx pgx.Tx, b sq.StatementBuilderType, buyer entity.User, id int64, opts purchaseOptions) (entity.Purchase, error) {
+	sql, args, err := b.
+		Select("price, owner_id, status, sale_mode, stock, sold, deleted_at, access_days").
+		Column("EXISTS (SELECT 1 FROM auctions WHERE auctions.asset_id = assets.id AND auctions.status = ?)", entity.AuctionStatusOpen).
+		From("assets").
+		Where(sq.Eq{"id": id}).
+		Suffix("FOR UPDATE").
+		ToSql()
+	if err != nil {
+		return entity.Purchase{}, fmt.Errorf("purchase - b.Select('assets'): %w", err)
+	}
+	p := entity.Purchase{AssetId: id, BuyerId: buyer.Id, ReceiptId: opts.receiptId, RecipientId: opts.recipient, GiftMessage: opts.message}
+	var status entity.AssetStatus
+	var stock *int64
+	var sold int64
+	var deletedAt *time.Time
+	var accessDays *int64
+	var onAuction bool
+	err = tx.QueryRow(ctx, sql, args...).Scan(&p.Price, &p.SellerId, &status, &p.SaleMode, &stock, &sold, &deletedAt, &accessDays, &onAuction)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return entity.Purchase{}, fmt.Errorf("purchase - row.Scan: %w (%w)", err, entity.ErrAssetNotFound)
+	}
+	if err != nil {
+		return entity.Purchase{}, fmt.Errorf("purchase - row.Scan: %w", err)
+	}
+	if p.SellerId == buyer.Id {
+		return entity.Purchase{}, fmt.Errorf("purchase: %w", entity.ErrOwnAsset) // This validation is here, as we get information about the owner of the asset in the transaction.
+	}
+	if opts.recipient != nil && p.SellerId == *opts.recipient {
+		return entity.Purchase{}, fmt.Errorf("purchase - recipient is the owner: %w", entity.ErrRecipientOwnsAsset)
+	}
+	if status != entity.AssetStatusPublished || deletedAt != nil {
+		return entity.Purchase{}, fmt.Errorf("purchase - status %s, deleted %t: %w", status, deletedAt != nil, entity.ErrAssetNotAvailable)
+	}
+	if onAuction {
+		return entity.Purchase{}, fmt.Errorf("purchase - asset is on auction: %w", entity.ErrAssetNotAvailable)
+	}
+	if stock != nil && sold >= *stock {
+		return entity.Purchase{}, fmt.Errorf("purchase - %d of %d sold: %w", sold, *stock, entity.ErrAssetSoldOut)
+	}
+
+	if opts.price != nil {
+		p.Price = *opts.price
+	}
+	if opts.promo != nil {
+		if !opts.promo.AppliesTo(id, p.SellerId) {
+			return entity.Purchase{}, fmt.Errorf("purchase - promo code %s: %w", opts.promo.Code, entity.ErrPromoCodeNotApplicable)
+		}
+		if opts.promo.UsedUp() {
+			return entity.Purchase{}, fmt.Errorf("purchase - promo code %s: %w", opts.promo.Code, entity.ErrPromoCodeExpired)
+		}
+		p.Discount = opts.promo.Discount(p.Price)
+		p.Price -= p.Discount
+	}
+	if !opts.prepaid {
+		err = charge(ctx, tx, b, buyer.Id, p.Price)
+		if err != nil {
+			return entity.Purchase{}, fmt.Errorf("purchase - charge: %w", err)
+		}
+	}
+
+	// The row is locked above, so the counter doubles as the edition sequence.
+	sql, args, err = b.
+		Update("assets").
+		Set("sold", sq.Expr("sold + 1")).
+		Where(sq.Eq{"id": id}).
+		Suffix("RETURNING sold").
+		ToSql()
+	if err != nil {
+		return entity.Purchase{}, fmt.Errorf("purchase - b.Update('assets.sold'): %w", err)
+	}
+	err = tx.QueryRow(ctx, sql, args...).Scan(&sold)
+	if err != nil {
+		return entity.Purchase{}, fmt.Errorf("purchase - tx.QueryRow('assets.sold'): %w", err)
+	}
+	if stock != nil {
+		edition := sold
+		p.Edition = &edition
+	}
+
+	switch p.SaleMode {
+	case entity.SaleModeTransfer:
+		sql, args, err = b.
+			Update("assets").
+			Set("owner_id", p.Holder()).
+			Set("status", entity.AssetStatusUnlisted).
+			Where(sq.Eq{"id": id}).
+			ToSql()
+		if err != nil {
+			return entity.Purchase{}, fmt.Errorf("purchase - b.Update('assets'): %w", err)
+		}
+		_, err = tx.Exec(ctx, sql, args...)
+		if err != nil {
+			return entity.Purchase{}, fmt.Errorf("purchase - tx.Exec('assets'): %w", err)
+		}
+	default:
+		var expiresAt interface{}
+		if accessDays != nil {
+			expiresAt = sq.Expr("now() + make_interval(days => ?)", *accessDays)
+		}
+		// An expired access is bought again, a current one means the holder already has the asset.
+		sql, args, err = b.
+			Insert("access_assets").
+			Columns("asset_id", "user_id", "edition", "expires_at").
+			Values(id, p.Holder(), p.Edition, expiresAt).
+			Suffix(`on conflict (asset_id, user_id) do update
+				set edition = excluded.edition, expires_at = excluded.expires_at
+				where access_assets.expires_at <= now()
+				returning expires_at`).
+			ToSql()
+		if err != nil {
+			return entity.Purchase{}, fmt.Errorf("purchase - b.Insert('access_assets'): %w", err)
+		}
+		err = tx.QueryRow(ctx, sql, args...).Scan(&p.AccessExpiresAt)
+		if errors.Is(err, pgx.ErrNoRows) && opts.recipient != nil {
+			return entity.Purchase{}, fmt.Errorf("purchase - access_assets: %w", entity.ErrRecipientOwnsAsset)
+		}
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entity.Purchase{}, fmt.Errorf("purchase - access_assets: %w", entity.ErrAssetAlreadyPurchased)
+		}
+		if err != nil {
+			return entity.Purchase{}, fmt.Errorf("purchase - tx.QueryRow('access_assets'): %w", err)
+		}
+	}
+
+	sql, args, err = b.
+		Insert("purchases").
+		Columns("asset_id", "buyer_id", "seller_id", "price", "sale_mode", "edition", "receipt_id", "recipient_id", "gift_message").
+		Values(p.AssetId, p.BuyerId, p.SellerId, p.Price, p.SaleMode, p.Edition, p.ReceiptId, p.RecipientId, sq.Expr("NULLIF(?, '')", p.GiftMessage)).
+		Suffix("RETURNING id, purchased_at").
+		ToSql()
+	if err != nil {
+		return entity.Purchase{}, fmt.Errorf("purchase - b.Insert('purchases'): %w", err)
+	}
+	err = tx.QueryRow(ctx, sql, args...).Scan(&p.Id, &p.PurchasedAt)
+	if err != nil {
+		return entity.Purchase{}, fmt.Errorf("purchase - tx.QueryRow('purchases'): %w", err)
+	}
+	if opts.promo != nil {
+		err = redeem(ctx, tx, b, opts.promo, p)
+		if err != nil {
+			return entity.Purchase{}, fmt.Errorf("purchase - redeem: %w", err)
+		}
+	}
+	err = writeOutbox(ctx, tx, b, entity.AggregateAsset, p.AssetId, purchasedEvent(p))
+	if err != nil {
+		return entity.Purchase{}, fmt.Errorf("purchase - writeOutbox: %w", err)
+	}
+	err = notifyMarket(ctx, tx, entity.MarketEvent{Type: entity.MarketAssetSold, AssetId: p.AssetId, OccurredAt: p.PurchasedAt})
+	if err != nil {
+		return entity.Purchase{}, fmt.Errorf("purchase - notifyMarket: %w", err)
+	}
+	return p, nil
+}
+
+// charge - takes amount from the user's balance, fails with entity.ErrInsufficientFunds if it is too low.
+func charge(ctx context.Context, tx pgx.Tx, b sq.StatementBuilderType, userId int64, amount float64) error {
+	sql, args, err := b.
+		Update("users").
+		Set("balance", sq.Expr("balance - ?", amount)).
+		Where(sq.Eq{"id": userId}).
+		Where(sq.GtOrEq{"balance": amount}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("charge - b.Update('users'): %w", err)
+	}
+	res, err := tx.Exec(ctx, sql, args...)
+	if err != nil {
+		return fmt.Errorf("charge - tx.Exec: %w", err)
+	}
+	if res.RowsAffected() == 0 {
+		return entity.ErrInsufficientFunds
+	}
+	return nil
+}
+
+// refund - returns amount to the user's balance.
+func refund(ctx context.Context, tx pgx.Tx, b sq.StatementBuilderType, userId int64, amount float64) error {
+	sql, args, err := b.
+		Update("users").
+		Set("balance", sq.Expr("balance + ?", amount)).
+		Where(sq.Eq{"id": userId}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("refund - b.Update('users'): %w", err)
+	}
+	_, err = tx.Exec(ctx, sql, args...)
+	if err != nil {
+		return fmt.Errorf("refund - tx.Exec: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+package repo
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/Klef99/bhs-task/internal/entity"
+	"github.com/Klef99/bhs-task/internal/usecase"
+)
+
+// memoryBucket - the policy is kept with the bucket to tell when it is full.
+type memoryBucket struct {
+	tokens    float64
+	updatedAt time.Time
+	rate      float64
+	burst     int
+}
+
+// refill - adds the tokens accumulated since the last update.
+func (b *memoryBucket) refill(now time.Time) {
+	b.tokens = math.Min(float64(b.burst), b.tokens+now.Sub(b.updatedAt).Seconds()*b.rate)
+	b.updatedAt = now
+}
+
+// RateLimitMemoryRepository - keeps the buckets in the process, each instance limits requests on its own.
+type RateLimitMemoryRepository struct {
+	mu      sync.Mutex
+	buckets map[string]*memoryBucket
+}
+
+var _ usecase.RateLimitRepository = (*RateLimitMemoryRepository)(nil)
+
+// New -.
+func NewRateLimitMemoryRepository() *RateLimitMemoryRepository {
+	return &RateLimitMemoryRepository{buckets: make(map[string]*memoryBucket)}
+}
+
+// Take - refills the bucket and takes a token from it if there is one, a new bucket starts full.
+func (r *RateLimitMemoryRepository) Take(_ context.Context, key string, policy entity.RateLimitPolicy) (entity.RateLimitBucket, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	b, ok := r.buckets[key]
+	if !ok {
+		b = &memoryBucket{tokens: float64(policy.Burst), updatedAt: now}
+		r.buckets[key] = b
+	}
+	// The policy may have changed since the bucket was created, the current one applies.
+	b.rate, b.burst = policy.Rate, policy.Burst
+	b.refill(now)
+	if b.tokens < 1 {
+		return entity.RateLimitBucket{Tokens: b.tokens, Allowed: false}, nil
+	}
+	b.tokens--
+	return entity.RateLimitBucket{Tokens: b.tokens, Allowed: true}, nil
+}
+
+// DeleteFull -.
+func (r *RateLimitMemoryRepository) DeleteFull(_ context.Context) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	var removed int64
+	for key, b := range r.buckets {
+		b.refill(now)
+		if b.tokens >= float64(b.burst) {
+			delete(r.buckets, key)
+			removed++
+		}
+	}
+	return removed, nil
+}
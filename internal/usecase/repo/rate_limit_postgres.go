@@ -0,0 +1,69 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Klef99/bhs-task/internal/entity"
+	"github.com/Klef99/bhs-task/internal/usecase"
+	"github.com/Klef99/bhs-task/pkg/postgres"
+	sq "github.com/Masterminds/squirrel"
+)
+
+// _refilled - tokens in a stored bucket refilled for the time since its last update, capped at the burst.
+// Rate and burst come from the request, so a changed policy applies to existing buckets.
+const _refilled = "LEAST(excluded.burst, rate_limits.tokens + EXTRACT(EPOCH FROM now() - rate_limits.updated_at) * excluded.rate)"
+
+// RateLimitRepository - keeps the buckets in the database, so instances share the limits.
+type RateLimitRepository struct {
+	*postgres.Postgres
+}
+
+var _ usecase.RateLimitRepository = (*RateLimitRepository)(nil)
+
+// New -.
+func NewRateLimitRepository(pg *postgres.Postgres) *RateLimitRepository {
+	return &RateLimitRepository{pg}
+}
+
+// Take - refills the bucket and takes a token from it if there is one, a new bucket starts full.
+// It is a single statement, the row lock of the upsert keeps concurrent requests from taking the same token.
+func (r *RateLimitRepository) Take(ctx context.Context, key string, policy entity.RateLimitPolicy) (entity.RateLimitBucket, error) {
+	sql, args, err := r.Builder.
+		Insert("rate_limits").
+		Columns("bucket", "tokens", "allowed", "rate", "burst", "updated_at").
+		Values(key, policy.Burst-1, true, policy.Rate, policy.Burst, sq.Expr("now()")).
+		Suffix(fmt.Sprintf(`ON CONFLICT (bucket) DO UPDATE SET
+			allowed = %[1]s >= 1,
+			tokens = CASE WHEN %[1]s >= 1 THEN %[1]s - 1 ELSE %[1]s END,
+			rate = excluded.rate,
+			burst = excluded.burst,
+			updated_at = now()`, _refilled)).
+		Suffix("RETURNING tokens, allowed").
+		ToSql()
+	if err != nil {
+		return entity.RateLimitBucket{}, fmt.Errorf("RateLimitRepository - Take - r.Builder: %w", err)
+	}
+	var bucket entity.RateLimitBucket
+	err = r.Pool.QueryRow(ctx, sql, args...).Scan(&bucket.Tokens, &bucket.Allowed)
+	if err != nil {
+		return entity.RateLimitBucket{}, fmt.Errorf("RateLimitRepository - Take - r.Pool.QueryRow: %w", err)
+	}
+	return bucket, nil
+}
+
+// DeleteFull -.
+func (r *RateLimitRepository) DeleteFull(ctx context.Context) (int64, error) {
+	sql, args, err := r.Builder.
+		Delete("rate_limits").
+		Where("tokens + EXTRACT(EPOCH FROM now() - updated_at) * rate >= burst").
+		ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("RateLimitRepository - DeleteFull - r.Builder: %w", err)
+	}
+	res, err := r.Pool.Exec(ctx, sql, args...)
+	if err != nil {
+		return 0, fmt.Errorf("RateLimitRepository - DeleteFull - r.Pool.Exec: %w", err)
+	}
+	return res.RowsAffected(), nil
+}
@@ -0,0 +1,181 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Klef99/bhs-task/internal/entity"
+	"github.com/Klef99/bhs-task/internal/usecase"
+	"github.com/Klef99/bhs-task/pkg/postgres"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+const _reviewColumns = "id, asset_id, user_id, rating, body, reply, replied_at, created_at"
+
+// ReviewRepository -.
+type ReviewRepository struct {
+	*postgres.Postgres
+}
+
+var _ usecase.ReviewRepository = (*ReviewRepository)(nil)
+
+// New -.
+func NewReviewRepository(pg *postgres.Postgres) *ReviewRepository {
+	return &ReviewRepository{pg}
+}
+
+// Create - stores the review and adds it to the asset's rating. Only users with access to the asset
+// can review it, once; the owner can't review their own asset.
+func (r *ReviewRepository) Create(ctx context.Context, review entity.Review) (entity.Review, error) {
+	tx, err := r.Pool.Begin(ctx)
+	if err != nil {
+		return entity.Review{}, fmt.Errorf("ReviewRepository - Create - r.Pool.Begin: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	sql, args, err := r.Builder.
+		Select("owner_id").
+		Column("EXISTS (SELECT 1 FROM access_assets WHERE access_assets.asset_id = assets.id AND access_assets.user_id = ? AND "+_activeAccess+")", review.UserId).
+		From("assets").
+		Where(sq.Eq{"id": review.AssetId}).
+		ToSql()
+	if err != nil {
+		return entity.Review{}, fmt.Errorf("ReviewRepository - Create - r.Builder.Select: %w", err)
+	}
+	var ownerId int64
+	var bought bool
+	err = tx.QueryRow(ctx, sql, args...).Scan(&ownerId, &bought)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return entity.Review{}, fmt.Errorf("ReviewRepository - Create - row.Scan: %w", entity.ErrAssetNotFound)
+	}
+	if err != nil {
+		return entity.Review{}, fmt.Errorf("ReviewRepository - Create - row.Scan: %w", err)
+	}
+	if !bought || ownerId == review.UserId {
+		return entity.Review{}, fmt.Errorf("ReviewRepository - Create: %w", entity.ErrAccessNotFound)
+	}
+
+	sql, args, err = r.Builder.
+		Insert("reviews").
+		Columns("asset_id", "user_id", "rating", "body").
+		Values(review.AssetId, review.UserId, review.Rating, review.Text).
+		Suffix("RETURNING id, created_at").
+		ToSql()
+	if err != nil {
+		return entity.Review{}, fmt.Errorf("ReviewRepository - Create - r.Builder.Insert: %w", err)
+	}
+	err = tx.QueryRow(ctx, sql, args...).Scan(&review.Id, &review.CreatedAt)
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == _uniqueViolation {
+		return entity.Review{}, fmt.Errorf("ReviewRepository - Create: %w", entity.ErrReviewExists)
+	}
+	if err != nil {
+		return entity.Review{}, fmt.Errorf("ReviewRepository - Create - row.Scan: %w", err)
+	}
+
+	sql, args, err = r.Builder.
+		Update("assets").
+		Set("rating_sum", sq.Expr("rating_sum + ?", review.Rating)).
+		Set("rating_count", sq.Expr("rating_count + 1")).
+		Where(sq.Eq{"id": review.AssetId}).
+		ToSql()
+	if err != nil {
+		return entity.Review{}, fmt.Errorf("ReviewRepository - Create - r.Builder.Update: %w", err)
+	}
+	_, err = tx.Exec(ctx, sql, args...)
+	if err != nil {
+		return entity.Review{}, fmt.Errorf("ReviewRepository - Create - tx.Exec('assets'): %w", err)
+	}
+	err = tx.Commit(ctx)
+	if err != nil {
+		return entity.Review{}, fmt.Errorf("ReviewRepository - Create - tx.Commit: %w", err)
+	}
+	return review, nil
+}
+
+// GetByAsset - reviews of the asset, newest first. Fails with entity.ErrAssetNotFound if the user can't see the asset.
+func (r *ReviewRepository) GetByAsset(ctx context.Context, user entity.User, assetId int64) ([]entity.Review, error) {
+	err := checkVisible(ctx, r.Postgres, user.Id, assetId)
+	if err != nil {
+		return nil, fmt.Errorf("ReviewRepository - GetByAsset - checkVisible: %w", err)
+	}
+	sql, args, err := r.Builder.
+		Select(_reviewColumns).
+		From("reviews").
+		Where(sq.Eq{"asset_id": assetId}).
+		OrderBy("created_at DESC", "id DESC").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("ReviewRepository - GetByAsset - r.Builder: %w", err)
+	}
+	rows, err := r.Pool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("ReviewRepository - GetByAsset - r.Pool.Query: %w", err)
+	}
+	defer rows.Close()
+	reviews := make([]entity.Review, 0)
+	for rows.Next() {
+		rv := entity.Review{}
+		err := rows.Scan(&rv.Id, &rv.AssetId, &rv.UserId, &rv.Rating, &rv.Text, &rv.Reply, &rv.RepliedAt, &rv.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("ReviewRepository - GetByAsset - rows.Scan: %w", err)
+		}
+		reviews = append(reviews, rv)
+	}
+	return reviews, nil
+}
+
+// Reply - the current owner of the reviewed asset answers the review, once.
+func (r *ReviewRepository) Reply(ctx context.Context, user entity.User, id int64, reply string) (entity.Review, error) {
+	tx, err := r.Pool.Begin(ctx)
+	if err != nil {
+		return entity.Review{}, fmt.Errorf("ReviewRepository - Reply - r.Pool.Begin: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	sql, args, err := r.Builder.
+		Select("reviews.reply IS NOT NULL").
+		From("reviews").
+		Join("assets ON assets.id = reviews.asset_id").
+		Where(sq.Eq{"reviews.id": id, "assets.owner_id": user.Id}).
+		Suffix("FOR UPDATE OF reviews").
+		ToSql()
+	if err != nil {
+		return entity.Review{}, fmt.Errorf("ReviewRepository - Reply - r.Builder.Select: %w", err)
+	}
+	var replied bool
+	err = tx.QueryRow(ctx, sql, args...).Scan(&replied)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return entity.Review{}, fmt.Errorf("ReviewRepository - Reply - row.Scan: %w", entity.ErrReviewNotFound)
+	}
+	if err != nil {
+		return entity.Review{}, fmt.Errorf("ReviewRepository - Reply - row.Scan: %w", err)
+	}
+	if replied {
+		return entity.Review{}, fmt.Errorf("ReviewRepository - Reply: %w", entity.ErrReviewReplied)
+	}
+
+	sql, args, err = r.Builder.
+		Update("reviews").
+		Set("reply", reply).
+		Set("replied_at", sq.Expr("now()")).
+		Where(sq.Eq{"id": id}).
+		Suffix("RETURNING " + _reviewColumns).
+		ToSql()
+	if err != nil {
+		return entity.Review{}, fmt.Errorf("ReviewRepository - Reply - r.Builder.Update: %w", err)
+	}
+	rv := entity.Review{}
+	err = tx.QueryRow(ctx, sql, args...).Scan(&rv.Id, &rv.AssetId, &rv.UserId, &rv.Rating, &rv.Text, &rv.Reply, &rv.RepliedAt, &rv.CreatedAt)
+	if err != nil {
+		return entity.Review{}, fmt.Errorf("ReviewRepository - Reply - row.Scan: %w", err)
+	}
+	err = tx.Commit(ctx)
+	if err != nil {
+		return entity.Review{}, fmt.Errorf("ReviewRepository - Reply - tx.Commit: %w", err)
+	}
+	return rv, nil
+}
@@ -0,0 +1,95 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Klef99/bhs-task/internal/entity"
+	"github.com/Klef99/bhs-task/internal/usecase"
+	"github.com/Klef99/bhs-task/pkg/postgres"
+	sq "github.com/Masterminds/squirrel"
+)
+
+// StatsRepository -.
+type StatsRepository struct {
+	*postgres.Postgres
+}
+
+var _ usecase.StatsRepository = (*StatsRepository)(nil)
+
+// New -.
+func NewStatsRepository(pg *postgres.Postgres) *StatsRepository {
+	return &StatsRepository{pg}
+}
+
+// salesOf - purchase records of the seller's sales within [from, to).
+func salesOf(user entity.User, from, to time.Time) sq.And {
+	return sq.And{
+		sq.Eq{"purchases.seller_id": user.Id},
+		sq.GtOrEq{"purchases.purchased_at": from},
+		sq.Lt{"purchases.purchased_at": to},
+	}
+}
+
+// GetAssetSales - units sold and revenue per asset, best selling first.
+func (r *StatsRepository) GetAssetSales(ctx context.Context, user entity.User, from, to time.Time) ([]entity.AssetSales, error) {
+	sql, args, err := r.Builder.
+		Select("assets.id, assets.name, count(*), COALESCE(sum(purchases.price), 0)").
+		From("purchases").
+		Join("assets ON assets.id = purchases.asset_id").
+		Where(salesOf(user, from, to)).
+		GroupBy("assets.id", "assets.name").
+		OrderBy("sum(purchases.price) DESC", "assets.id").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("StatsRepository - GetAssetSales - r.Builder: %w", err)
+	}
+	rows, err := r.Pool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("StatsRepository - GetAssetSales - r.Pool.Query: %w", err)
+	}
+	defer rows.Close()
+	sales := make([]entity.AssetSales, 0)
+	for rows.Next() {
+		s := entity.AssetSales{}
+		err := rows.Scan(&s.AssetId, &s.Name, &s.Units, &s.Revenue)
+		if err != nil {
+			return nil, fmt.Errorf("StatsRepository - GetAssetSales - rows.Scan: %w", err)
+		}
+		sales = append(sales, s)
+	}
+	return sales, nil
+}
+
+// GetSalesSeries - units sold and revenue per UTC bucket, oldest first. Buckets without sales are left out.
+func (r *StatsRepository) GetSalesSeries(ctx context.Context, user entity.User, from, to time.Time, bucket entity.StatsBucket) ([]entity.SalesPoint, error) {
+	sql, args, err := r.Builder.
+		Select().
+		Column("date_trunc(?, purchases.purchased_at AT TIME ZONE 'UTC') AS start", string(bucket)).
+		Column("count(*), COALESCE(sum(purchases.price), 0)").
+		From("purchases").
+		Where(salesOf(user, from, to)).
+		GroupBy("start").
+		OrderBy("start").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("StatsRepository - GetSalesSeries - r.Builder: %w", err)
+	}
+	rows, err := r.Pool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("StatsRepository - GetSalesSeries - r.Pool.Query: %w", err)
+	}
+	defer rows.Close()
+	series := make([]entity.SalesPoint, 0)
+	for rows.Next() {
+		p := entity.SalesPoint{}
+		err := rows.Scan(&p.Start, &p.Units, &p.Revenue)
+		if err != nil {
+			return nil, fmt.Errorf("StatsRepository - GetSalesSeries - rows.Scan: %w", err)
+		}
+		p.Start = p.Start.UTC()
+		series = append(series, p)
+	}
+	return series, nil
+}
@@ -3,6 +3,7 @@ package repo
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/Klef99/bhs-task/internal/entity"
 	"github.com/Klef99/bhs-task/internal/usecase"
@@ -26,7 +27,7 @@ func NewUserRepository(pg *postgres.Postgres, hs hasher.Interface) *UserReposito
 
 // CreateUser -.
 func (r *UserRepository) CreateUser(ctx context.Context, crd entity.Credentials) (bool, error) {
-	hashedBytes, err := r.Hasher.HashPassword(crd.Password)
+	hashedBytes, err := r.Hasher.HashPassword(ctx, crd.Password)
 	if err != nil {
 		return false, fmt.Errorf("UserRepository - CreateUser - bcrypt.GenerateFromPassword: %w", err)
 	}
@@ -65,7 +66,7 @@ func (r *UserRepository) LoginUser(ctx context.Context, crd entity.Credentials)
 			return -1, fmt.Errorf("UserRepository - LoginUser - rows.Scan: %w", err)
 		}
 	}
-	err = r.Hasher.CompareHashAndPassword(passwordHash, crd.Password)
+	err = r.Hasher.CompareHashAndPassword(ctx, passwordHash, crd.Password)
 	if err != nil {
 		return -1, fmt.Errorf("UserRepository - LoginUser - bcrypt.CompareHashAndPassword: %w", err)
 	}
@@ -102,6 +103,16 @@ func (r *UserRepository) MakeDeposit(ctx context.Context, user entity.User, amou
 		}
 	}
 	rows.Close()
+	err = writeOutbox(ctx, tx, r.Builder, entity.AggregateUser, user.Id, entity.Event{
+		Type:       entity.EventDepositCompleted,
+		OccurredAt: time.Now().UTC(),
+		Data:       entity.Deposit{UserId: user.Id, Amount: amount, Balance: balance},
+		UserIds:    []int64{user.Id},
+	})
+	if err != nil {
+		tx.Rollback(ctx)
+		return -1, fmt.Errorf("UserRepository - MakeDeposit - writeOutbox: %w", err)
+	}
 	err = tx.Commit(ctx)
 	if err != nil {
 		tx.Rollback(ctx)
@@ -124,3 +135,40 @@ func (r *UserRepository) CheckDeposit(ctx context.Context, user entity.User) (fl
 	}
 	return balance, nil
 }
+
+// GetRole -.
+func (r *UserRepository) GetRole(ctx context.Context, user entity.User) (entity.Role, error) {
+	sql, args, err := r.Builder.Select("role").From("users").Where(sq.Eq{"id": user.Id}).ToSql()
+	if err != nil {
+		return "", fmt.Errorf("UserRepository - GetRole - r.Builder: %w", err)
+	}
+	var role entity.Role
+	err = r.Pool.QueryRow(ctx, sql, args...).Scan(&role)
+	if err != nil {
+		return "", fmt.Errorf("UserRepository - GetRole - row.Scan: %w", err)
+	}
+	return role, nil
+}
+
+// GetUsersByIds - users that don't exist are left out, the order is not kept.
+func (r *UserRepository) GetUsersByIds(ctx context.Context, ids []int64) ([]entity.User, error) {
+	sql, args, err := r.Builder.Select("id, username").From("users").Where("id = ANY(?)", ids).ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("UserRepository - GetUsersByIds - r.Builder: %w", err)
+	}
+	rows, err := r.Pool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("UserRepository - GetUsersByIds - r.Pool.Query: %w", err)
+	}
+	defer rows.Close()
+	users := make([]entity.User, 0, len(ids))
+	for rows.Next() {
+		u := entity.User{}
+		err := rows.Scan(&u.Id, &u.Username)
+		if err != nil {
+			return nil, fmt.Errorf("UserRepository - GetUsersByIds - rows.Scan: %w", err)
+		}
+		users = append(users, u)
+	}
+	return users, nil
+}
@@ -0,0 +1,295 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Klef99/bhs-task/internal/entity"
+	"github.com/Klef99/bhs-task/internal/usecase"
+	"github.com/Klef99/bhs-task/pkg/postgres"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+)
+
+// WebhookRepository -.
+type WebhookRepository struct {
+	*postgres.Postgres
+}
+
+var _ usecase.WebhookRepository = (*WebhookRepository)(nil)
+
+// New -.
+func NewWebhookRepository(pg *postgres.Postgres) *WebhookRepository {
+	return &WebhookRepository{pg}
+}
+
+func (r *WebhookRepository) Create(ctx context.Context, wh entity.Webhook) (entity.Webhook, error) {
+	events := make([]string, 0, len(wh.Events))
+	for _, e := range wh.Events {
+		events = append(events, string(e))
+	}
+	sql, args, err := r.Builder.
+		Insert("webhooks").
+		Columns("user_id", "url", "secret", "events", `"global"`).
+		Values(wh.UserId, wh.URL, wh.Secret, events, wh.Global).
+		Suffix("RETURNING id, created_at").
+		ToSql()
+	if err != nil {
+		return entity.Webhook{}, fmt.Errorf("WebhookRepository - Create - r.Builder: %w", err)
+	}
+	err = r.Pool.QueryRow(ctx, sql, args...).Scan(&wh.Id, &wh.CreatedAt)
+	if err != nil {
+		return entity.Webhook{}, fmt.Errorf("WebhookRepository - Create - r.Pool.QueryRow: %w", err)
+	}
+	return wh, nil
+}
+
+// GetByUser - webhooks registered by the user, without their secrets.
+func (r *WebhookRepository) GetByUser(ctx context.Context, user entity.User) ([]entity.Webhook, error) {
+	sql, args, err := r.Builder.
+		Select(`id, user_id, url, events, "global", created_at`).
+		From("webhooks").
+		Where(sq.Eq{"user_id": user.Id}).
+		OrderBy("id").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("WebhookRepository - GetByUser - r.Builder: %w", err)
+	}
+	rows, err := r.Pool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("WebhookRepository - GetByUser - r.Pool.Query: %w", err)
+	}
+	defer rows.Close()
+	webhooks := make([]entity.Webhook, 0)
+	for rows.Next() {
+		wh := entity.Webhook{}
+		var events []string
+		err := rows.Scan(&wh.Id, &wh.UserId, &wh.URL, &events, &wh.Global, &wh.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("WebhookRepository - GetByUser - rows.Scan: %w", err)
+		}
+		for _, e := range events {
+			wh.Events = append(wh.Events, entity.EventType(e))
+		}
+		webhooks = append(webhooks, wh)
+	}
+	return webhooks, nil
+}
+
+// Delete - removes the user's webhook together with its deliveries.
+func (r *WebhookRepository) Delete(ctx context.Context, user entity.User, id int64) (bool, error) {
+	sql, args, err := r.Builder.
+		Delete("webhooks").
+		Where(sq.Eq{"id": id, "user_id": user.Id}).
+		ToSql()
+	if err != nil {
+		return false, fmt.Errorf("WebhookRepository - Delete - r.Builder: %w", err)
+	}
+	res, err := r.Pool.Exec(ctx, sql, args...)
+	if err != nil {
+		return false, fmt.Errorf("WebhookRepository - Delete - r.Pool.Exec: %w", err)
+	}
+	return res.RowsAffected() > 0, nil
+}
+
+// Enqueue - schedules a delivery of the payload to every webhook subscribed to the event type,
+// that is the global ones and those of the given users. Returns the number of deliveries.
+func (r *WebhookRepository) Enqueue(ctx context.Context, eventType entity.EventType, payload []byte, userIds []int64) (int64, error) {
+	subscribed := r.Builder.
+		Select("id").
+		Column("?", string(eventType)).
+		Column("?::jsonb", string(payload)).
+		From("webhooks").
+		Where("? = ANY(events)", string(eventType)).
+		Where(sq.Or{sq.Eq{`"global"`: true}, sq.Expr("user_id = ANY(?)", userIds)})
+	sql, args, err := r.Builder.
+		Insert("webhook_deliveries").
+		Columns("webhook_id", "event_type", "payload").
+		Select(subscribed).
+		ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("WebhookRepository - Enqueue - r.Builder: %w", err)
+	}
+	res, err := r.Pool.Exec(ctx, sql, args...)
+	if err != nil {
+		return 0, fmt.Errorf("WebhookRepository - Enqueue - r.Pool.Exec: %w", err)
+	}
+	return res.RowsAffected(), nil
+}
+
+// GetDeliveries - deliveries of the user's webhook, newest first.
+func (r *WebhookRepository) GetDeliveries(ctx context.Context, user entity.User, webhookId int64) ([]entity.WebhookDelivery, error) {
+	sql, args, err := r.Builder.
+		Select("1").
+		From("webhooks").
+		Where(sq.Eq{"id": webhookId, "user_id": user.Id}).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("WebhookRepository - GetDeliveries - r.Builder.Select('webhooks'): %w", err)
+	}
+	var one int
+	err = r.Pool.QueryRow(ctx, sql, args...).Scan(&one)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("WebhookRepository - GetDeliveries - row.Scan: %w", entity.ErrWebhookNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("WebhookRepository - GetDeliveries - row.Scan: %w", err)
+	}
+
+	sql, args, err = r.selectDelivery().
+		Where(sq.Eq{"webhook_deliveries.webhook_id": webhookId}).
+		OrderBy("webhook_deliveries.created_at DESC", "webhook_deliveries.id DESC").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("WebhookRepository - GetDeliveries - r.Builder: %w", err)
+	}
+	rows, err := r.Pool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("WebhookRepository - GetDeliveries - r.Pool.Query: %w", err)
+	}
+	defer rows.Close()
+	deliveries := make([]entity.WebhookDelivery, 0)
+	for rows.Next() {
+		d, err := scanDelivery(rows)
+		if err != nil {
+			return nil, fmt.Errorf("WebhookRepository - GetDeliveries - rows.Scan: %w", err)
+		}
+		d.URL, d.Secret = "", ""
+		deliveries = append(deliveries, d)
+	}
+	return deliveries, nil
+}
+
+// Redeliver - puts a delivery of the user's webhook back in the queue with a fresh set of attempts.
+func (r *WebhookRepository) Redeliver(ctx context.Context, user entity.User, id int64) (entity.WebhookDelivery, error) {
+	owned := r.Builder.
+		Select("id").
+		From("webhooks").
+		Where(sq.Eq{"user_id": user.Id})
+	ownedSql, ownedArgs, err := owned.ToSql()
+	if err != nil {
+		return entity.WebhookDelivery{}, fmt.Errorf("WebhookRepository - Redeliver - r.Builder.Select('webhooks'): %w", err)
+	}
+	sql, args, err := r.Builder.
+		Update("webhook_deliveries").
+		Set("status", entity.DeliveryStatusPending).
+		Set("attempts", 0).
+		Set("next_attempt_at", sq.Expr("now()")).
+		Set("delivered_at", nil).
+		Where(sq.Eq{"id": id}).
+		Where(sq.Expr("webhook_id IN ("+ownedSql+")", ownedArgs...)).
+		Suffix("RETURNING id, webhook_id, event_type, payload, status, attempts, next_attempt_at, last_status_code, COALESCE(last_error, ''), created_at, delivered_at").
+		ToSql()
+	if err != nil {
+		return entity.WebhookDelivery{}, fmt.Errorf("WebhookRepository - Redeliver - r.Builder: %w", err)
+	}
+	d := entity.WebhookDelivery{}
+	err = r.Pool.QueryRow(ctx, sql, args...).Scan(&d.Id, &d.WebhookId, &d.EventType, &d.Payload, &d.Status, &d.Attempts,
+		&d.NextAttemptAt, &d.LastStatusCode, &d.LastError, &d.CreatedAt, &d.DeliveredAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return entity.WebhookDelivery{}, fmt.Errorf("WebhookRepository - Redeliver - row.Scan: %w", entity.ErrWebhookDeliveryNotFound)
+	}
+	if err != nil {
+		return entity.WebhookDelivery{}, fmt.Errorf("WebhookRepository - Redeliver - row.Scan: %w", err)
+	}
+	return d, nil
+}
+
+// ClaimDue - pending deliveries whose next attempt is due, with the URL and secret of their webhook.
+// Their next attempt is pushed back by lease so that other instances skip them while they are being sent.
+func (r *WebhookRepository) ClaimDue(ctx context.Context, limit uint64, lease time.Duration) ([]entity.WebhookDelivery, error) {
+	tx, err := r.Pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("WebhookRepository - ClaimDue - r.Pool.Begin: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	sql, args, err := r.selectDelivery().
+		Where(sq.Eq{"webhook_deliveries.status": entity.DeliveryStatusPending}).
+		Where("webhook_deliveries.next_attempt_at <= now()").
+		OrderBy("webhook_deliveries.next_attempt_at").
+		Limit(limit).
+		Suffix("FOR UPDATE OF webhook_deliveries SKIP LOCKED").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("WebhookRepository - ClaimDue - r.Builder: %w", err)
+	}
+	rows, err := tx.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("WebhookRepository - ClaimDue - tx.Query: %w", err)
+	}
+	deliveries := make([]entity.WebhookDelivery, 0)
+	ids := make([]int64, 0)
+	for rows.Next() {
+		d, err := scanDelivery(rows)
+		if err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("WebhookRepository - ClaimDue - rows.Scan: %w", err)
+		}
+		deliveries = append(deliveries, d)
+		ids = append(ids, d.Id)
+	}
+	rows.Close()
+	if len(ids) == 0 {
+		return deliveries, nil
+	}
+
+	sql, args, err = r.Builder.
+		Update("webhook_deliveries").
+		Set("next_attempt_at", sq.Expr("now() + make_interval(secs => ?)", lease.Seconds())).
+		Where(sq.Eq{"id": ids}).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("WebhookRepository - ClaimDue - r.Builder.Update: %w", err)
+	}
+	_, err = tx.Exec(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("WebhookRepository - ClaimDue - tx.Exec: %w", err)
+	}
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("WebhookRepository - ClaimDue - tx.Commit: %w", err)
+	}
+	return deliveries, nil
+}
+
+// RecordAttempt - stores the outcome of a delivery attempt.
+func (r *WebhookRepository) RecordAttempt(ctx context.Context, d entity.WebhookDelivery) error {
+	sql, args, err := r.Builder.
+		Update("webhook_deliveries").
+		Set("status", d.Status).
+		Set("attempts", d.Attempts).
+		Set("next_attempt_at", d.NextAttemptAt).
+		Set("last_status_code", d.LastStatusCode).
+		Set("last_error", sq.Expr("NULLIF(?, '')", d.LastError)).
+		Set("delivered_at", d.DeliveredAt).
+		Where(sq.Eq{"id": d.Id}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("WebhookRepository - RecordAttempt - r.Builder: %w", err)
+	}
+	_, err = r.Pool.Exec(ctx, sql, args...)
+	if err != nil {
+		return fmt.Errorf("WebhookRepository - RecordAttempt - r.Pool.Exec: %w", err)
+	}
+	return nil
+}
+
+func (r *WebhookRepository) selectDelivery() sq.SelectBuilder {
+	return r.Builder.
+		Select("webhook_deliveries.id, webhook_deliveries.webhook_id, webhook_deliveries.event_type, webhook_deliveries.payload").
+		Columns("webhook_deliveries.status, webhook_deliveries.attempts, webhook_deliveries.next_attempt_at").
+		Columns("webhook_deliveries.last_status_code, COALESCE(webhook_deliveries.last_error, '')").
+		Columns("webhook_deliveries.created_at, webhook_deliveries.delivered_at, webhooks.url, webhooks.secret").
+		From("webhook_deliveries").
+		Join("webhooks ON webhooks.id = webhook_deliveries.webhook_id")
+}
+
+func scanDelivery(row pgx.Row) (entity.WebhookDelivery, error) {
+	d := entity.WebhookDelivery{}
+	err := row.Scan(&d.Id, &d.WebhookId, &d.EventType, &d.Payload, &d.Status, &d.Attempts, &d.NextAttemptAt,
+		&d.LastStatusCode, &d.LastError, &d.CreatedAt, &d.DeliveredAt, &d.URL, &d.Secret)
+	return d, err
+}
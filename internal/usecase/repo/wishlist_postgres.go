@@ -0,0 +1,113 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Klef99/bhs-task/internal/entity"
+	"github.com/Klef99/bhs-task/internal/usecase"
+	"github.com/Klef99/bhs-task/pkg/postgres"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+)
+
+// WishlistRepository -.
+type WishlistRepository struct {
+	*postgres.Postgres
+}
+
+var _ usecase.WishlistRepository = (*WishlistRepository)(nil)
+
+// New -.
+func NewWishlistRepository(pg *postgres.Postgres) *WishlistRepository {
+	return &WishlistRepository{pg}
+}
+
+// Add - saves a published asset of another user, it may be sold out or on auction. Adding it twice is a no-op.
+func (r *WishlistRepository) Add(ctx context.Context, user entity.User, assetId int64) (bool, error) {
+	sql, args, err := r.Builder.
+		Select("owner_id, status").
+		From("assets").
+		Where(sq.Eq{"id": assetId, "deleted_at": nil}).
+		ToSql()
+	if err != nil {
+		return false, fmt.Errorf("WishlistRepository - Add - r.Builder.Select: %w", err)
+	}
+	var ownerId int64
+	var status entity.AssetStatus
+	err = r.Pool.QueryRow(ctx, sql, args...).Scan(&ownerId, &status)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return false, fmt.Errorf("WishlistRepository - Add - row.Scan: %w", entity.ErrAssetNotFound)
+	}
+	if err != nil {
+		return false, fmt.Errorf("WishlistRepository - Add - row.Scan: %w", err)
+	}
+	if ownerId == user.Id {
+		return false, fmt.Errorf("WishlistRepository - Add - user can't wishlist their own asset")
+	}
+	if status != entity.AssetStatusPublished {
+		return false, fmt.Errorf("WishlistRepository - Add - status %s: %w", status, entity.ErrAssetNotAvailable)
+	}
+
+	sql, args, err = r.Builder.
+		Insert("wishlist_items").
+		Columns("user_id", "asset_id").
+		Values(user.Id, assetId).
+		Suffix("on conflict (user_id, asset_id) do nothing").
+		ToSql()
+	if err != nil {
+		return false, fmt.Errorf("WishlistRepository - Add - r.Builder.Insert: %w", err)
+	}
+	_, err = r.Pool.Exec(ctx, sql, args...)
+	if err != nil {
+		return false, fmt.Errorf("WishlistRepository - Add - r.Pool.Exec: %w", err)
+	}
+	return true, nil
+}
+
+func (r *WishlistRepository) Remove(ctx context.Context, user entity.User, assetId int64) (bool, error) {
+	sql, args, err := r.Builder.
+		Delete("wishlist_items").
+		Where(sq.Eq{"user_id": user.Id, "asset_id": assetId}).
+		ToSql()
+	if err != nil {
+		return false, fmt.Errorf("WishlistRepository - Remove - r.Builder: %w", err)
+	}
+	res, err := r.Pool.Exec(ctx, sql, args...)
+	if err != nil {
+		return false, fmt.Errorf("WishlistRepository - Remove - r.Pool.Exec: %w", err)
+	}
+	return res.RowsAffected() > 0, nil
+}
+
+// GetItems - the wishlist, newest first, with the current price and whether the asset is on the market.
+// Deleted assets stay on the wishlist as unavailable until removed by the user.
+func (r *WishlistRepository) GetItems(ctx context.Context, user entity.User) ([]entity.WishlistItem, error) {
+	sql, args, err := r.Builder.
+		Select("assets.id, assets.name, assets.price, assets.status, wishlist_items.added_at").
+		Column(onMarket(user.Id)).
+		From("wishlist_items").
+		Join("assets ON assets.id = wishlist_items.asset_id").
+		Where(sq.Eq{"wishlist_items.user_id": user.Id}).
+		OrderBy("wishlist_items.added_at DESC", "assets.id").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("WishlistRepository - GetItems - r.Builder: %w", err)
+	}
+	rows, err := r.Pool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("WishlistRepository - GetItems - r.Pool.Query: %w", err)
+	}
+	defer rows.Close()
+	items := make([]entity.WishlistItem, 0)
+	for rows.Next() {
+		i := entity.WishlistItem{}
+		err := rows.Scan(&i.AssetId, &i.Name, &i.Price, &i.Status, &i.AddedAt, &i.Available)
+		if err != nil {
+			return nil, fmt.Errorf("WishlistRepository - GetItems - rows.Scan: %w", err)
+		}
+		items = append(items, i)
+	}
+	return items, nil
+}
@@ -0,0 +1,68 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/Klef99/bhs-task/internal/entity"
+)
+
+// ReviewUseCase -.
+type ReviewUseCase struct {
+	repo ReviewRepository
+}
+
+var _ Review = (*ReviewUseCase)(nil)
+
+// New -.
+func NewReviewUseCase(r ReviewRepository) *ReviewUseCase {
+	return &ReviewUseCase{repo: r}
+}
+
+// PostReview - the user rates an asset they have access to, once per asset.
+func (uc *ReviewUseCase) PostReview(ctx context.Context, user entity.User, review entity.Review) (entity.Review, error) {
+	if user.Id <= 0 || review.AssetId <= 0 {
+		return entity.Review{}, fmt.Errorf("ReviewUseCase - PostReview - invalid user or asset id")
+	}
+	review.UserId = user.Id
+	review.Text = strings.TrimSpace(review.Text)
+	err := review.Validate()
+	if err != nil {
+		return entity.Review{}, fmt.Errorf("ReviewUseCase - PostReview - review.Validate: %w", err)
+	}
+	created, err := uc.repo.Create(ctx, review)
+	if err != nil {
+		return entity.Review{}, fmt.Errorf("ReviewUseCase - PostReview - uc.repo.Create: %w", err)
+	}
+	return created, nil
+}
+
+// GetReviews - reviews of an asset the user can see.
+func (uc *ReviewUseCase) GetReviews(ctx context.Context, user entity.User, assetId int64) ([]entity.Review, error) {
+	if user.Id <= 0 || assetId <= 0 {
+		return nil, fmt.Errorf("ReviewUseCase - GetReviews - invalid user or asset id")
+	}
+	reviews, err := uc.repo.GetByAsset(ctx, user, assetId)
+	if err != nil {
+		return nil, fmt.Errorf("ReviewUseCase - GetReviews - uc.repo.GetByAsset: %w", err)
+	}
+	return reviews, nil
+}
+
+// ReplyToReview - the owner of the reviewed asset answers the review, once.
+func (uc *ReviewUseCase) ReplyToReview(ctx context.Context, user entity.User, id int64, reply string) (entity.Review, error) {
+	if user.Id <= 0 || id <= 0 {
+		return entity.Review{}, fmt.Errorf("ReviewUseCase - ReplyToReview - invalid user or review id")
+	}
+	reply = strings.TrimSpace(reply)
+	if reply == "" || utf8.RuneCountInString(reply) > entity.MaxReviewLength {
+		return entity.Review{}, fmt.Errorf("ReviewUseCase - ReplyToReview - reply must be between 1 and %d characters", entity.MaxReviewLength)
+	}
+	rv, err := uc.repo.Reply(ctx, user, id, reply)
+	if err != nil {
+		return entity.Review{}, fmt.Errorf("ReviewUseCase - ReplyToReview - uc.repo.Reply: %w", err)
+	}
+	return rv, nil
+}
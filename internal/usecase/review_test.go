@@ -0,0 +1,257 @@
+package usecase_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/Klef99/bhs-task/internal/entity"
+	"github.com/Klef99/bhs-task/internal/usecase"
+	"github.com/stretchr/testify/require"
+	gomock "go.uber.org/mock/gomock"
+)
+
+type postReviewTest struct {
+	name   string
+	user   entity.User
+	review entity.Review
+	mock   func()
+	res    entity.Review
+	err    error
+}
+
+type getReviewsTest struct {
+	name    string
+	user    entity.User
+	assetId int64
+	mock    func()
+	res     []entity.Review
+	err     error
+}
+
+type replyToReviewTest struct {
+	name  string
+	user  entity.User
+	id    int64
+	reply string
+	mock  func()
+	res   entity.Review
+	err   error
+}
+
+func ReviewUseCase(t *testing.T) (*usecase.ReviewUseCase, *MockReviewRepository) {
+	t.Helper()
+
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+
+	repo := NewMockReviewRepository(mockCtl)
+
+	ReviewUseCase := usecase.NewReviewUseCase(repo)
+	return ReviewUseCase, repo
+}
+
+func TestPostReview(t *testing.T) {
+	t.Parallel()
+
+	review, repo := ReviewUseCase(t)
+	tests := []postReviewTest{
+		{
+			name:   "success",
+			user:   entity.User{Id: 2, Username: "test2"},
+			review: entity.Review{AssetId: 1, Rating: 5, Text: "  Great sword  "},
+			mock: func() {
+				repo.EXPECT().Create(context.Background(), entity.Review{AssetId: 1, UserId: 2, Rating: 5, Text: "Great sword"}).Return(entity.Review{Id: 1, AssetId: 1, UserId: 2, Rating: 5, Text: "Great sword"}, nil)
+			},
+			res: entity.Review{Id: 1, AssetId: 1, UserId: 2, Rating: 5, Text: "Great sword"},
+			err: nil,
+		},
+		{
+			name:   "rating too high",
+			user:   entity.User{Id: 2, Username: "test2"},
+			review: entity.Review{AssetId: 1, Rating: 6},
+			mock:   func() {},
+			res:    entity.Review{},
+			err:    fmt.Errorf("ReviewUseCase - PostReview - review.Validate: rating must be between 1 and 5"),
+		},
+		{
+			name:   "rating missing",
+			user:   entity.User{Id: 2, Username: "test2"},
+			review: entity.Review{AssetId: 1},
+			mock:   func() {},
+			res:    entity.Review{},
+			err:    fmt.Errorf("ReviewUseCase - PostReview - review.Validate: rating must be between 1 and 5"),
+		},
+		{
+			name:   "text too long",
+			user:   entity.User{Id: 2, Username: "test2"},
+			review: entity.Review{AssetId: 1, Rating: 3, Text: strings.Repeat("a", 2001)},
+			mock:   func() {},
+			res:    entity.Review{},
+			err:    fmt.Errorf("ReviewUseCase - PostReview - review.Validate: review is longer than 2000 characters"),
+		},
+		{
+			name:   "not a buyer",
+			user:   entity.User{Id: 3, Username: "test3"},
+			review: entity.Review{AssetId: 1, Rating: 1},
+			mock: func() {
+				repo.EXPECT().Create(context.Background(), entity.Review{AssetId: 1, UserId: 3, Rating: 1}).Return(entity.Review{}, entity.ErrAccessNotFound)
+			},
+			res: entity.Review{},
+			err: entity.ErrAccessNotFound,
+		},
+		{
+			name:   "already reviewed",
+			user:   entity.User{Id: 4, Username: "test4"},
+			review: entity.Review{AssetId: 1, Rating: 4},
+			mock: func() {
+				repo.EXPECT().Create(context.Background(), entity.Review{AssetId: 1, UserId: 4, Rating: 4}).Return(entity.Review{}, entity.ErrReviewExists)
+			},
+			res: entity.Review{},
+			err: entity.ErrReviewExists,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			tc.mock()
+			res, err := review.PostReview(context.Background(), tc.user, tc.review)
+			require.Equal(t, res, tc.res)
+			if err != nil {
+				require.ErrorContains(t, err, tc.err.Error())
+			} else {
+				require.Nil(t, err)
+			}
+		})
+	}
+}
+
+func TestGetReviews(t *testing.T) {
+	t.Parallel()
+
+	review, repo := ReviewUseCase(t)
+	reply := "Thanks!"
+	tests := []getReviewsTest{
+		{
+			name:    "success",
+			user:    entity.User{Id: 1, Username: "test"},
+			assetId: 1,
+			mock: func() {
+				repo.EXPECT().GetByAsset(context.Background(), entity.User{Id: 1, Username: "test"}, int64(1)).Return([]entity.Review{
+					{Id: 2, AssetId: 1, UserId: 3, Rating: 4, Reply: &reply},
+					{Id: 1, AssetId: 1, UserId: 2, Rating: 5},
+				}, nil)
+			},
+			res: []entity.Review{
+				{Id: 2, AssetId: 1, UserId: 3, Rating: 4, Reply: &reply},
+				{Id: 1, AssetId: 1, UserId: 2, Rating: 5},
+			},
+			err: nil,
+		},
+		{
+			name:    "asset not visible",
+			user:    entity.User{Id: 1, Username: "test"},
+			assetId: 2,
+			mock: func() {
+				repo.EXPECT().GetByAsset(context.Background(), entity.User{Id: 1, Username: "test"}, int64(2)).Return(nil, entity.ErrAssetNotFound)
+			},
+			res: nil,
+			err: entity.ErrAssetNotFound,
+		},
+		{
+			name:    "invalid asset id",
+			user:    entity.User{Id: 1, Username: "test"},
+			assetId: 0,
+			mock:    func() {},
+			res:     nil,
+			err:     fmt.Errorf("ReviewUseCase - GetReviews - invalid user or asset id"),
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			tc.mock()
+			res, err := review.GetReviews(context.Background(), tc.user, tc.assetId)
+			require.Equal(t, res, tc.res)
+			if err != nil {
+				require.ErrorContains(t, err, tc.err.Error())
+			} else {
+				require.Nil(t, err)
+			}
+		})
+	}
+}
+
+func TestReplyToReview(t *testing.T) {
+	t.Parallel()
+
+	review, repo := ReviewUseCase(t)
+	reply := "Thanks for the feedback"
+	tests := []replyToReviewTest{
+		{
+			name:  "success",
+			user:  entity.User{Id: 1, Username: "test"},
+			id:    1,
+			reply: " Thanks for the feedback ",
+			mock: func() {
+				repo.EXPECT().Reply(context.Background(), entity.User{Id: 1, Username: "test"}, int64(1), "Thanks for the feedback").Return(entity.Review{Id: 1, AssetId: 1, UserId: 2, Rating: 5, Reply: &reply}, nil)
+			},
+			res: entity.Review{Id: 1, AssetId: 1, UserId: 2, Rating: 5, Reply: &reply},
+			err: nil,
+		},
+		{
+			name:  "empty reply",
+			user:  entity.User{Id: 1, Username: "test"},
+			id:    1,
+			reply: "   ",
+			mock:  func() {},
+			res:   entity.Review{},
+			err:   fmt.Errorf("ReviewUseCase - ReplyToReview - reply must be between 1 and 2000 characters"),
+		},
+		{
+			name:  "already replied",
+			user:  entity.User{Id: 1, Username: "test"},
+			id:    2,
+			reply: "Again",
+			mock: func() {
+				repo.EXPECT().Reply(context.Background(), entity.User{Id: 1, Username: "test"}, int64(2), "Again").Return(entity.Review{}, entity.ErrReviewReplied)
+			},
+			res: entity.Review{},
+			err: entity.ErrReviewReplied,
+		},
+		{
+			name:  "not the seller",
+			user:  entity.User{Id: 3, Username: "test3"},
+			id:    1,
+			reply: "Hi",
+			mock: func() {
+				repo.EXPECT().Reply(context.Background(), entity.User{Id: 3, Username: "test3"}, int64(1), "Hi").Return(entity.Review{}, entity.ErrReviewNotFound)
+			},
+			res: entity.Review{},
+			err: entity.ErrReviewNotFound,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			tc.mock()
+			res, err := review.ReplyToReview(context.Background(), tc.user, tc.id, tc.reply)
+			require.Equal(t, res, tc.res)
+			if err != nil {
+				require.ErrorContains(t, err, tc.err.Error())
+			} else {
+				require.Nil(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Klef99/bhs-task/internal/entity"
+)
+
+// _maxStatsPoints - limits the length of the series, e.g. about three years of days.
+const _maxStatsPoints = 1100
+
+// StatsUseCase -.
+type StatsUseCase struct {
+	repo StatsRepository
+}
+
+var _ Stats = (*StatsUseCase)(nil)
+
+// New -.
+func NewStatsUseCase(r StatsRepository) *StatsUseCase {
+	return &StatsUseCase{repo: r}
+}
+
+// GetSellerStats - sales of the user's assets within [from, to): per asset, in total and as a series
+// of buckets. Every bucket overlapping the range is present in the series, including those without sales.
+func (uc *StatsUseCase) GetSellerStats(ctx context.Context, user entity.User, from, to time.Time, bucket entity.StatsBucket) (entity.SellerStats, error) {
+	if user.Id <= 0 {
+		return entity.SellerStats{}, fmt.Errorf("StatsUseCase - GetSellerStats - invalid user id")
+	}
+	if !bucket.Valid() {
+		return entity.SellerStats{}, fmt.Errorf("StatsUseCase - GetSellerStats - invalid bucket")
+	}
+	if !from.Before(to) {
+		return entity.SellerStats{}, fmt.Errorf("StatsUseCase - GetSellerStats - range start must be before its end")
+	}
+	from, to = from.UTC(), to.UTC()
+	starts := make([]time.Time, 0)
+	for start := bucket.Truncate(from); start.Before(to); start = bucket.Next(start) {
+		if len(starts) == _maxStatsPoints {
+			return entity.SellerStats{}, fmt.Errorf("StatsUseCase - GetSellerStats - %s buckets: %w", bucket, entity.ErrStatsRangeTooLong)
+		}
+		starts = append(starts, start)
+	}
+
+	assets, err := uc.repo.GetAssetSales(ctx, user, from, to)
+	if err != nil {
+		return entity.SellerStats{}, fmt.Errorf("StatsUseCase - GetSellerStats - uc.repo.GetAssetSales: %w", err)
+	}
+	points, err := uc.repo.GetSalesSeries(ctx, user, from, to, bucket)
+	if err != nil {
+		return entity.SellerStats{}, fmt.Errorf("StatsUseCase - GetSellerStats - uc.repo.GetSalesSeries: %w", err)
+	}
+
+	stats := entity.SellerStats{From: from, To: to, Bucket: bucket, Assets: assets, Series: make([]entity.SalesPoint, 0, len(starts))}
+	for _, a := range assets {
+		stats.TotalUnits += a.Units
+		stats.TotalRevenue += a.Revenue
+	}
+	byStart := make(map[int64]entity.SalesPoint, len(points))
+	for _, p := range points {
+		byStart[p.Start.Unix()] = p
+	}
+	for _, start := range starts {
+		p, ok := byStart[start.Unix()]
+		if !ok {
+			p = entity.SalesPoint{Start: start}
+		}
+		stats.Series = append(stats.Series, p)
+	}
+	return stats, nil
+}
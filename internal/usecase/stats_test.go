@@ -0,0 +1,174 @@
+package usecase_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/Klef99/bhs-task/internal/entity"
+	"github.com/Klef99/bhs-task/internal/usecase"
+	"github.com/stretchr/testify/require"
+	gomock "go.uber.org/mock/gomock"
+)
+
+type getSellerStatsTest struct {
+	name   string
+	user   entity.User
+	from   time.Time
+	to     time.Time
+	bucket entity.StatsBucket
+	mock   func()
+	res    entity.SellerStats
+	err    error
+}
+
+func StatsUseCase(t *testing.T) (*usecase.StatsUseCase, *MockStatsRepository) {
+	t.Helper()
+
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+
+	repo := NewMockStatsRepository(mockCtl)
+
+	StatsUseCase := usecase.NewStatsUseCase(repo)
+	return StatsUseCase, repo
+}
+
+func utcDay(year int, month time.Month, d int) time.Time {
+	return time.Date(year, month, d, 0, 0, 0, 0, time.UTC)
+}
+
+func TestGetSellerStats(t *testing.T) {
+	t.Parallel()
+
+	stats, repo := StatsUseCase(t)
+	seller := entity.User{Id: 1, Username: "test"}
+	tests := []getSellerStatsTest{
+		{
+			name:   "daily with gaps",
+			user:   seller,
+			from:   utcDay(2024, 6, 1),
+			to:     utcDay(2024, 6, 4),
+			bucket: entity.StatsBucketDay,
+			mock: func() {
+				repo.EXPECT().GetAssetSales(context.Background(), seller, utcDay(2024, 6, 1), utcDay(2024, 6, 4)).Return([]entity.AssetSales{
+					{AssetId: 2, Name: "Sword", Units: 2, Revenue: 20},
+					{AssetId: 3, Name: "Shield", Units: 1, Revenue: 5},
+				}, nil)
+				repo.EXPECT().GetSalesSeries(context.Background(), seller, utcDay(2024, 6, 1), utcDay(2024, 6, 4), entity.StatsBucketDay).Return([]entity.SalesPoint{
+					{Start: utcDay(2024, 6, 1), Units: 1, Revenue: 10},
+					{Start: utcDay(2024, 6, 3), Units: 2, Revenue: 15},
+				}, nil)
+			},
+			res: entity.SellerStats{
+				From: utcDay(2024, 6, 1), To: utcDay(2024, 6, 4), Bucket: entity.StatsBucketDay,
+				TotalUnits: 3, TotalRevenue: 25,
+				Assets: []entity.AssetSales{
+					{AssetId: 2, Name: "Sword", Units: 2, Revenue: 20},
+					{AssetId: 3, Name: "Shield", Units: 1, Revenue: 5},
+				},
+				Series: []entity.SalesPoint{
+					{Start: utcDay(2024, 6, 1), Units: 1, Revenue: 10},
+					{Start: utcDay(2024, 6, 2)},
+					{Start: utcDay(2024, 6, 3), Units: 2, Revenue: 15},
+				},
+			},
+			err: nil,
+		},
+		{
+			name:   "weekly starts on monday",
+			user:   seller,
+			from:   utcDay(2024, 6, 5),
+			to:     utcDay(2024, 6, 12),
+			bucket: entity.StatsBucketWeek,
+			mock: func() {
+				repo.EXPECT().GetAssetSales(context.Background(), seller, utcDay(2024, 6, 5), utcDay(2024, 6, 12)).Return([]entity.AssetSales{}, nil)
+				repo.EXPECT().GetSalesSeries(context.Background(), seller, utcDay(2024, 6, 5), utcDay(2024, 6, 12), entity.StatsBucketWeek).Return([]entity.SalesPoint{}, nil)
+			},
+			res: entity.SellerStats{
+				From: utcDay(2024, 6, 5), To: utcDay(2024, 6, 12), Bucket: entity.StatsBucketWeek,
+				Assets: []entity.AssetSales{},
+				Series: []entity.SalesPoint{{Start: utcDay(2024, 6, 3)}, {Start: utcDay(2024, 6, 10)}},
+			},
+			err: nil,
+		},
+		{
+			name:   "monthly",
+			user:   seller,
+			from:   utcDay(2024, 1, 31),
+			to:     utcDay(2024, 3, 1),
+			bucket: entity.StatsBucketMonth,
+			mock: func() {
+				repo.EXPECT().GetAssetSales(context.Background(), seller, utcDay(2024, 1, 31), utcDay(2024, 3, 1)).Return([]entity.AssetSales{}, nil)
+				repo.EXPECT().GetSalesSeries(context.Background(), seller, utcDay(2024, 1, 31), utcDay(2024, 3, 1), entity.StatsBucketMonth).Return([]entity.SalesPoint{
+					{Start: utcDay(2024, 2, 1), Units: 4, Revenue: 40},
+				}, nil)
+			},
+			res: entity.SellerStats{
+				From: utcDay(2024, 1, 31), To: utcDay(2024, 3, 1), Bucket: entity.StatsBucketMonth,
+				Assets: []entity.AssetSales{},
+				Series: []entity.SalesPoint{{Start: utcDay(2024, 1, 1)}, {Start: utcDay(2024, 2, 1), Units: 4, Revenue: 40}},
+			},
+			err: nil,
+		},
+		{
+			name:   "empty range",
+			user:   seller,
+			from:   utcDay(2024, 6, 1),
+			to:     utcDay(2024, 6, 1),
+			bucket: entity.StatsBucketDay,
+			mock:   func() {},
+			res:    entity.SellerStats{},
+			err:    fmt.Errorf("StatsUseCase - GetSellerStats - range start must be before its end"),
+		},
+		{
+			name:   "unknown bucket",
+			user:   seller,
+			from:   utcDay(2024, 6, 1),
+			to:     utcDay(2024, 6, 2),
+			bucket: "hour",
+			mock:   func() {},
+			res:    entity.SellerStats{},
+			err:    fmt.Errorf("StatsUseCase - GetSellerStats - invalid bucket"),
+		},
+		{
+			name:   "too many days",
+			user:   seller,
+			from:   utcDay(2020, 1, 1),
+			to:     utcDay(2024, 1, 1),
+			bucket: entity.StatsBucketDay,
+			mock:   func() {},
+			res:    entity.SellerStats{},
+			err:    entity.ErrStatsRangeTooLong,
+		},
+		{
+			name:   "repository error",
+			user:   entity.User{Id: 2, Username: "test2"},
+			from:   utcDay(2024, 6, 1),
+			to:     utcDay(2024, 6, 2),
+			bucket: entity.StatsBucketDay,
+			mock: func() {
+				repo.EXPECT().GetAssetSales(context.Background(), entity.User{Id: 2, Username: "test2"}, utcDay(2024, 6, 1), utcDay(2024, 6, 2)).Return(nil, errInternalServErr)
+			},
+			res: entity.SellerStats{},
+			err: errInternalServErr,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			tc.mock()
+			res, err := stats.GetSellerStats(context.Background(), tc.user, tc.from, tc.to, tc.bucket)
+			require.Equal(t, res, tc.res)
+			if err != nil {
+				require.ErrorContains(t, err, tc.err.Error())
+			} else {
+				require.Nil(t, err)
+			}
+		})
+	}
+}
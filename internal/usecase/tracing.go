@@ -0,0 +1,226 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/Klef99/bhs-task/internal/entity"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const _tracerName = "github.com/Klef99/bhs-task/internal/usecase"
+
+// endSpan - marks the span as failed if err is not nil and ends it.
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// TracedUserUseCase - records a span for every call to the wrapped use case.
+type TracedUserUseCase struct {
+	next   User
+	tracer trace.Tracer
+}
+
+var _ User = (*TracedUserUseCase)(nil)
+
+// New -.
+func NewTracedUserUseCase(next User, tp trace.TracerProvider) *TracedUserUseCase {
+	return &TracedUserUseCase{next: next, tracer: tp.Tracer(_tracerName)}
+}
+
+// Register -.
+func (t *TracedUserUseCase) Register(ctx context.Context, crd entity.Credentials) (bool, error) {
+	ctx, span := t.tracer.Start(ctx, "UserUseCase.Register")
+	res, err := t.next.Register(ctx, crd)
+	endSpan(span, err)
+	return res, err
+}
+
+// Login -.
+func (t *TracedUserUseCase) Login(ctx context.Context, crd entity.Credentials) (entity.User, error) {
+	ctx, span := t.tracer.Start(ctx, "UserUseCase.Login")
+	res, err := t.next.Login(ctx, crd)
+	if err == nil {
+		span.SetAttributes(attribute.Int64("user.id", res.Id))
+	}
+	endSpan(span, err)
+	return res, err
+}
+
+// MakeDeposit -.
+func (t *TracedUserUseCase) MakeDeposit(ctx context.Context, user entity.User, amount float64) (float64, error) {
+	ctx, span := t.tracer.Start(ctx, "UserUseCase.MakeDeposit", trace.WithAttributes(attribute.Int64("user.id", user.Id)))
+	res, err := t.next.MakeDeposit(ctx, user, amount)
+	endSpan(span, err)
+	return res, err
+}
+
+// CheckDeposit -.
+func (t *TracedUserUseCase) CheckDeposit(ctx context.Context, user entity.User) (float64, error) {
+	ctx, span := t.tracer.Start(ctx, "UserUseCase.CheckDeposit", trace.WithAttributes(attribute.Int64("user.id", user.Id)))
+	res, err := t.next.CheckDeposit(ctx, user)
+	endSpan(span, err)
+	return res, err
+}
+
+// IsAdmin -.
+func (t *TracedUserUseCase) IsAdmin(ctx context.Context, user entity.User) (bool, error) {
+	ctx, span := t.tracer.Start(ctx, "UserUseCase.IsAdmin", trace.WithAttributes(attribute.Int64("user.id", user.Id)))
+	res, err := t.next.IsAdmin(ctx, user)
+	endSpan(span, err)
+	return res, err
+}
+
+// GetUsersByIds -.
+func (t *TracedUserUseCase) GetUsersByIds(ctx context.Context, ids []int64) ([]entity.User, error) {
+	ctx, span := t.tracer.Start(ctx, "UserUseCase.GetUsersByIds")
+	res, err := t.next.GetUsersByIds(ctx, ids)
+	endSpan(span, err)
+	return res, err
+}
+
+// TracedAssetUseCase - records a span for every call to the wrapped use case.
+type TracedAssetUseCase struct {
+	next   Asset
+	tracer trace.Tracer
+}
+
+var _ Asset = (*TracedAssetUseCase)(nil)
+
+// New -.
+func NewTracedAssetUseCase(next Asset, tp trace.TracerProvider) *TracedAssetUseCase {
+	return &TracedAssetUseCase{next: next, tracer: tp.Tracer(_tracerName)}
+}
+
+// CreateAsset -.
+func (t *TracedAssetUseCase) CreateAsset(ctx context.Context, ast entity.Asset) (bool, error) {
+	ctx, span := t.tracer.Start(ctx, "AssetUseCase.CreateAsset", trace.WithAttributes(attribute.Int64("user.id", ast.Owner_id)))
+	res, err := t.next.CreateAsset(ctx, ast)
+	endSpan(span, err)
+	return res, err
+}
+
+// DeleteAsset -.
+func (t *TracedAssetUseCase) DeleteAsset(ctx context.Context, user entity.User, id int64) (bool, error) {
+	ctx, span := t.tracer.Start(ctx, "AssetUseCase.DeleteAsset", trace.WithAttributes(attribute.Int64("user.id", user.Id), attribute.Int64("asset.id", id)))
+	res, err := t.next.DeleteAsset(ctx, user, id)
+	endSpan(span, err)
+	return res, err
+}
+
+// BuyAsset -.
+func (t *TracedAssetUseCase) BuyAsset(ctx context.Context, user entity.User, id int64, promoCode string) (bool, error) {
+	ctx, span := t.tracer.Start(ctx, "AssetUseCase.BuyAsset", trace.WithAttributes(attribute.Int64("user.id", user.Id), attribute.Int64("asset.id", id)))
+	res, err := t.next.BuyAsset(ctx, user, id, promoCode)
+	endSpan(span, err)
+	return res, err
+}
+
+// UserAssetsList -.
+func (t *TracedAssetUseCase) UserAssetsList(ctx context.Context, user entity.User) ([]entity.Asset, error) {
+	ctx, span := t.tracer.Start(ctx, "AssetUseCase.UserAssetsList", trace.WithAttributes(attribute.Int64("user.id", user.Id)))
+	res, err := t.next.UserAssetsList(ctx, user)
+	endSpan(span, err)
+	return res, err
+}
+
+// GetAssetById -.
+func (t *TracedAssetUseCase) GetAssetById(ctx context.Context, user entity.User, id int64) (entity.Asset, error) {
+	ctx, span := t.tracer.Start(ctx, "AssetUseCase.GetAssetById", trace.WithAttributes(attribute.Int64("user.id", user.Id), attribute.Int64("asset.id", id)))
+	res, err := t.next.GetAssetById(ctx, user, id)
+	endSpan(span, err)
+	return res, err
+}
+
+// GetAssetsByIds -.
+func (t *TracedAssetUseCase) GetAssetsByIds(ctx context.Context, user entity.User, ids []int64) ([]entity.Asset, error) {
+	ctx, span := t.tracer.Start(ctx, "AssetUseCase.GetAssetsByIds", trace.WithAttributes(attribute.Int64("user.id", user.Id)))
+	res, err := t.next.GetAssetsByIds(ctx, user, ids)
+	endSpan(span, err)
+	return res, err
+}
+
+// GetAssetsToBuying -.
+func (t *TracedAssetUseCase) GetAssetsToBuying(ctx context.Context, user entity.User) ([]entity.Asset, error) {
+	ctx, span := t.tracer.Start(ctx, "AssetUseCase.GetAssetsToBuying", trace.WithAttributes(attribute.Int64("user.id", user.Id)))
+	res, err := t.next.GetAssetsToBuying(ctx, user)
+	endSpan(span, err)
+	return res, err
+}
+
+// GetPurchasedAssets -.
+func (t *TracedAssetUseCase) GetPurchasedAssets(ctx context.Context, user entity.User) ([]entity.Asset, error) {
+	ctx, span := t.tracer.Start(ctx, "AssetUseCase.GetPurchasedAssets", trace.WithAttributes(attribute.Int64("user.id", user.Id)))
+	res, err := t.next.GetPurchasedAssets(ctx, user)
+	endSpan(span, err)
+	return res, err
+}
+
+// ChangeAssetStatus -.
+func (t *TracedAssetUseCase) ChangeAssetStatus(ctx context.Context, user entity.User, id int64, status entity.AssetStatus) (bool, error) {
+	ctx, span := t.tracer.Start(ctx, "AssetUseCase.ChangeAssetStatus", trace.WithAttributes(attribute.Int64("user.id", user.Id), attribute.Int64("asset.id", id)))
+	res, err := t.next.ChangeAssetStatus(ctx, user, id, status)
+	endSpan(span, err)
+	return res, err
+}
+
+// PurgeAsset -.
+func (t *TracedAssetUseCase) PurgeAsset(ctx context.Context, id int64) (bool, error) {
+	ctx, span := t.tracer.Start(ctx, "AssetUseCase.PurgeAsset", trace.WithAttributes(attribute.Int64("asset.id", id)))
+	res, err := t.next.PurgeAsset(ctx, id)
+	endSpan(span, err)
+	return res, err
+}
+
+// GetProvenance -.
+func (t *TracedAssetUseCase) GetProvenance(ctx context.Context, user entity.User, id int64) ([]entity.Purchase, error) {
+	ctx, span := t.tracer.Start(ctx, "AssetUseCase.GetProvenance", trace.WithAttributes(attribute.Int64("user.id", user.Id), attribute.Int64("asset.id", id)))
+	res, err := t.next.GetProvenance(ctx, user, id)
+	endSpan(span, err)
+	return res, err
+}
+
+// RenewAccess -.
+func (t *TracedAssetUseCase) RenewAccess(ctx context.Context, user entity.User, id int64) (entity.Purchase, error) {
+	ctx, span := t.tracer.Start(ctx, "AssetUseCase.RenewAccess", trace.WithAttributes(attribute.Int64("user.id", user.Id), attribute.Int64("asset.id", id)))
+	res, err := t.next.RenewAccess(ctx, user, id)
+	endSpan(span, err)
+	return res, err
+}
+
+// SweepExpiredAccess -.
+func (t *TracedAssetUseCase) SweepExpiredAccess(ctx context.Context) (int64, error) {
+	ctx, span := t.tracer.Start(ctx, "AssetUseCase.SweepExpiredAccess")
+	res, err := t.next.SweepExpiredAccess(ctx)
+	endSpan(span, err)
+	return res, err
+}
+
+// GiftAsset -.
+func (t *TracedAssetUseCase) GiftAsset(ctx context.Context, user entity.User, id int64, recipient string, message string) (entity.Purchase, error) {
+	ctx, span := t.tracer.Start(ctx, "AssetUseCase.GiftAsset", trace.WithAttributes(attribute.Int64("user.id", user.Id), attribute.Int64("asset.id", id)))
+	res, err := t.next.GiftAsset(ctx, user, id, recipient, message)
+	endSpan(span, err)
+	return res, err
+}
+
+// GetPurchaseHistory -.
+func (t *TracedAssetUseCase) GetPurchaseHistory(ctx context.Context, user entity.User) ([]entity.Purchase, error) {
+	ctx, span := t.tracer.Start(ctx, "AssetUseCase.GetPurchaseHistory", trace.WithAttributes(attribute.Int64("user.id", user.Id)))
+	res, err := t.next.GetPurchaseHistory(ctx, user)
+	endSpan(span, err)
+	return res, err
+}
+
+// ChangeAssetPrice -.
+func (t *TracedAssetUseCase) ChangeAssetPrice(ctx context.Context, user entity.User, id int64, price float64) (bool, error) {
+	ctx, span := t.tracer.Start(ctx, "AssetUseCase.ChangeAssetPrice", trace.WithAttributes(attribute.Int64("user.id", user.Id), attribute.Int64("asset.id", id)))
+	res, err := t.next.ChangeAssetPrice(ctx, user, id, price)
+	endSpan(span, err)
+	return res, err
+}
@@ -0,0 +1,88 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Klef99/bhs-task/internal/entity"
+	"github.com/Klef99/bhs-task/internal/usecase"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	gomock "go.uber.org/mock/gomock"
+)
+
+type tracedBuyAssetTest struct {
+	name   string
+	user   entity.User
+	id     int64
+	resErr error
+	res    bool
+	status codes.Code
+}
+
+func TracedAssetUseCase(t *testing.T) (*usecase.TracedAssetUseCase, *MockAsset, *tracetest.SpanRecorder) {
+	t.Helper()
+
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+
+	next := NewMockAsset(mockCtl)
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	TracedAssetUseCase := usecase.NewTracedAssetUseCase(next, tp)
+	return TracedAssetUseCase, next, recorder
+}
+
+func TestTracedBuyAsset(t *testing.T) {
+	t.Parallel()
+
+	tests := []tracedBuyAssetTest{
+		{
+			name:   "bought",
+			user:   entity.User{Id: 1, Username: "test"},
+			id:     2,
+			resErr: nil,
+			res:    true,
+			status: codes.Unset,
+		},
+		{
+			name:   "error marks the span",
+			user:   entity.User{Id: 1, Username: "test"},
+			id:     3,
+			resErr: entity.ErrInsufficientFunds,
+			res:    false,
+			status: codes.Error,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			traced, next, recorder := TracedAssetUseCase(t)
+			next.EXPECT().BuyAsset(gomock.Any(), tc.user, tc.id, "").DoAndReturn(
+				func(ctx context.Context, _ entity.User, _ int64, _ string) (bool, error) {
+					// The wrapped use case runs within the span, so repository queries become its children.
+					require.True(t, trace.SpanFromContext(ctx).SpanContext().IsValid())
+					return tc.res, tc.resErr
+				})
+
+			res, err := traced.BuyAsset(context.Background(), tc.user, tc.id, "")
+			require.Equal(t, tc.res, res)
+			require.Equal(t, tc.resErr, err)
+
+			spans := recorder.Ended()
+			require.Len(t, spans, 1)
+			require.Equal(t, "AssetUseCase.BuyAsset", spans[0].Name())
+			require.Equal(t, tc.status, spans[0].Status().Code)
+			require.Contains(t, spans[0].Attributes(), attribute.Int64("asset.id", tc.id))
+			require.Contains(t, spans[0].Attributes(), attribute.Int64("user.id", tc.user.Id))
+		})
+	}
+}
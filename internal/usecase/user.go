@@ -58,7 +58,7 @@ func (uc *UserUseCase) MakeDeposit(ctx context.Context, user entity.User, amount
 	if err != nil {
 		return -1, fmt.Errorf("UserUseCase - Deposit - uc.repo.Deposit: %w", err)
 	}
-	return balance, err
+	return balance, nil
 }
 
 // CheckDeposit -.
@@ -72,3 +72,27 @@ func (uc *UserUseCase) CheckDeposit(ctx context.Context, user entity.User) (floa
 	}
 	return balance, err
 }
+
+// IsAdmin -.
+func (uc *UserUseCase) IsAdmin(ctx context.Context, user entity.User) (bool, error) {
+	if user.Id < 1 {
+		return false, fmt.Errorf("UserUseCase - IsAdmin - invalid input: user id must be provided")
+	}
+	role, err := uc.repo.GetRole(ctx, user)
+	if err != nil {
+		return false, fmt.Errorf("UserUseCase - IsAdmin - uc.repo.GetRole: %w", err)
+	}
+	return role == entity.RoleAdmin, nil
+}
+
+// GetUsersByIds - for batch loading, users that don't exist are left out.
+func (uc *UserUseCase) GetUsersByIds(ctx context.Context, ids []int64) ([]entity.User, error) {
+	if len(ids) == 0 {
+		return []entity.User{}, nil
+	}
+	users, err := uc.repo.GetUsersByIds(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("UserUseCase - GetUsersByIds - uc.repo.GetUsersByIds: %w", err)
+	}
+	return users, nil
+}
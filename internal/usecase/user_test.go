@@ -30,6 +30,14 @@ type checkDepositTest struct {
 	err  error
 }
 
+type isAdminTest struct {
+	name string
+	user entity.User
+	mock func()
+	res  bool
+	err  error
+}
+
 type makeDepositTest struct {
 	name   string
 	user   entity.User
@@ -39,6 +47,14 @@ type makeDepositTest struct {
 	err    error
 }
 
+type getUsersByIdsTest struct {
+	name string
+	ids  []int64
+	mock func()
+	res  []entity.User
+	err  error
+}
+
 func UserUseCase(t *testing.T) (*usecase.UserUseCase, *MockUserRepository) {
 	t.Helper()
 
@@ -294,3 +310,114 @@ func TestMakeDeposit(t *testing.T) {
 		})
 	}
 }
+
+func TestIsAdmin(t *testing.T) {
+	t.Parallel()
+
+	user, repo := UserUseCase(t)
+	tests := []isAdminTest{
+		{
+			name: "empty user",
+			user: entity.User{},
+			mock: func() {},
+			res:  false,
+			err:  fmt.Errorf("UserUseCase - IsAdmin - invalid input: user id must be provided"),
+		},
+		{
+			name: "admin",
+			user: entity.User{Username: "admin", Id: 1},
+			mock: func() {
+				repo.EXPECT().GetRole(context.Background(), entity.User{Username: "admin", Id: 1}).Return(entity.RoleAdmin, nil)
+			},
+			res: true,
+			err: nil,
+		},
+		{
+			name: "regular user",
+			user: entity.User{Username: "test", Id: 2},
+			mock: func() {
+				repo.EXPECT().GetRole(context.Background(), entity.User{Username: "test", Id: 2}).Return(entity.RoleUser, nil)
+			},
+			res: false,
+			err: nil,
+		},
+		{
+			name: "user not exist",
+			user: entity.User{Username: "test", Id: 3},
+			mock: func() {
+				repo.EXPECT().GetRole(context.Background(), entity.User{Username: "test", Id: 3}).Return(entity.Role(""), errInternalServErr)
+			},
+			res: false,
+			err: errInternalServErr,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			tc.mock()
+
+			res, err := user.IsAdmin(context.Background(), tc.user)
+			require.Equal(t, res, tc.res)
+			if err != nil {
+				require.ErrorContains(t, err, tc.err.Error())
+			} else {
+				require.Nil(t, err)
+			}
+		})
+	}
+}
+
+func TestGetUsersByIds(t *testing.T) {
+	t.Parallel()
+
+	user, repo := UserUseCase(t)
+	tests := []getUsersByIdsTest{
+		{
+			name: "success",
+			ids:  []int64{1, 2, 3},
+			mock: func() {
+				repo.EXPECT().GetUsersByIds(context.Background(), []int64{1, 2, 3}).Return([]entity.User{{Id: 1, Username: "test"}, {Id: 3, Username: "seller"}}, nil)
+			},
+			res: []entity.User{{Id: 1, Username: "test"}, {Id: 3, Username: "seller"}},
+			err: nil,
+		},
+		{
+			name: "no ids",
+			ids:  []int64{},
+			mock: func() {},
+			res:  []entity.User{},
+			err:  nil,
+		},
+		{
+			name: "repository error",
+			ids:  []int64{4},
+			mock: func() {
+				repo.EXPECT().GetUsersByIds(context.Background(), []int64{4}).Return(nil, errInternalServErr)
+			},
+			res: nil,
+			err: errInternalServErr,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			tc.mock()
+
+			res, err := user.GetUsersByIds(context.Background(), tc.ids)
+			require.Equal(t, res, tc.res)
+			if err != nil {
+				require.ErrorContains(t, err, tc.err.Error())
+			} else {
+				require.Nil(t, err)
+			}
+		})
+	}
+}
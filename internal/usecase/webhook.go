@@ -0,0 +1,203 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/Klef99/bhs-task/internal/entity"
+)
+
+const (
+	_deliveryBatch = 20            // deliveries sent per DeliverDue call
+	_deliveryLease = time.Minute   // how long a claimed delivery is hidden from other instances
+	_maxBackoff    = 6 * time.Hour // upper bound of the delay between attempts
+	_maxLastError  = 500           // characters of the last error kept with a delivery or an outbox event
+	_secretPrefix  = "whsec_"
+)
+
+// WebhookUseCase -.
+type WebhookUseCase struct {
+	repo        WebhookRepository
+	sender      WebhookSender
+	maxAttempts int
+	backoff     time.Duration
+}
+
+var (
+	_ Webhook        = (*WebhookUseCase)(nil)
+	_ EventPublisher = (*WebhookUseCase)(nil)
+)
+
+// New - a failed delivery is retried after backoff, doubling with every attempt, until maxAttempts are made.
+func NewWebhookUseCase(r WebhookRepository, s WebhookSender, maxAttempts int, backoff time.Duration) *WebhookUseCase {
+	return &WebhookUseCase{repo: r, sender: s, maxAttempts: maxAttempts, backoff: backoff}
+}
+
+// CreateWebhook - registers a webhook receiving the events of the user. The secret is generated and returned once.
+func (uc *WebhookUseCase) CreateWebhook(ctx context.Context, user entity.User, wh entity.Webhook) (entity.Webhook, error) {
+	wh.Global = false
+	return uc.create(ctx, "CreateWebhook", user, wh)
+}
+
+// CreateGlobalWebhook - registers a webhook receiving the events of every user, for admins only.
+func (uc *WebhookUseCase) CreateGlobalWebhook(ctx context.Context, user entity.User, wh entity.Webhook) (entity.Webhook, error) {
+	wh.Global = true
+	return uc.create(ctx, "CreateGlobalWebhook", user, wh)
+}
+
+func (uc *WebhookUseCase) create(ctx context.Context, method string, user entity.User, wh entity.Webhook) (entity.Webhook, error) {
+	if user.Id <= 0 {
+		return entity.Webhook{}, fmt.Errorf("WebhookUseCase - %s - invalid user id", method)
+	}
+	err := wh.Validate()
+	if err != nil {
+		return entity.Webhook{}, fmt.Errorf("WebhookUseCase - %s - wh.Validate: %w", method, err)
+	}
+	events := make([]entity.EventType, 0, len(wh.Events))
+	seen := make(map[entity.EventType]bool, len(wh.Events))
+	for _, e := range wh.Events {
+		if !seen[e] {
+			seen[e] = true
+			events = append(events, e)
+		}
+	}
+	secret := make([]byte, 32)
+	_, err = rand.Read(secret)
+	if err != nil {
+		return entity.Webhook{}, fmt.Errorf("WebhookUseCase - %s - rand.Read: %w", method, err)
+	}
+	wh.UserId = user.Id
+	wh.Events = events
+	wh.Secret = _secretPrefix + hex.EncodeToString(secret)
+	wh, err = uc.repo.Create(ctx, wh)
+	if err != nil {
+		return entity.Webhook{}, fmt.Errorf("WebhookUseCase - %s - uc.repo.Create: %w", method, err)
+	}
+	return wh, nil
+}
+
+func (uc *WebhookUseCase) GetWebhooks(ctx context.Context, user entity.User) ([]entity.Webhook, error) {
+	if user.Id <= 0 {
+		return nil, fmt.Errorf("WebhookUseCase - GetWebhooks - invalid user id")
+	}
+	webhooks, err := uc.repo.GetByUser(ctx, user)
+	if err != nil {
+		return nil, fmt.Errorf("WebhookUseCase - GetWebhooks - uc.repo.GetByUser: %w", err)
+	}
+	return webhooks, nil
+}
+
+func (uc *WebhookUseCase) DeleteWebhook(ctx context.Context, user entity.User, id int64) (bool, error) {
+	if user.Id <= 0 || id <= 0 {
+		return false, fmt.Errorf("WebhookUseCase - DeleteWebhook - invalid user or webhook id")
+	}
+	ok, err := uc.repo.Delete(ctx, user, id)
+	if err != nil {
+		return false, fmt.Errorf("WebhookUseCase - DeleteWebhook - uc.repo.Delete: %w", err)
+	}
+	return ok, nil
+}
+
+func (uc *WebhookUseCase) GetDeliveries(ctx context.Context, user entity.User, webhookId int64) ([]entity.WebhookDelivery, error) {
+	if user.Id <= 0 || webhookId <= 0 {
+		return nil, fmt.Errorf("WebhookUseCase - GetDeliveries - invalid user or webhook id")
+	}
+	deliveries, err := uc.repo.GetDeliveries(ctx, user, webhookId)
+	if err != nil {
+		return nil, fmt.Errorf("WebhookUseCase - GetDeliveries - uc.repo.GetDeliveries: %w", err)
+	}
+	return deliveries, nil
+}
+
+// Redeliver - sends the delivery again on the next run, with the full number of attempts.
+func (uc *WebhookUseCase) Redeliver(ctx context.Context, user entity.User, deliveryId int64) (entity.WebhookDelivery, error) {
+	if user.Id <= 0 || deliveryId <= 0 {
+		return entity.WebhookDelivery{}, fmt.Errorf("WebhookUseCase - Redeliver - invalid user or delivery id")
+	}
+	d, err := uc.repo.Redeliver(ctx, user, deliveryId)
+	if err != nil {
+		return entity.WebhookDelivery{}, fmt.Errorf("WebhookUseCase - Redeliver - uc.repo.Redeliver: %w", err)
+	}
+	return d, nil
+}
+
+// Publish - queues the event relayed from the outbox for the subscribed webhooks.
+func (uc *WebhookUseCase) Publish(ctx context.Context, event entity.OutboxEvent) error {
+	_, err := uc.repo.Enqueue(ctx, event.Type, event.Payload, event.UserIds)
+	if err != nil {
+		return fmt.Errorf("WebhookUseCase - Publish - uc.repo.Enqueue: %w", err)
+	}
+	return nil
+}
+
+// DeliverDue - sends a batch of due deliveries, returns how many were attempted.
+func (uc *WebhookUseCase) DeliverDue(ctx context.Context) (int, error) {
+	deliveries, err := uc.repo.ClaimDue(ctx, _deliveryBatch, _deliveryLease)
+	if err != nil {
+		return 0, fmt.Errorf("WebhookUseCase - DeliverDue - uc.repo.ClaimDue: %w", err)
+	}
+	for _, d := range deliveries {
+		d = uc.attempt(ctx, d)
+		err = uc.repo.RecordAttempt(ctx, d)
+		if err != nil {
+			return 0, fmt.Errorf("WebhookUseCase - DeliverDue - uc.repo.RecordAttempt: %w", err)
+		}
+	}
+	return len(deliveries), nil
+}
+
+// attempt - sends the delivery and returns it with the outcome. Anything but a 2xx answer is a failure.
+func (uc *WebhookUseCase) attempt(ctx context.Context, d entity.WebhookDelivery) entity.WebhookDelivery {
+	headers := map[string]string{
+		"X-Webhook-Event":    string(d.EventType),
+		"X-Webhook-Delivery": strconv.FormatInt(d.Id, 10),
+	}
+	code, err := uc.sender.Send(ctx, d.URL, d.Secret, headers, d.Payload)
+	now := time.Now()
+	d.Attempts++
+	d.LastStatusCode = nil
+	d.LastError = ""
+	if err == nil {
+		d.LastStatusCode = &code
+		if code >= 200 && code < 300 {
+			d.Status = entity.DeliveryStatusSucceeded
+			d.DeliveredAt = &now
+			return d
+		}
+		d.LastError = fmt.Sprintf("unexpected status code %d", code)
+	} else {
+		d.LastError = lastError(err)
+	}
+	if d.Attempts >= uc.maxAttempts {
+		d.Status = entity.DeliveryStatusFailed
+		return d
+	}
+	d.Status = entity.DeliveryStatusPending
+	d.NextAttemptAt = now.Add(retryAfter(uc.backoff, d.Attempts))
+	return d
+}
+
+// retryAfter - delay before the next attempt, backoff doubling with every failed one.
+func retryAfter(backoff time.Duration, attempts int) time.Duration {
+	delay := backoff
+	for i := 1; i < attempts && delay < _maxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > _maxBackoff {
+		delay = _maxBackoff
+	}
+	return delay
+}
+
+// lastError - the error message cut to the length kept in the database.
+func lastError(err error) string {
+	msg := err.Error()
+	if r := []rune(msg); len(r) > _maxLastError {
+		msg = string(r[:_maxLastError])
+	}
+	return msg
+}
@@ -0,0 +1,277 @@
+package usecase_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Klef99/bhs-task/internal/entity"
+	"github.com/Klef99/bhs-task/internal/usecase"
+	"github.com/stretchr/testify/require"
+	gomock "go.uber.org/mock/gomock"
+)
+
+type createWebhookTest struct {
+	name    string
+	user    entity.User
+	webhook entity.Webhook
+	mock    func()
+	res     entity.Webhook
+	err     error
+}
+
+type publishTest struct {
+	name  string
+	event entity.OutboxEvent
+	mock  func()
+	err   error
+}
+
+type deliverDueTest struct {
+	name     string
+	claimed  []entity.WebhookDelivery
+	code     int
+	sendErr  error
+	res      int
+	recorded entity.WebhookDelivery
+	retryIn  time.Duration
+	err      error
+}
+
+func WebhookUseCase(t *testing.T) (*usecase.WebhookUseCase, *MockWebhookRepository, *MockWebhookSender) {
+	t.Helper()
+
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+
+	repo := NewMockWebhookRepository(mockCtl)
+	sender := NewMockWebhookSender(mockCtl)
+
+	WebhookUseCase := usecase.NewWebhookUseCase(repo, sender, 3, time.Minute)
+	return WebhookUseCase, repo, sender
+}
+
+func TestCreateWebhook(t *testing.T) {
+	t.Parallel()
+
+	webhook, repo, _ := WebhookUseCase(t)
+	stored := func(_ context.Context, wh entity.Webhook) (entity.Webhook, error) {
+		wh.Id = 1
+		return wh, nil
+	}
+	tests := []createWebhookTest{
+		{
+			name:    "success with duplicate events",
+			user:    entity.User{Id: 1, Username: "test"},
+			webhook: entity.Webhook{URL: "https://game.example.com/hooks", Events: []entity.EventType{entity.EventAssetPurchased, entity.EventAssetPurchased}},
+			mock: func() {
+				repo.EXPECT().Create(context.Background(), gomock.Any()).DoAndReturn(stored)
+			},
+			res: entity.Webhook{Id: 1, UserId: 1, URL: "https://game.example.com/hooks", Events: []entity.EventType{entity.EventAssetPurchased}},
+			err: nil,
+		},
+		{
+			name:    "global flag from the request is ignored",
+			user:    entity.User{Id: 2, Username: "test2"},
+			webhook: entity.Webhook{URL: "http://hooks.example.org:9000", Events: []entity.EventType{entity.EventDepositCompleted}, Global: true},
+			mock: func() {
+				repo.EXPECT().Create(context.Background(), gomock.Any()).DoAndReturn(stored)
+			},
+			res: entity.Webhook{Id: 1, UserId: 2, URL: "http://hooks.example.org:9000", Events: []entity.EventType{entity.EventDepositCompleted}},
+			err: nil,
+		},
+		{
+			name:    "invalid url",
+			user:    entity.User{Id: 1, Username: "test"},
+			webhook: entity.Webhook{URL: "ftp://game.example.com", Events: []entity.EventType{entity.EventAssetCreated}},
+			mock:    func() {},
+			res:     entity.Webhook{},
+			err:     fmt.Errorf("WebhookUseCase - CreateWebhook - wh.Validate: url must be an absolute http or https url"),
+		},
+		{
+			name:    "metadata address",
+			user:    entity.User{Id: 1, Username: "test"},
+			webhook: entity.Webhook{URL: "http://169.254.169.254/latest/meta-data", Events: []entity.EventType{entity.EventAssetCreated}},
+			mock:    func() {},
+			res:     entity.Webhook{},
+			err:     fmt.Errorf("WebhookUseCase - CreateWebhook - wh.Validate: url must point to a public host"),
+		},
+		{
+			name:    "localhost",
+			user:    entity.User{Id: 1, Username: "test"},
+			webhook: entity.Webhook{URL: "http://localhost:9000", Events: []entity.EventType{entity.EventAssetCreated}},
+			mock:    func() {},
+			res:     entity.Webhook{},
+			err:     fmt.Errorf("WebhookUseCase - CreateWebhook - wh.Validate: url must point to a public host"),
+		},
+		{
+			name:    "unknown event",
+			user:    entity.User{Id: 1, Username: "test"},
+			webhook: entity.Webhook{URL: "https://game.example.com/hooks", Events: []entity.EventType{"asset.deleted"}},
+			mock:    func() {},
+			res:     entity.Webhook{},
+			err:     fmt.Errorf(`WebhookUseCase - CreateWebhook - wh.Validate: unknown event type "asset.deleted"`),
+		},
+		{
+			name:    "no events",
+			user:    entity.User{Id: 1, Username: "test"},
+			webhook: entity.Webhook{URL: "https://game.example.com/hooks"},
+			mock:    func() {},
+			res:     entity.Webhook{},
+			err:     fmt.Errorf("WebhookUseCase - CreateWebhook - wh.Validate: at least one event type is required"),
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			tc.mock()
+			res, err := webhook.CreateWebhook(context.Background(), tc.user, tc.webhook)
+			if err != nil {
+				require.ErrorContains(t, err, tc.err.Error())
+			} else {
+				require.Nil(t, err)
+				require.True(t, strings.HasPrefix(res.Secret, "whsec_"))
+				res.Secret = ""
+			}
+			require.Equal(t, res, tc.res)
+		})
+	}
+}
+
+func TestCreateGlobalWebhook(t *testing.T) {
+	t.Parallel()
+
+	webhook, repo, _ := WebhookUseCase(t)
+	repo.EXPECT().Create(context.Background(), gomock.Any()).DoAndReturn(func(_ context.Context, wh entity.Webhook) (entity.Webhook, error) {
+		require.True(t, wh.Global)
+		wh.Id = 3
+		return wh, nil
+	})
+
+	res, err := webhook.CreateGlobalWebhook(context.Background(), entity.User{Id: 1, Username: "admin"},
+		entity.Webhook{URL: "https://audit.example.com", Events: []entity.EventType{entity.EventAssetCreated}})
+	require.Nil(t, err)
+	require.Equal(t, int64(3), res.Id)
+	require.True(t, res.Global)
+}
+
+func TestPublish(t *testing.T) {
+	t.Parallel()
+
+	webhook, repo, _ := WebhookUseCase(t)
+	payload := json.RawMessage(`{"type":"deposit.completed","data":{"user_id":1,"amount":10,"balance":110}}`)
+	tests := []publishTest{
+		{
+			name:  "enqueued for the concerned users",
+			event: entity.OutboxEvent{Id: 1, AggregateType: entity.AggregateUser, AggregateId: 1, Type: entity.EventDepositCompleted, Payload: payload, UserIds: []int64{1}},
+			mock: func() {
+				repo.EXPECT().Enqueue(context.Background(), entity.EventDepositCompleted, []byte(payload), []int64{1}).Return(int64(1), nil)
+			},
+			err: nil,
+		},
+		{
+			name:  "repository error",
+			event: entity.OutboxEvent{Id: 2, AggregateType: entity.AggregateAsset, AggregateId: 1, Type: entity.EventAssetCreated, Payload: payload, UserIds: []int64{2}},
+			mock: func() {
+				repo.EXPECT().Enqueue(context.Background(), entity.EventAssetCreated, []byte(payload), []int64{2}).Return(int64(0), errInternalServErr)
+			},
+			err: errInternalServErr,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			tc.mock()
+			err := webhook.Publish(context.Background(), tc.event)
+			if err != nil {
+				require.ErrorContains(t, err, tc.err.Error())
+			} else {
+				require.Nil(t, tc.err)
+			}
+		})
+	}
+}
+
+func TestDeliverDue(t *testing.T) {
+	t.Parallel()
+
+	payload := json.RawMessage(`{"type":"asset.purchased"}`)
+	ok, serverErr := 200, 503
+	tests := []deliverDueTest{
+		{
+			name:     "delivered",
+			claimed:  []entity.WebhookDelivery{{Id: 1, EventType: entity.EventAssetPurchased, Payload: payload, Status: entity.DeliveryStatusPending, URL: "https://game.example.com", Secret: "whsec_1"}},
+			code:     ok,
+			res:      1,
+			recorded: entity.WebhookDelivery{Id: 1, Status: entity.DeliveryStatusSucceeded, Attempts: 1, LastStatusCode: &ok},
+			err:      nil,
+		},
+		{
+			name:     "server error is retried",
+			claimed:  []entity.WebhookDelivery{{Id: 2, EventType: entity.EventAssetPurchased, Payload: payload, Status: entity.DeliveryStatusPending, Attempts: 1, URL: "https://game.example.com", Secret: "whsec_1"}},
+			code:     serverErr,
+			res:      1,
+			recorded: entity.WebhookDelivery{Id: 2, Status: entity.DeliveryStatusPending, Attempts: 2, LastStatusCode: &serverErr, LastError: "unexpected status code 503"},
+			retryIn:  2 * time.Minute,
+			err:      nil,
+		},
+		{
+			name:     "last attempt fails",
+			claimed:  []entity.WebhookDelivery{{Id: 3, EventType: entity.EventAssetPurchased, Payload: payload, Status: entity.DeliveryStatusPending, Attempts: 2, URL: "https://game.example.com", Secret: "whsec_1"}},
+			sendErr:  errors.New("connection refused"),
+			res:      1,
+			recorded: entity.WebhookDelivery{Id: 3, Status: entity.DeliveryStatusFailed, Attempts: 3, LastError: "connection refused"},
+			err:      nil,
+		},
+		{
+			name:    "nothing due",
+			claimed: []entity.WebhookDelivery{},
+			res:     0,
+			err:     nil,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			webhook, repo, sender := WebhookUseCase(t)
+			before := time.Now()
+			repo.EXPECT().ClaimDue(context.Background(), uint64(20), time.Minute).Return(tc.claimed, nil)
+			for _, d := range tc.claimed {
+				sender.EXPECT().Send(context.Background(), d.URL, d.Secret, map[string]string{
+					"X-Webhook-Event":    string(d.EventType),
+					"X-Webhook-Delivery": fmt.Sprint(d.Id),
+				}, []byte(d.Payload)).Return(tc.code, tc.sendErr)
+				repo.EXPECT().RecordAttempt(context.Background(), gomock.Any()).DoAndReturn(func(_ context.Context, d entity.WebhookDelivery) error {
+					switch d.Status {
+					case entity.DeliveryStatusSucceeded:
+						require.NotNil(t, d.DeliveredAt)
+					case entity.DeliveryStatusPending:
+						require.WithinDuration(t, before.Add(tc.retryIn), d.NextAttemptAt, 5*time.Second)
+					}
+					require.Equal(t, tc.recorded, entity.WebhookDelivery{Id: d.Id, Status: d.Status, Attempts: d.Attempts, LastStatusCode: d.LastStatusCode, LastError: d.LastError})
+					return nil
+				})
+			}
+			res, err := webhook.DeliverDue(context.Background())
+			require.Equal(t, res, tc.res)
+			if err != nil {
+				require.ErrorContains(t, err, tc.err.Error())
+			} else {
+				require.Nil(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Klef99/bhs-task/internal/entity"
+)
+
+// WishlistUseCase -.
+type WishlistUseCase struct {
+	repo WishlistRepository
+}
+
+var _ Wishlist = (*WishlistUseCase)(nil)
+
+// New -.
+func NewWishlistUseCase(r WishlistRepository) *WishlistUseCase {
+	return &WishlistUseCase{repo: r}
+}
+
+func (uc *WishlistUseCase) AddToWishlist(ctx context.Context, user entity.User, assetId int64) (bool, error) {
+	if user.Id <= 0 || assetId <= 0 {
+		return false, fmt.Errorf("WishlistUseCase - AddToWishlist - invalid user or asset id")
+	}
+	status, err := uc.repo.Add(ctx, user, assetId)
+	if err != nil {
+		return false, fmt.Errorf("WishlistUseCase - AddToWishlist - uc.repo.Add: %w", err)
+	}
+	return status, nil
+}
+
+func (uc *WishlistUseCase) RemoveFromWishlist(ctx context.Context, user entity.User, assetId int64) (bool, error) {
+	if user.Id <= 0 || assetId <= 0 {
+		return false, fmt.Errorf("WishlistUseCase - RemoveFromWishlist - invalid user or asset id")
+	}
+	status, err := uc.repo.Remove(ctx, user, assetId)
+	if err != nil {
+		return false, fmt.Errorf("WishlistUseCase - RemoveFromWishlist - uc.repo.Remove: %w", err)
+	}
+	return status, nil
+}
+
+// GetWishlist - the saved assets with their current price and availability on the market.
+func (uc *WishlistUseCase) GetWishlist(ctx context.Context, user entity.User) ([]entity.WishlistItem, error) {
+	if user.Id <= 0 {
+		return nil, fmt.Errorf("WishlistUseCase - GetWishlist - invalid user id")
+	}
+	items, err := uc.repo.GetItems(ctx, user)
+	if err != nil {
+		return nil, fmt.Errorf("WishlistUseCase - GetWishlist - uc.repo.GetItems: %w", err)
+	}
+	return items, nil
+}
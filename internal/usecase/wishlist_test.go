@@ -0,0 +1,202 @@
+package usecase_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/Klef99/bhs-task/internal/entity"
+	"github.com/Klef99/bhs-task/internal/usecase"
+	"github.com/stretchr/testify/require"
+	gomock "go.uber.org/mock/gomock"
+)
+
+type wishlistItemTest struct {
+	name    string
+	user    entity.User
+	assetId int64
+	mock    func()
+	res     bool
+	err     error
+}
+
+type getWishlistTest struct {
+	name string
+	user entity.User
+	mock func()
+	res  []entity.WishlistItem
+	err  error
+}
+
+func WishlistUseCase(t *testing.T) (*usecase.WishlistUseCase, *MockWishlistRepository) {
+	t.Helper()
+
+	mockCtl := gomock.NewController(t)
+	defer mockCtl.Finish()
+
+	repo := NewMockWishlistRepository(mockCtl)
+
+	WishlistUseCase := usecase.NewWishlistUseCase(repo)
+	return WishlistUseCase, repo
+}
+
+func TestAddToWishlist(t *testing.T) {
+	t.Parallel()
+
+	wishlist, repo := WishlistUseCase(t)
+	tests := []wishlistItemTest{
+		{
+			name:    "success",
+			user:    entity.User{Id: 1, Username: "test"},
+			assetId: 2,
+			mock: func() {
+				repo.EXPECT().Add(context.Background(), entity.User{Id: 1, Username: "test"}, int64(2)).Return(true, nil)
+			},
+			res: true,
+			err: nil,
+		},
+		{
+			name:    "invalid asset id",
+			user:    entity.User{Id: 1, Username: "test"},
+			assetId: -1,
+			mock:    func() {},
+			res:     false,
+			err:     fmt.Errorf("WishlistUseCase - AddToWishlist - invalid user or asset id"),
+		},
+		{
+			name:    "asset not found",
+			user:    entity.User{Id: 1, Username: "test"},
+			assetId: 3,
+			mock: func() {
+				repo.EXPECT().Add(context.Background(), entity.User{Id: 1, Username: "test"}, int64(3)).Return(false, entity.ErrAssetNotFound)
+			},
+			res: false,
+			err: entity.ErrAssetNotFound,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			tc.mock()
+			res, err := wishlist.AddToWishlist(context.Background(), tc.user, tc.assetId)
+			require.Equal(t, res, tc.res)
+			if err != nil {
+				require.ErrorContains(t, err, tc.err.Error())
+			} else {
+				require.Nil(t, err)
+			}
+		})
+	}
+}
+
+func TestRemoveFromWishlist(t *testing.T) {
+	t.Parallel()
+
+	wishlist, repo := WishlistUseCase(t)
+	tests := []wishlistItemTest{
+		{
+			name:    "success",
+			user:    entity.User{Id: 1, Username: "test"},
+			assetId: 2,
+			mock: func() {
+				repo.EXPECT().Remove(context.Background(), entity.User{Id: 1, Username: "test"}, int64(2)).Return(true, nil)
+			},
+			res: true,
+			err: nil,
+		},
+		{
+			name:    "not on the wishlist",
+			user:    entity.User{Id: 1, Username: "test"},
+			assetId: 3,
+			mock: func() {
+				repo.EXPECT().Remove(context.Background(), entity.User{Id: 1, Username: "test"}, int64(3)).Return(false, nil)
+			},
+			res: false,
+			err: nil,
+		},
+		{
+			name:    "invalid user id",
+			user:    entity.User{},
+			assetId: 2,
+			mock:    func() {},
+			res:     false,
+			err:     fmt.Errorf("WishlistUseCase - RemoveFromWishlist - invalid user or asset id"),
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			tc.mock()
+			res, err := wishlist.RemoveFromWishlist(context.Background(), tc.user, tc.assetId)
+			require.Equal(t, res, tc.res)
+			if err != nil {
+				require.ErrorContains(t, err, tc.err.Error())
+			} else {
+				require.Nil(t, err)
+			}
+		})
+	}
+}
+
+func TestGetWishlist(t *testing.T) {
+	t.Parallel()
+
+	wishlist, repo := WishlistUseCase(t)
+	addedAt := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	tests := []getWishlistTest{
+		{
+			name: "success",
+			user: entity.User{Id: 1, Username: "test"},
+			mock: func() {
+				repo.EXPECT().GetItems(context.Background(), entity.User{Id: 1, Username: "test"}).Return([]entity.WishlistItem{
+					{AssetId: 2, Name: "Sword", Price: 10, Status: entity.AssetStatusPublished, Available: true, AddedAt: addedAt},
+					{AssetId: 3, Name: "Shield", Price: 5, Status: entity.AssetStatusArchived, Available: false, AddedAt: addedAt},
+				}, nil)
+			},
+			res: []entity.WishlistItem{
+				{AssetId: 2, Name: "Sword", Price: 10, Status: entity.AssetStatusPublished, Available: true, AddedAt: addedAt},
+				{AssetId: 3, Name: "Shield", Price: 5, Status: entity.AssetStatusArchived, Available: false, AddedAt: addedAt},
+			},
+			err: nil,
+		},
+		{
+			name: "invalid user id",
+			user: entity.User{},
+			mock: func() {},
+			res:  nil,
+			err:  fmt.Errorf("WishlistUseCase - GetWishlist - invalid user id"),
+		},
+		{
+			name: "repository error",
+			user: entity.User{Id: 2, Username: "test2"},
+			mock: func() {
+				repo.EXPECT().GetItems(context.Background(), entity.User{Id: 2, Username: "test2"}).Return(nil, errInternalServErr)
+			},
+			res: nil,
+			err: errInternalServErr,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			tc.mock()
+			res, err := wishlist.GetWishlist(context.Background(), tc.user)
+			require.Equal(t, res, tc.res)
+			if err != nil {
+				require.ErrorContains(t, err, tc.err.Error())
+			} else {
+				require.Nil(t, err)
+			}
+		})
+	}
+}
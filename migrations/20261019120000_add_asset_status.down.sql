@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS public.assets_status_idx;
+ALTER TABLE public.assets DROP CONSTRAINT IF EXISTS assets_status_check;
+ALTER TABLE public.assets DROP COLUMN IF EXISTS status;
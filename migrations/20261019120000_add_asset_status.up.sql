@@ -0,0 +1,5 @@
+-- Existing assets have always been on the market, new ones start as drafts.
+ALTER TABLE public.assets ADD COLUMN IF NOT EXISTS status text NOT NULL DEFAULT 'published';
+ALTER TABLE public.assets ALTER COLUMN status SET DEFAULT 'draft';
+ALTER TABLE public.assets ADD CONSTRAINT assets_status_check CHECK ((status = ANY (ARRAY['draft'::text, 'published'::text, 'unlisted'::text, 'archived'::text])));
+CREATE INDEX IF NOT EXISTS assets_status_idx ON public.assets USING btree (status);
@@ -0,0 +1,5 @@
+ALTER TABLE public.users DROP CONSTRAINT IF EXISTS users_role_check;
+ALTER TABLE public.users DROP COLUMN IF EXISTS "role";
+
+DROP INDEX IF EXISTS public.assets_not_deleted_idx;
+ALTER TABLE public.assets DROP COLUMN IF EXISTS deleted_at;
@@ -0,0 +1,5 @@
+ALTER TABLE public.assets ADD COLUMN IF NOT EXISTS deleted_at timestamptz;
+CREATE INDEX IF NOT EXISTS assets_not_deleted_idx ON public.assets USING btree (owner_id) WHERE deleted_at IS NULL;
+
+ALTER TABLE public.users ADD COLUMN IF NOT EXISTS "role" text NOT NULL DEFAULT 'user';
+ALTER TABLE public.users ADD CONSTRAINT users_role_check CHECK (("role" = ANY (ARRAY['user'::text, 'admin'::text])));
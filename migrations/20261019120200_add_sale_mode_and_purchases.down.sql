@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS public.purchases;
+
+ALTER TABLE public.assets DROP CONSTRAINT IF EXISTS assets_sale_mode_check;
+ALTER TABLE public.assets DROP COLUMN IF EXISTS sale_mode;
@@ -0,0 +1,18 @@
+ALTER TABLE public.assets ADD COLUMN IF NOT EXISTS sale_mode text NOT NULL DEFAULT 'license';
+ALTER TABLE public.assets ADD CONSTRAINT assets_sale_mode_check CHECK ((sale_mode = ANY (ARRAY['license'::text, 'transfer'::text])));
+
+-- Purchases make up the buyers' history and outlive the asset, a purged one leaves them with a NULL asset_id.
+CREATE TABLE IF NOT EXISTS public.purchases (
+	id bigserial NOT NULL,
+	asset_id int4,
+	buyer_id int4 NOT NULL,
+	seller_id int4 NOT NULL,
+	price numeric NOT NULL,
+	sale_mode text NOT NULL,
+	purchased_at timestamptz NOT NULL DEFAULT now(),
+	CONSTRAINT purchases_pk PRIMARY KEY (id),
+	CONSTRAINT purchases_assets_fk FOREIGN KEY (asset_id) REFERENCES public.assets(id) ON DELETE SET NULL ON UPDATE CASCADE,
+	CONSTRAINT purchases_buyer_fk FOREIGN KEY (buyer_id) REFERENCES public.users(id) ON DELETE CASCADE ON UPDATE CASCADE,
+	CONSTRAINT purchases_seller_fk FOREIGN KEY (seller_id) REFERENCES public.users(id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+CREATE INDEX IF NOT EXISTS purchases_asset_idx ON public.purchases USING btree (asset_id, purchased_at);
@@ -0,0 +1,6 @@
+ALTER TABLE public.purchases DROP COLUMN IF EXISTS edition;
+ALTER TABLE public.access_assets DROP COLUMN IF EXISTS edition;
+
+ALTER TABLE public.assets DROP CONSTRAINT IF EXISTS assets_stock_check;
+ALTER TABLE public.assets DROP COLUMN IF EXISTS sold;
+ALTER TABLE public.assets DROP COLUMN IF EXISTS stock;
@@ -0,0 +1,6 @@
+ALTER TABLE public.assets ADD COLUMN IF NOT EXISTS stock int4;
+ALTER TABLE public.assets ADD COLUMN IF NOT EXISTS sold int4 NOT NULL DEFAULT 0;
+ALTER TABLE public.assets ADD CONSTRAINT assets_stock_check CHECK (((stock IS NULL) OR ((stock > 0) AND (sold <= stock))));
+
+ALTER TABLE public.access_assets ADD COLUMN IF NOT EXISTS edition int4;
+ALTER TABLE public.purchases ADD COLUMN IF NOT EXISTS edition int4;
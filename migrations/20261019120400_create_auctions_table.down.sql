@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS public.bids;
+DROP TABLE IF EXISTS public.auctions;
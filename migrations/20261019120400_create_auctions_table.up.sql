@@ -0,0 +1,34 @@
+CREATE TABLE IF NOT EXISTS public.auctions (
+	id bigserial NOT NULL,
+	asset_id int4 NOT NULL,
+	seller_id int4 NOT NULL,
+	reserve_price numeric NOT NULL DEFAULT 0,
+	end_at timestamptz NOT NULL,
+	status text NOT NULL DEFAULT 'open',
+	created_at timestamptz NOT NULL DEFAULT now(),
+	settled_at timestamptz,
+	CONSTRAINT auctions_pk PRIMARY KEY (id),
+	CONSTRAINT auctions_reserve_price_check CHECK ((reserve_price >= (0)::numeric)),
+	CONSTRAINT auctions_status_check CHECK ((status = ANY (ARRAY['open'::text, 'sold'::text, 'unsold'::text]))),
+	CONSTRAINT auctions_assets_fk FOREIGN KEY (asset_id) REFERENCES public.assets(id) ON DELETE CASCADE ON UPDATE CASCADE,
+	CONSTRAINT auctions_users_fk FOREIGN KEY (seller_id) REFERENCES public.users(id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+-- At most one open auction per asset.
+CREATE UNIQUE INDEX IF NOT EXISTS auctions_open_asset_idx ON public.auctions USING btree (asset_id) WHERE status = 'open';
+CREATE INDEX IF NOT EXISTS auctions_open_end_at_idx ON public.auctions USING btree (end_at) WHERE status = 'open';
+
+CREATE TABLE IF NOT EXISTS public.bids (
+	id bigserial NOT NULL,
+	auction_id int8 NOT NULL,
+	bidder_id int4 NOT NULL,
+	amount numeric NOT NULL,
+	status text NOT NULL DEFAULT 'held',
+	created_at timestamptz NOT NULL DEFAULT now(),
+	CONSTRAINT bids_pk PRIMARY KEY (id),
+	CONSTRAINT bids_amount_check CHECK ((amount > (0)::numeric)),
+	CONSTRAINT bids_status_check CHECK ((status = ANY (ARRAY['held'::text, 'released'::text, 'won'::text]))),
+	CONSTRAINT bids_auctions_fk FOREIGN KEY (auction_id) REFERENCES public.auctions(id) ON DELETE CASCADE ON UPDATE CASCADE,
+	CONSTRAINT bids_users_fk FOREIGN KEY (bidder_id) REFERENCES public.users(id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+-- At most one held (highest) bid per auction.
+CREATE UNIQUE INDEX IF NOT EXISTS bids_held_auction_idx ON public.bids USING btree (auction_id) WHERE status = 'held';
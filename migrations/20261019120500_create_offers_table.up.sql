@@ -0,0 +1,28 @@
+CREATE TABLE IF NOT EXISTS public.offers (
+	id bigserial NOT NULL,
+	asset_id int4 NOT NULL,
+	buyer_id int4 NOT NULL,
+	seller_id int4 NOT NULL,
+	proposed_by int4 NOT NULL,
+	parent_id int8,
+	price numeric NOT NULL,
+	status text NOT NULL DEFAULT 'pending',
+	purchase_id int8,
+	expires_at timestamptz NOT NULL,
+	created_at timestamptz NOT NULL DEFAULT now(),
+	responded_at timestamptz,
+	CONSTRAINT offers_pk PRIMARY KEY (id),
+	CONSTRAINT offers_price_check CHECK ((price > (0)::numeric)),
+	CONSTRAINT offers_status_check CHECK ((status = ANY (ARRAY['pending'::text, 'accepted'::text, 'rejected'::text, 'countered'::text, 'expired'::text]))),
+	CONSTRAINT offers_proposed_by_check CHECK ((proposed_by = buyer_id OR proposed_by = seller_id)),
+	CONSTRAINT offers_assets_fk FOREIGN KEY (asset_id) REFERENCES public.assets(id) ON DELETE CASCADE ON UPDATE CASCADE,
+	CONSTRAINT offers_buyer_fk FOREIGN KEY (buyer_id) REFERENCES public.users(id) ON DELETE CASCADE ON UPDATE CASCADE,
+	CONSTRAINT offers_seller_fk FOREIGN KEY (seller_id) REFERENCES public.users(id) ON DELETE CASCADE ON UPDATE CASCADE,
+	CONSTRAINT offers_parent_fk FOREIGN KEY (parent_id) REFERENCES public.offers(id) ON DELETE SET NULL,
+	CONSTRAINT offers_purchases_fk FOREIGN KEY (purchase_id) REFERENCES public.purchases(id) ON DELETE SET NULL
+);
+-- At most one pending negotiation per buyer and asset.
+CREATE UNIQUE INDEX IF NOT EXISTS offers_pending_buyer_asset_idx ON public.offers USING btree (asset_id, buyer_id) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS offers_pending_expires_at_idx ON public.offers USING btree (expires_at) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS offers_buyer_id_idx ON public.offers USING btree (buyer_id);
+CREATE INDEX IF NOT EXISTS offers_seller_id_idx ON public.offers USING btree (seller_id);
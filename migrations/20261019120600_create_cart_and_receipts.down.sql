@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS public.purchases_receipt_idx;
+ALTER TABLE public.purchases DROP CONSTRAINT IF EXISTS purchases_receipts_fk;
+ALTER TABLE public.purchases DROP COLUMN IF EXISTS receipt_id;
+DROP TABLE IF EXISTS public.receipts;
+DROP TABLE IF EXISTS public.cart_items;
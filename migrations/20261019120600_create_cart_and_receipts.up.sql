@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS public.cart_items (
+	user_id int4 NOT NULL,
+	asset_id int4 NOT NULL,
+	added_at timestamptz NOT NULL DEFAULT now(),
+	CONSTRAINT cart_items_pk PRIMARY KEY (user_id, asset_id),
+	CONSTRAINT cart_items_users_fk FOREIGN KEY (user_id) REFERENCES public.users(id) ON DELETE CASCADE ON UPDATE CASCADE,
+	CONSTRAINT cart_items_assets_fk FOREIGN KEY (asset_id) REFERENCES public.assets(id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS public.receipts (
+	id bigserial NOT NULL,
+	buyer_id int4 NOT NULL,
+	total numeric NOT NULL DEFAULT 0,
+	created_at timestamptz NOT NULL DEFAULT now(),
+	CONSTRAINT receipts_pk PRIMARY KEY (id),
+	CONSTRAINT receipts_users_fk FOREIGN KEY (buyer_id) REFERENCES public.users(id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+ALTER TABLE public.purchases ADD COLUMN IF NOT EXISTS receipt_id int8;
+ALTER TABLE public.purchases ADD CONSTRAINT purchases_receipts_fk FOREIGN KEY (receipt_id) REFERENCES public.receipts(id) ON DELETE SET NULL;
+CREATE INDEX IF NOT EXISTS purchases_receipt_idx ON public.purchases USING btree (receipt_id) WHERE receipt_id IS NOT NULL;
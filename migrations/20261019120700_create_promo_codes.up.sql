@@ -0,0 +1,37 @@
+CREATE TABLE IF NOT EXISTS public.promo_codes (
+	id bigserial NOT NULL,
+	code text NOT NULL,
+	kind text NOT NULL,
+	value numeric NOT NULL,
+	asset_id int4,
+	seller_id int4,
+	created_by int4 NOT NULL,
+	max_uses int8,
+	used int8 NOT NULL DEFAULT 0,
+	expires_at timestamptz,
+	created_at timestamptz NOT NULL DEFAULT now(),
+	CONSTRAINT promo_codes_pk PRIMARY KEY (id),
+	CONSTRAINT promo_codes_code_key UNIQUE (code),
+	CONSTRAINT promo_codes_kind_check CHECK ((kind = ANY (ARRAY['percent'::text, 'fixed'::text]))),
+	CONSTRAINT promo_codes_value_check CHECK ((value > (0)::numeric AND (kind <> 'percent' OR value <= (100)::numeric))),
+	CONSTRAINT promo_codes_uses_check CHECK ((max_uses IS NULL OR (max_uses > 0 AND used <= max_uses))),
+	CONSTRAINT promo_codes_assets_fk FOREIGN KEY (asset_id) REFERENCES public.assets(id) ON DELETE CASCADE ON UPDATE CASCADE,
+	CONSTRAINT promo_codes_seller_fk FOREIGN KEY (seller_id) REFERENCES public.users(id) ON DELETE CASCADE ON UPDATE CASCADE,
+	CONSTRAINT promo_codes_created_by_fk FOREIGN KEY (created_by) REFERENCES public.users(id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+CREATE INDEX IF NOT EXISTS promo_codes_created_by_idx ON public.promo_codes USING btree (created_by);
+
+CREATE TABLE IF NOT EXISTS public.promo_redemptions (
+	id bigserial NOT NULL,
+	promo_code_id int8 NOT NULL,
+	purchase_id int8 NOT NULL,
+	user_id int4 NOT NULL,
+	discount numeric NOT NULL,
+	redeemed_at timestamptz NOT NULL DEFAULT now(),
+	CONSTRAINT promo_redemptions_pk PRIMARY KEY (id),
+	CONSTRAINT promo_redemptions_purchase_key UNIQUE (purchase_id),
+	CONSTRAINT promo_redemptions_promo_codes_fk FOREIGN KEY (promo_code_id) REFERENCES public.promo_codes(id) ON DELETE CASCADE,
+	CONSTRAINT promo_redemptions_purchases_fk FOREIGN KEY (purchase_id) REFERENCES public.purchases(id) ON DELETE CASCADE,
+	CONSTRAINT promo_redemptions_users_fk FOREIGN KEY (user_id) REFERENCES public.users(id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+CREATE INDEX IF NOT EXISTS promo_redemptions_promo_code_idx ON public.promo_redemptions USING btree (promo_code_id);
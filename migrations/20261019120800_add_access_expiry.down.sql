@@ -0,0 +1,7 @@
+ALTER TABLE public.purchases DROP COLUMN IF EXISTS renewal;
+
+DROP INDEX IF EXISTS public.access_assets_expires_at_idx;
+ALTER TABLE public.access_assets DROP COLUMN IF EXISTS expires_at;
+
+ALTER TABLE public.assets DROP CONSTRAINT IF EXISTS assets_access_days_check;
+ALTER TABLE public.assets DROP COLUMN IF EXISTS access_days;
@@ -0,0 +1,8 @@
+ALTER TABLE public.assets ADD COLUMN IF NOT EXISTS access_days int4;
+ALTER TABLE public.assets ADD CONSTRAINT assets_access_days_check CHECK ((access_days IS NULL OR (access_days > 0 AND sale_mode = 'license'::text)));
+
+ALTER TABLE public.access_assets ADD COLUMN IF NOT EXISTS expires_at timestamptz;
+CREATE INDEX IF NOT EXISTS access_assets_expires_at_idx ON public.access_assets USING btree (expires_at) WHERE expires_at IS NOT NULL;
+
+-- A renewal extends an access already sold, it is recorded in purchases but not counted in assets.sold.
+ALTER TABLE public.purchases ADD COLUMN IF NOT EXISTS renewal bool NOT NULL DEFAULT false;
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS public.purchases_buyer_idx;
+DROP INDEX IF EXISTS public.purchases_recipient_idx;
+ALTER TABLE public.purchases DROP CONSTRAINT IF EXISTS purchases_recipients_fk;
+ALTER TABLE public.purchases DROP COLUMN IF EXISTS gift_message;
+ALTER TABLE public.purchases DROP COLUMN IF EXISTS recipient_id;
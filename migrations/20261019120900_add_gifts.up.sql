@@ -0,0 +1,6 @@
+-- A gift is a purchase whose asset goes to recipient_id instead of the paying buyer_id.
+ALTER TABLE public.purchases ADD COLUMN IF NOT EXISTS recipient_id int4;
+ALTER TABLE public.purchases ADD COLUMN IF NOT EXISTS gift_message text;
+ALTER TABLE public.purchases ADD CONSTRAINT purchases_recipients_fk FOREIGN KEY (recipient_id) REFERENCES public.users(id) ON DELETE SET NULL ON UPDATE CASCADE;
+CREATE INDEX IF NOT EXISTS purchases_recipient_idx ON public.purchases USING btree (recipient_id) WHERE recipient_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS purchases_buyer_idx ON public.purchases USING btree (buyer_id);
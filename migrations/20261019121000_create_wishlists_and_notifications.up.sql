@@ -0,0 +1,25 @@
+CREATE TABLE IF NOT EXISTS public.wishlist_items (
+	user_id int4 NOT NULL,
+	asset_id int4 NOT NULL,
+	added_at timestamptz NOT NULL DEFAULT now(),
+	CONSTRAINT wishlist_items_pk PRIMARY KEY (user_id, asset_id),
+	CONSTRAINT wishlist_items_users_fk FOREIGN KEY (user_id) REFERENCES public.users(id) ON DELETE CASCADE ON UPDATE CASCADE,
+	CONSTRAINT wishlist_items_assets_fk FOREIGN KEY (asset_id) REFERENCES public.assets(id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+CREATE INDEX IF NOT EXISTS wishlist_items_asset_idx ON public.wishlist_items USING btree (asset_id);
+
+CREATE TABLE IF NOT EXISTS public.notifications (
+	id bigserial NOT NULL,
+	user_id int4 NOT NULL,
+	kind text NOT NULL,
+	asset_id int4,
+	old_price numeric,
+	new_price numeric,
+	created_at timestamptz NOT NULL DEFAULT now(),
+	read_at timestamptz,
+	CONSTRAINT notifications_pk PRIMARY KEY (id),
+	CONSTRAINT notifications_kind_check CHECK ((kind = ANY (ARRAY['price_drop'::text]))),
+	CONSTRAINT notifications_users_fk FOREIGN KEY (user_id) REFERENCES public.users(id) ON DELETE CASCADE ON UPDATE CASCADE,
+	CONSTRAINT notifications_assets_fk FOREIGN KEY (asset_id) REFERENCES public.assets(id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+CREATE INDEX IF NOT EXISTS notifications_user_idx ON public.notifications USING btree (user_id, created_at DESC);
@@ -0,0 +1,3 @@
+ALTER TABLE public.assets DROP COLUMN IF EXISTS rating_count;
+ALTER TABLE public.assets DROP COLUMN IF EXISTS rating_sum;
+DROP TABLE IF EXISTS public.reviews;
@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS public.reviews (
+	id bigserial NOT NULL,
+	asset_id int4 NOT NULL,
+	user_id int4 NOT NULL,
+	rating int2 NOT NULL,
+	body text NOT NULL DEFAULT '',
+	reply text,
+	replied_at timestamptz,
+	created_at timestamptz NOT NULL DEFAULT now(),
+	CONSTRAINT reviews_pk PRIMARY KEY (id),
+	CONSTRAINT reviews_asset_user_unique UNIQUE (asset_id, user_id),
+	CONSTRAINT reviews_rating_check CHECK ((rating BETWEEN 1 AND 5)),
+	CONSTRAINT reviews_assets_fk FOREIGN KEY (asset_id) REFERENCES public.assets(id) ON DELETE CASCADE ON UPDATE CASCADE,
+	CONSTRAINT reviews_users_fk FOREIGN KEY (user_id) REFERENCES public.users(id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+-- Aggregate kept up to date with every review, the average is rating_sum / rating_count.
+ALTER TABLE public.assets ADD COLUMN IF NOT EXISTS rating_sum int8 NOT NULL DEFAULT 0;
+ALTER TABLE public.assets ADD COLUMN IF NOT EXISTS rating_count int8 NOT NULL DEFAULT 0;
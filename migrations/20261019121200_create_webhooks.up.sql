@@ -0,0 +1,32 @@
+CREATE TABLE IF NOT EXISTS public.webhooks (
+	id bigserial NOT NULL,
+	user_id int4 NOT NULL,
+	url text NOT NULL,
+	secret text NOT NULL,
+	events text[] NOT NULL,
+	"global" bool NOT NULL DEFAULT false,
+	created_at timestamptz NOT NULL DEFAULT now(),
+	CONSTRAINT webhooks_pk PRIMARY KEY (id),
+	CONSTRAINT webhooks_events_check CHECK ((cardinality(events) > 0 AND events <@ ARRAY['asset.created'::text, 'asset.purchased'::text, 'deposit.completed'::text])),
+	CONSTRAINT webhooks_users_fk FOREIGN KEY (user_id) REFERENCES public.users(id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+CREATE INDEX IF NOT EXISTS webhooks_user_idx ON public.webhooks USING btree (user_id);
+
+CREATE TABLE IF NOT EXISTS public.webhook_deliveries (
+	id bigserial NOT NULL,
+	webhook_id int8 NOT NULL,
+	event_type text NOT NULL,
+	payload jsonb NOT NULL,
+	status text NOT NULL DEFAULT 'pending',
+	attempts int4 NOT NULL DEFAULT 0,
+	next_attempt_at timestamptz NOT NULL DEFAULT now(),
+	last_status_code int4,
+	last_error text,
+	created_at timestamptz NOT NULL DEFAULT now(),
+	delivered_at timestamptz,
+	CONSTRAINT webhook_deliveries_pk PRIMARY KEY (id),
+	CONSTRAINT webhook_deliveries_status_check CHECK ((status = ANY (ARRAY['pending'::text, 'succeeded'::text, 'failed'::text]))),
+	CONSTRAINT webhook_deliveries_webhooks_fk FOREIGN KEY (webhook_id) REFERENCES public.webhooks(id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+CREATE INDEX IF NOT EXISTS webhook_deliveries_webhook_idx ON public.webhook_deliveries USING btree (webhook_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS webhook_deliveries_due_idx ON public.webhook_deliveries USING btree (next_attempt_at) WHERE status = 'pending';
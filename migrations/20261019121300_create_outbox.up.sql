@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS public.outbox (
+	id bigserial NOT NULL,
+	aggregate_type text NOT NULL,
+	aggregate_id int8 NOT NULL,
+	event_type text NOT NULL,
+	payload jsonb NOT NULL,
+	user_ids int8[] NOT NULL DEFAULT '{}',
+	attempts int4 NOT NULL DEFAULT 0,
+	next_attempt_at timestamptz NOT NULL DEFAULT now(),
+	last_error text,
+	created_at timestamptz NOT NULL DEFAULT now(),
+	published_at timestamptz,
+	CONSTRAINT outbox_pk PRIMARY KEY (id)
+);
+CREATE INDEX IF NOT EXISTS outbox_pending_idx ON public.outbox USING btree (id) WHERE published_at IS NULL;
+CREATE INDEX IF NOT EXISTS outbox_pending_aggregate_idx ON public.outbox USING btree (aggregate_type, aggregate_id, id) WHERE published_at IS NULL;
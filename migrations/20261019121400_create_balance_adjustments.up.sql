@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS public.balance_adjustments (
+	id bigserial NOT NULL,
+	user_id int4 NOT NULL,
+	amount numeric NOT NULL,
+	reason text NOT NULL,
+	actor text NOT NULL,
+	created_at timestamptz NOT NULL DEFAULT now(),
+	CONSTRAINT balance_adjustments_pk PRIMARY KEY (id),
+	CONSTRAINT balance_adjustments_amount_check CHECK ((amount <> (0)::numeric)),
+	CONSTRAINT balance_adjustments_reason_check CHECK ((reason <> ''::text)),
+	CONSTRAINT balance_adjustments_users_fk FOREIGN KEY (user_id) REFERENCES public.users(id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+CREATE INDEX IF NOT EXISTS balance_adjustments_user_idx ON public.balance_adjustments USING btree (user_id, created_at DESC);
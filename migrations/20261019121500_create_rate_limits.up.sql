@@ -0,0 +1,11 @@
+CREATE UNLOGGED TABLE IF NOT EXISTS public.rate_limits (
+	bucket text NOT NULL,
+	tokens float8 NOT NULL,
+	allowed bool NOT NULL,
+	rate float8 NOT NULL,
+	burst int4 NOT NULL,
+	updated_at timestamptz NOT NULL DEFAULT now(),
+	CONSTRAINT rate_limits_pk PRIMARY KEY (bucket),
+	CONSTRAINT rate_limits_rate_check CHECK ((rate > (0)::double precision)),
+	CONSTRAINT rate_limits_burst_check CHECK ((burst >= 1))
+);
@@ -0,0 +1,32 @@
+package grpcserver
+
+import (
+	"net"
+	"time"
+
+	"google.golang.org/grpc"
+)
+
+// Option -.
+type Option func(*Server)
+
+// Port -.
+func Port(port string) Option {
+	return func(s *Server) {
+		s.address = net.JoinHostPort("", port)
+	}
+}
+
+// ShutdownTimeout -.
+func ShutdownTimeout(timeout time.Duration) Option {
+	return func(s *Server) {
+		s.shutdownTimeout = timeout
+	}
+}
+
+// ServerOptions - e.g. interceptors, applied when the grpc.Server is created.
+func ServerOptions(opts ...grpc.ServerOption) Option {
+	return func(s *Server) {
+		s.serverOptions = append(s.serverOptions, opts...)
+	}
+}
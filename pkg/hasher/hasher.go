@@ -1,24 +1,35 @@
 package hasher
 
 import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
 	"golang.org/x/crypto/bcrypt"
 )
 
 const (
 	_defaultCost = bcrypt.DefaultCost
+	_tracerName  = "github.com/Klef99/bhs-task/pkg/hasher"
 )
 
 type Interface interface {
-	HashPassword(password string) ([]byte, error)
-	CompareHashAndPassword(password_hash, password string) error
+	HashPassword(ctx context.Context, password string) ([]byte, error)
+	CompareHashAndPassword(ctx context.Context, password_hash, password string) error
 }
 
 type Hasher struct {
-	Cost int
+	Cost     int
+	tracer   trace.Tracer
+	duration *prometheus.HistogramVec
 }
 
 func NewHasher(opts ...Option) *Hasher {
-	hs := &Hasher{Cost: _defaultCost}
+	hs := &Hasher{Cost: _defaultCost, tracer: noop.NewTracerProvider().Tracer(_tracerName)}
 	// Custom options
 	for _, opt := range opts {
 		opt(hs)
@@ -28,15 +39,35 @@ func NewHasher(opts ...Option) *Hasher {
 
 var _ Interface = (*Hasher)(nil)
 
-func (h *Hasher) HashPassword(password string) ([]byte, error) {
+// HashPassword - bcrypt is slow on purpose, the span shows how much of a request it takes.
+func (h *Hasher) HashPassword(ctx context.Context, password string) ([]byte, error) {
+	_, span := h.tracer.Start(ctx, "bcrypt.GenerateFromPassword", trace.WithAttributes(attribute.Int("bcrypt.cost", h.Cost)))
+	defer span.End()
+	defer h.observe("hash", time.Now())
+
 	hashedBytes, err := bcrypt.GenerateFromPassword([]byte(password), h.Cost)
 	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
 		return []byte{}, err
 	}
 	return hashedBytes, nil
 }
 
-func (h *Hasher) CompareHashAndPassword(passwordHash, password string) error {
+// CompareHashAndPassword - a mismatch is an expected outcome and doesn't mark the span as failed.
+func (h *Hasher) CompareHashAndPassword(ctx context.Context, passwordHash, password string) error {
+	_, span := h.tracer.Start(ctx, "bcrypt.CompareHashAndPassword")
+	defer span.End()
+	defer h.observe("compare", time.Now())
+
 	err := bcrypt.CompareHashAndPassword([]byte(passwordHash), []byte(password))
+	if err != nil && err != bcrypt.ErrMismatchedHashAndPassword {
+		span.SetStatus(codes.Error, err.Error())
+	}
 	return err
 }
+
+func (h *Hasher) observe(operation string, start time.Time) {
+	if h.duration != nil {
+		h.duration.WithLabelValues(operation).Observe(time.Since(start).Seconds())
+	}
+}
@@ -1,5 +1,11 @@
 package hasher
 
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"go.opentelemetry.io/otel/trace"
+)
+
 // Option -.
 type Option func(*Hasher)
 
@@ -9,3 +15,21 @@ func HasherCost(cost int) Option {
 		c.Cost = cost
 	}
 }
+
+// TracerProvider -.
+func TracerProvider(tp trace.TracerProvider) Option {
+	return func(c *Hasher) {
+		c.tracer = tp.Tracer(_tracerName)
+	}
+}
+
+// Registerer - records the duration of hashing and comparing in the bcrypt_duration_seconds histogram.
+func Registerer(reg prometheus.Registerer) Option {
+	return func(c *Hasher) {
+		c.duration = promauto.With(reg).NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "bcrypt_duration_seconds",
+			Help:    "Time taken by bcrypt, by operation: hash or compare.",
+			Buckets: []float64{.01, .025, .05, .1, .25, .5, 1, 2.5},
+		}, []string{"operation"})
+	}
+}
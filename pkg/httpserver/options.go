@@ -1,6 +1,7 @@
 package httpserver
 
 import (
+	"crypto/tls"
 	"net"
 	"time"
 )
@@ -35,3 +36,33 @@ func ShutdownTimeout(timeout time.Duration) Option {
 		s.shutdownTimeout = timeout
 	}
 }
+
+// TLS - serves HTTPS only, with the certificate and key from the files. They are loaded again when they change.
+func TLS(certFile, keyFile string) Option {
+	return func(s *Server) {
+		s.certFile = certFile
+		s.keyFile = keyFile
+	}
+}
+
+// MinTLSVersion - TLS 1.2 by default.
+func MinTLSVersion(version uint16) Option {
+	return func(s *Server) {
+		s.minVersion = version
+	}
+}
+
+// CipherSuites - suites offered over TLS 1.2, those of TLS 1.3 aren't configurable. Go's defaults if empty.
+func CipherSuites(suites []uint16) Option {
+	return func(s *Server) {
+		s.cipherSuites = suites
+	}
+}
+
+// ClientCA - verifies client certificates against the CA bundle in the file, auth tells whether clients must present one.
+func ClientCA(file string, auth tls.ClientAuthType) Option {
+	return func(s *Server) {
+		s.clientCAFile = file
+		s.clientAuth = auth
+	}
+}
@@ -3,6 +3,9 @@ package httpserver
 
 import (
 	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
 	"net/http"
 	"time"
 )
@@ -19,6 +22,13 @@ type Server struct {
 	server          *http.Server
 	notify          chan error
 	shutdownTimeout time.Duration
+
+	certFile     string
+	keyFile      string
+	minVersion   uint16
+	cipherSuites []uint16
+	clientCAFile string
+	clientAuth   tls.ClientAuthType
 }
 
 // New -.
@@ -34,6 +44,7 @@ func New(handler http.Handler, opts ...Option) *Server {
 		server:          httpServer,
 		notify:          make(chan error, 1),
 		shutdownTimeout: _defaultShutdownTimeout,
+		minVersion:      tls.VersionTLS12,
 	}
 
 	// Custom options
@@ -48,20 +59,89 @@ func New(handler http.Handler, opts ...Option) *Server {
 
 func (s *Server) start() {
 	go func() {
-		s.notify <- s.server.ListenAndServe()
+		var err error
+		if !s.tlsEnabled() {
+			err = s.server.ListenAndServe()
+		} else if err = s.configureTLS(); err == nil {
+			// The certificate comes from TLSConfig.GetCertificate.
+			err = s.server.ListenAndServeTLS("", "")
+		}
+		s.notify <- err
 		close(s.notify)
 	}()
 }
 
+func (s *Server) tlsEnabled() bool {
+	return s.certFile != "" || s.clientCAFile != "" || s.clientAuth != tls.NoClientCert
+}
+
+// configureTLS - with TLS set the server doesn't accept plaintext connections.
+func (s *Server) configureTLS() error {
+	cfg, err := s.tlsConfig()
+	if err != nil {
+		return err
+	}
+	// net/http serves HTTP/2 to clients negotiating it.
+	cfg.NextProtos = []string{"h2", "http/1.1"}
+	s.server.TLSConfig = cfg
+	return nil
+}
+
+func (s *Server) tlsConfig() (*tls.Config, error) {
+	if s.certFile == "" || s.keyFile == "" {
+		return nil, errors.New("httpserver - tlsConfig - certificate and key files are required")
+	}
+	// Without a CA bundle client certificates would be verified against the system roots.
+	if s.clientAuth >= tls.VerifyClientCertIfGiven && s.clientCAFile == "" {
+		return nil, errors.New("httpserver - tlsConfig - verifying client certificates requires a CA file")
+	}
+	certs, err := newCertReloader(s.certFile, s.keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("httpserver - tlsConfig - newCertReloader: %w", err)
+	}
+	cfg := &tls.Config{
+		GetCertificate: certs.GetCertificate,
+		MinVersion:     s.minVersion,
+		CipherSuites:   s.cipherSuites,
+		ClientAuth:     s.clientAuth,
+	}
+	if s.clientCAFile != "" {
+		cfg.ClientCAs, err = loadCertPool(s.clientCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("httpserver - tlsConfig - loadCertPool: %w", err)
+		}
+	}
+	return cfg, nil
+}
+
+// TLSConfig - the TLS settings a server created with opts would use, so other listeners can serve the same
+// certificate, versions and client verification. Nil if opts don't set TLS.
+func TLSConfig(opts ...Option) (*tls.Config, error) {
+	s := &Server{server: &http.Server{}, minVersion: tls.VersionTLS12}
+	for _, opt := range opts {
+		opt(s)
+	}
+	if !s.tlsEnabled() {
+		return nil, nil
+	}
+	return s.tlsConfig()
+}
+
 // Notify -.
 func (s *Server) Notify() <-chan error {
 	return s.notify
 }
 
-// Shutdown -.
+// Shutdown - stops accepting connections and waits for in-flight requests to complete within the shutdown timeout,
+// connections still active after it are closed.
 func (s *Server) Shutdown() error {
 	ctx, cancel := context.WithTimeout(context.Background(), s.shutdownTimeout)
 	defer cancel()
 
-	return s.server.Shutdown(ctx)
+	err := s.server.Shutdown(ctx)
+	if err != nil {
+		s.server.Close()
+		return err
+	}
+	return nil
 }
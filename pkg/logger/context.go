@@ -0,0 +1,17 @@
+package logger
+
+import "context"
+
+type ctxKey struct{}
+
+// NewContext - returns a copy of ctx carrying l. Loggers of the code handling ctx pick l up with WithContext,
+// e.g. a logger with the request id set by the HTTP middleware.
+func NewContext(ctx context.Context, l Interface) context.Context {
+	return context.WithValue(ctx, ctxKey{}, l)
+}
+
+// FromContext - the logger ctx carries, nil if there is none.
+func FromContext(ctx context.Context) Interface {
+	l, _ := ctx.Value(ctxKey{}).(Interface)
+	return l
+}
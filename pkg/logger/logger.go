@@ -1,13 +1,18 @@
 package logger
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"strings"
 
 	"github.com/rs/zerolog"
+	"go.opentelemetry.io/otel/trace"
 )
 
+// Fields - structured data added to every message of a logger.
+type Fields map[string]interface{}
+
 // Interface -.
 type Interface interface {
 	Debug(message interface{}, args ...interface{})
@@ -15,6 +20,8 @@ type Interface interface {
 	Warn(message string, args ...interface{})
 	Error(message interface{}, args ...interface{})
 	Fatal(message interface{}, args ...interface{})
+	WithContext(ctx context.Context) Interface
+	WithFields(fields Fields) Interface
 }
 
 // Logger -.
@@ -82,6 +89,36 @@ func (l *Logger) Fatal(message interface{}, args ...interface{}) {
 	os.Exit(1)
 }
 
+// WithContext - the logger scoped to ctx by NewContext if there is one, or l otherwise, with the trace and
+// span ids of the span in ctx added to every message, so logs can be matched to requests and traces.
+func (l *Logger) WithContext(ctx context.Context) Interface {
+	base := l
+	if scoped, ok := FromContext(ctx).(*Logger); ok {
+		base = scoped
+	}
+	sc := trace.SpanContextFromContext(ctx)
+	if !sc.IsValid() {
+		return base
+	}
+	logger := base.logger.With().
+		Str("trace_id", sc.TraceID().String()).
+		Str("span_id", sc.SpanID().String()).
+		Logger()
+
+	return &Logger{
+		logger: &logger,
+	}
+}
+
+// WithFields -.
+func (l *Logger) WithFields(fields Fields) Interface {
+	logger := l.logger.With().Fields(map[string]interface{}(fields)).Logger()
+
+	return &Logger{
+		logger: &logger,
+	}
+}
+
 func (l *Logger) log(message string, args ...interface{}) {
 	if len(args) == 0 {
 		l.logger.Info().Msg(message)
@@ -0,0 +1,619 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: v1/asset.proto
+
+package v1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	AssetService_CreateAsset_FullMethodName         = "/bhs.v1.AssetService/CreateAsset"
+	AssetService_DeleteAsset_FullMethodName         = "/bhs.v1.AssetService/DeleteAsset"
+	AssetService_BuyAsset_FullMethodName            = "/bhs.v1.AssetService/BuyAsset"
+	AssetService_ListUserAssets_FullMethodName      = "/bhs.v1.AssetService/ListUserAssets"
+	AssetService_GetAsset_FullMethodName            = "/bhs.v1.AssetService/GetAsset"
+	AssetService_ListMarketAssets_FullMethodName    = "/bhs.v1.AssetService/ListMarketAssets"
+	AssetService_ListPurchasedAssets_FullMethodName = "/bhs.v1.AssetService/ListPurchasedAssets"
+	AssetService_ChangeAssetStatus_FullMethodName   = "/bhs.v1.AssetService/ChangeAssetStatus"
+	AssetService_ChangeAssetPrice_FullMethodName    = "/bhs.v1.AssetService/ChangeAssetPrice"
+	AssetService_PurgeAsset_FullMethodName          = "/bhs.v1.AssetService/PurgeAsset"
+	AssetService_GetProvenance_FullMethodName       = "/bhs.v1.AssetService/GetProvenance"
+	AssetService_RenewAccess_FullMethodName         = "/bhs.v1.AssetService/RenewAccess"
+	AssetService_GiftAsset_FullMethodName           = "/bhs.v1.AssetService/GiftAsset"
+	AssetService_GetPurchaseHistory_FullMethodName  = "/bhs.v1.AssetService/GetPurchaseHistory"
+)
+
+// AssetServiceClient is the client API for AssetService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// AssetService - mirrors usecase.Asset for the authenticated user. PurgeAsset needs the admin role.
+type AssetServiceClient interface {
+	CreateAsset(ctx context.Context, in *CreateAssetRequest, opts ...grpc.CallOption) (*CreateAssetResponse, error)
+	DeleteAsset(ctx context.Context, in *DeleteAssetRequest, opts ...grpc.CallOption) (*DeleteAssetResponse, error)
+	BuyAsset(ctx context.Context, in *BuyAssetRequest, opts ...grpc.CallOption) (*BuyAssetResponse, error)
+	ListUserAssets(ctx context.Context, in *ListUserAssetsRequest, opts ...grpc.CallOption) (*ListAssetsResponse, error)
+	GetAsset(ctx context.Context, in *GetAssetRequest, opts ...grpc.CallOption) (*Asset, error)
+	ListMarketAssets(ctx context.Context, in *ListMarketAssetsRequest, opts ...grpc.CallOption) (*ListAssetsResponse, error)
+	ListPurchasedAssets(ctx context.Context, in *ListPurchasedAssetsRequest, opts ...grpc.CallOption) (*ListAssetsResponse, error)
+	ChangeAssetStatus(ctx context.Context, in *ChangeAssetStatusRequest, opts ...grpc.CallOption) (*ChangeAssetStatusResponse, error)
+	ChangeAssetPrice(ctx context.Context, in *ChangeAssetPriceRequest, opts ...grpc.CallOption) (*ChangeAssetPriceResponse, error)
+	PurgeAsset(ctx context.Context, in *PurgeAssetRequest, opts ...grpc.CallOption) (*PurgeAssetResponse, error)
+	GetProvenance(ctx context.Context, in *GetProvenanceRequest, opts ...grpc.CallOption) (*ListPurchasesResponse, error)
+	RenewAccess(ctx context.Context, in *RenewAccessRequest, opts ...grpc.CallOption) (*Purchase, error)
+	GiftAsset(ctx context.Context, in *GiftAssetRequest, opts ...grpc.CallOption) (*Purchase, error)
+	GetPurchaseHistory(ctx context.Context, in *GetPurchaseHistoryRequest, opts ...grpc.CallOption) (*ListPurchasesResponse, error)
+}
+
+type assetServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAssetServiceClient(cc grpc.ClientConnInterface) AssetServiceClient {
+	return &assetServiceClient{cc}
+}
+
+func (c *assetServiceClient) CreateAsset(ctx context.Context, in *CreateAssetRequest, opts ...grpc.CallOption) (*CreateAssetResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateAssetResponse)
+	err := c.cc.Invoke(ctx, AssetService_CreateAsset_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *assetServiceClient) DeleteAsset(ctx context.Context, in *DeleteAssetRequest, opts ...grpc.CallOption) (*DeleteAssetResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteAssetResponse)
+	err := c.cc.Invoke(ctx, AssetService_DeleteAsset_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *assetServiceClient) BuyAsset(ctx context.Context, in *BuyAssetRequest, opts ...grpc.CallOption) (*BuyAssetResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BuyAssetResponse)
+	err := c.cc.Invoke(ctx, AssetService_BuyAsset_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *assetServiceClient) ListUserAssets(ctx context.Context, in *ListUserAssetsRequest, opts ...grpc.CallOption) (*ListAssetsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListAssetsResponse)
+	err := c.cc.Invoke(ctx, AssetService_ListUserAssets_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *assetServiceClient) GetAsset(ctx context.Context, in *GetAssetRequest, opts ...grpc.CallOption) (*Asset, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Asset)
+	err := c.cc.Invoke(ctx, AssetService_GetAsset_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *assetServiceClient) ListMarketAssets(ctx context.Context, in *ListMarketAssetsRequest, opts ...grpc.CallOption) (*ListAssetsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListAssetsResponse)
+	err := c.cc.Invoke(ctx, AssetService_ListMarketAssets_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *assetServiceClient) ListPurchasedAssets(ctx context.Context, in *ListPurchasedAssetsRequest, opts ...grpc.CallOption) (*ListAssetsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListAssetsResponse)
+	err := c.cc.Invoke(ctx, AssetService_ListPurchasedAssets_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *assetServiceClient) ChangeAssetStatus(ctx context.Context, in *ChangeAssetStatusRequest, opts ...grpc.CallOption) (*ChangeAssetStatusResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ChangeAssetStatusResponse)
+	err := c.cc.Invoke(ctx, AssetService_ChangeAssetStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *assetServiceClient) ChangeAssetPrice(ctx context.Context, in *ChangeAssetPriceRequest, opts ...grpc.CallOption) (*ChangeAssetPriceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ChangeAssetPriceResponse)
+	err := c.cc.Invoke(ctx, AssetService_ChangeAssetPrice_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *assetServiceClient) PurgeAsset(ctx context.Context, in *PurgeAssetRequest, opts ...grpc.CallOption) (*PurgeAssetResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PurgeAssetResponse)
+	err := c.cc.Invoke(ctx, AssetService_PurgeAsset_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *assetServiceClient) GetProvenance(ctx context.Context, in *GetProvenanceRequest, opts ...grpc.CallOption) (*ListPurchasesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListPurchasesResponse)
+	err := c.cc.Invoke(ctx, AssetService_GetProvenance_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *assetServiceClient) RenewAccess(ctx context.Context, in *RenewAccessRequest, opts ...grpc.CallOption) (*Purchase, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Purchase)
+	err := c.cc.Invoke(ctx, AssetService_RenewAccess_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *assetServiceClient) GiftAsset(ctx context.Context, in *GiftAssetRequest, opts ...grpc.CallOption) (*Purchase, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Purchase)
+	err := c.cc.Invoke(ctx, AssetService_GiftAsset_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *assetServiceClient) GetPurchaseHistory(ctx context.Context, in *GetPurchaseHistoryRequest, opts ...grpc.CallOption) (*ListPurchasesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListPurchasesResponse)
+	err := c.cc.Invoke(ctx, AssetService_GetPurchaseHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AssetServiceServer is the server API for AssetService service.
+// All implementations must embed UnimplementedAssetServiceServer
+// for forward compatibility.
+//
+// AssetService - mirrors usecase.Asset for the authenticated user. PurgeAsset needs the admin role.
+type AssetServiceServer interface {
+	CreateAsset(context.Context, *CreateAssetRequest) (*CreateAssetResponse, error)
+	DeleteAsset(context.Context, *DeleteAssetRequest) (*DeleteAssetResponse, error)
+	BuyAsset(context.Context, *BuyAssetRequest) (*BuyAssetResponse, error)
+	ListUserAssets(context.Context, *ListUserAssetsRequest) (*ListAssetsResponse, error)
+	GetAsset(context.Context, *GetAssetRequest) (*Asset, error)
+	ListMarketAssets(context.Context, *ListMarketAssetsRequest) (*ListAssetsResponse, error)
+	ListPurchasedAssets(context.Context, *ListPurchasedAssetsRequest) (*ListAssetsResponse, error)
+	ChangeAssetStatus(context.Context, *ChangeAssetStatusRequest) (*ChangeAssetStatusResponse, error)
+	ChangeAssetPrice(context.Context, *ChangeAssetPriceRequest) (*ChangeAssetPriceResponse, error)
+	PurgeAsset(context.Context, *PurgeAssetRequest) (*PurgeAssetResponse, error)
+	GetProvenance(context.Context, *GetProvenanceRequest) (*ListPurchasesResponse, error)
+	RenewAccess(context.Context, *RenewAccessRequest) (*Purchase, error)
+	GiftAsset(context.Context, *GiftAssetRequest) (*Purchase, error)
+	GetPurchaseHistory(context.Context, *GetPurchaseHistoryRequest) (*ListPurchasesResponse, error)
+	mustEmbedUnimplementedAssetServiceServer()
+}
+
+// UnimplementedAssetServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedAssetServiceServer struct{}
+
+func (UnimplementedAssetServiceServer) CreateAsset(context.Context, *CreateAssetRequest) (*CreateAssetResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateAsset not implemented")
+}
+func (UnimplementedAssetServiceServer) DeleteAsset(context.Context, *DeleteAssetRequest) (*DeleteAssetResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteAsset not implemented")
+}
+func (UnimplementedAssetServiceServer) BuyAsset(context.Context, *BuyAssetRequest) (*BuyAssetResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BuyAsset not implemented")
+}
+func (UnimplementedAssetServiceServer) ListUserAssets(context.Context, *ListUserAssetsRequest) (*ListAssetsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListUserAssets not implemented")
+}
+func (UnimplementedAssetServiceServer) GetAsset(context.Context, *GetAssetRequest) (*Asset, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAsset not implemented")
+}
+func (UnimplementedAssetServiceServer) ListMarketAssets(context.Context, *ListMarketAssetsRequest) (*ListAssetsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListMarketAssets not implemented")
+}
+func (UnimplementedAssetServiceServer) ListPurchasedAssets(context.Context, *ListPurchasedAssetsRequest) (*ListAssetsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPurchasedAssets not implemented")
+}
+func (UnimplementedAssetServiceServer) ChangeAssetStatus(context.Context, *ChangeAssetStatusRequest) (*ChangeAssetStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ChangeAssetStatus not implemented")
+}
+func (UnimplementedAssetServiceServer) ChangeAssetPrice(context.Context, *ChangeAssetPriceRequest) (*ChangeAssetPriceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ChangeAssetPrice not implemented")
+}
+func (UnimplementedAssetServiceServer) PurgeAsset(context.Context, *PurgeAssetRequest) (*PurgeAssetResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PurgeAsset not implemented")
+}
+func (UnimplementedAssetServiceServer) GetProvenance(context.Context, *GetProvenanceRequest) (*ListPurchasesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetProvenance not implemented")
+}
+func (UnimplementedAssetServiceServer) RenewAccess(context.Context, *RenewAccessRequest) (*Purchase, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RenewAccess not implemented")
+}
+func (UnimplementedAssetServiceServer) GiftAsset(context.Context, *GiftAssetRequest) (*Purchase, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GiftAsset not implemented")
+}
+func (UnimplementedAssetServiceServer) GetPurchaseHistory(context.Context, *GetPurchaseHistoryRequest) (*ListPurchasesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPurchaseHistory not implemented")
+}
+func (UnimplementedAssetServiceServer) mustEmbedUnimplementedAssetServiceServer() {}
+func (UnimplementedAssetServiceServer) testEmbeddedByValue()                      {}
+
+// UnsafeAssetServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AssetServiceServer will
+// result in compilation errors.
+type UnsafeAssetServiceServer interface {
+	mustEmbedUnimplementedAssetServiceServer()
+}
+
+func RegisterAssetServiceServer(s grpc.ServiceRegistrar, srv AssetServiceServer) {
+	// If the following call pancis, it indicates UnimplementedAssetServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&AssetService_ServiceDesc, srv)
+}
+
+func _AssetService_CreateAsset_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateAssetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AssetServiceServer).CreateAsset(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AssetService_CreateAsset_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AssetServiceServer).CreateAsset(ctx, req.(*CreateAssetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AssetService_DeleteAsset_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteAssetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AssetServiceServer).DeleteAsset(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AssetService_DeleteAsset_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AssetServiceServer).DeleteAsset(ctx, req.(*DeleteAssetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AssetService_BuyAsset_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BuyAssetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AssetServiceServer).BuyAsset(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AssetService_BuyAsset_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AssetServiceServer).BuyAsset(ctx, req.(*BuyAssetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AssetService_ListUserAssets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListUserAssetsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AssetServiceServer).ListUserAssets(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AssetService_ListUserAssets_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AssetServiceServer).ListUserAssets(ctx, req.(*ListUserAssetsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AssetService_GetAsset_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAssetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AssetServiceServer).GetAsset(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AssetService_GetAsset_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AssetServiceServer).GetAsset(ctx, req.(*GetAssetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AssetService_ListMarketAssets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListMarketAssetsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AssetServiceServer).ListMarketAssets(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AssetService_ListMarketAssets_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AssetServiceServer).ListMarketAssets(ctx, req.(*ListMarketAssetsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AssetService_ListPurchasedAssets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPurchasedAssetsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AssetServiceServer).ListPurchasedAssets(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AssetService_ListPurchasedAssets_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AssetServiceServer).ListPurchasedAssets(ctx, req.(*ListPurchasedAssetsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AssetService_ChangeAssetStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChangeAssetStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AssetServiceServer).ChangeAssetStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AssetService_ChangeAssetStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AssetServiceServer).ChangeAssetStatus(ctx, req.(*ChangeAssetStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AssetService_ChangeAssetPrice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChangeAssetPriceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AssetServiceServer).ChangeAssetPrice(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AssetService_ChangeAssetPrice_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AssetServiceServer).ChangeAssetPrice(ctx, req.(*ChangeAssetPriceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AssetService_PurgeAsset_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PurgeAssetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AssetServiceServer).PurgeAsset(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AssetService_PurgeAsset_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AssetServiceServer).PurgeAsset(ctx, req.(*PurgeAssetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AssetService_GetProvenance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetProvenanceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AssetServiceServer).GetProvenance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AssetService_GetProvenance_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AssetServiceServer).GetProvenance(ctx, req.(*GetProvenanceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AssetService_RenewAccess_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RenewAccessRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AssetServiceServer).RenewAccess(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AssetService_RenewAccess_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AssetServiceServer).RenewAccess(ctx, req.(*RenewAccessRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AssetService_GiftAsset_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GiftAssetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AssetServiceServer).GiftAsset(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AssetService_GiftAsset_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AssetServiceServer).GiftAsset(ctx, req.(*GiftAssetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AssetService_GetPurchaseHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPurchaseHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AssetServiceServer).GetPurchaseHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AssetService_GetPurchaseHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AssetServiceServer).GetPurchaseHistory(ctx, req.(*GetPurchaseHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// AssetService_ServiceDesc is the grpc.ServiceDesc for AssetService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var AssetService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "bhs.v1.AssetService",
+	HandlerType: (*AssetServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateAsset",
+			Handler:    _AssetService_CreateAsset_Handler,
+		},
+		{
+			MethodName: "DeleteAsset",
+			Handler:    _AssetService_DeleteAsset_Handler,
+		},
+		{
+			MethodName: "BuyAsset",
+			Handler:    _AssetService_BuyAsset_Handler,
+		},
+		{
+			MethodName: "ListUserAssets",
+			Handler:    _AssetService_ListUserAssets_Handler,
+		},
+		{
+			MethodName: "GetAsset",
+			Handler:    _AssetService_GetAsset_Handler,
+		},
+		{
+			MethodName: "ListMarketAssets",
+			Handler:    _AssetService_ListMarketAssets_Handler,
+		},
+		{
+			MethodName: "ListPurchasedAssets",
+			Handler:    _AssetService_ListPurchasedAssets_Handler,
+		},
+		{
+			MethodName: "ChangeAssetStatus",
+			Handler:    _AssetService_ChangeAssetStatus_Handler,
+		},
+		{
+			MethodName: "ChangeAssetPrice",
+			Handler:    _AssetService_ChangeAssetPrice_Handler,
+		},
+		{
+			MethodName: "PurgeAsset",
+			Handler:    _AssetService_PurgeAsset_Handler,
+		},
+		{
+			MethodName: "GetProvenance",
+			Handler:    _AssetService_GetProvenance_Handler,
+		},
+		{
+			MethodName: "RenewAccess",
+			Handler:    _AssetService_RenewAccess_Handler,
+		},
+		{
+			MethodName: "GiftAsset",
+			Handler:    _AssetService_GiftAsset_Handler,
+		},
+		{
+			MethodName: "GetPurchaseHistory",
+			Handler:    _AssetService_GetPurchaseHistory_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "v1/asset.proto",
+}
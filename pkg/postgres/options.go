@@ -1,6 +1,11 @@
 package postgres
 
-import "time"
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel/trace"
+)
 
 // Option -.
 type Option func(*Postgres)
@@ -25,3 +30,17 @@ func ConnTimeout(timeout time.Duration) Option {
 		c.connTimeout = timeout
 	}
 }
+
+// TracerProvider - records a span for every query.
+func TracerProvider(tp trace.TracerProvider) Option {
+	return func(c *Postgres) {
+		c.tracer = tp
+	}
+}
+
+// Registerer - exports the pool statistics as pgxpool_* metrics.
+func Registerer(reg prometheus.Registerer) Option {
+	return func(c *Postgres) {
+		c.registerer = reg
+	}
+}
@@ -9,6 +9,8 @@ import (
 
 	"github.com/Masterminds/squirrel"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel/trace"
 )
 
 const (
@@ -22,6 +24,8 @@ type Postgres struct {
 	maxPoolSize  int
 	connAttempts int
 	connTimeout  time.Duration
+	tracer       trace.TracerProvider
+	registerer   prometheus.Registerer
 
 	Builder squirrel.StatementBuilderType
 	Pool    *pgxpool.Pool
@@ -47,6 +51,9 @@ func New(url string, opts ...Option) (*Postgres, error) {
 	}
 
 	poolConfig.MaxConns = int32(pg.maxPoolSize)
+	if pg.tracer != nil {
+		poolConfig.ConnConfig.Tracer = &queryTracer{tracer: pg.tracer.Tracer(_tracerName)}
+	}
 
 	for pg.connAttempts > 0 {
 		pg.Pool, err = pgxpool.NewWithConfig(context.Background(), poolConfig)
@@ -65,6 +72,14 @@ func New(url string, opts ...Option) (*Postgres, error) {
 		return nil, fmt.Errorf("postgres - NewPostgres - connAttempts == 0: %w", err)
 	}
 
+	if pg.registerer != nil {
+		err = pg.registerer.Register(newCollector(pg.Pool))
+		if err != nil {
+			pg.Pool.Close()
+			return nil, fmt.Errorf("postgres - NewPostgres - pg.registerer.Register: %w", err)
+		}
+	}
+
 	return pg, nil
 }
 
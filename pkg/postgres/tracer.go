@@ -0,0 +1,70 @@
+package postgres
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const _tracerName = "github.com/Klef99/bhs-task/pkg/postgres"
+
+var (
+	_stringLiteral  = regexp.MustCompile(`'(?:[^']|'')*'`)
+	_numericLiteral = regexp.MustCompile(`(^|[^\w$.])\d+(?:\.\d+)?`)
+)
+
+// queryTracer - starts a span per query. Arguments are never recorded and literals are masked in the statement.
+type queryTracer struct {
+	tracer trace.Tracer
+}
+
+var _ pgx.QueryTracer = (*queryTracer)(nil)
+
+// TraceQueryStart -.
+func (t *queryTracer) TraceQueryStart(ctx context.Context, conn *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	statement := sanitize(data.SQL)
+	ctx, _ = t.tracer.Start(ctx, operation(statement),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.DBSystemPostgreSQL,
+			semconv.DBNamespace(conn.Config().Database),
+			semconv.DBQueryText(statement),
+		),
+	)
+	return ctx
+}
+
+// TraceQueryEnd - called when the rows of the query are closed.
+func (t *queryTracer) TraceQueryEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryEndData) {
+	span := trace.SpanFromContext(ctx)
+	if data.Err != nil {
+		span.RecordError(data.Err)
+		span.SetStatus(codes.Error, data.Err.Error())
+	} else {
+		span.SetAttributes(attribute.Int64("db.rows_affected", data.CommandTag.RowsAffected()))
+	}
+	span.End()
+}
+
+// sanitize - replaces string and numeric literals with ? and collapses whitespace.
+// Values passed as arguments ($1, $2...) are left as placeholders.
+func sanitize(sql string) string {
+	sql = _stringLiteral.ReplaceAllString(sql, "?")
+	sql = _numericLiteral.ReplaceAllString(sql, "${1}?")
+	return strings.Join(strings.Fields(sql), " ")
+}
+
+// operation - the first keyword of the statement names the span: SELECT, UPDATE, BEGIN...
+func operation(statement string) string {
+	op, _, _ := strings.Cut(statement, " ")
+	if op == "" {
+		return "postgres"
+	}
+	return strings.ToUpper(op)
+}
@@ -0,0 +1,49 @@
+package tracing
+
+import "time"
+
+// Option -.
+type Option func(*Tracing)
+
+// Exporter - none, stdout or otlp.
+func Exporter(exporter string) Option {
+	return func(t *Tracing) {
+		t.exporter = exporter
+	}
+}
+
+// Endpoint - host:port of the OTLP gRPC collector.
+func Endpoint(endpoint string) Option {
+	return func(t *Tracing) {
+		t.endpoint = endpoint
+	}
+}
+
+// Insecure - connect to the collector without TLS.
+func Insecure(insecure bool) Option {
+	return func(t *Tracing) {
+		t.insecure = insecure
+	}
+}
+
+// SampleRatio - share of new traces recorded, from 0 to 1. Traces started upstream follow the caller's decision.
+func SampleRatio(ratio float64) Option {
+	return func(t *Tracing) {
+		t.sampleRatio = ratio
+	}
+}
+
+// Service -.
+func Service(name, version string) Option {
+	return func(t *Tracing) {
+		t.serviceName = name
+		t.serviceVersion = version
+	}
+}
+
+// ShutdownTimeout -.
+func ShutdownTimeout(timeout time.Duration) Option {
+	return func(t *Tracing) {
+		t.shutdownTimeout = timeout
+	}
+}
@@ -0,0 +1,108 @@
+// Package tracing sets up OpenTelemetry tracing.
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+const (
+	ExporterNone   = "none"
+	ExporterStdout = "stdout"
+	ExporterOTLP   = "otlp"
+
+	_defaultEndpoint        = "localhost:4317"
+	_defaultSampleRatio     = 1
+	_defaultShutdownTimeout = 3 * time.Second
+)
+
+// Tracing -.
+type Tracing struct {
+	exporter        string
+	endpoint        string
+	insecure        bool
+	sampleRatio     float64
+	serviceName     string
+	serviceVersion  string
+	shutdownTimeout time.Duration
+
+	Provider   trace.TracerProvider
+	Propagator propagation.TextMapPropagator
+	shutdown   func(context.Context) error
+}
+
+// New - also registers the provider and the W3C trace context propagator globally.
+// With the none exporter spans are not recorded, but incoming trace context is still propagated.
+func New(opts ...Option) (*Tracing, error) {
+	t := &Tracing{
+		exporter:        ExporterNone,
+		endpoint:        _defaultEndpoint,
+		sampleRatio:     _defaultSampleRatio,
+		shutdownTimeout: _defaultShutdownTimeout,
+		Propagator:      propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}),
+		shutdown:        func(context.Context) error { return nil },
+	}
+
+	// Custom options
+	for _, opt := range opts {
+		opt(t)
+	}
+
+	var exporter sdktrace.SpanExporter
+	var err error
+	switch t.exporter {
+	case ExporterNone, "":
+		t.Provider = noop.NewTracerProvider()
+	case ExporterStdout:
+		exporter, err = stdouttrace.New(stdouttrace.WithWriter(os.Stdout))
+	case ExporterOTLP:
+		clientOpts := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(t.endpoint)}
+		if t.insecure {
+			clientOpts = append(clientOpts, otlptracegrpc.WithInsecure())
+		}
+		exporter, err = otlptracegrpc.New(context.Background(), clientOpts...)
+	default:
+		return nil, fmt.Errorf("tracing - New - unknown exporter %q", t.exporter)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("tracing - New - %s exporter: %w", t.exporter, err)
+	}
+
+	if exporter != nil {
+		provider := sdktrace.NewTracerProvider(
+			sdktrace.WithBatcher(exporter),
+			sdktrace.WithResource(resource.NewWithAttributes(semconv.SchemaURL,
+				semconv.ServiceName(t.serviceName),
+				semconv.ServiceVersion(t.serviceVersion),
+			)),
+			sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(t.sampleRatio))),
+		)
+		t.Provider = provider
+		t.shutdown = provider.Shutdown
+	}
+
+	otel.SetTracerProvider(t.Provider)
+	otel.SetTextMapPropagator(t.Propagator)
+
+	return t, nil
+}
+
+// Shutdown - exports the spans that are still buffered.
+func (t *Tracing) Shutdown() error {
+	ctx, cancel := context.WithTimeout(context.Background(), t.shutdownTimeout)
+	defer cancel()
+
+	return t.shutdown(ctx)
+}
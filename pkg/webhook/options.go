@@ -0,0 +1,13 @@
+package webhook
+
+import "time"
+
+// Option -.
+type Option func(*Client)
+
+// Timeout - of a single delivery attempt.
+func Timeout(timeout time.Duration) Option {
+	return func(c *Client) {
+		c.client.Timeout = timeout
+	}
+}
@@ -0,0 +1,110 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+const (
+	_defaultTimeout = 10 * time.Second
+
+	// _maxResponseBody - read from the endpoint's response so the connection can be reused.
+	_maxResponseBody = 64 << 10
+
+	HeaderTimestamp = "X-Webhook-Timestamp"
+	HeaderSignature = "X-Webhook-Signature"
+)
+
+// ErrForbiddenAddress - the endpoint resolves to an address of the server's own networks.
+var ErrForbiddenAddress = errors.New("webhook endpoint address is not public")
+
+type Interface interface {
+	Send(ctx context.Context, url, secret string, headers map[string]string, body []byte) (int, error)
+}
+
+// Client - posts signed JSON payloads to webhook endpoints.
+type Client struct {
+	client *http.Client
+}
+
+var _ Interface = (*Client)(nil)
+
+// NewClient - endpoints are registered by users, so the client only connects to public addresses, checked after
+// DNS resolution, bypasses proxies and doesn't follow redirects, a redirect counts as a failed attempt.
+func NewClient(opts ...Option) *Client {
+	dialer := &net.Dialer{Timeout: _defaultTimeout, Control: dialPublicOnly}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	c := &Client{client: &http.Client{
+		Timeout:   _defaultTimeout,
+		Transport: transport,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}}
+	// Custom options
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// dialPublicOnly - fits net.Dialer.Control, which gets the resolved address of every connection attempt.
+func dialPublicOnly(_, address string, _ syscall.RawConn) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrForbiddenAddress, address)
+	}
+	addr := addrPort.Addr().Unmap()
+	if addr.IsLoopback() || addr.IsPrivate() || addr.IsLinkLocalUnicast() || addr.IsLinkLocalMulticast() ||
+		addr.IsUnspecified() || addr.IsMulticast() {
+		return fmt.Errorf("%w: %s", ErrForbiddenAddress, addr)
+	}
+	return nil
+}
+
+// Sign - hex encoded HMAC-SHA256 of "<timestamp>.<body>" keyed with secret. Receivers recompute it
+// from the X-Webhook-Timestamp header and the raw body, and should reject stale timestamps.
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Send - posts body to url with the signature headers and the extra headers, returns the response
+// status code. Only transport failures are returned as errors, the caller decides which codes succeed.
+func (c *Client) Send(ctx context.Context, url, secret string, headers map[string]string, body []byte) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return 0, fmt.Errorf("webhook - Send - http.NewRequestWithContext: %w", err)
+	}
+	timestamp := time.Now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(HeaderSignature, "sha256="+Sign(secret, timestamp, body))
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("webhook - Send - c.client.Do: %w", err)
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, _maxResponseBody))
+	return resp.StatusCode, nil
+}